@@ -1,44 +1,241 @@
 package config
 
 import (
-	"log"
-	"os"
-
-	"github.com/joho/godotenv"
+	"time"
 )
 
+// DefaultJWTSecret is used when JWT_SECRET is not set. It is rejected in production.
+const DefaultJWTSecret = "your-secret-key-change-in-production"
+
+// Config is the typed application configuration. Every field can be set in
+// the optional config file (YAML or TOML, keys as in the yaml/toml tags) and
+// overridden by the environment variable in its env tag; default tags give
+// the value used when neither is set.
 type Config struct {
-	Port        string
-	Environment string
-	DatabaseURL string
-	JWTSecret   string
-	JWTExpiry   string
-}
-
-func Load() *Config {
-	// Load .env file if it exists (ignore error if file doesn't exist)
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
-	}
-
-	jwtSecret := getEnv("JWT_SECRET", "")
-	if jwtSecret == "" {
-		log.Println("Warning: JWT_SECRET not set, using default (not secure for production)")
-		jwtSecret = "your-secret-key-change-in-production"
-	}
-
-	return &Config{
-		Port:        getEnv("PORT", "8080"),
-		Environment: getEnv("ENVIRONMENT", "development"),
-		DatabaseURL: getEnv("DATABASE_URL", ""),
-		JWTSecret:   jwtSecret,
-		JWTExpiry:   getEnv("JWT_EXPIRY", "24h"), // Default 24 hours
-	}
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+	Environment string `yaml:"environment" toml:"environment" env:"ENVIRONMENT" default:"development"`
+
+	HTTP          HTTPConfig          `yaml:"http" toml:"http"`
+	Database      DatabaseConfig      `yaml:"database" toml:"database"`
+	Auth          AuthConfig          `yaml:"auth" toml:"auth"`
+	Log           LogConfig           `yaml:"log" toml:"log"`
+	Tracing       TracingConfig       `yaml:"tracing" toml:"tracing"`
+	CORS          CORSConfig          `yaml:"cors" toml:"cors"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limit" toml:"rate_limit"`
+	Mail          MailConfig          `yaml:"mail" toml:"mail"`
+	Storage       StorageConfig       `yaml:"storage" toml:"storage"`
+	Uploads       UploadsConfig       `yaml:"uploads" toml:"uploads"`
+	Scheduler     SchedulerConfig     `yaml:"scheduler" toml:"scheduler"`
+	FeatureFlags  FeatureFlagsConfig  `yaml:"feature_flags" toml:"feature_flags"`
+	Events        EventsConfig        `yaml:"events" toml:"events"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks" toml:"webhooks"`
+	Stream        StreamConfig        `yaml:"stream" toml:"stream"`
+	Notifications NotificationsConfig `yaml:"notifications" toml:"notifications"`
+	Digests       DigestsConfig       `yaml:"digests" toml:"digests"`
+	Calendar      CalendarConfig      `yaml:"calendar" toml:"calendar"`
+	NGO           NGOConfig           `yaml:"ngo" toml:"ngo"`
+}
+
+// HTTPConfig configures the HTTP server and request handling
+type HTTPConfig struct {
+	Port              string        `yaml:"port" toml:"port" env:"PORT" default:"8080"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT" default:"15s"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" toml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" default:"5s"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" toml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" default:"120s"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" default:"15s"`
+
+	// Request limits and security headers
+	MaxBodyBytes       int64 `yaml:"max_body_bytes" toml:"max_body_bytes" env:"MAX_BODY_BYTES" default:"1048576"`                       // 1 MiB
+	MaxUploadBodyBytes int64 `yaml:"max_upload_body_bytes" toml:"max_upload_body_bytes" env:"MAX_UPLOAD_BODY_BYTES" default:"10485760"` // 10 MiB
+	// HSTSEnabled defaults to true in production
+	HSTSEnabled bool `yaml:"hsts_enabled" toml:"hsts_enabled" env:"HSTS_ENABLED"`
+	TrustProxy  bool `yaml:"trust_proxy" toml:"trust_proxy" env:"TRUST_PROXY" default:"false"`
+}
+
+// DatabaseConfig configures the PostgreSQL connection pool
+type DatabaseConfig struct {
+	URL             string        `yaml:"url" toml:"url" env:"DATABASE_URL" secret:"true"`
+	MaxOpenConns    int           `yaml:"max_open_conns" toml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" toml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"5"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" toml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" default:"5m"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" toml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" default:"0s"`
+	// StrictStartup makes the server exit instead of serving without a
+	// database. It defaults to true in production.
+	StrictStartup bool `yaml:"strict_startup" toml:"strict_startup" env:"STRICT_STARTUP"`
+}
+
+// AuthConfig configures JWT authentication
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret" toml:"jwt_secret" env:"JWT_SECRET" default:"your-secret-key-change-in-production" secret:"true"`
+	JWTExpiry time.Duration `yaml:"jwt_expiry" toml:"jwt_expiry" env:"JWT_EXPIRY" default:"24h"`
+}
+
+// LogConfig configures structured logging
+type LogConfig struct {
+	Level  string `yaml:"level" toml:"level" env:"LOG_LEVEL" default:"info"`
+	Format string `yaml:"format" toml:"format" env:"LOG_FORMAT" default:"json"`
+}
+
+// TracingConfig configures OpenTelemetry tracing
+type TracingConfig struct {
+	ServiceName  string  `yaml:"service_name" toml:"service_name" env:"OTEL_SERVICE_NAME" default:"foodlink-backend"`
+	Exporter     string  `yaml:"exporter" toml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint" toml:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"`
+	SampleRatio  float64 `yaml:"sample_ratio" toml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1.0"`
+}
+
+// CORSConfig configures cross-origin resource sharing
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed_origins" toml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" default:"*"`
+	AllowedMethods   []string `yaml:"allowed_methods" toml:"allowed_methods" env:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,DELETE,PATCH,OPTIONS"`
+	AllowedHeaders   []string `yaml:"allowed_headers" toml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" default:"Content-Type,Authorization,X-Request-ID,If-Match"`
+	AllowCredentials bool     `yaml:"allow_credentials" toml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" default:"false"`
+}
+
+// RateLimitConfig configures API rate limiting
+type RateLimitConfig struct {
+	Enabled bool   `yaml:"enabled" toml:"enabled" env:"RATE_LIMIT_ENABLED" default:"true"`
+	Store   string `yaml:"store" toml:"store" env:"RATE_LIMIT_STORE" default:"memory"`
+	// Quotas overrides the default quotas, as group:role=requests/period[:burst] entries
+	Quotas string `yaml:"quotas" toml:"quotas" env:"RATE_LIMIT_QUOTAS"`
+}
+
+// MailConfig configures outgoing email
+type MailConfig struct {
+	// Driver is "log" to only log messages, or "smtp"
+	Driver       string `yaml:"driver" toml:"driver" env:"MAIL_DRIVER" default:"log"`
+	From         string `yaml:"from" toml:"from" env:"MAIL_FROM" default:"Foodlink <no-reply@foodlink.local>"`
+	SMTPHost     string `yaml:"smtp_host" toml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort     int    `yaml:"smtp_port" toml:"smtp_port" env:"SMTP_PORT" default:"587"`
+	SMTPUsername string `yaml:"smtp_username" toml:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword string `yaml:"smtp_password" toml:"smtp_password" env:"SMTP_PASSWORD" secret:"true"`
+}
+
+// StorageConfig configures file storage
+type StorageConfig struct {
+	// Driver is "local" or "s3"
+	Driver            string `yaml:"driver" toml:"driver" env:"STORAGE_DRIVER" default:"local"`
+	LocalPath         string `yaml:"local_path" toml:"local_path" env:"STORAGE_LOCAL_PATH" default:"./uploads"`
+	S3Bucket          string `yaml:"s3_bucket" toml:"s3_bucket" env:"S3_BUCKET"`
+	S3Region          string `yaml:"s3_region" toml:"s3_region" env:"S3_REGION" default:"us-east-1"`
+	S3Endpoint        string `yaml:"s3_endpoint" toml:"s3_endpoint" env:"S3_ENDPOINT"`
+	S3AccessKeyID     string `yaml:"s3_access_key_id" toml:"s3_access_key_id" env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `yaml:"s3_secret_access_key" toml:"s3_secret_access_key" env:"S3_SECRET_ACCESS_KEY" secret:"true"`
+}
+
+// UploadsConfig limits uploaded files and their download links
+type UploadsConfig struct {
+	MaxFileBytes int64 `yaml:"max_file_bytes" toml:"max_file_bytes" env:"UPLOADS_MAX_FILE_BYTES" default:"8388608"` // 8 MiB
+	// MaxPixels rejects images whose decoded size would exhaust memory
+	MaxPixels     int           `yaml:"max_pixels" toml:"max_pixels" env:"UPLOADS_MAX_PIXELS" default:"25000000"`
+	ThumbnailSize int           `yaml:"thumbnail_size" toml:"thumbnail_size" env:"UPLOADS_THUMBNAIL_SIZE" default:"320"`
+	URLExpiry     time.Duration `yaml:"url_expiry" toml:"url_expiry" env:"UPLOADS_URL_EXPIRY" default:"15m"`
+}
+
+// SchedulerConfig configures background jobs
+type SchedulerConfig struct {
+	Enabled      bool          `yaml:"enabled" toml:"enabled" env:"SCHEDULER_ENABLED" default:"true"`
+	PollInterval time.Duration `yaml:"poll_interval" toml:"poll_interval" env:"SCHEDULER_POLL_INTERVAL" default:"5s"`
+	Concurrency  int           `yaml:"concurrency" toml:"concurrency" env:"SCHEDULER_CONCURRENCY" default:"4"`
+	// JobTimeout bounds a single job run
+	JobTimeout time.Duration `yaml:"job_timeout" toml:"job_timeout" env:"SCHEDULER_JOB_TIMEOUT" default:"5m"`
+	Retention  time.Duration `yaml:"retention" toml:"retention" env:"SCHEDULER_RETENTION" default:"168h"`
+}
+
+// FeatureFlagsConfig configures feature flag evaluation
+type FeatureFlagsConfig struct {
+	RefreshInterval time.Duration `yaml:"refresh_interval" toml:"refresh_interval" env:"FEATURE_FLAGS_REFRESH_INTERVAL" default:"30s"`
+}
+
+// EventsConfig configures delivery of domain events from the outbox
+type EventsConfig struct {
+	DispatchInterval time.Duration `yaml:"dispatch_interval" toml:"dispatch_interval" env:"EVENTS_DISPATCH_INTERVAL" default:"1s"`
+	BatchSize        int           `yaml:"batch_size" toml:"batch_size" env:"EVENTS_BATCH_SIZE" default:"50"`
+	MaxAttempts      int           `yaml:"max_attempts" toml:"max_attempts" env:"EVENTS_MAX_ATTEMPTS" default:"8"`
+	// Lease is how long a claimed delivery stays locked before another
+	// instance may retry it
+	Lease     time.Duration `yaml:"lease" toml:"lease" env:"EVENTS_LEASE" default:"1m"`
+	Retention time.Duration `yaml:"retention" toml:"retention" env:"EVENTS_RETENTION" default:"168h"`
+}
+
+// WebhooksConfig configures delivery of outbound organization webhooks
+type WebhooksConfig struct {
+	DeliveryInterval time.Duration `yaml:"delivery_interval" toml:"delivery_interval" env:"WEBHOOKS_DELIVERY_INTERVAL" default:"5s"`
+	BatchSize        int           `yaml:"batch_size" toml:"batch_size" env:"WEBHOOKS_BATCH_SIZE" default:"20"`
+	Timeout          time.Duration `yaml:"timeout" toml:"timeout" env:"WEBHOOKS_TIMEOUT" default:"10s"`
+	MaxAttempts      int           `yaml:"max_attempts" toml:"max_attempts" env:"WEBHOOKS_MAX_ATTEMPTS" default:"8"`
+	// DisableAfter is how many consecutive failed attempts disable a
+	// subscription
+	DisableAfter int           `yaml:"disable_after" toml:"disable_after" env:"WEBHOOKS_DISABLE_AFTER" default:"20"`
+	Retention    time.Duration `yaml:"retention" toml:"retention" env:"WEBHOOKS_RETENTION" default:"720h"`
+	// AllowPrivateNetworks permits endpoints on loopback and private
+	// addresses, for local development
+	AllowPrivateNetworks bool `yaml:"allow_private_networks" toml:"allow_private_networks" env:"WEBHOOKS_ALLOW_PRIVATE_NETWORKS" default:"false"`
+	// BacklogThreshold is how many deliveries may be overdue, due for longer
+	// than BacklogAge, before the readiness check fails
+	BacklogThreshold int           `yaml:"backlog_threshold" toml:"backlog_threshold" env:"WEBHOOKS_BACKLOG_THRESHOLD" default:"100"`
+	BacklogAge       time.Duration `yaml:"backlog_age" toml:"backlog_age" env:"WEBHOOKS_BACKLOG_AGE" default:"5m"`
+}
+
+// StreamConfig configures the Server-Sent Events stream
+type StreamConfig struct {
+	// Heartbeat is how often an idle stream sends a comment to keep proxies
+	// from closing it
+	Heartbeat time.Duration `yaml:"heartbeat" toml:"heartbeat" env:"STREAM_HEARTBEAT" default:"15s"`
+	// ReplayWindow is how long events are kept for Last-Event-ID resume
+	ReplayWindow time.Duration `yaml:"replay_window" toml:"replay_window" env:"STREAM_REPLAY_WINDOW" default:"1h"`
+	// ReplayLimit caps the events replayed on resume; past it the client is
+	// told to reload instead
+	ReplayLimit int `yaml:"replay_limit" toml:"replay_limit" env:"STREAM_REPLAY_LIMIT" default:"500"`
+	// ClientBuffer is how many events may queue for a slow client before it
+	// is disconnected
+	ClientBuffer int `yaml:"client_buffer" toml:"client_buffer" env:"STREAM_CLIENT_BUFFER" default:"64"`
+}
+
+// NotificationsConfig configures delivery of notifications by email, SMS
+// and push
+type NotificationsConfig struct {
+	DeliveryInterval time.Duration `yaml:"delivery_interval" toml:"delivery_interval" env:"NOTIFICATIONS_DELIVERY_INTERVAL" default:"10s"`
+	BatchSize        int           `yaml:"batch_size" toml:"batch_size" env:"NOTIFICATIONS_BATCH_SIZE" default:"50"`
+	MaxAttempts      int           `yaml:"max_attempts" toml:"max_attempts" env:"NOTIFICATIONS_MAX_ATTEMPTS" default:"5"`
+	// DigestThreshold is how many notifications a user may get on one
+	// channel within DigestWindow before further ones are batched into a
+	// digest, sent DigestWindow after the first batched one
+	DigestThreshold int           `yaml:"digest_threshold" toml:"digest_threshold" env:"NOTIFICATIONS_DIGEST_THRESHOLD" default:"5"`
+	DigestWindow    time.Duration `yaml:"digest_window" toml:"digest_window" env:"NOTIFICATIONS_DIGEST_WINDOW" default:"1h"`
+	Retention       time.Duration `yaml:"retention" toml:"retention" env:"NOTIFICATIONS_RETENTION" default:"720h"`
+	// SMSDriver and PushDriver pick the providers; "log" only logs
+	SMSDriver  string `yaml:"sms_driver" toml:"sms_driver" env:"NOTIFICATIONS_SMS_DRIVER" default:"log"`
+	PushDriver string `yaml:"push_driver" toml:"push_driver" env:"NOTIFICATIONS_PUSH_DRIVER" default:"log"`
+	// BacklogThreshold is how many deliveries may be overdue, due for longer
+	// than BacklogAge, before the readiness check fails
+	BacklogThreshold int           `yaml:"backlog_threshold" toml:"backlog_threshold" env:"NOTIFICATIONS_BACKLOG_THRESHOLD" default:"100"`
+	BacklogAge       time.Duration `yaml:"backlog_age" toml:"backlog_age" env:"NOTIFICATIONS_BACKLOG_AGE" default:"5m"`
+}
+
+// DigestsConfig configures the daily and weekly email digests
+type DigestsConfig struct {
+	// DailySchedule and WeeklySchedule are cron expressions in UTC
+	DailySchedule  string `yaml:"daily_schedule" toml:"daily_schedule" env:"DIGESTS_DAILY_SCHEDULE" default:"0 7 * * *"`
+	WeeklySchedule string `yaml:"weekly_schedule" toml:"weekly_schedule" env:"DIGESTS_WEEKLY_SCHEDULE" default:"0 7 * * 1"`
+	// BaseURL is the public address of the API, used in unsubscribe links
+	BaseURL   string `yaml:"base_url" toml:"base_url" env:"DIGESTS_BASE_URL" default:"http://localhost:8080"`
+	BatchSize int    `yaml:"batch_size" toml:"batch_size" env:"DIGESTS_BATCH_SIZE" default:"100"`
+}
+
+// CalendarConfig configures the iCalendar feeds
+type CalendarConfig struct {
+	// BaseURL is the public address of the API that calendar apps fetch
+	// feeds from
+	BaseURL string `yaml:"base_url" toml:"base_url" env:"CALENDAR_BASE_URL" default:"http://localhost:8080"`
+}
+
+// NGOConfig holds defaults for NGO partners
+type NGOConfig struct {
+	DefaultPickupRadiusKm float64 `yaml:"default_pickup_radius_km" toml:"default_pickup_radius_km" env:"NGO_DEFAULT_PICKUP_RADIUS_KM" default:"5"`
+}
+
+// IsProduction reports whether the server runs in production
+func (c *Config) IsProduction() bool {
+	return c.Environment == "production"
 }
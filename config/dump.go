@@ -0,0 +1,33 @@
+package config
+
+import (
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces secret values in Dump
+const Redacted = "[REDACTED]"
+
+// Redact returns a copy of the configuration with every secret field that is
+// set replaced by Redacted
+func (c *Config) Redact() *Config {
+	redacted := *c
+	_ = walk(reflect.ValueOf(&redacted).Elem(), func(field reflect.StructField, value reflect.Value) error {
+		if field.Tag.Get("secret") == "true" && value.Kind() == reflect.String && value.String() != "" {
+			value.SetString(Redacted)
+		}
+		return nil
+	})
+	return &redacted
+}
+
+// Dump renders the redacted configuration as YAML, in the same layout as the
+// config file
+func (c *Config) Dump() (string, error) {
+	out, err := yaml.Marshal(c.Redact())
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable pointing to an optional config file
+const FileEnv = "CONFIG_FILE"
+
+// Load reads the configuration from the file named by CONFIG_FILE, if any,
+// and the environment, then validates it
+func Load() (*Config, error) {
+	return LoadFile(os.Getenv(FileEnv))
+}
+
+// LoadFile reads the configuration from path (YAML or TOML, chosen by
+// extension; empty for none) and the environment, then validates it.
+// Values are applied in order: defaults, file, environment.
+func LoadFile(path string) (*Config, error) {
+	// Load .env file if it exists (ignore error if file doesn't exist)
+	if err := godotenv.Load(); err != nil {
+		slog.Info("No .env file found, using environment variables")
+	}
+
+	var data []byte
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	// The environment decides some defaults, so resolve it first
+	probe := &Config{}
+	if err := decodeFile(path, data, probe); err != nil {
+		return nil, err
+	}
+	environment := probe.Environment
+	if value := os.Getenv("ENVIRONMENT"); value != "" {
+		environment = value
+	}
+
+	cfg := &Config{}
+	if err := applyDefaults(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+	if environment == "production" {
+		cfg.HTTP.HSTSEnabled = true
+		cfg.Database.StrictStartup = true
+	}
+
+	if err := decodeFile(path, data, cfg); err != nil {
+		return nil, err
+	}
+	// Report unparsable environment values together with validation errors
+	problems := applyEnv(reflect.ValueOf(cfg).Elem())
+	if err := cfg.Validate(); err != nil {
+		problems = append(problems, err.(*ValidationError).Problems...)
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	if cfg.Auth.JWTSecret == DefaultJWTSecret {
+		slog.Warn("JWT_SECRET not set, using default (not secure for production)")
+	}
+	return cfg, nil
+}
+
+// decodeFile decodes a YAML or TOML config file into cfg
+func decodeFile(path string, data []byte, cfg *Config) error {
+	if path == "" {
+		return nil
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("invalid config file %s: unknown key %s", path, undecoded[0])
+		}
+	default:
+		return fmt.Errorf("unsupported config file %s: use .yaml, .yml or .toml", path)
+	}
+	return nil
+}
+
+// applyDefaults sets every field from its default tag
+func applyDefaults(v reflect.Value) error {
+	return walk(v, func(field reflect.StructField, value reflect.Value) error {
+		def, ok := field.Tag.Lookup("default")
+		if !ok {
+			return nil
+		}
+		if err := setValue(value, def); err != nil {
+			return fmt.Errorf("invalid default for %s: %w", field.Name, err)
+		}
+		return nil
+	})
+}
+
+// applyEnv overrides fields from their environment variables and returns the
+// variables that could not be parsed
+func applyEnv(v reflect.Value) []string {
+	var problems []string
+	_ = walk(v, func(field reflect.StructField, value reflect.Value) error {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+		raw, ok := os.LookupEnv(name)
+		if !ok || raw == "" {
+			return nil
+		}
+		if err := setValue(value, raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+		return nil
+	})
+	return problems
+}
+
+// walk calls fn for every leaf field of the struct v, descending into nested
+// config sections
+func walk(v reflect.Value, fn func(reflect.StructField, reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			if err := walk(value, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses raw into the field value
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		value.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		value.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("unsupported config type %s", value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"foodlink_backend/jobs"
+	"foodlink_backend/ratelimit"
+)
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks the configuration and reports every invalid field by its
+// file key
+func (c *Config) Validate() error {
+	var problems []string
+	fail := func(key, format string, args ...interface{}) {
+		problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch c.Environment {
+	case "development", "staging", "production", "test":
+	default:
+		fail("environment", "must be one of development, staging, production, test (got %q)", c.Environment)
+	}
+
+	// HTTP
+	if port, err := strconv.Atoi(c.HTTP.Port); err != nil || port < 1 || port > 65535 {
+		fail("http.port", "must be a port number between 1 and 65535 (got %q)", c.HTTP.Port)
+	}
+	for key, d := range map[string]int64{
+		"http.read_timeout":        int64(c.HTTP.ReadTimeout),
+		"http.read_header_timeout": int64(c.HTTP.ReadHeaderTimeout),
+		"http.write_timeout":       int64(c.HTTP.WriteTimeout),
+		"http.idle_timeout":        int64(c.HTTP.IdleTimeout),
+		"http.shutdown_timeout":    int64(c.HTTP.ShutdownTimeout),
+	} {
+		if d < 0 {
+			fail(key, "must not be negative")
+		}
+	}
+	if c.HTTP.MaxBodyBytes <= 0 {
+		fail("http.max_body_bytes", "must be positive")
+	}
+	if c.HTTP.MaxUploadBodyBytes < c.HTTP.MaxBodyBytes {
+		fail("http.max_upload_body_bytes", "must be at least http.max_body_bytes")
+	}
+
+	// Database
+	if c.Database.URL != "" {
+		if u, err := url.Parse(c.Database.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			fail("database.url", "must be a postgres:// or postgresql:// URL")
+		}
+	} else if c.Database.StrictStartup {
+		fail("database.url", "is required when database.strict_startup is enabled")
+	}
+	if c.Database.MaxOpenConns < 1 {
+		fail("database.max_open_conns", "must be at least 1")
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.max_idle_conns", "must be between 0 and database.max_open_conns")
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		fail("database.conn_max_lifetime", "must not be negative")
+	}
+	if c.Database.ConnMaxIdleTime < 0 {
+		fail("database.conn_max_idle_time", "must not be negative")
+	}
+
+	// Auth
+	if c.Auth.JWTSecret == "" {
+		fail("auth.jwt_secret", "must not be empty")
+	} else if c.IsProduction() {
+		if c.Auth.JWTSecret == DefaultJWTSecret {
+			fail("auth.jwt_secret", "must be set in production")
+		} else if len(c.Auth.JWTSecret) < 32 {
+			fail("auth.jwt_secret", "must be at least 32 characters in production")
+		}
+	}
+	if c.Auth.JWTExpiry <= 0 {
+		fail("auth.jwt_expiry", "must be positive")
+	}
+
+	// Logging and tracing
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		fail("log.level", "must be one of debug, info, warn, error (got %q)", c.Log.Level)
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "json", "text":
+	default:
+		fail("log.format", "must be json or text (got %q)", c.Log.Format)
+	}
+	switch strings.ToLower(c.Tracing.Exporter) {
+	case "none", "stdout", "otlp":
+	default:
+		fail("tracing.exporter", "must be one of none, stdout, otlp (got %q)", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	// CORS
+	if len(c.CORS.AllowedOrigins) == 0 {
+		fail("cors.allowed_origins", "must list at least one origin")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" {
+			fail("cors.allowed_origins", "%q is not an origin like https://example.org", origin)
+		}
+	}
+
+	// Rate limiting
+	switch c.RateLimit.Store {
+	case "memory", "postgres":
+	default:
+		fail("rate_limit.store", "must be memory or postgres (got %q)", c.RateLimit.Store)
+	}
+	if err := ratelimit.DefaultPolicy().Override(c.RateLimit.Quotas); err != nil {
+		fail("rate_limit.quotas", "%v", err)
+	}
+
+	// Mail
+	switch c.Mail.Driver {
+	case "log":
+	case "smtp":
+		if c.Mail.SMTPHost == "" {
+			fail("mail.smtp_host", "is required for the smtp driver")
+		}
+	default:
+		fail("mail.driver", "must be log or smtp (got %q)", c.Mail.Driver)
+	}
+	if c.Mail.SMTPPort < 1 || c.Mail.SMTPPort > 65535 {
+		fail("mail.smtp_port", "must be between 1 and 65535")
+	}
+	if c.Mail.From == "" {
+		fail("mail.from", "must not be empty")
+	} else if _, err := mail.ParseAddress(c.Mail.From); err != nil {
+		fail("mail.from", "must be an email address (%v)", err)
+	}
+
+	// Storage
+	switch c.Storage.Driver {
+	case "local":
+		if c.Storage.LocalPath == "" {
+			fail("storage.local_path", "is required for the local driver")
+		}
+	case "s3":
+		if c.Storage.S3Bucket == "" {
+			fail("storage.s3_bucket", "is required for the s3 driver")
+		}
+		if c.Storage.S3Region == "" {
+			fail("storage.s3_region", "is required for the s3 driver")
+		}
+		if c.Storage.S3Endpoint != "" {
+			if u, err := url.Parse(c.Storage.S3Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				fail("storage.s3_endpoint", "must be an http or https URL")
+			}
+		}
+		if c.Storage.S3AccessKeyID == "" || c.Storage.S3SecretAccessKey == "" {
+			fail("storage.s3_access_key_id", "and storage.s3_secret_access_key are required for the s3 driver")
+		}
+	default:
+		fail("storage.driver", "must be local or s3 (got %q)", c.Storage.Driver)
+	}
+
+	// Uploads
+	if c.Uploads.MaxFileBytes <= 0 {
+		fail("uploads.max_file_bytes", "must be positive")
+	} else if c.Uploads.MaxFileBytes > c.HTTP.MaxUploadBodyBytes {
+		fail("uploads.max_file_bytes", "must not exceed http.max_upload_body_bytes")
+	}
+	if c.Uploads.MaxPixels < 1 {
+		fail("uploads.max_pixels", "must be at least 1")
+	}
+	if c.Uploads.ThumbnailSize < 16 {
+		fail("uploads.thumbnail_size", "must be at least 16")
+	}
+	if c.Uploads.URLExpiry <= 0 {
+		fail("uploads.url_expiry", "must be positive")
+	}
+
+	// Scheduler and feature flags
+	if c.Scheduler.PollInterval <= 0 {
+		fail("scheduler.poll_interval", "must be positive")
+	}
+	if c.Scheduler.Concurrency < 1 {
+		fail("scheduler.concurrency", "must be at least 1")
+	}
+	if c.Scheduler.JobTimeout <= 0 {
+		fail("scheduler.job_timeout", "must be positive")
+	}
+	if c.Scheduler.Retention <= 0 {
+		fail("scheduler.retention", "must be positive")
+	}
+	if c.FeatureFlags.RefreshInterval <= 0 {
+		fail("feature_flags.refresh_interval", "must be positive")
+	}
+
+	// Events
+	if c.Events.DispatchInterval <= 0 {
+		fail("events.dispatch_interval", "must be positive")
+	}
+	if c.Events.BatchSize < 1 {
+		fail("events.batch_size", "must be at least 1")
+	}
+	if c.Events.MaxAttempts < 1 {
+		fail("events.max_attempts", "must be at least 1")
+	}
+	if c.Events.Lease <= 0 {
+		fail("events.lease", "must be positive")
+	}
+	if c.Events.Retention <= 0 {
+		fail("events.retention", "must be positive")
+	}
+
+	// Webhooks
+	if c.Webhooks.DeliveryInterval <= 0 {
+		fail("webhooks.delivery_interval", "must be positive")
+	}
+	if c.Webhooks.BatchSize < 1 {
+		fail("webhooks.batch_size", "must be at least 1")
+	}
+	if c.Webhooks.Timeout <= 0 {
+		fail("webhooks.timeout", "must be positive")
+	}
+	if c.Webhooks.MaxAttempts < 1 {
+		fail("webhooks.max_attempts", "must be at least 1")
+	}
+	if c.Webhooks.DisableAfter < 1 {
+		fail("webhooks.disable_after", "must be at least 1")
+	}
+	if c.Webhooks.Retention <= 0 {
+		fail("webhooks.retention", "must be positive")
+	}
+	if c.Webhooks.AllowPrivateNetworks && c.IsProduction() {
+		fail("webhooks.allow_private_networks", "must be false in production")
+	}
+	if c.Webhooks.BacklogThreshold < 0 {
+		fail("webhooks.backlog_threshold", "must not be negative")
+	}
+	if c.Webhooks.BacklogAge <= 0 {
+		fail("webhooks.backlog_age", "must be positive")
+	}
+
+	// Stream
+	if c.Stream.Heartbeat <= 0 {
+		fail("stream.heartbeat", "must be positive")
+	}
+	if c.Stream.ReplayWindow <= 0 {
+		fail("stream.replay_window", "must be positive")
+	}
+	if c.Stream.ReplayLimit < 1 {
+		fail("stream.replay_limit", "must be at least 1")
+	}
+	if c.Stream.ClientBuffer < 1 {
+		fail("stream.client_buffer", "must be at least 1")
+	}
+
+	// Notifications
+	if c.Notifications.DeliveryInterval <= 0 {
+		fail("notifications.delivery_interval", "must be positive")
+	}
+	if c.Notifications.BatchSize < 1 {
+		fail("notifications.batch_size", "must be at least 1")
+	}
+	if c.Notifications.MaxAttempts < 1 {
+		fail("notifications.max_attempts", "must be at least 1")
+	}
+	if c.Notifications.DigestThreshold < 1 {
+		fail("notifications.digest_threshold", "must be at least 1")
+	}
+	if c.Notifications.DigestWindow <= 0 {
+		fail("notifications.digest_window", "must be positive")
+	}
+	if c.Notifications.Retention <= 0 {
+		fail("notifications.retention", "must be positive")
+	}
+	if c.Notifications.SMSDriver != "log" {
+		fail("notifications.sms_driver", "must be log (got %q)", c.Notifications.SMSDriver)
+	}
+	if c.Notifications.PushDriver != "log" {
+		fail("notifications.push_driver", "must be log (got %q)", c.Notifications.PushDriver)
+	}
+	if c.Notifications.BacklogThreshold < 0 {
+		fail("notifications.backlog_threshold", "must not be negative")
+	}
+	if c.Notifications.BacklogAge <= 0 {
+		fail("notifications.backlog_age", "must be positive")
+	}
+
+	// Digests
+	if _, err := jobs.ParseSchedule(c.Digests.DailySchedule); err != nil {
+		fail("digests.daily_schedule", "%v", err)
+	}
+	if _, err := jobs.ParseSchedule(c.Digests.WeeklySchedule); err != nil {
+		fail("digests.weekly_schedule", "%v", err)
+	}
+	if u, err := url.Parse(c.Digests.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fail("digests.base_url", "must be an absolute http or https URL (got %q)", c.Digests.BaseURL)
+	}
+	if c.Digests.BatchSize < 1 {
+		fail("digests.batch_size", "must be at least 1")
+	}
+
+	// Calendar
+	if u, err := url.Parse(c.Calendar.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fail("calendar.base_url", "must be an absolute http or https URL (got %q)", c.Calendar.BaseURL)
+	}
+
+	// NGO
+	if c.NGO.DefaultPickupRadiusKm <= 0 {
+		fail("ngo.default_pickup_radius_km", "must be positive")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
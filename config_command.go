@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"foodlink_backend/config"
+	"os"
+)
+
+// runConfigCommand implements the "config" subcommand and returns the exit code.
+//
+//	foodlink_backend config check [--config path]
+//
+// check loads the configuration exactly as the server would, prints every
+// validation error, or the redacted configuration when it is valid.
+func runConfigCommand(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "usage: foodlink_backend config check [--config path]")
+		return 2
+	}
+
+	flags := flag.NewFlagSet("config check", flag.ContinueOnError)
+	path := flags.String("config", os.Getenv(config.FileEnv), "YAML or TOML config file (defaults to $"+config.FileEnv+")")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	cfg, err := config.LoadFile(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	dump, err := cfg.Dump()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to render configuration:", err)
+		return 1
+	}
+	fmt.Print(dump)
+	fmt.Fprintln(os.Stderr, "configuration is valid")
+	return 0
+}
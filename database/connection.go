@@ -1,40 +1,41 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
-	"time"
+	"log/slog"
 
 	"foodlink_backend/config"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 var DB *sql.DB
 
 // Init initializes the database connection
 func Init(cfg *config.Config) error {
-	if cfg.DatabaseURL == "" {
+	if cfg.Database.URL == "" {
 		return fmt.Errorf("database URL is not configured")
 	}
 
-	var err error
-	DB, err = sql.Open("postgres", cfg.DatabaseURL)
+	connector, err := pq.NewConnector(cfg.Database.URL)
 	if err != nil {
 		return fmt.Errorf("failed to open database connection: %w", err)
 	}
+	DB = sql.OpenDB(&tracedConnector{base: connector})
 
 	// Set connection pool settings
-	DB.SetMaxOpenConns(25)
-	DB.SetMaxIdleConns(5)
-	DB.SetConnMaxLifetime(5 * time.Minute)
+	DB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	DB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	DB.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	DB.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
 
 	// Test the connection
 	if err := DB.Ping(); err != nil {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Database connection established successfully")
+	slog.Info("Database connection established successfully")
 	return nil
 }
 
@@ -54,6 +55,14 @@ func HealthCheck() error {
 	return DB.Ping()
 }
 
+// Ping checks the database connection within ctx
+func Ping(ctx context.Context) error {
+	if DB == nil {
+		return fmt.Errorf("database connection is not initialized")
+	}
+	return DB.PingContext(ctx)
+}
+
 // GetDB returns the database connection
 func GetDB() *sql.DB {
 	return DB
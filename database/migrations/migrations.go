@@ -3,10 +3,14 @@ package migrations
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"sort"
 )
 
-// Migration represents a database migration
+// Migration represents a database migration. Migrations run before
+// schema.sql, so they bring tables created by earlier versions of it up to
+// date; on a new database they find nothing to change and schema.sql
+// creates the current tables.
 type Migration struct {
 	Version int
 	Name    string
@@ -16,9 +20,11 @@ type Migration struct {
 
 var migrations []Migration
 
-// RegisterMigration registers a new migration
+// RegisterMigration registers a new migration. Migrations run in version
+// order, whichever package registers them.
 func RegisterMigration(migration Migration) {
 	migrations = append(migrations, migration)
+	sort.SliceStable(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
 }
 
 // GetMigrations returns all registered migrations
@@ -95,11 +101,11 @@ func RunMigrations(db *sql.DB) error {
 
 	for _, migration := range migrations {
 		if applied[migration.Version] {
-			log.Printf("Migration %d (%s) already applied, skipping", migration.Version, migration.Name)
+			slog.Debug("Migration already applied, skipping", "version", migration.Version, "name", migration.Name)
 			continue
 		}
 
-		log.Printf("Running migration %d (%s)", migration.Version, migration.Name)
+		slog.Info("Running migration", "version", migration.Version, "name", migration.Name)
 
 		if err := migration.Up(db); err != nil {
 			return fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
@@ -109,8 +115,63 @@ func RunMigrations(db *sql.DB) error {
 			return err
 		}
 
-		log.Printf("Migration %d (%s) completed successfully", migration.Version, migration.Name)
+		slog.Info("Migration completed successfully", "version", migration.Version, "name", migration.Name)
 	}
 
 	return nil
 }
+
+// PendingMigrations returns the registered migrations that have not been
+// applied yet. It does not create the tracking table, so it is safe to call
+// from health checks.
+func PendingMigrations(db *sql.DB) ([]Migration, error) {
+	if len(migrations) == 0 {
+		return nil, nil
+	}
+
+	var tracked bool
+	if err := db.QueryRow(`SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&tracked); err != nil {
+		return nil, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	if !tracked {
+		return migrations, nil
+	}
+
+	applied, err := GetAppliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// queryRower is a *sql.DB or *sql.Tx
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// TableExists reports whether the table exists in the public schema
+func TableExists(q queryRower, table string) (bool, error) {
+	var exists bool
+	err := q.QueryRow(`SELECT EXISTS (SELECT FROM information_schema.tables WHERE table_schema = 'public' AND table_name = $1)`, table).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check table %s: %w", table, err)
+	}
+	return exists, nil
+}
+
+// ColumnExists reports whether the table of the public schema has the column
+func ColumnExists(q queryRower, table, column string) (bool, error) {
+	var exists bool
+	err := q.QueryRow(`SELECT EXISTS (SELECT FROM information_schema.columns WHERE table_schema = 'public' AND table_name = $1 AND column_name = $2)`, table, column).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check column %s.%s: %w", table, column, err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier is the set of query methods shared by *sql.DB and *sql.Tx, so
+// repositories can run the same statements inside or outside a transaction
+type Querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// contextQuerier is the context-aware counterpart of Querier
+type contextQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// boundQuerier runs every statement with a fixed context
+type boundQuerier struct {
+	ctx context.Context
+	q   contextQuerier
+}
+
+func (b boundQuerier) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return b.q.ExecContext(b.ctx, query, args...)
+}
+
+func (b boundQuerier) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return b.q.QueryContext(b.ctx, query, args...)
+}
+
+func (b boundQuerier) QueryRow(query string, args ...interface{}) *sql.Row {
+	return b.q.QueryRowContext(b.ctx, query, args...)
+}
+
+// Conn returns a Querier for a repository: the transaction when tx is set,
+// otherwise the connection pool. Statements run with ctx, so they are traced
+// as part of the request and cancelled with it; a nil ctx means
+// context.Background().
+func Conn(ctx context.Context, db *sql.DB, tx *sql.Tx) Querier {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if tx != nil {
+		return boundQuerier{ctx: ctx, q: tx}
+	}
+	return boundQuerier{ctx: ctx, q: db}
+}
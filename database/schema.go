@@ -1,9 +1,10 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 )
@@ -27,7 +28,7 @@ func InitSchema() error {
 		return fmt.Errorf("failed to execute schema: %w", err)
 	}
 
-	log.Println("Database schema initialized successfully")
+	slog.Info("Database schema initialized successfully")
 	return nil
 }
 
@@ -96,3 +97,24 @@ func BeginTransaction() (*sql.Tx, error) {
 	}
 	return DB.Begin()
 }
+
+// WithTransaction runs fn in a transaction on db, committing when fn returns
+// nil and rolling back otherwise. A nil ctx means context.Background().
+func WithTransaction(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	if db == nil {
+		return fmt.Errorf("database connection is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "foodlink_backend/database"
+
+// tracedConnector wraps a driver.Connector so every query made with a traced
+// context produces a client span named after the statement
+type tracedConnector struct {
+	base driver.Connector
+}
+
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn}, nil
+}
+
+func (c *tracedConnector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// tracedConn forwards to the wrapped connection, adding spans around
+// QueryContext and ExecContext
+type tracedConn struct {
+	driver.Conn
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		endQuerySpan(span, err)
+		return nil, err
+	}
+	if span == nil {
+		return rows, nil
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, span := startQuerySpan(ctx, query)
+	result, err := execer.ExecContext(ctx, query, args)
+	if span != nil && err == nil {
+		if affected, affectedErr := result.RowsAffected(); affectedErr == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", affected))
+		}
+	}
+	endQuerySpan(span, err)
+	return result, err
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// tracedRows counts the rows read and ends the span when the result set is
+// exhausted or closed
+type tracedRows struct {
+	driver.Rows
+	span  trace.Span
+	count int64
+	ended bool
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.count++
+		return nil
+	}
+	if err == io.EOF {
+		r.end(nil)
+	} else {
+		r.end(err)
+	}
+	return err
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	r.end(nil)
+	return err
+}
+
+func (r *tracedRows) end(err error) {
+	if r.ended {
+		return
+	}
+	r.ended = true
+	r.span.SetAttributes(attribute.Int64("db.response.returned_rows", r.count))
+	endQuerySpan(r.span, err)
+}
+
+// startQuerySpan starts a span for query when ctx is part of a trace.
+// Queries outside a request, such as schema setup, are not traced.
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+	operation, table := describeStatement(query)
+	name := operation
+	if table != "" {
+		name += " " + table
+	}
+	return otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", operation),
+			attribute.String("db.collection.name", table),
+			attribute.String("db.query.text", strings.Join(strings.Fields(query), " ")),
+		),
+	)
+}
+
+func endQuerySpan(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// describeStatement extracts the operation and main table of a SQL statement,
+// e.g. "SELECT" and "inventory_items"
+func describeStatement(query string) (string, string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "SQL", ""
+	}
+	operation := strings.ToUpper(fields[0])
+
+	tableAfter := func(keyword string) string {
+		for i := 1; i < len(fields)-1; i++ {
+			if strings.EqualFold(fields[i], keyword) {
+				return strings.Trim(fields[i+1], "(),;")
+			}
+		}
+		return ""
+	}
+
+	switch operation {
+	case "SELECT", "DELETE":
+		return operation, tableAfter("FROM")
+	case "INSERT":
+		return operation, tableAfter("INTO")
+	case "UPDATE":
+		if len(fields) > 1 {
+			return operation, strings.Trim(fields[1], "(),;")
+		}
+	}
+	return operation, ""
+}
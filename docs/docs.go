@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/": {
+        "/admin/events": {
             "get": {
-                "description": "Welcome message for API v1",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List outbox entries, one per event and subscriber, most recent first. Use status=dead for the dead-letter queue (admin only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,22 +39,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "api"
+                    "events"
+                ],
+                "summary": "List event deliveries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "processing",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type, e.g. PickupDelivered",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries (default: 50, max: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "API v1 welcome",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/outbox.Entry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/auth/login": {
+        "/admin/events/{id}/retry": {
             "post": {
-                "description": "Authenticate user and get access token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Requeue a dead-lettered delivery with a fresh set of attempts (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -57,50 +114,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "events"
                 ],
-                "summary": "Login user",
+                "summary": "Retry dead-lettered event",
                 "parameters": [
                     {
-                        "description": "Login request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Outbox entry ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.AuthResponse"
+                            "$ref": "#/definitions/outbox.Entry"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/flags": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logout user (token invalidation handled client-side)",
+                "description": "List every feature flag with its targeting rules (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -108,36 +181,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "feature-flags"
                 ],
-                "summary": "Logout user",
+                "summary": "List feature flags",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/featureflags.Flag"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/auth/me": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get authenticated user information",
+                "description": "Create a feature flag. Percentage defaults to 100; empty role, organization and environment lists match everyone (admin only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -145,33 +222,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "feature-flags"
+                ],
+                "summary": "Create feature flag",
+                "parameters": [
+                    {
+                        "description": "Feature flag",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/flags.CreateFlagRequest"
+                        }
+                    }
                 ],
-                "summary": "Get current user",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/auth.UserResponse"
+                            "$ref": "#/definitions/featureflags.Flag"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
+        "/admin/flags/{key}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Refresh the access token using refresh token",
+                "description": "Get a feature flag with its targeting rules (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -179,28 +285,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "feature-flags"
+                ],
+                "summary": "Get feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Flag key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Refresh access token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.AuthResponse"
+                            "$ref": "#/definitions/featureflags.Flag"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a feature flag's settings and targeting rules (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -208,50 +338,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "feature-flags"
                 ],
-                "summary": "Register a new user",
+                "summary": "Update feature flag",
                 "parameters": [
                     {
-                        "description": "Registration request",
+                        "type": "string",
+                        "description": "Flag key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feature flag",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterRequest"
+                            "$ref": "#/definitions/flags.UpdateFlagRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.AuthResponse"
+                            "$ref": "#/definitions/featureflags.Flag"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/badges": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all badges for the authenticated user",
+                "description": "Delete a feature flag; it evaluates as off afterwards (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -259,36 +406,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "badges"
+                    "feature-flags"
+                ],
+                "summary": "Delete feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Flag key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user badges",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/badges.Badge"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/badges/available": {
+        "/admin/jobs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of all available badges",
+                "description": "List the registered background jobs with their cron schedule, next fire time and latest run, and whether the answering instance is the scheduler leader (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -296,36 +464,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "badges"
+                    "jobs"
                 ],
-                "summary": "Get available badges",
+                "summary": "List background jobs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/badges.AvailableBadge"
-                            }
+                            "$ref": "#/definitions/jobruns.Overview"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/badges/unlock": {
-            "post": {
+        "/admin/jobs/runs": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Unlock a badge for the authenticated user (system endpoint)",
+                "description": "List background job runs, most recent first. Use status=failed for runs that used up their attempts (admin only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -333,56 +504,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "badges"
+                    "jobs"
                 ],
-                "summary": "Unlock badge",
+                "summary": "List job runs",
                 "parameters": [
                     {
-                        "description": "Badge unlock data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/badges.UnlockBadgeRequest"
-                        }
+                        "enum": [
+                            "pending",
+                            "running",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Run status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job name, e.g. ngo_offers.expire_offers",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of runs (default: 50, max: 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/badges.Badge"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/jobruns.Run"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/impact": {
-            "get": {
+        "/admin/jobs/runs/{id}/retry": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get overall community impact statistics",
+                "description": "Requeue a failed run with a fresh set of attempts (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -390,67 +579,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-impact"
+                    "jobs"
+                ],
+                "summary": "Retry failed job run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get community impact",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/leaderboard.Impact"
+                            "$ref": "#/definitions/jobruns.Run"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
-                    }
-                }
-            }
-        },
-        "/community/impact/personal": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get personal contribution statistics",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "community-impact"
-                ],
-                "summary": "Get personal impact",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/leaderboard.Impact"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/kitchen-events": {
-            "get": {
+        "/admin/jobs/{name}/run": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all community kitchen events, optionally filtered by status",
+                "description": "Enqueue a one-off run of a registered job, due immediately (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -458,93 +646,121 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-kitchen-events"
+                    "jobs"
                 ],
-                "summary": "List kitchen events",
+                "summary": "Run job now",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by status (upcoming, in-progress, completed)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/kitchen_events.KitchenEvent"
-                            }
+                            "$ref": "#/definitions/jobruns.Run"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/recipes/import": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new community kitchen event",
+                "description": "Import a catalog file (admin only): a JSON array of recipes, or with Content-Type text/csv, one row per ingredient with a header naming recipe, ingredient and any of description, cuisine, meal_type, tags (separated by semicolons), prep_minutes, servings, instructions, quantity, unit, food_item and optional. Recipes are matched by name and replaced; ingredients are linked to the food item of their name or food_item. All recipes are imported or none.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "community-kitchen-events"
+                    "recipes"
                 ],
-                "summary": "Create kitchen event",
+                "summary": "Import recipes",
                 "parameters": [
                     {
-                        "description": "Kitchen event data",
+                        "description": "Catalog",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.CreateKitchenEventRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recipes.RecipeInput"
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
+                            "$ref": "#/definitions/recipes.ImportResult"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/kitchen-events/{id}": {
-            "get": {
+        "/admin/recipes/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific kitchen event",
+                "description": "Remove a recipe from the catalog (admin only). Meal plans made from it are kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -552,13 +768,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-kitchen-events"
+                    "recipes"
                 ],
-                "summary": "Get kitchen event by ID",
+                "summary": "Delete recipe",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kitchen Event ID",
+                        "description": "Recipe ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -568,30 +784,47 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/resources": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing kitchen event",
+                "description": "Add a resource to the library (admin only). Category and tags are stored lowercased.",
                 "consumes": [
                     "application/json"
                 ],
@@ -599,63 +832,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-kitchen-events"
+                    "resources"
                 ],
-                "summary": "Update kitchen event",
+                "summary": "Create resource",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Kitchen Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Kitchen event data",
+                        "description": "Resource",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.UpdateKitchenEventRequest"
+                            "$ref": "#/definitions/resources.CreateResourceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
+                            "$ref": "#/definitions/resources.Resource"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/kitchen-events/{id}/volunteer": {
-            "post": {
+        "/admin/resources/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Sign up as a volunteer for a kitchen event",
+                "description": "Update the given fields of a resource (admin only). Tags, when given, replace the resource's tags.",
                 "consumes": [
                     "application/json"
                 ],
@@ -663,24 +889,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-kitchen-events"
+                    "resources"
                 ],
-                "summary": "Volunteer for event",
+                "summary": "Update resource",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Kitchen Event ID",
+                        "description": "Resource ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Volunteer data",
+                        "description": "Fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.VolunteerRequest"
+                            "$ref": "#/definitions/resources.UpdateResourceRequest"
                         }
                     }
                 ],
@@ -688,32 +914,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
+                            "$ref": "#/definitions/resources.Resource"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/community/leaderboard": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get community leaderboard by type",
+                "description": "Remove a resource from the library (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -721,41 +957,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leaderboard"
+                    "resources"
                 ],
-                "summary": "Get leaderboard",
+                "summary": "Delete resource",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Leaderboard type (top-sharers, zero-waste, volunteer-stars, building-impact, weekly-xp)",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/leaderboard.Leaderboard"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/leftovers": {
+        "/api/v1/": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all leftover items, optionally filtered by status",
+                "description": "Welcome message for API v1",
                 "consumes": [
                     "application/json"
                 ],
@@ -763,42 +1016,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
-                ],
-                "summary": "List leftover items",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by status (available, claimed)",
-                        "name": "status",
-                        "in": "query"
-                    }
+                    "api"
                 ],
+                "summary": "API v1 welcome",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/leftovers.LeftoverItem"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/handlers.APIResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new leftover item post",
+                "description": "Authenticate user and get access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -806,50 +1039,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
+                    "auth"
                 ],
-                "summary": "Create leftover item",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "description": "Leftover item data",
+                        "description": "Login request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/leftovers.CreateLeftoverItemRequest"
+                            "$ref": "#/definitions/auth.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/leftovers.LeftoverItem"
+                            "$ref": "#/definitions/auth.AuthResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/leftovers/{id}": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific leftover item",
+                "description": "Logout user (token invalidation handled client-side)",
                 "consumes": [
                     "application/json"
                 ],
@@ -857,46 +1090,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
-                ],
-                "summary": "Get leftover item by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Leftover Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Logout user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/leftovers.LeftoverItem"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/me": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing leftover item",
+                "description": "Get authenticated user information",
                 "consumes": [
                     "application/json"
                 ],
@@ -904,67 +1127,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
-                ],
-                "summary": "Update leftover item",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Leftover Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Leftover item data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/leftovers.UpdateLeftoverItemRequest"
-                        }
-                    }
+                    "auth"
                 ],
+                "summary": "Get current user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/leftovers.LeftoverItem"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/auth.UserResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/refresh": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a leftover item",
+                "description": "Refresh the access token using refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -972,57 +1161,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
-                ],
-                "summary": "Delete leftover item",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Leftover Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Refresh access token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/auth.AuthResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/leftovers/{id}/claim": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Claim a leftover item",
+                "description": "Register a new user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -1030,24 +1190,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
+                    "auth"
                 ],
-                "summary": "Claim leftover",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Leftover Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Claim data",
+                        "description": "Registration request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/leftovers.CreateLeftoverClaimRequest"
+                            "$ref": "#/definitions/auth.RegisterRequest"
                         }
                     }
                 ],
@@ -1055,32 +1208,32 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/leftovers.LeftoverClaim"
+                            "$ref": "#/definitions/auth.AuthResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/leftovers/{id}/claims": {
+        "/badges": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all claims for a leftover item",
+                "description": "Get all badges for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1088,45 +1241,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-leftovers"
-                ],
-                "summary": "Get claims",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Leftover Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "badges"
                 ],
+                "summary": "Get user badges",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/leftovers.LeftoverClaim"
+                                "$ref": "#/definitions/badges.Badge"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/profile": {
+        "/badges/available": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the authenticated user's community profile",
+                "description": "Get list of all available badges",
                 "consumes": [
                     "application/json"
                 ],
@@ -1134,37 +1278,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-profiles"
+                    "badges"
                 ],
-                "summary": "Get user's community profile",
+                "summary": "Get available badges",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/profiles.CommunityProfile"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/badges.AvailableBadge"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/badges/unlock": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the authenticated user's community profile",
+                "description": "Unlock a badge for the authenticated user (system endpoint)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1172,54 +1315,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-profiles"
+                    "badges"
                 ],
-                "summary": "Update community profile",
+                "summary": "Unlock badge",
                 "parameters": [
                     {
-                        "description": "Profile data",
+                        "description": "Badge unlock data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/profiles.UpdateProfileRequest"
+                            "$ref": "#/definitions/badges.UnlockBadgeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/profiles.CommunityProfile"
+                            "$ref": "#/definitions/badges.Badge"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/calendar/feeds": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new community profile for the authenticated user",
+                "description": "List the user's calendar feeds that are not revoked. Feed URLs are only returned when feeds are created.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1227,17 +1372,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-profiles"
+                    "calendar"
                 ],
-                "summary": "Create community profile",
-                "parameters": [
-                    {
-                        "description": "Profile data",
+                "summary": "List calendar feeds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/calendar.Feed"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an iCalendar feed to subscribe to from a calendar app: the household's meal plans (meal-plans), the community kitchen events the user volunteers for (kitchen-events), or, for NGO accounts, their scheduled pickups with volunteer and donor contact details (pickups). The returned url holds a secret token and is shown only once; anyone with it can read the feed until it is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Create calendar feed",
+                "parameters": [
+                    {
+                        "description": "Feed kind and name",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/profiles.CreateProfileRequest"
+                            "$ref": "#/definitions/calendar.CreateFeedRequest"
                         }
                     }
                 ],
@@ -1245,32 +1425,38 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/profiles.CommunityProfile"
+                            "$ref": "#/definitions/calendar.Feed"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/profile/{username}": {
-            "get": {
+        "/calendar/feeds/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a community profile by username",
+                "description": "Revoke a calendar feed. Its URL stops working at once; calendar apps subscribed to it keep the events they last fetched.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1278,14 +1464,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-profiles"
+                    "calendar"
                 ],
-                "summary": "Get profile by username",
+                "summary": "Revoke calendar feed",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username",
-                        "name": "username",
+                        "description": "Feed ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1294,32 +1480,82 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/profiles.CommunityProfile"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/surplus": {
+        "/calendar/{token}.ics": {
+            "get": {
+                "description": "Get an iCalendar (RFC 5545) feed by the secret token of its URL, without signing in. Events keep their UID across updates, and their SEQUENCE goes up when their time, title, details, location or status change. Events from the last 30 days are included. Meals and kitchen events are in local time; pickups are in UTC. Responses carry an ETag for conditional requests.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar object",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/community/impact": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all community surplus posts, optionally filtered by status",
+                "description": "Get overall community impact statistics",
                 "consumes": [
                     "application/json"
                 ],
@@ -1327,13 +1563,81 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-impact"
                 ],
-                "summary": "List surplus posts",
+                "summary": "Get community impact",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/leaderboard.Impact"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/community/impact/personal": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get personal contribution statistics",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "community-impact"
+                ],
+                "summary": "Get personal impact",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/leaderboard.Impact"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/community/kitchen-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all community kitchen events, optionally filtered by status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "community-kitchen-events"
+                ],
+                "summary": "List kitchen events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by status (available, claimed, expired)",
+                        "description": "Filter by status (upcoming, in-progress, completed)",
                         "name": "status",
                         "in": "query"
                     }
@@ -1344,14 +1648,14 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/surplus.SurplusPost"
+                                "$ref": "#/definitions/kitchen_events.KitchenEvent"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -1362,7 +1666,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new community surplus post",
+                "description": "Create a new community kitchen event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1370,17 +1674,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-kitchen-events"
                 ],
-                "summary": "Create surplus post",
+                "summary": "Create kitchen event",
                 "parameters": [
                     {
-                        "description": "Surplus post data",
+                        "description": "Kitchen event data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/surplus.CreateSurplusPostRequest"
+                            "$ref": "#/definitions/kitchen_events.CreateKitchenEventRequest"
                         }
                     }
                 ],
@@ -1388,32 +1692,32 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/surplus.SurplusPost"
+                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/surplus/{id}": {
+        "/community/kitchen-events/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific surplus post",
+                "description": "Get details of a specific kitchen event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1421,13 +1725,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-kitchen-events"
                 ],
-                "summary": "Get surplus post by ID",
+                "summary": "Get kitchen event by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
+                        "description": "Kitchen Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1437,19 +1741,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/surplus.SurplusPost"
+                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -1460,7 +1764,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing surplus post",
+                "description": "Update an existing kitchen event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1468,24 +1772,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-kitchen-events"
                 ],
-                "summary": "Update surplus post",
+                "summary": "Update kitchen event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
+                        "description": "Kitchen Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Surplus post data",
+                        "description": "Kitchen event data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/surplus.UpdateSurplusPostRequest"
+                            "$ref": "#/definitions/kitchen_events.UpdateKitchenEventRequest"
                         }
                     }
                 ],
@@ -1493,42 +1797,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/surplus.SurplusPost"
+                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/community/kitchen-events/{id}/volunteer": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a surplus post",
+                "description": "Sign up as a volunteer for a kitchen event",
                 "consumes": [
                     "application/json"
                 ],
@@ -1536,57 +1836,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-kitchen-events"
                 ],
-                "summary": "Delete surplus post",
+                "summary": "Volunteer for event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
+                        "description": "Kitchen Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Volunteer data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/kitchen_events.VolunteerRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/kitchen_events.KitchenEvent"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/surplus/{id}/comments": {
+        "/community/leaderboard": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all comments for a surplus post",
+                "description": "Get community leaderboard by type",
                 "consumes": [
                     "application/json"
                 ],
@@ -1594,43 +1894,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-leaderboard"
                 ],
-                "summary": "Get comments",
+                "summary": "Get leaderboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Leaderboard type (top-sharers, zero-waste, volunteer-stars, building-impact, weekly-xp)",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/surplus.SurplusComment"
-                            }
+                            "$ref": "#/definitions/leaderboard.Leaderboard"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/community/leftovers": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a comment to a surplus post",
+                "description": "Get all leftover items, optionally filtered by status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1638,57 +1936,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-leftovers"
                 ],
-                "summary": "Add comment",
+                "summary": "List leftover items",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Comment data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/surplus.CreateSurplusCommentRequest"
-                        }
+                        "description": "Filter by status (available, claimed)",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/surplus.SurplusComment"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/leftovers.LeftoverItem"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/community/surplus/{id}/request": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a request for a surplus post",
+                "description": "Create a new leftover item post",
                 "consumes": [
                     "application/json"
                 ],
@@ -1696,24 +1979,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-leftovers"
                 ],
-                "summary": "Request surplus",
+                "summary": "Create leftover item",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Surplus Post ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Request data",
+                        "description": "Leftover item data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/surplus.CreateSurplusRequestRequest"
+                            "$ref": "#/definitions/leftovers.CreateLeftoverItemRequest"
                         }
                     }
                 ],
@@ -1721,32 +1997,32 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/surplus.SurplusRequest"
+                            "$ref": "#/definitions/leftovers.LeftoverItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/community/surplus/{id}/requests": {
+        "/community/leftovers/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all requests for a surplus post (post owner only)",
+                "description": "Get details of a specific leftover item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1754,13 +2030,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-leftovers"
                 ],
-                "summary": "Get surplus requests",
+                "summary": "Get leftover item by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
+                        "description": "Leftover Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1770,35 +2046,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/surplus.SurplusRequest"
-                            }
+                            "$ref": "#/definitions/leftovers.LeftoverItem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/community/surplus/{id}/requests/{requestId}": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Approve or decline a surplus request (post owner only)",
+                "description": "Update an existing leftover item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1806,31 +2077,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "community-surplus"
+                    "community-leftovers"
                 ],
-                "summary": "Approve/decline request",
+                "summary": "Update leftover item",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Post ID",
+                        "description": "Leftover Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Request ID",
-                        "name": "requestId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Request status",
+                        "description": "Leftover item data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/surplus.UpdateSurplusRequestRequest"
+                            "$ref": "#/definitions/leftovers.UpdateLeftoverItemRequest"
                         }
                     }
                 ],
@@ -1838,38 +2102,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/surplus.SurplusRequest"
+                            "$ref": "#/definitions/leftovers.LeftoverItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/consumption": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all consumption logs for the authenticated user",
+                "description": "Delete a leftover item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1877,85 +2145,131 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "consumption"
+                    "community-leftovers"
+                ],
+                "summary": "Delete leftover item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leftover Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get consumption logs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/consumption.ConsumptionLog"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new consumption log entry",
+                "description": "Partially update a leftover item with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "consumption"
+                    "community-leftovers"
                 ],
-                "summary": "Log consumption",
+                "summary": "Patch leftover item",
                 "parameters": [
                     {
-                        "description": "Consumption log data",
+                        "type": "string",
+                        "description": "Leftover Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch document",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/consumption.CreateConsumptionLogRequest"
+                            "$ref": "#/definitions/leftovers.PatchLeftoverItemRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/consumption.ConsumptionLog"
+                            "$ref": "#/definitions/leftovers.LeftoverItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/consumption/stats": {
-            "get": {
+        "/community/leftovers/{id}/claim": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get consumption statistics for the authenticated user",
+                "description": "Claim a leftover item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1963,33 +2277,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "consumption"
+                    "community-leftovers"
+                ],
+                "summary": "Claim leftover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Leftover Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Claim data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/leftovers.CreateLeftoverClaimRequest"
+                        }
+                    }
                 ],
-                "summary": "Get consumption statistics",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/consumption.ConsumptionStats"
+                            "$ref": "#/definitions/leftovers.LeftoverClaim"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/consumption/{id}": {
+        "/community/leftovers/{id}/claims": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific consumption log",
+                "description": "Get all claims for a leftover item",
                 "consumes": [
                     "application/json"
                 ],
@@ -1997,13 +2335,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "consumption"
+                    "community-leftovers"
                 ],
-                "summary": "Get consumption log by ID",
+                "summary": "Get claims",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Consumption Log ID",
+                        "description": "Leftover Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2013,30 +2351,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/consumption.ConsumptionLog"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/leftovers.LeftoverClaim"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/community/profile": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing consumption log",
+                "description": "Get the authenticated user's community profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -2044,67 +2381,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "consumption"
-                ],
-                "summary": "Update consumption log",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Consumption Log ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Consumption log data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/consumption.UpdateConsumptionLogRequest"
-                        }
-                    }
+                    "community-profiles"
                 ],
+                "summary": "Get user's community profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/consumption.ConsumptionLog"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/profiles.CommunityProfile"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a consumption log entry",
+                "description": "Update the authenticated user's community profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -2112,76 +2419,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "consumption"
+                    "community-profiles"
                 ],
-                "summary": "Delete consumption log",
+                "summary": "Update community profile",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Consumption Log ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Profile data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/profiles.UpdateProfileRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/profiles.CommunityProfile"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    }
-                }
-            }
-        },
-        "/food-items": {
-            "get": {
-                "description": "Get a list of all food items (reference data)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "food-items"
-                ],
-                "summary": "List all food items",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/food_items.FoodItem"
-                            }
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Create a new food item (admin only)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new community profile for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2189,17 +2474,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "food-items"
+                    "community-profiles"
                 ],
-                "summary": "Create food item",
+                "summary": "Create community profile",
                 "parameters": [
                     {
-                        "description": "Food item data",
+                        "description": "Profile data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/food_items.CreateFoodItemRequest"
+                            "$ref": "#/definitions/profiles.CreateProfileRequest"
                         }
                     }
                 ],
@@ -2207,82 +2492,48 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/food_items.FoodItem"
+                            "$ref": "#/definitions/profiles.CommunityProfile"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    }
-                }
-            }
-        },
-        "/food-items/{id}": {
-            "get": {
-                "description": "Get details of a specific food item",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "food-items"
-                ],
-                "summary": "Get food item by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Food Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/food_items.FoodItem"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing food item (admin only)",
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially update the authenticated user's community profile with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "food-items"
+                    "community-profiles"
                 ],
-                "summary": "Update food item",
+                "summary": "Patch community profile",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Food Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Food item data",
+                        "description": "Merge patch document",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/food_items.UpdateFoodItemRequest"
+                            "$ref": "#/definitions/profiles.PatchProfileRequest"
                         }
                     }
                 ],
@@ -2290,25 +2541,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/food_items.FoodItem"
+                            "$ref": "#/definitions/profiles.CommunityProfile"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a food item (admin only)",
+            }
+        },
+        "/community/profile/{username}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a community profile by username",
                 "consumes": [
                     "application/json"
                 ],
@@ -2316,14 +2586,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "food-items"
+                    "community-profiles"
                 ],
-                "summary": "Delete food item",
+                "summary": "Get profile by username",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Food Item ID",
-                        "name": "id",
+                        "description": "Username",
+                        "name": "username",
                         "in": "path",
                         "required": true
                     }
@@ -2332,52 +2602,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/profiles.CommunityProfile"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "Check if the server is running and database connectivity",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.HealthResponse"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/inventory": {
+        "/community/surplus": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all inventory items for the authenticated user",
+                "description": "Get all community surplus posts, optionally filtered by status",
                 "consumes": [
                     "application/json"
                 ],
@@ -2385,23 +2635,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
+                ],
+                "summary": "List surplus posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (available, claimed, expired)",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user inventory",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/inventory.InventoryItem"
+                                "$ref": "#/definitions/surplus.SurplusPost"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -2412,7 +2670,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new item to user's inventory",
+                "description": "Create a new community surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2420,17 +2678,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
                 ],
-                "summary": "Add inventory item",
+                "summary": "Create surplus post",
                 "parameters": [
                     {
-                        "description": "Inventory item data",
+                        "description": "Surplus post data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/inventory.CreateInventoryItemRequest"
+                            "$ref": "#/definitions/surplus.CreateSurplusPostRequest"
                         }
                     }
                 ],
@@ -2438,32 +2696,32 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/inventory.InventoryItem"
+                            "$ref": "#/definitions/surplus.SurplusPost"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/inventory/expired": {
+        "/community/surplus/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all expired inventory items",
+                "description": "Get details of a specific surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2471,36 +2729,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
+                ],
+                "summary": "Get surplus post by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Surplus Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get expired items",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/inventory.InventoryItem"
-                            }
+                            "$ref": "#/definitions/surplus.SurplusPost"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/inventory/expiring": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get inventory items expiring within specified days (default 7)",
+                "description": "Update an existing surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2508,44 +2776,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
                 ],
-                "summary": "Get expiring items",
+                "summary": "Update surplus post",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of days (default: 7)",
-                        "name": "days",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Surplus Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Surplus post data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/surplus.UpdateSurplusPostRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/inventory.InventoryItem"
-                            }
+                            "$ref": "#/definitions/surplus.SurplusPost"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/inventory/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific inventory item",
+                "description": "Delete a surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2553,13 +2844,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
                 ],
-                "summary": "Get inventory item by ID",
+                "summary": "Delete surplus post",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Inventory Item ID",
+                        "description": "Surplus Post ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2569,55 +2860,64 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/inventory.InventoryItem"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
-            "put": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing inventory item",
+                "description": "Partially update a surplus post with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
                 ],
-                "summary": "Update inventory item",
+                "summary": "Patch surplus post",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Inventory Item ID",
+                        "description": "Surplus Post ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Inventory item data",
+                        "description": "Merge patch document",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/inventory.UpdateInventoryItemRequest"
+                            "$ref": "#/definitions/surplus.PatchSurplusPostRequest"
                         }
                     }
                 ],
@@ -2625,42 +2925,50 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/inventory.InventoryItem"
+                            "$ref": "#/definitions/surplus.SurplusPost"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/community/surplus/{id}/comments": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an inventory item",
+                "description": "Get all comments for a surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2668,13 +2976,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "inventory"
+                    "community-surplus"
                 ],
-                "summary": "Delete inventory item",
+                "summary": "Get comments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Inventory Item ID",
+                        "description": "Surplus Post ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2684,41 +2992,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/surplus.SurplusComment"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/ngo/capacity": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get NGO capacity settings for the authenticated user",
+                "description": "Add a comment to a surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2726,37 +3020,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ngo-capacity"
+                    "community-surplus"
+                ],
+                "summary": "Add comment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Surplus Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/surplus.CreateSurplusCommentRequest"
+                        }
+                    }
                 ],
-                "summary": "Get capacity settings",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/capacity.NGOCapacitySettings"
+                            "$ref": "#/definitions/surplus.SurplusComment"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/community/surplus/{id}/request": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create or update NGO capacity settings",
+                "description": "Create a request for a surplus post",
                 "consumes": [
                     "application/json"
                 ],
@@ -2764,56 +3078,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ngo-capacity"
+                    "community-surplus"
                 ],
-                "summary": "Create or update capacity settings",
+                "summary": "Request surplus",
                 "parameters": [
                     {
-                        "description": "Capacity settings data",
+                        "type": "string",
+                        "description": "Surplus Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/capacity.CreateNGOCapacitySettingsRequest"
+                            "$ref": "#/definitions/surplus.CreateSurplusRequestRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/capacity.NGOCapacitySettings"
-                        }
-                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/capacity.NGOCapacitySettings"
+                            "$ref": "#/definitions/surplus.SurplusRequest"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/ngo/offers": {
+        "/community/surplus/{id}/requests": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all donation offers for the authenticated NGO",
+                "description": "Get all requests for a surplus post (post owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2821,15 +3136,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ngo-offers"
+                    "community-surplus"
                 ],
-                "summary": "List donation offers",
+                "summary": "Get surplus requests",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Surplus Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2838,27 +3154,33 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/offers.NGODonationOffer"
+                                "$ref": "#/definitions/surplus.SurplusRequest"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/ngo/offers/{id}": {
-            "get": {
+        "/community/surplus/{id}/requests/{requestId}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific donation offer",
+                "description": "Approve or decline a surplus request (post owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2866,158 +3188,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ngo-offers"
+                    "community-surplus"
                 ],
-                "summary": "Get offer details",
+                "summary": "Approve/decline request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Offer ID",
+                        "description": "Surplus Post ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/offers.NGODonationOffer"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    }
-                }
-            }
-        },
-        "/ngo/offers/{id}/accept": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Accept a donation offer",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ngo-offers"
-                ],
-                "summary": "Accept offer",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Offer ID",
-                        "name": "id",
+                        "description": "Request ID",
+                        "name": "requestId",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/offers.NGODonationOffer"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    {
+                        "description": "Request status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/surplus.UpdateSurplusRequestRequest"
                         }
                     }
-                }
-            }
-        },
-        "/ngo/offers/{id}/decline": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Decline a donation offer",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ngo-offers"
-                ],
-                "summary": "Decline offer",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Offer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/offers.NGODonationOffer"
+                            "$ref": "#/definitions/surplus.SurplusRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/nutrition": {
+        "/consumption": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get nutrition data for the authenticated user within a date range",
+                "description": "Get all consumption logs for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3025,37 +3259,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "nutrition"
-                ],
-                "summary": "Get nutrition data",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query"
-                    }
+                    "consumption"
                 ],
+                "summary": "Get consumption logs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/nutrition.NutritionData"
+                                "$ref": "#/definitions/consumption.ConsumptionLog"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -3066,7 +3286,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new nutrition data entry",
+                "description": "Create a new consumption log entry",
                 "consumes": [
                     "application/json"
                 ],
@@ -3074,17 +3294,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "nutrition"
+                    "consumption"
                 ],
-                "summary": "Log nutrition data",
+                "summary": "Log consumption",
                 "parameters": [
                     {
-                        "description": "Nutrition data",
+                        "description": "Consumption log data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/nutrition.CreateNutritionDataRequest"
+                            "$ref": "#/definitions/consumption.CreateConsumptionLogRequest"
                         }
                     }
                 ],
@@ -3092,32 +3312,32 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/nutrition.NutritionData"
+                            "$ref": "#/definitions/consumption.ConsumptionLog"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/nutrition/stats": {
-            "get": {
+        "/consumption/batch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get nutrition statistics for the authenticated user",
+                "description": "Apply up to 500 create, update and delete operations to the authenticated user's consumption logs. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3125,41 +3345,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "nutrition"
+                    "consumption"
                 ],
-                "summary": "Get nutrition statistics",
+                "summary": "Batch create, update and delete consumption logs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Number of days (default: 30)",
-                        "name": "days",
-                        "in": "query"
+                        "description": "Batch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/nutrition.NutritionStats"
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
                         }
                     }
                 }
             }
         },
-        "/nutrition/today": {
+        "/consumption/stats": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get today's nutrition data for the authenticated user",
+                "description": "Get consumption statistics for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3167,39 +3408,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "nutrition"
+                    "consumption"
                 ],
-                "summary": "Get today's nutrition",
+                "summary": "Get consumption statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/nutrition.NutritionData"
+                            "$ref": "#/definitions/consumption.ConsumptionStats"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/nutrition/{id}": {
+        "/consumption/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific nutrition data entry",
+                "description": "Get details of a specific consumption log",
                 "consumes": [
                     "application/json"
                 ],
@@ -3207,13 +3442,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "nutrition"
+                    "consumption"
                 ],
-                "summary": "Get nutrition data by ID",
+                "summary": "Get consumption log by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nutrition Data ID",
+                        "description": "Consumption Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3223,19 +3458,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/nutrition.NutritionData"
+                            "$ref": "#/definitions/consumption.ConsumptionLog"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -3246,7 +3481,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing nutrition data entry",
+                "description": "Update an existing consumption log",
                 "consumes": [
                     "application/json"
                 ],
@@ -3254,24 +3489,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "nutrition"
+                    "consumption"
                 ],
-                "summary": "Update nutrition data",
+                "summary": "Update consumption log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Nutrition Data ID",
+                        "description": "Consumption Log ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Nutrition data",
+                        "description": "Consumption log data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/nutrition.UpdateNutritionDataRequest"
+                            "$ref": "#/definitions/consumption.UpdateConsumptionLogRequest"
                         }
                     }
                 ],
@@ -3279,44 +3514,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/nutrition.NutritionData"
+                            "$ref": "#/definitions/consumption.ConsumptionLog"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/preferences": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get family preferences for the authenticated user's household",
+                "description": "Delete a consumption log entry",
                 "consumes": [
                     "application/json"
                 ],
@@ -3324,37 +3557,89 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "preferences"
+                    "consumption"
+                ],
+                "summary": "Delete consumption log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Consumption Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get family preferences",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/digests/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get which email digests the user receives. Until changed, families get the weekly digest and restaurants and NGOs the daily one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "digests"
+                ],
+                "summary": "Get email digest subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/digests.Subscriptions"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create or update family preferences",
+                "description": "Subscribe to or unsubscribe from the daily and weekly email digests; fields left out are unchanged",
                 "consumes": [
                     "application/json"
                 ],
@@ -3362,17 +3647,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "preferences"
+                    "digests"
                 ],
-                "summary": "Create or update preferences",
+                "summary": "Update email digest subscriptions",
                 "parameters": [
                     {
-                        "description": "Preferences data",
-                        "name": "request",
+                        "description": "Subscriptions to change",
+                        "name": "subscriptions",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/preferences.CreatePreferencesRequest"
+                            "$ref": "#/definitions/digests.UpdateSubscriptionsRequest"
                         }
                     }
                 ],
@@ -3380,88 +3665,146 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/preferences.FamilyPreferences"
-                        }
-                    },
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                            "$ref": "#/definitions/digests.Subscriptions"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create or update family preferences",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/digests/unsubscribe": {
+            "get": {
+                "description": "Target of the unsubscribe link in every digest; no sign-in needed. The link is signed for one user and frequency. POST serves one-click unsubscribe from mail clients (RFC 8058).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "preferences"
+                    "digests"
                 ],
-                "summary": "Create or update preferences",
+                "summary": "Unsubscribe from an email digest",
                 "parameters": [
                     {
-                        "description": "Preferences data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/preferences.CreatePreferencesRequest"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "daily or weekly",
+                        "name": "frequency",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "201": {
-                        "description": "Created",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Target of the unsubscribe link in every digest; no sign-in needed. The link is signed for one user and frequency. POST serves one-click unsubscribe from mail clients (RFC 8058).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "digests"
+                ],
+                "summary": "Unsubscribe from an email digest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "daily or weekly",
+                        "name": "frequency",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/price-comparisons": {
+        "/flags": {
             "get": {
-                "description": "Get all price comparisons",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every feature flag evaluated for the caller. Anonymous callers get the flags that apply to everyone.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3469,23 +3812,46 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "price-comparisons"
+                    "feature-flags"
                 ],
-                "summary": "Get price comparisons",
+                "summary": "Get feature flags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/flags.EvaluatedFlags"
+                        }
+                    }
+                }
+            }
+        },
+        "/food-items": {
+            "get": {
+                "description": "Get a list of all food items (reference data)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "food-items"
+                ],
+                "summary": "List all food items",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/price_comparisons.PriceComparison"
+                                "$ref": "#/definitions/food_items.FoodItem"
                             }
                         }
                     }
                 }
             },
             "post": {
-                "description": "Create a new price comparison",
+                "description": "Create a new food item (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3493,17 +3859,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "price-comparisons"
+                    "food-items"
                 ],
-                "summary": "Create price comparison",
+                "summary": "Create food item",
                 "parameters": [
                     {
-                        "description": "Price comparison data",
+                        "description": "Food item data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/price_comparisons.CreatePriceComparisonRequest"
+                            "$ref": "#/definitions/food_items.CreateFoodItemRequest"
                         }
                     }
                 ],
@@ -3511,21 +3877,21 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/price_comparisons.PriceComparison"
+                            "$ref": "#/definitions/food_items.FoodItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/price-comparisons/{id}": {
+        "/food-items/{id}": {
             "get": {
-                "description": "Get details of a specific price comparison",
+                "description": "Get details of a specific food item",
                 "consumes": [
                     "application/json"
                 ],
@@ -3533,13 +3899,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "price-comparisons"
+                    "food-items"
                 ],
-                "summary": "Get price comparison by ID",
+                "summary": "Get food item by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Price Comparison ID",
+                        "description": "Food Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3549,19 +3915,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/price_comparisons.PriceComparison"
+                            "$ref": "#/definitions/food_items.FoodItem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
             "put": {
-                "description": "Update an existing price comparison",
+                "description": "Update an existing food item (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3569,24 +3935,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "price-comparisons"
+                    "food-items"
                 ],
-                "summary": "Update price comparison",
+                "summary": "Update food item",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Price Comparison ID",
+                        "description": "Food Item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Price comparison data",
+                        "description": "Food item data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/price_comparisons.UpdatePriceComparisonRequest"
+                            "$ref": "#/definitions/food_items.UpdateFoodItemRequest"
                         }
                     }
                 ],
@@ -3594,32 +3960,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/price_comparisons.PriceComparison"
+                            "$ref": "#/definitions/food_items.FoodItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/restaurant/donations": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all donation logs for the authenticated restaurant",
+            },
+            "delete": {
+                "description": "Delete a food item (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3627,34 +3986,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-donations"
+                    "food-items"
+                ],
+                "summary": "Delete food item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List donations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/donations.DonationLog"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new donation log entry",
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the server is running and database connectivity. Returns 503 when the database is unreachable; prefer /livez and /readyz for probes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3662,90 +4027,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-donations"
-                ],
-                "summary": "Log donation",
-                "parameters": [
-                    {
-                        "description": "Donation log data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/donations.CreateDonationLogRequest"
-                        }
-                    }
+                    "health"
                 ],
+                "summary": "Health check",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/donations.DonationLog"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/handlers.HealthResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/handlers.HealthResponse"
                         }
                     }
                 }
             }
         },
-        "/restaurant/impact": {
+        "/health/details": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get impact metrics for the authenticated restaurant",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Runs every dependency check (database, pending migrations, scheduler, notification and webhook delivery backlogs) and reports status, latency and errors per dependency (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-impact"
+                    "health"
                 ],
-                "summary": "Get impact metrics",
+                "summary": "Detailed health",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/donations.ImpactMetrics"
+                            "$ref": "#/definitions/handlers.HealthDetailsResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/handlers.HealthDetailsResponse"
                         }
                     }
                 }
             }
         },
-        "/restaurant/inventory": {
+        "/inventory": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all inventory items for the authenticated restaurant",
+                "description": "Get all inventory items for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -3753,23 +4104,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-inventory"
+                    "inventory"
                 ],
-                "summary": "List restaurant inventory",
+                "summary": "Get user inventory",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                                "$ref": "#/definitions/inventory.InventoryItem"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -3780,7 +4131,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new item to restaurant inventory",
+                "description": "Add a new item to user's inventory",
                 "consumes": [
                     "application/json"
                 ],
@@ -3788,7 +4139,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-inventory"
+                    "inventory"
                 ],
                 "summary": "Add inventory item",
                 "parameters": [
@@ -3798,7 +4149,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/inventory.CreateRestaurantInventoryItemRequest"
+                            "$ref": "#/definitions/inventory.CreateInventoryItemRequest"
                         }
                     }
                 ],
@@ -3806,25 +4157,125 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                            "$ref": "#/definitions/inventory.InventoryItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/inventory/expiring": {
+        "/inventory/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply up to 500 create, update and delete operations to the authenticated user's inventory. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Batch create, update and delete inventory items",
+                "parameters": [
+                    {
+                        "description": "Batch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/expired": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all expired inventory items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get expired items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/inventory.InventoryItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/expiring": {
             "get": {
                 "security": [
                     {
@@ -3839,7 +4290,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-inventory"
+                    "inventory"
                 ],
                 "summary": "Get expiring items",
                 "parameters": [
@@ -3856,20 +4307,20 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                                "$ref": "#/definitions/inventory.InventoryItem"
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/inventory/{id}": {
+        "/inventory/{id}": {
             "get": {
                 "security": [
                     {
@@ -3884,7 +4335,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-inventory"
+                    "inventory"
                 ],
                 "summary": "Get inventory item by ID",
                 "parameters": [
@@ -3900,19 +4351,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                            "$ref": "#/definitions/inventory.InventoryItem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -3931,7 +4382,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-inventory"
+                    "inventory"
                 ],
                 "summary": "Update inventory item",
                 "parameters": [
@@ -3948,7 +4399,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/inventory.UpdateRestaurantInventoryItemRequest"
+                            "$ref": "#/definitions/inventory.UpdateInventoryItemRequest"
                         }
                     }
                 ],
@@ -3956,31 +4407,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                            "$ref": "#/definitions/inventory.InventoryItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -3999,7 +4450,7 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-inventory"
+                    "inventory"
                 ],
                 "summary": "Delete inventory item",
                 "parameters": [
@@ -4024,118 +4475,126 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    }
-                }
-            }
-        },
-        "/restaurant/menu": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all menu items for the authenticated restaurant",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "restaurant-menu"
-                ],
-                "summary": "List menu items",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/menu.RestaurantMenuItem"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new item to restaurant menu",
+                "description": "Partially update an inventory item with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-menu"
+                    "inventory"
                 ],
-                "summary": "Add menu item",
+                "summary": "Patch inventory item",
                 "parameters": [
                     {
-                        "description": "Menu item data",
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch document",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/menu.CreateRestaurantMenuItemRequest"
+                            "$ref": "#/definitions/inventory.PatchInventoryItemRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/menu.RestaurantMenuItem"
+                            "$ref": "#/definitions/inventory.InventoryItem"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/menu/{id}": {
+        "/livez": {
+            "get": {
+                "description": "Reports that the process is running. It never checks dependencies, so a database outage does not restart the server.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LiveResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/meal-plans": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get details of a specific menu item",
+                "description": "List the household's meal plans between two dates, by default the current week. Each plan carries warnings for conflicts with the household's allergies and dietary type, and its ingredients scaled to the household size of the family preferences or to servings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4143,114 +4602,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-menu"
+                    "meal-plans"
                 ],
-                "summary": "Get menu item by ID",
+                "summary": "List meal plans",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Menu Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/menu.RestaurantMenuItem"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
+                        "description": "First date (YYYY-MM-DD), Monday of the current week by default",
+                        "name": "from",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update an existing menu item",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "restaurant-menu"
-                ],
-                "summary": "Update menu item",
-                "parameters": [
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), six days after from by default",
+                        "name": "to",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Menu Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "breakfast, lunch, dinner or snack",
+                        "name": "meal_type",
+                        "in": "query"
                     },
                     {
-                        "description": "Menu item data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/menu.UpdateRestaurantMenuItemRequest"
-                        }
+                        "type": "integer",
+                        "description": "Servings to scale ingredients to",
+                        "name": "servings",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/menu.RestaurantMenuItem"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/meal_plans.MealPlan"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a menu item",
+                "description": "Plan a meal for the household. Meals conflicting with the household's allergies or dietary type are saved with warnings.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4258,95 +4669,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-menu"
+                    "meal-plans"
                 ],
-                "summary": "Delete menu item",
+                "summary": "Create meal plan",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Menu Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Meal plan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/meal_plans.CreateMealPlanRequest"
                         }
                     }
-                }
-            }
-        },
-        "/restaurant/preferences": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get restaurant preferences for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "restaurant-preferences"
                 ],
-                "summary": "Get preferences",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/preferences.RestaurantPreferences"
+                            "$ref": "#/definitions/meal_plans.MealPlan"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/meal-plans/copy-week": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create or update restaurant preferences",
+                "description": "Copy the household's meal plans of one week (Monday to Sunday) to another, keeping weekdays and meal types. With replace, the target week's plans are removed first; otherwise the copies are added to them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4354,56 +4720,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-preferences"
+                    "meal-plans"
                 ],
-                "summary": "Create or update preferences",
+                "summary": "Copy meal plan week",
                 "parameters": [
                     {
-                        "description": "Preferences data",
+                        "description": "Weeks, by any of their dates",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/preferences.CreateRestaurantPreferencesRequest"
+                            "$ref": "#/definitions/meal_plans.CopyWeekRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/preferences.RestaurantPreferences"
-                        }
-                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/preferences.RestaurantPreferences"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/meal_plans.MealPlan"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/shifts": {
+        "/meal-plans/month": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all shift schedules for the authenticated restaurant",
+                "description": "Get the household's meal plans of a month, by day. Plans carry warnings and scaled ingredients as in the list.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4411,34 +4774,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-staff"
+                    "meal-plans"
+                ],
+                "summary": "Get meal plan month",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM), the current one by default",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Servings to scale ingredients to",
+                        "name": "servings",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List shifts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/staff.ShiftSchedule"
-                            }
+                            "$ref": "#/definitions/meal_plans.Calendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/meal-plans/repeat": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new shift schedule",
+                "description": "Repeat the household's meal plans from from to to, as a rotation of that many days, back to back until until (at most a year after to). With replace, the plans already after to up to until are removed first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4446,17 +4828,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-staff"
+                    "meal-plans"
                 ],
-                "summary": "Create shift",
+                "summary": "Repeat meal plan rotation",
                 "parameters": [
                     {
-                        "description": "Shift data",
+                        "description": "Rotation and end date",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/staff.CreateShiftScheduleRequest"
+                            "$ref": "#/definitions/meal_plans.RepeatRequest"
                         }
                     }
                 ],
@@ -4464,32 +4846,35 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/staff.ShiftSchedule"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/meal_plans.MealPlan"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/surplus": {
+        "/meal-plans/week": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all surplus items for the authenticated restaurant",
+                "description": "Get the household's meal plans of a week, Monday to Sunday, by day. Plans carry warnings and scaled ingredients as in the list.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4497,34 +4882,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-surplus"
+                    "meal-plans"
+                ],
+                "summary": "Get meal plan week",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Any date of the week (YYYY-MM-DD), today by default",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Servings to scale ingredients to",
+                        "name": "servings",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List surplus items",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/surplus.RestaurantSurplusItem"
-                            }
+                            "$ref": "#/definitions/meal_plans.Calendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/meal-plans/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new surplus item",
+                "description": "Get a meal plan of the household, with its ingredients scaled to the household size or to servings",
                 "consumes": [
                     "application/json"
                 ],
@@ -4532,50 +4936,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-surplus"
+                    "meal-plans"
                 ],
-                "summary": "Create surplus item",
+                "summary": "Get meal plan",
                 "parameters": [
                     {
-                        "description": "Surplus item data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/surplus.CreateRestaurantSurplusItemRequest"
-                        }
+                        "type": "string",
+                        "description": "Meal plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Servings to scale ingredients to",
+                        "name": "servings",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                            "$ref": "#/definitions/meal_plans.MealPlan"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/restaurant/surplus/{id}": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing surplus item",
+                "description": "Update the given fields of a meal plan; an empty ingredients list clears them. Conflicts come back as warnings as on create.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4583,24 +4995,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-surplus"
+                    "meal-plans"
                 ],
-                "summary": "Update surplus item",
+                "summary": "Update meal plan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Item ID",
+                        "description": "Meal plan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Surplus item data",
+                        "description": "Fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/surplus.UpdateRestaurantSurplusItemRequest"
+                            "$ref": "#/definitions/meal_plans.UpdateMealPlanRequest"
                         }
                     }
                 ],
@@ -4608,44 +5020,36 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                            "$ref": "#/definitions/meal_plans.MealPlan"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/restaurant/surplus/{id}/assign": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Assign a surplus item to NGO or community kitchen",
+                "description": "Remove a meal plan of the household",
                 "consumes": [
                     "application/json"
                 ],
@@ -4653,63 +5057,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-surplus"
+                    "meal-plans"
                 ],
-                "summary": "Assign surplus item",
+                "summary": "Delete meal plan",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Surplus Item ID",
+                        "description": "Meal plan ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Assignment data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/surplus.AssignSurplusItemRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/tasks": {
+        "/ngo/capacity": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all staff tasks for the authenticated restaurant",
+                "description": "Get NGO capacity settings for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -4717,23 +5115,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-staff"
+                    "ngo-capacity"
                 ],
-                "summary": "List tasks",
+                "summary": "Get capacity settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/staff.StaffTask"
-                            }
+                            "$ref": "#/definitions/capacity.NGOCapacitySettings"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
@@ -4744,7 +5145,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new staff task",
+                "description": "Create or update NGO capacity settings",
                 "consumes": [
                     "application/json"
                 ],
@@ -4752,50 +5153,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-staff"
+                    "ngo-capacity"
                 ],
-                "summary": "Create task",
+                "summary": "Create or update capacity settings",
                 "parameters": [
                     {
-                        "description": "Task data",
+                        "description": "Capacity settings data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/staff.CreateStaffTaskRequest"
+                            "$ref": "#/definitions/capacity.CreateNGOCapacitySettingsRequest"
                         }
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/capacity.NGOCapacitySettings"
+                        }
+                    },
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/staff.StaffTask"
+                            "$ref": "#/definitions/capacity.NGOCapacitySettings"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/restaurant/tasks/{id}": {
-            "put": {
+        "/ngo/offers": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing staff task",
+                "description": "Get all donation offers for the authenticated NGO",
                 "consumes": [
                     "application/json"
                 ],
@@ -4803,69 +5210,148 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "restaurant-staff"
+                    "ngo-offers"
                 ],
-                "summary": "Update task",
+                "summary": "List donation offers",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Task ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/offers.NGODonationOffer"
+                            }
+                        }
                     },
-                    {
-                        "description": "Task data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/staff.UpdateStaffTaskRequest"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
+                }
+            }
+        },
+        "/ngo/offers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific donation offer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ngo-offers"
+                ],
+                "summary": "Get offer details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/staff.StaffTask"
+                            "$ref": "#/definitions/offers.NGODonationOffer"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/ngo/offers/{id}/accept": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept a donation offer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ngo-offers"
+                ],
+                "summary": "Accept offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/offers.NGODonationOffer"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/xp": {
-            "get": {
+        "/ngo/offers/{id}/decline": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get XP and level information for the authenticated user",
+                "description": "Decline a donation offer",
                 "consumes": [
                     "application/json"
                 ],
@@ -4873,33 +5359,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "xp"
+                    "ngo-offers"
+                ],
+                "summary": "Decline offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user XP",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/xp.UserXP"
+                            "$ref": "#/definitions/offers.NGODonationOffer"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/xp/add": {
-            "post": {
+        "/notifications": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add XP to the authenticated user (system endpoint)",
+                "description": "List the user's community and NGO notifications, unread first and newest first within each",
                 "consumes": [
                     "application/json"
                 ],
@@ -4907,50 +5414,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "xp"
+                    "notifications"
                 ],
-                "summary": "Add XP",
+                "summary": "List notifications",
                 "parameters": [
                     {
-                        "description": "XP data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/xp.AddXPRequest"
-                        }
+                        "type": "string",
+                        "description": "community or ngo; both when omitted",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/xp.UserXP"
+                            "$ref": "#/definitions/notifications.NotificationList"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
             }
         },
-        "/xp/leaderboard": {
+        "/notifications/deliveries": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get XP leaderboard",
+                "description": "List the user's latest email, SMS and push deliveries with their status, attempts and last error, newest first. Batched deliveries wait to be merged into a digest.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4958,13 +5480,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "xp"
+                    "notifications"
                 ],
-                "summary": "Get leaderboard",
+                "summary": "List notification deliveries",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "email, sms or push",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, sending, sent, failed, batched or digested",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Number of entries (default: 10, max: 100)",
+                        "description": "Maximum number of deliveries (default 50, at most 200)",
                         "name": "limit",
                         "in": "query"
                     }
@@ -4975,1492 +5509,8096 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/xp.LeaderboardEntry"
+                                "$ref": "#/definitions/notifications.Delivery"
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/push-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the devices registered for the user's push notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List push devices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notifications.PushToken"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a device token for push notifications. A token registered by another account moves to this one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Register push device",
+                "parameters": [
+                    {
+                        "description": "Device",
+                        "name": "device",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notifications.RegisterPushTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.PushToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/push-tokens/{token}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop sending push notifications to a device",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Remove push device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device token, URL-encoded",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark all of the user's unread notifications read, optionally of one source only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "community or ngo; both when omitted",
+                        "name": "source",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer",
+                                "format": "int64"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the user's time zone, quiet hours, phone number and the channels each notification type is sent on besides in-app",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notification settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.Settings"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/errors.AppError"
+                            "$ref": "#/definitions/errors.Problem"
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the fields that are set. channels replaces the channels of the listed source and type pairs; an empty list makes a type in-app only. Quiet hours are HH:MM in the user's time zone and wrap past midnight when the start is later than the end; empty strings turn them off. Only critical NGO notifications are sent during quiet hours.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Update notification settings",
+                "parameters": [
+                    {
+                        "description": "Settings to change",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notifications.UpdateSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.Settings"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count the user's unread notifications, in total and per source",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Count unread notifications",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.UnreadCount"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the user's notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Delete notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark one of the user's notifications read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark notification read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notifications.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nutrition": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get nutrition data for the authenticated user within a date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrition"
+                ],
+                "summary": "Get nutrition data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/nutrition.NutritionData"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new nutrition data entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrition"
+                ],
+                "summary": "Log nutrition data",
+                "parameters": [
+                    {
+                        "description": "Nutrition data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.CreateNutritionDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.NutritionData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nutrition/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get nutrition statistics for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrition"
+                ],
+                "summary": "Get nutrition statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days (default: 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.NutritionStats"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nutrition/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get today's nutrition data for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrition"
+                ],
+                "summary": "Get today's nutrition",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.NutritionData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/nutrition/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific nutrition data entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrition"
+                ],
+                "summary": "Get nutrition data by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nutrition Data ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.NutritionData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing nutrition data entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nutrition"
+                ],
+                "summary": "Update nutrition data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nutrition Data ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nutrition data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.UpdateNutritionDataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/nutrition.NutritionData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get family preferences for the authenticated user's household",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Get family preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update family preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Create or update preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/preferences.CreatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update family preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "preferences"
+                ],
+                "summary": "Create or update preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/preferences.CreatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.FamilyPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-comparisons": {
+            "get": {
+                "description": "Get all price comparisons",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-comparisons"
+                ],
+                "summary": "Get price comparisons",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/price_comparisons.PriceComparison"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new price comparison",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-comparisons"
+                ],
+                "summary": "Create price comparison",
+                "parameters": [
+                    {
+                        "description": "Price comparison data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/price_comparisons.CreatePriceComparisonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/price_comparisons.PriceComparison"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/price-comparisons/{id}": {
+            "get": {
+                "description": "Get details of a specific price comparison",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-comparisons"
+                ],
+                "summary": "Get price comparison by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Price Comparison ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/price_comparisons.PriceComparison"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing price comparison",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "price-comparisons"
+                ],
+                "summary": "Update price comparison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Price Comparison ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price comparison data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/price_comparisons.UpdatePriceComparisonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/price_comparisons.PriceComparison"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Runs every dependency check (database, pending migrations, scheduler, notification and webhook delivery backlogs) and returns 503 when any fails. Error details are only shown on /health/details.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes": {
+            "get": {
+                "description": "List the recipe catalog by name. Recipes without a meal type match any meal_type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by cuisine",
+                        "name": "cuisine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "breakfast, lunch, dinner or snack",
+                        "name": "meal_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tag, such as vegan",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recipes.RecipeList"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rank the recipes that use the user's inventory items expiring within days, those using the most and soonest-expiring items first. Recipes conflicting with the household's allergies are left out. Preferred cuisines, the dietary type and the meal prep preference of the family preferences raise the score; recipes conflicting with the dietary type rank far lower and carry warnings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Suggest recipes for expiring items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Items expiring within this many days (default 3, at most 14)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "breakfast, lunch, dinner or snack",
+                        "name": "meal_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions (default 10, at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recipes.Suggestion"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}": {
+            "get": {
+                "description": "Get a recipe of the catalog with its ingredients",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recipes.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/plan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a recipe to the household's meal plans on a date, its ingredients scaled to servings. Without meal_type and servings, the recipe's are used, or dinner. The plan carries warnings as in meal plans.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Add recipe to meal plans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Date, meal type and servings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/recipes.PlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/meal_plans.MealPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources": {
+            "get": {
+                "description": "List the resource library with category and tag facets. With q, resources are searched by title, tags, category and description, best matches first; q supports \"quoted phrases\", OR and -excluded words.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "List resources",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full-text search query",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/resources.ResourceList"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/recommendations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get resources for the food categories the user has in stock and wasted at least twice in the last 90 days, by their consumption logs. Resources are matched by a tag naming the category, storage tips first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Get recommended resources",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/resources.Recommendation"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}": {
+            "get": {
+                "description": "Get a resource of the library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Get resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/resources.Resource"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/resources/{id}/related": {
+            "get": {
+                "description": "Get resources sharing tags with a resource, those sharing the most first, then those of the same category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resources"
+                ],
+                "summary": "Get related resources",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of resources (default 5, at most 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/resources.Resource"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/donations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all donation logs for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-donations"
+                ],
+                "summary": "List donations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/donations.DonationLog"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new donation log entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-donations"
+                ],
+                "summary": "Log donation",
+                "parameters": [
+                    {
+                        "description": "Donation log data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/donations.CreateDonationLogRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/donations.DonationLog"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/impact": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get impact metrics for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-impact"
+                ],
+                "summary": "Get impact metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/donations.ImpactMetrics"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/inventory": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all inventory items for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "List restaurant inventory",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new item to restaurant inventory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "Add inventory item",
+                "parameters": [
+                    {
+                        "description": "Inventory item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/inventory.CreateRestaurantInventoryItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/inventory/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply up to 500 create, update and delete operations to the restaurant's inventory. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "Batch create, update and delete inventory items",
+                "parameters": [
+                    {
+                        "description": "Batch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/inventory/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get inventory items expiring within specified days (default 7)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "Get expiring items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days (default: 7)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/inventory/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "Get inventory item by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "Update inventory item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Inventory item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/inventory.UpdateRestaurantInventoryItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.RestaurantInventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-inventory"
+                ],
+                "summary": "Delete inventory item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/menu": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all menu items for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "List menu items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/menu.RestaurantMenuItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new item to restaurant menu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "Add menu item",
+                "parameters": [
+                    {
+                        "description": "Menu item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/menu.CreateRestaurantMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/menu.RestaurantMenuItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/menu/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply up to 500 create, update and delete operations to the restaurant's menu. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "Batch create, update and delete menu items",
+                "parameters": [
+                    {
+                        "description": "Batch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/menu/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific menu item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "Get menu item by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/menu.RestaurantMenuItem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing menu item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "Update menu item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Menu item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/menu.UpdateRestaurantMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/menu.RestaurantMenuItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a menu item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "Delete menu item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially update a menu item with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-menu"
+                ],
+                "summary": "Patch menu item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Menu Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch document",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/menu.PatchRestaurantMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/menu.RestaurantMenuItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get restaurant preferences for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-preferences"
+                ],
+                "summary": "Get preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.RestaurantPreferences"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update restaurant preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-preferences"
+                ],
+                "summary": "Create or update preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/preferences.CreateRestaurantPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.RestaurantPreferences"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/preferences.RestaurantPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/shifts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all shift schedules for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-staff"
+                ],
+                "summary": "List shifts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/staff.ShiftSchedule"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new shift schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-staff"
+                ],
+                "summary": "Create shift",
+                "parameters": [
+                    {
+                        "description": "Shift data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/staff.CreateShiftScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/staff.ShiftSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/surplus": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all surplus items for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-surplus"
+                ],
+                "summary": "List surplus items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new surplus item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-surplus"
+                ],
+                "summary": "Create surplus item",
+                "parameters": [
+                    {
+                        "description": "Surplus item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/surplus.CreateRestaurantSurplusItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/surplus/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing surplus item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-surplus"
+                ],
+                "summary": "Update surplus item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Surplus Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Surplus item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/surplus.UpdateRestaurantSurplusItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/surplus/{id}/assign": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assign a surplus item to NGO or community kitchen",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-surplus"
+                ],
+                "summary": "Assign surplus item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Surplus Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Assignment data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/surplus.AssignSurplusItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/surplus.RestaurantSurplusItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all staff tasks for the authenticated restaurant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-staff"
+                ],
+                "summary": "List tasks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/staff.StaffTask"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new staff task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-staff"
+                ],
+                "summary": "Create task",
+                "parameters": [
+                    {
+                        "description": "Task data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/staff.CreateStaffTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/staff.StaffTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/restaurant/tasks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing staff task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "restaurant-staff"
+                ],
+                "summary": "Update task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Task data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/staff.UpdateStaffTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/staff.StaffTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shop/inventory": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all inventory items for the authenticated shop",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "List shop inventory",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/inventory.ShopInventoryItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new item to shop inventory",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "Add inventory item",
+                "parameters": [
+                    {
+                        "description": "Inventory item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/inventory.CreateShopInventoryItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.ShopInventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shop/inventory/barcode/{barcode}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Look up a shop inventory item by its barcode, returning the batch that expires first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "Get inventory item by barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Barcode",
+                        "name": "barcode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.ShopInventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shop/inventory/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply up to 500 create, update and delete operations to the shop's inventory. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "Batch create, update and delete inventory items",
+                "parameters": [
+                    {
+                        "description": "Batch operations",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/utils.BatchResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/shop/inventory/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "Get inventory item by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.ShopInventoryItem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "Update inventory item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Inventory item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/inventory.UpdateShopInventoryItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/inventory.ShopInventoryItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an inventory item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shop-inventory"
+                ],
+                "summary": "Delete inventory item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Inventory Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the household's shopping list, pending items first and by priority, with the week's running totals against the weekly budget of the family preferences. Prices are estimates for an item's whole quantity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Get shopping list",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only purchased (true) or pending (false) items",
+                        "name": "purchased",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Group items by category or aisle",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.ShoppingList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an item to the household's shopping list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Add shopping list item",
+                "parameters": [
+                    {
+                        "description": "Item",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.CreateShoppingListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.ShoppingListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-list/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add what the household's meal plans between from and to (the next seven days by default) need and its inventory lacks. Ingredients such as \"200 g rice\" are summed by name, with units converted, scaled to the household size, and reduced by the members' inventory that has not expired by the meal's date. Pending items of the same name are raised to what is needed rather than added twice. Each line gets the cheapest store and an estimated price from the price comparisons, and a priority by how soon it is needed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Generate shopping list from meal plans",
+                "parameters": [
+                    {
+                        "description": "Date range",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.GenerateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.GenerateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-list/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an item of the household's shopping list. The ETag header is the item's version.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Get shopping list item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.ShoppingListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the given fields of an item. Send the ETag or version read as If-Match: if another household member changed the item since, nothing is saved and 412 is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Update shopping list item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the item was read at",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.UpdateShoppingListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.ShoppingListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from the household's list. If-Match is checked like for PUT.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Remove shopping list item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the item was read at",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Partially update an item with a JSON Merge Patch (RFC 7396); null clears unit, category, aisle or estimated_price. If-Match is checked like for PUT.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Patch shopping list item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the item was read at",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch document",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.PatchShoppingListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.ShoppingListItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-list/{id}/purchase": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark an item purchased and, unless add_to_inventory is false, add it to the buyer's inventory. Without expiry_date, the expiry is estimated from the typical expiry days of the food item of the same name. An item is bought once: a second purchase returns 409. If-Match is checked like for PUT.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping-list"
+                ],
+                "summary": "Mark shopping list item purchased",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version the item was read at",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Inventory options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.PurchaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/shopping_list.PurchaseResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of the user's updates: offer.created and pickup.status_changed for NGOs, surplus.requested and surplus.commented for surplus posters, leftover.claimed for leftover owners, and notification.created for everyone. Each message has an id, an event name and a JSON data line. Reconnect with the Last-Event-ID header (or the last_event_id query parameter) to replay missed events from the last hour; when too many were missed a reset event is sent instead and the client should reload. Browsers' EventSource cannot set headers, so the access token may be passed as the access_token query parameter. A comment line is sent periodically to keep the connection open.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "stream"
+                ],
+                "summary": "Stream real-time updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Access token, for clients that cannot set headers",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "text/event-stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the user's uploads, newest first, with download links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "List uploads",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/uploads.UploadList"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG, GIF or WebP image as the \"file\" field of a multipart form. The type is sniffed from the content, metadata such as EXIF location is stripped and a thumbnail is made except for WebP. Other resources reference the returned ID.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Upload image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/uploads.Upload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an upload with download links of the image and its thumbnail, valid for a limited time. Any signed-in user may get an upload referenced by a shared resource.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Get upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/uploads.Upload"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the user's uploads and its files. Resources referencing it lose their image.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Delete upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/uploads/{id}/content": {
+            "get": {
+                "description": "Download an image or its thumbnail from a signed link returned with the upload. The link works without signing in until it expires.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Download upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "original or thumbnail",
+                        "name": "variant",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry, in Unix seconds",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the organization's webhook subscriptions. Secrets are not included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Subscription"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe an endpoint to the organization's events. An empty event_types list receives every type. The response contains the signing secret, which is not shown again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/event-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the events organizations can subscribe to, and which account receives each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook event types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.EventTypeInfo"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one of the organization's webhook subscriptions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the URL, description, event types or active state. Setting active to true re-enables a disabled subscription; its pending deliveries are then sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.UpdateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a subscription together with its delivery log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The subscription's delivery log, most recent first, with the outcome of each delivery's latest attempt",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivering",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries (default: 50, max: 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Delivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/rotate-secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the signing secret. The response contains the new secret; the old one stops working immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Rotate webhook secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a signed WebhookTest event to the endpoint now, also when the subscription is disabled, and return the logged delivery with the endpoint's response. Test deliveries are not retried.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send test event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Delivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/xp": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get XP and level information for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "xp"
+                ],
+                "summary": "Get user XP",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/xp.UserXP"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/xp/add": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add XP to the authenticated user (system endpoint)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "xp"
+                ],
+                "summary": "Add XP",
+                "parameters": [
+                    {
+                        "description": "XP data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/xp.AddXPRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/xp.UserXP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/xp/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get XP leaderboard",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "xp"
+                ],
+                "summary": "Get leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of entries (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/xp.LeaderboardEntry"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.User"
+                }
+            }
+        },
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "family",
+                        "restaurant",
+                        "shop",
+                        "ngo",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "auth.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.UserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "household_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "badges.AvailableBadge": {
+            "type": "object",
+            "properties": {
+                "badge_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "xp_reward": {
+                    "type": "integer"
+                }
+            }
+        },
+        "badges.Badge": {
+            "type": "object",
+            "properties": {
+                "badge_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "unlocked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "xp_reward": {
+                    "type": "integer"
+                }
+            }
+        },
+        "badges.UnlockBadgeRequest": {
+            "type": "object",
+            "required": [
+                "badge_id",
+                "name"
+            ],
+            "properties": {
+                "badge_id": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "icon": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "xp_reward": {
+                    "type": "integer"
+                }
+            }
+        },
+        "calendar.CreateFeedRequest": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "meal-plans",
+                        "kitchen-events",
+                        "pickups"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "calendar.Feed": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL holds the feed's secret token. It is only returned when the feed\nis created; a lost URL is replaced by revoking the feed and creating\nanother.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "capacity.CreateNGOCapacitySettingsRequest": {
+            "type": "object",
+            "required": [
+                "contact_phone",
+                "daily_capacity_kg",
+                "location",
+                "manager_name",
+                "org_name",
+                "pickup_window"
+            ],
+            "properties": {
+                "auto_acceptance": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "contact_email": {
+                    "type": "string"
+                },
+                "contact_phone": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "daily_capacity_kg": {
+                    "type": "number"
+                },
+                "dry_capacity_kg": {
+                    "type": "number"
+                },
+                "geo_point": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "location": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "manager_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "org_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "pickup_window": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "policy_notes": {
+                    "type": "string"
+                },
+                "preferred_food_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferred_pickup_radius_km": {
+                    "type": "number"
+                },
+                "refrigerated_capacity_kg": {
+                    "type": "number"
+                },
+                "restricted_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "safety_rules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "storage_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "capacity.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "capacity.NGOCapacitySettings": {
+            "type": "object",
+            "properties": {
+                "auto_acceptance": {
+                    "$ref": "#/definitions/capacity.JSONB"
+                },
+                "contact_email": {
+                    "type": "string"
+                },
+                "contact_phone": {
+                    "type": "string"
+                },
+                "current_utilization_kg": {
+                    "type": "number"
+                },
+                "daily_capacity_kg": {
+                    "type": "number"
+                },
+                "dry_capacity_kg": {
+                    "type": "number"
+                },
+                "geo_point": {
+                    "$ref": "#/definitions/capacity.JSONB"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "level_progress_pct": {
+                    "type": "number"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "manager_name": {
+                    "type": "string"
+                },
+                "org_name": {
+                    "type": "string"
+                },
+                "pickup_window": {
+                    "$ref": "#/definitions/capacity.JSONB"
+                },
+                "policy_notes": {
+                    "type": "string"
+                },
+                "preferred_food_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferred_pickup_radius_km": {
+                    "type": "number"
+                },
+                "refrigerated_capacity_kg": {
+                    "type": "number"
+                },
+                "restricted_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "safety_rules": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "storage_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "xp_points": {
+                    "type": "integer"
+                }
+            }
+        },
+        "consumption.ConsumptionLog": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "consumed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inventory_item_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "was_wasted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "consumption.ConsumptionStats": {
+            "type": "object",
+            "properties": {
+                "total_consumed": {
+                    "type": "number"
+                },
+                "total_logs": {
+                    "type": "integer"
+                },
+                "total_wasted": {
+                    "type": "number"
+                },
+                "waste_percentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "consumption.CreateConsumptionLogRequest": {
+            "type": "object",
+            "required": [
+                "food_name",
+                "quantity"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "consumed_at": {
+                    "type": "string"
+                },
+                "food_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "inventory_item_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "was_wasted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "consumption.UpdateConsumptionLogRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "consumed_at": {
+                    "type": "string"
+                },
+                "food_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "was_wasted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "digests.Subscriptions": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "type": "boolean"
+                },
+                "weekly": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "digests.UpdateSubscriptionsRequest": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "type": "boolean"
+                },
+                "weekly": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "donations.CreateDonationLogRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "items",
+                "quantity",
+                "recipient_name",
+                "recipient_type",
+                "unit"
+            ],
+            "properties": {
+                "co2_saved_kg": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "meals_provided": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "recipient_type": {
+                    "type": "string",
+                    "enum": [
+                        "ngo",
+                        "community-kitchen"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "donations.DonationLog": {
+            "type": "object",
+            "properties": {
+                "co2_saved_kg": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "string"
+                },
+                "meals_provided": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recipient_name": {
+                    "type": "string"
+                },
+                "recipient_type": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "donations.ImpactMetrics": {
+            "type": "object",
+            "properties": {
+                "category_breakdown": {
+                    "$ref": "#/definitions/donations.JSONB"
+                },
+                "co2_prevented_kg": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "monthly_trend": {
+                    "$ref": "#/definitions/donations.JSONB"
+                },
+                "surplus_donation_rate": {
+                    "type": "number"
+                },
+                "sustainability_score": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "waste_prevented_kg": {
+                    "type": "number"
+                },
+                "water_saved_liters": {
+                    "type": "number"
+                },
+                "weekly_trend": {
+                    "$ref": "#/definitions/donations.JSONB"
+                }
+            }
+        },
+        "donations.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "errors.Problem": {
+            "type": "object",
+            "properties": {
+                "cause": {
+                    "description": "Cause is the underlying error, only included outside production",
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "featureflags.Flag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Expose the shop API"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "key": {
+                    "type": "string",
+                    "example": "shop_module"
+                },
+                "org_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "percentage": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shop",
+                        "admin"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "flags.CreateFlagRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Expose the shop API"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "key": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "shop_module"
+                },
+                "org_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "percentage": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 100
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shop",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "flags.EvaluatedFlags": {
+            "type": "object",
+            "properties": {
+                "flags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                }
+            }
+        },
+        "flags.UpdateFlagRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Expose the shop API"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "org_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "percentage": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 100
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "shop",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "food_items.CreateFoodItemRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "name",
+                "typical_expiry_days"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "storage_tips": {
+                    "type": "string"
+                },
+                "typical_expiry_days": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "food_items.FoodItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "storage_tips": {
+                    "type": "string"
+                },
+                "typical_expiry_days": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "food_items.UpdateFoodItemRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "storage_tips": {
+                    "type": "string"
+                },
+                "typical_expiry_days": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "foodlink_backend_features_community_surplus.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "foodlink_backend_features_restaurant_surplus.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "handlers.APIResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Welcome to Foodlink API v1"
+                }
+            }
+        },
+        "handlers.HealthDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "go_version": {
+                    "type": "string",
+                    "example": "go1.25.5"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                },
+                "uptime_seconds": {
+                    "type": "number",
+                    "example": 3600
+                }
+            }
+        },
+        "handlers.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "type": "string",
+                    "example": "connected"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Server is running"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "handlers.LiveResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number",
+                    "example": 1.25
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "inventory.CreateInventoryItemRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "quantity"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "food_item_id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "inventory.CreateRestaurantInventoryItemRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "expiry_date",
+                "name",
+                "quantity",
+                "storage_type",
+                "unit"
+            ],
+            "properties": {
+                "alert_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "batch_code": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "invoice_upload_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "storage_type": {
+                    "type": "string",
+                    "enum": [
+                        "fresh",
+                        "chilled",
+                        "frozen",
+                        "dry"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "inventory.CreateShopInventoryItemRequest": {
+            "type": "object",
+            "required": [
+                "barcode",
+                "category",
+                "expiry_date",
+                "name",
+                "price",
+                "storage_type",
+                "unit"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "cost": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "image_upload_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "number"
+                },
+                "shelf_location": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "stock_quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "storage_type": {
+                    "type": "string",
+                    "enum": [
+                        "frozen",
+                        "chilled",
+                        "ambient"
+                    ]
+                },
+                "surplus_eligible": {
+                    "type": "boolean"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "inventory.InventoryItem": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "food_item_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "inventory.PatchInventoryItemRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "quantity"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "food_item_id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "inventory.RestaurantInventoryItem": {
+            "type": "object",
+            "properties": {
+                "alert_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "batch_code": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invoice_upload_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "storage_type": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "inventory.ShopInventoryItem": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cost": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_upload_id": {
+                    "type": "string"
+                },
+                "markdown_status": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "shelf_location": {
+                    "type": "string"
+                },
+                "stock_quantity": {
+                    "type": "number"
+                },
+                "storage_type": {
+                    "type": "string"
+                },
+                "surplus_eligible": {
+                    "type": "boolean"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "inventory.UpdateInventoryItemRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "food_item_id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "inventory.UpdateRestaurantInventoryItemRequest": {
+            "type": "object",
+            "properties": {
+                "alert_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "batch_code": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "invoice_upload_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "storage_type": {
+                    "type": "string",
+                    "enum": [
+                        "fresh",
+                        "chilled",
+                        "frozen",
+                        "dry"
+                    ]
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "inventory.UpdateShopInventoryItemRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "cost": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "image_upload_id": {
+                    "type": "string"
+                },
+                "markdown_status": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "scheduled",
+                        "active"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "price": {
+                    "type": "number"
+                },
+                "shelf_location": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "stock_quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "storage_type": {
+                    "type": "string",
+                    "enum": [
+                        "frozen",
+                        "chilled",
+                        "ambient"
+                    ]
+                },
+                "surplus_eligible": {
+                    "type": "boolean"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "jobruns.JobSummary": {
+            "type": "object",
+            "properties": {
+                "last_run": {
+                    "$ref": "#/definitions/jobruns.Run"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string"
+                }
+            }
+        },
+        "jobruns.Overview": {
+            "type": "object",
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jobruns.JobSummary"
+                    }
+                },
+                "leader": {
+                    "description": "Leader reports whether this instance enqueues scheduled runs",
+                    "type": "boolean"
+                }
+            }
+        },
+        "jobruns.Run": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "kitchen_events.CreateKitchenEventRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "description",
+                "location",
+                "time",
+                "title"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "image": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "volunteers_needed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kitchen_events.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "kitchen_events.KitchenEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "food_saved_kg": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "volunteers": {
+                    "$ref": "#/definitions/kitchen_events.JSONB"
+                },
+                "volunteers_needed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kitchen_events.UpdateKitchenEventRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "food_saved_kg": {
+                    "type": "number"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "volunteers_needed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kitchen_events.VolunteerRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "leaderboard.Impact": {
+            "type": "object",
+            "properties": {
+                "co2_prevented_kg": {
+                    "type": "number"
+                },
+                "donations": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meals_provided": {
+                    "type": "integer"
+                },
+                "personal_contribution": {
+                    "$ref": "#/definitions/leaderboard.JSONB"
+                },
+                "total_surplus_kg": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "water_saved_liters": {
+                    "type": "number"
+                },
+                "weekly_trend": {
+                    "$ref": "#/definitions/leaderboard.JSONB"
+                }
+            }
+        },
+        "leaderboard.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "leaderboard.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/leaderboard.JSONB"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
             }
-        }
-    },
-    "definitions": {
-        "auth.AuthResponse": {
+        },
+        "leftovers.CreateLeftoverClaimRequest": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "leftovers.CreateLeftoverItemRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "dish_name",
+                "distance_km",
+                "pickup_window",
+                "portions"
+            ],
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dish_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "image_upload_id": {
                     "type": "string"
                 },
-                "expires_in": {
+                "pickup_window": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "portions": {
                     "type": "integer"
+                }
+            }
+        },
+        "leftovers.LeftoverClaim": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "refresh_token": {
+                "id": {
                     "type": "string"
                 },
-                "token_type": {
+                "leftover_item_id": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.User"
+                "message": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
                 }
             }
         },
-        "auth.LoginRequest": {
+        "leftovers.LeftoverItem": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "avatar_url": {
                     "type": "string"
                 },
-                "password": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dish_name": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_upload_id": {
+                    "type": "string"
+                },
+                "pickup_window": {
+                    "type": "string"
+                },
+                "portions": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_name": {
                     "type": "string"
                 }
             }
         },
-        "auth.RegisterRequest": {
+        "leftovers.PatchLeftoverItemRequest": {
             "type": "object",
             "required": [
-                "email",
-                "name",
-                "password"
+                "description",
+                "dish_name",
+                "distance_km",
+                "pickup_window",
+                "portions",
+                "status"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "name": {
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dish_name": {
                     "type": "string",
                     "maxLength": 255,
-                    "minLength": 2
+                    "minLength": 1
                 },
-                "password": {
+                "distance_km": {
+                    "type": "number"
+                },
+                "image_upload_id": {
+                    "type": "string"
+                },
+                "pickup_window": {
                     "type": "string",
-                    "minLength": 8
+                    "minLength": 1
                 },
-                "role": {
+                "portions": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string",
                     "enum": [
-                        "family",
-                        "restaurant",
-                        "shop",
-                        "ngo",
-                        "admin"
+                        "available",
+                        "claimed"
                     ]
                 }
             }
         },
-        "auth.User": {
+        "leftovers.UpdateLeftoverItemRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "email": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "minLength": 1
                 },
-                "household_id": {
-                    "type": "string"
+                "dietary_tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "dish_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "distance_km": {
+                    "type": "number"
                 },
-                "name": {
+                "image_upload_id": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string"
+                "pickup_window": {
+                    "type": "string",
+                    "minLength": 1
                 },
-                "updated_at": {
+                "portions": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "auth.UserResponse": {
+        "meal_plans.Calendar": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meal_plans.CalendarDay"
+                    }
                 },
-                "email": {
+                "from": {
                     "type": "string"
                 },
-                "household_id": {
+                "to": {
                     "type": "string"
-                },
-                "id": {
+                }
+            }
+        },
+        "meal_plans.CalendarDay": {
+            "type": "object",
+            "properties": {
+                "date": {
                     "type": "string"
                 },
-                "name": {
+                "meals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meal_plans.MealPlan"
+                    }
+                }
+            }
+        },
+        "meal_plans.CopyWeekRequest": {
+            "type": "object",
+            "required": [
+                "from_week",
+                "to_week"
+            ],
+            "properties": {
+                "from_week": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string"
+                "replace": {
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "to_week": {
                     "type": "string"
                 }
             }
         },
-        "badges.AvailableBadge": {
+        "meal_plans.CreateMealPlanRequest": {
             "type": "object",
+            "required": [
+                "date",
+                "meal_type",
+                "name"
+            ],
             "properties": {
-                "badge_id": {
+                "date": {
                     "type": "string"
                 },
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "icon": {
-                    "type": "string"
+                "ingredients": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meal_type": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ]
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "xp_reward": {
-                    "type": "integer"
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
                 }
             }
         },
-        "badges.Badge": {
+        "meal_plans.MealPlan": {
             "type": "object",
             "properties": {
-                "badge_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "date": {
+                    "description": "Date is YYYY-MM-DD",
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
-                "icon": {
+                "household_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meal_type": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
-                "unlocked_at": {
+                "scaled": {
+                    "$ref": "#/definitions/meal_plans.Scaled"
+                },
+                "servings": {
+                    "type": "integer"
+                },
+                "updated_at": {
                     "type": "string"
                 },
                 "user_id": {
                     "type": "string"
                 },
-                "xp_reward": {
-                    "type": "integer"
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meal_plans.Warning"
+                    }
                 }
             }
         },
-        "badges.UnlockBadgeRequest": {
+        "meal_plans.RepeatRequest": {
             "type": "object",
             "required": [
-                "badge_id",
-                "name"
+                "from",
+                "to",
+                "until"
             ],
             "properties": {
-                "badge_id": {
+                "from": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "replace": {
+                    "type": "boolean"
                 },
-                "icon": {
+                "to": {
                     "type": "string"
                 },
-                "name": {
+                "until": {
                     "type": "string"
-                },
-                "xp_reward": {
-                    "type": "integer"
                 }
             }
         },
-        "capacity.CreateNGOCapacitySettingsRequest": {
+        "meal_plans.Scaled": {
             "type": "object",
-            "required": [
-                "contact_phone",
-                "daily_capacity_kg",
-                "location",
-                "manager_name",
-                "org_name",
-                "pickup_window"
-            ],
             "properties": {
-                "auto_acceptance": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "contact_email": {
-                    "type": "string"
-                },
-                "contact_phone": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 1
-                },
-                "daily_capacity_kg": {
-                    "type": "number"
-                },
-                "dry_capacity_kg": {
-                    "type": "number"
-                },
-                "geo_point": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "location": {
-                    "type": "string",
-                    "minLength": 1
-                },
-                "manager_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "org_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "pickup_window": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "policy_notes": {
-                    "type": "string"
-                },
-                "preferred_food_types": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "preferred_pickup_radius_km": {
-                    "type": "number"
-                },
-                "refrigerated_capacity_kg": {
+                "factor": {
                     "type": "number"
                 },
-                "restricted_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "safety_rules": {
+                "ingredients": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "storage_types": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "servings": {
+                    "type": "integer"
                 }
             }
         },
-        "capacity.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "capacity.NGOCapacitySettings": {
+        "meal_plans.UpdateMealPlanRequest": {
             "type": "object",
             "properties": {
-                "auto_acceptance": {
-                    "$ref": "#/definitions/capacity.JSONB"
-                },
-                "contact_email": {
-                    "type": "string"
-                },
-                "contact_phone": {
-                    "type": "string"
-                },
-                "current_utilization_kg": {
-                    "type": "number"
-                },
-                "daily_capacity_kg": {
-                    "type": "number"
-                },
-                "dry_capacity_kg": {
-                    "type": "number"
-                },
-                "geo_point": {
-                    "$ref": "#/definitions/capacity.JSONB"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "level": {
-                    "type": "integer"
-                },
-                "level_progress_pct": {
-                    "type": "number"
-                },
-                "location": {
-                    "type": "string"
-                },
-                "manager_name": {
-                    "type": "string"
-                },
-                "org_name": {
-                    "type": "string"
-                },
-                "pickup_window": {
-                    "$ref": "#/definitions/capacity.JSONB"
-                },
-                "policy_notes": {
+                "date": {
                     "type": "string"
                 },
-                "preferred_food_types": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "preferred_pickup_radius_km": {
-                    "type": "number"
-                },
-                "refrigerated_capacity_kg": {
-                    "type": "number"
-                },
-                "restricted_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "safety_rules": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "storage_types": {
+                "ingredients": {
                     "type": "array",
+                    "maxItems": 100,
                     "items": {
                         "type": "string"
                     }
                 },
-                "updated_at": {
-                    "type": "string"
+                "meal_type": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ]
                 },
-                "user_id": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "xp_points": {
-                    "type": "integer"
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
                 }
             }
         },
-        "consumption.ConsumptionLog": {
+        "meal_plans.Warning": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "consumed_at": {
-                    "type": "string"
-                },
-                "created_at": {
+                "conflict": {
+                    "description": "Conflict is the allergy or dietary type it conflicts with",
                     "type": "string"
                 },
-                "food_name": {
+                "ingredient": {
+                    "description": "Ingredient is the ingredient, or the meal name, that conflicts",
                     "type": "string"
                 },
-                "id": {
+                "message": {
                     "type": "string"
                 },
-                "inventory_item_id": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "menu.CreateRestaurantMenuItemRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "ingredients",
+                "margin",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 },
-                "notes": {
-                    "type": "string"
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
                 },
-                "quantity": {
+                "margin": {
                     "type": "number"
                 },
-                "unit": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "updated_at": {
-                    "type": "string"
+                "predicted_waste_score": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
                 },
-                "user_id": {
-                    "type": "string"
+                "price": {
+                    "type": "number"
                 },
-                "was_wasted": {
-                    "type": "boolean"
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "consumption.ConsumptionStats": {
+        "menu.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "menu.PatchRestaurantMenuItemRequest": {
             "type": "object",
+            "required": [
+                "category",
+                "ingredients",
+                "name",
+                "price"
+            ],
             "properties": {
-                "total_consumed": {
-                    "type": "number"
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 },
-                "total_logs": {
-                    "type": "integer"
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
                 },
-                "total_wasted": {
+                "margin": {
                     "type": "number"
                 },
-                "waste_percentage": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "predicted_waste_score": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "price": {
                     "type": "number"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "consumption.CreateConsumptionLogRequest": {
+        "menu.RestaurantMenuItem": {
             "type": "object",
-            "required": [
-                "food_name",
-                "quantity"
-            ],
             "properties": {
                 "category": {
-                    "type": "string",
-                    "maxLength": 100
+                    "type": "string"
                 },
-                "consumed_at": {
+                "created_at": {
                     "type": "string"
                 },
-                "food_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "id": {
+                    "type": "string"
                 },
-                "inventory_item_id": {
+                "ingredients": {
+                    "$ref": "#/definitions/menu.JSONB"
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "notes": {
+                "predicted_waste_score": {
                     "type": "string"
                 },
-                "quantity": {
+                "price": {
                     "type": "number"
                 },
-                "unit": {
-                    "type": "string",
-                    "maxLength": 50
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "was_wasted": {
-                    "type": "boolean"
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "consumption.UpdateConsumptionLogRequest": {
+        "menu.UpdateRestaurantMenuItemRequest": {
             "type": "object",
             "properties": {
                 "category": {
                     "type": "string",
-                    "maxLength": 100
+                    "maxLength": 100,
+                    "minLength": 1
                 },
-                "consumed_at": {
-                    "type": "string"
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
                 },
-                "food_name": {
+                "margin": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "notes": {
-                    "type": "string"
+                "predicted_waste_score": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
                 },
-                "quantity": {
+                "price": {
                     "type": "number"
                 },
-                "unit": {
-                    "type": "string",
-                    "maxLength": 50
-                },
-                "was_wasted": {
-                    "type": "boolean"
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "donations.CreateDonationLogRequest": {
+        "notifications.ChannelPreference": {
             "type": "object",
             "required": [
-                "date",
-                "items",
-                "quantity",
-                "recipient_name",
-                "recipient_type",
-                "unit"
+                "source",
+                "type"
             ],
             "properties": {
-                "co2_saved_kg": {
-                    "type": "number"
-                },
-                "date": {
-                    "type": "string"
-                },
-                "items": {
-                    "type": "string",
-                    "minLength": 1
-                },
-                "meals_provided": {
-                    "type": "integer"
-                },
-                "notes": {
-                    "type": "string"
-                },
-                "quantity": {
-                    "type": "number"
-                },
-                "recipient_name": {
-                    "type": "string",
-                    "minLength": 1
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "recipient_type": {
+                "source": {
                     "type": "string",
                     "enum": [
-                        "ngo",
-                        "community-kitchen"
+                        "community",
+                        "ngo"
                     ]
                 },
-                "unit": {
+                "type": {
                     "type": "string",
-                    "minLength": 1
+                    "maxLength": 50
                 }
             }
         },
-        "donations.DonationLog": {
+        "notifications.Delivery": {
             "type": "object",
             "properties": {
-                "co2_saved_kg": {
-                    "type": "number"
+                "attempts": {
+                    "type": "integer"
+                },
+                "channel": {
+                    "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "date": {
+                "digest": {
+                    "type": "boolean"
+                },
+                "digest_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "items": {
+                "last_error": {
                     "type": "string"
                 },
-                "meals_provided": {
-                    "type": "integer"
-                },
-                "notes": {
+                "message": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "next_attempt_at": {
+                    "type": "string"
                 },
-                "recipient_name": {
+                "notification_id": {
                     "type": "string"
                 },
-                "recipient_type": {
+                "sent_at": {
                     "type": "string"
                 },
-                "unit": {
+                "status": {
                     "type": "string"
                 },
-                "user_id": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "donations.ImpactMetrics": {
+        "notifications.Notification": {
             "type": "object",
             "properties": {
-                "category_breakdown": {
-                    "$ref": "#/definitions/donations.JSONB"
-                },
-                "co2_prevented_kg": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "monthly_trend": {
-                    "$ref": "#/definitions/donations.JSONB"
-                },
-                "surplus_donation_rate": {
-                    "type": "number"
+                "message": {
+                    "type": "string"
                 },
-                "sustainability_score": {
-                    "type": "integer"
+                "read": {
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "related_entity_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "severity": {
                     "type": "string"
                 },
-                "waste_prevented_kg": {
-                    "type": "number"
-                },
-                "water_saved_liters": {
-                    "type": "number"
+                "source": {
+                    "type": "string"
                 },
-                "weekly_trend": {
-                    "$ref": "#/definitions/donations.JSONB"
-                }
-            }
-        },
-        "donations.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "errors.AppError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
+                "title": {
+                    "type": "string"
                 },
-                "message": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "food_items.CreateFoodItemRequest": {
+        "notifications.NotificationList": {
             "type": "object",
-            "required": [
-                "category",
-                "name",
-                "typical_expiry_days"
-            ],
             "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "limit": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notifications.Notification"
+                    }
                 },
-                "storage_tips": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "typical_expiry_days": {
-                    "type": "integer",
-                    "minimum": 1
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "food_items.FoodItem": {
+        "notifications.PushToken": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
+                "platform": {
                     "type": "string"
                 },
-                "storage_tips": {
-                    "type": "string"
-                },
-                "typical_expiry_days": {
-                    "type": "integer"
-                },
-                "updated_at": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "food_items.UpdateFoodItemRequest": {
+        "notifications.RegisterPushTokenRequest": {
             "type": "object",
+            "required": [
+                "platform",
+                "token"
+            ],
             "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
-                },
-                "name": {
+                "platform": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "storage_tips": {
-                    "type": "string"
+                    "enum": [
+                        "ios",
+                        "android",
+                        "web"
+                    ]
                 },
-                "typical_expiry_days": {
-                    "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "foodlink_backend_features_community_surplus.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "foodlink_backend_features_restaurant_surplus.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "handlers.APIResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
+                "token": {
                     "type": "string",
-                    "example": "Welcome to Foodlink API v1"
+                    "maxLength": 512
                 }
             }
         },
-        "handlers.HealthResponse": {
+        "notifications.Settings": {
             "type": "object",
             "properties": {
-                "database": {
-                    "type": "string",
-                    "example": "connected"
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notifications.ChannelPreference"
+                    }
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Server is running"
+                "phone": {
+                    "type": "string"
                 },
-                "status": {
-                    "type": "string",
-                    "example": "ok"
+                "quiet_hours_end": {
+                    "type": "string"
+                },
+                "quiet_hours_start": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
-        "inventory.CreateInventoryItemRequest": {
+        "notifications.UnreadCount": {
             "type": "object",
-            "required": [
-                "name",
-                "quantity"
-            ],
             "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "expiry_date": {
-                    "type": "string"
-                },
-                "food_item_id": {
-                    "type": "string"
-                },
-                "location": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "community": {
+                    "type": "integer"
                 },
-                "quantity": {
-                    "type": "number"
+                "ngo": {
+                    "type": "integer"
                 },
-                "unit": {
-                    "type": "string",
-                    "maxLength": 50
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "inventory.CreateRestaurantInventoryItemRequest": {
+        "notifications.UpdateSettingsRequest": {
             "type": "object",
-            "required": [
-                "category",
-                "expiry_date",
-                "name",
-                "quantity",
-                "storage_type",
-                "unit"
-            ],
             "properties": {
-                "alert_tags": {
+                "channels": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/notifications.ChannelPreference"
                     }
                 },
-                "batch_code": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "category": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
-                },
-                "expiry_date": {
+                "phone": {
                     "type": "string"
                 },
-                "invoice_image": {
+                "quiet_hours_end": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "quantity": {
-                    "type": "number"
-                },
-                "storage_type": {
-                    "type": "string",
-                    "enum": [
-                        "fresh",
-                        "chilled",
-                        "frozen",
-                        "dry"
-                    ]
+                "quiet_hours_start": {
+                    "type": "string"
                 },
-                "unit": {
+                "timezone": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 1
+                    "maxLength": 64
                 }
             }
         },
-        "inventory.InventoryItem": {
+        "nutrition.CreateNutritionDataRequest": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
+                "calcium": {
+                    "type": "number"
                 },
-                "created_at": {
-                    "type": "string"
+                "calories": {
+                    "type": "number"
                 },
-                "expiry_date": {
+                "carbs": {
+                    "type": "number"
+                },
+                "date": {
                     "type": "string"
                 },
-                "food_item_id": {
-                    "type": "string"
+                "fats": {
+                    "type": "number"
+                },
+                "fiber": {
+                    "type": "number"
+                },
+                "iron": {
+                    "type": "number"
+                },
+                "nutrition_score": {
+                    "type": "integer"
                 },
-                "id": {
-                    "type": "string"
+                "protein": {
+                    "type": "number"
                 },
-                "location": {
-                    "type": "string"
+                "sodium": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string"
+                "sugar": {
+                    "type": "number"
                 },
-                "quantity": {
+                "vitamin_a": {
                     "type": "number"
                 },
-                "unit": {
-                    "type": "string"
+                "vitamin_b": {
+                    "type": "number"
                 },
-                "updated_at": {
-                    "type": "string"
+                "vitamin_c": {
+                    "type": "number"
                 },
-                "user_id": {
-                    "type": "string"
+                "vitamin_d": {
+                    "type": "number"
                 }
             }
         },
-        "inventory.RestaurantInventoryItem": {
+        "nutrition.NutritionData": {
             "type": "object",
             "properties": {
-                "alert_tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "calcium": {
+                    "type": "number"
                 },
-                "batch_code": {
-                    "type": "string"
+                "calories": {
+                    "type": "number"
                 },
-                "category": {
-                    "type": "string"
+                "carbs": {
+                    "type": "number"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "expiry_date": {
+                "date": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "fats": {
+                    "type": "number"
                 },
-                "invoice_image": {
-                    "type": "string"
+                "fiber": {
+                    "type": "number"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "quantity": {
+                "iron": {
                     "type": "number"
                 },
-                "status": {
-                    "type": "string"
+                "nutrition_score": {
+                    "type": "integer"
                 },
-                "storage_type": {
-                    "type": "string"
+                "protein": {
+                    "type": "number"
                 },
-                "unit": {
-                    "type": "string"
+                "sodium": {
+                    "type": "number"
+                },
+                "sugar": {
+                    "type": "number"
                 },
                 "updated_at": {
                     "type": "string"
                 },
                 "user_id": {
                     "type": "string"
+                },
+                "vitamin_a": {
+                    "type": "number"
+                },
+                "vitamin_b": {
+                    "type": "number"
+                },
+                "vitamin_c": {
+                    "type": "number"
+                },
+                "vitamin_d": {
+                    "type": "number"
                 }
             }
         },
-        "inventory.UpdateInventoryItemRequest": {
+        "nutrition.NutritionStats": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 100
-                },
-                "expiry_date": {
-                    "type": "string"
+                "avg_calories": {
+                    "type": "number"
                 },
-                "food_item_id": {
-                    "type": "string"
+                "avg_carbs": {
+                    "type": "number"
                 },
-                "location": {
-                    "type": "string",
-                    "maxLength": 100
+                "avg_fats": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "avg_nutrition_score": {
+                    "type": "integer"
                 },
-                "quantity": {
+                "avg_protein": {
                     "type": "number"
                 },
-                "unit": {
-                    "type": "string",
-                    "maxLength": 50
+                "total_days": {
+                    "type": "integer"
                 }
             }
         },
-        "inventory.UpdateRestaurantInventoryItemRequest": {
+        "nutrition.UpdateNutritionDataRequest": {
             "type": "object",
             "properties": {
-                "alert_tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "calcium": {
+                    "type": "number"
                 },
-                "batch_code": {
-                    "type": "string",
-                    "maxLength": 100
+                "calories": {
+                    "type": "number"
                 },
-                "category": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "carbs": {
+                    "type": "number"
                 },
-                "expiry_date": {
-                    "type": "string"
+                "fats": {
+                    "type": "number"
                 },
-                "invoice_image": {
-                    "type": "string"
+                "fiber": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "iron": {
+                    "type": "number"
                 },
-                "quantity": {
+                "nutrition_score": {
+                    "type": "integer"
+                },
+                "protein": {
                     "type": "number"
                 },
-                "status": {
-                    "type": "string"
+                "sodium": {
+                    "type": "number"
                 },
-                "storage_type": {
-                    "type": "string",
-                    "enum": [
-                        "fresh",
-                        "chilled",
-                        "frozen",
-                        "dry"
-                    ]
+                "sugar": {
+                    "type": "number"
                 },
-                "unit": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 1
+                "vitamin_a": {
+                    "type": "number"
+                },
+                "vitamin_b": {
+                    "type": "number"
+                },
+                "vitamin_c": {
+                    "type": "number"
+                },
+                "vitamin_d": {
+                    "type": "number"
                 }
             }
         },
-        "kitchen_events.CreateKitchenEventRequest": {
+        "offers.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "offers.NGODonationOffer": {
             "type": "object",
-            "required": [
-                "date",
-                "description",
-                "location",
-                "time",
-                "title"
-            ],
             "properties": {
-                "date": {
-                    "type": "string"
+                "contact": {
+                    "$ref": "#/definitions/offers.JSONB"
                 },
-                "description": {
-                    "type": "string",
-                    "minLength": 1
+                "created_at": {
+                    "type": "string"
                 },
-                "image": {
+                "dietary_notes": {
                     "type": "string"
                 },
-                "location": {
-                    "type": "string",
-                    "minLength": 1
+                "distance_km": {
+                    "type": "number"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "donor_name": {
+                    "type": "string"
                 },
-                "time": {
+                "donor_type": {
                     "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "expires_at": {
+                    "type": "string"
                 },
-                "volunteers_needed": {
+                "freshness_score": {
                     "type": "integer"
-                }
-            }
-        },
-        "kitchen_events.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "kitchen_events.KitchenEvent": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                },
+                "geo_point": {
+                    "$ref": "#/definitions/offers.JSONB"
+                },
+                "id": {
                     "type": "string"
                 },
-                "date": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "items": {
+                    "$ref": "#/definitions/offers.JSONB"
+                },
+                "location_label": {
                     "type": "string"
                 },
-                "description": {
+                "match_reason": {
                     "type": "string"
                 },
-                "food_saved_kg": {
-                    "type": "number"
+                "meals_estimated": {
+                    "type": "integer"
                 },
-                "id": {
+                "ngo_user_id": {
                     "type": "string"
                 },
-                "image": {
+                "offer_title": {
                     "type": "string"
                 },
-                "location": {
+                "partner_id": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "pickup_window": {
+                    "$ref": "#/definitions/offers.JSONB"
                 },
-                "tags": {
+                "safety_flags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "time": {
-                    "type": "string"
-                },
-                "title": {
+                "status": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "volunteers": {
-                    "$ref": "#/definitions/kitchen_events.JSONB"
+                "urgency_level": {
+                    "type": "string"
                 },
-                "volunteers_needed": {
-                    "type": "integer"
+                "weight_kg": {
+                    "type": "number"
                 }
             }
         },
-        "kitchen_events.UpdateKitchenEventRequest": {
+        "outbox.Entry": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "string"
+                "attempts": {
+                    "type": "integer"
                 },
-                "description": {
-                    "type": "string",
-                    "minLength": 1
+                "delivered_at": {
+                    "type": "string"
                 },
-                "food_saved_kg": {
-                    "type": "number"
+                "event_id": {
+                    "type": "string"
                 },
-                "image": {
+                "event_type": {
                     "type": "string"
                 },
-                "location": {
-                    "type": "string",
-                    "minLength": 1
+                "id": {
+                    "type": "string"
                 },
-                "status": {
+                "last_error": {
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "next_attempt_at": {
+                    "type": "string"
                 },
-                "time": {
+                "occurred_at": {
                     "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "payload": {
+                    "type": "object"
                 },
-                "volunteers_needed": {
-                    "type": "integer"
-                }
-            }
-        },
-        "kitchen_events.VolunteerRequest": {
-            "type": "object",
-            "properties": {
-                "role": {
+                "status": {
+                    "type": "string"
+                },
+                "subscriber": {
                     "type": "string"
                 }
             }
         },
-        "leaderboard.Impact": {
+        "preferences.CreatePreferencesRequest": {
             "type": "object",
+            "required": [
+                "household_id",
+                "household_size"
+            ],
             "properties": {
-                "co2_prevented_kg": {
-                    "type": "number"
+                "age_groups": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "donations": {
-                    "type": "integer"
+                "allergies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
+                "avoid_excess": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "budget_range": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "cooking_frequency": {
                     "type": "string"
                 },
-                "meals_provided": {
+                "daily_calories": {
                     "type": "integer"
                 },
-                "personal_contribution": {
-                    "$ref": "#/definitions/leaderboard.JSONB"
-                },
-                "total_surplus_kg": {
-                    "type": "number"
+                "dietary_restrictions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updated_at": {
+                "dietary_type": {
                     "type": "string"
                 },
-                "water_saved_liters": {
-                    "type": "number"
+                "eating_schedule": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "weekly_trend": {
-                    "$ref": "#/definitions/leaderboard.JSONB"
-                }
-            }
-        },
-        "leaderboard.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "leaderboard.Leaderboard": {
-            "type": "object",
-            "properties": {
-                "entries": {
-                    "$ref": "#/definitions/leaderboard.JSONB"
+                "health_conditions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
+                "household_id": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "household_size": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "updated_at": {
+                "leftover_comfort_level": {
                     "type": "string"
-                }
-            }
-        },
-        "leftovers.CreateLeftoverClaimRequest": {
-            "type": "object",
-            "properties": {
-                "message": {
+                },
+                "macro_goal": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "meal_prep_preference": {
                     "type": "string"
-                }
-            }
-        },
-        "leftovers.CreateLeftoverItemRequest": {
-            "type": "object",
-            "required": [
-                "description",
-                "dish_name",
-                "distance_km",
-                "pickup_window",
-                "portions"
-            ],
-            "properties": {
-                "allergens": {
+                },
+                "preferred_cuisines": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "description": {
-                    "type": "string",
-                    "minLength": 1
-                },
-                "dietary_tags": {
+                "preferred_stores": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "dish_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "distance_km": {
-                    "type": "number"
+                "price_sensitivity": {
+                    "type": "string"
                 },
-                "image": {
+                "sustainability_preference": {
                     "type": "string"
                 },
-                "pickup_window": {
-                    "type": "string",
-                    "minLength": 1
+                "vitamins_focus": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "portions": {
-                    "type": "integer"
+                "waste_sensitivity_level": {
+                    "type": "string"
+                },
+                "weekly_budget": {
+                    "type": "number"
                 }
             }
         },
-        "leftovers.LeftoverClaim": {
+        "preferences.CreateRestaurantPreferencesRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "leftover_item_id": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
+                "cuisine_type": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "user_id": {
-                    "type": "string"
+                "donation_preferences": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "user_name": {
+                "operating_hours": {
                     "type": "string"
                 }
             }
         },
-        "leftovers.LeftoverItem": {
+        "preferences.FamilyPreferences": {
             "type": "object",
             "properties": {
-                "allergens": {
+                "age_groups": {
+                    "$ref": "#/definitions/preferences.JSONB"
+                },
+                "allergies": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "avatar_url": {
+                "avoid_excess": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "budget_range": {
+                    "$ref": "#/definitions/preferences.JSONB"
+                },
+                "cooking_frequency": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "daily_calories": {
+                    "type": "integer"
                 },
-                "dietary_tags": {
+                "dietary_restrictions": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "dish_name": {
+                "dietary_type": {
                     "type": "string"
                 },
-                "distance_km": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "string"
+                "eating_schedule": {
+                    "$ref": "#/definitions/preferences.JSONB"
                 },
-                "image": {
-                    "type": "string"
+                "health_conditions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "pickup_window": {
+                "household_id": {
                     "type": "string"
                 },
-                "portions": {
+                "household_size": {
                     "type": "integer"
                 },
-                "status": {
+                "id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "leftover_comfort_level": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "macro_goal": {
+                    "$ref": "#/definitions/preferences.JSONB"
                 },
-                "user_name": {
+                "meal_prep_preference": {
                     "type": "string"
-                }
-            }
-        },
-        "leftovers.UpdateLeftoverItemRequest": {
-            "type": "object",
-            "properties": {
-                "allergens": {
+                },
+                "preferred_cuisines": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "description": {
-                    "type": "string",
-                    "minLength": 1
-                },
-                "dietary_tags": {
+                "preferred_stores": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "dish_name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "distance_km": {
-                    "type": "number"
-                },
-                "image": {
+                "price_sensitivity": {
                     "type": "string"
                 },
-                "pickup_window": {
-                    "type": "string",
-                    "minLength": 1
-                },
-                "portions": {
-                    "type": "integer"
+                "sustainability_preference": {
+                    "type": "string"
                 },
-                "status": {
+                "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "menu.CreateRestaurantMenuItemRequest": {
-            "type": "object",
-            "required": [
-                "category",
-                "ingredients",
-                "margin",
-                "name",
-                "price"
-            ],
-            "properties": {
-                "category": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
                 },
-                "ingredients": {
+                "vitamins_focus": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "additionalProperties": true
+                        "type": "string"
                     }
                 },
-                "margin": {
-                    "type": "number"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
-                },
-                "predicted_waste_score": {
-                    "type": "string",
-                    "enum": [
-                        "low",
-                        "medium",
-                        "high"
-                    ]
+                "waste_sensitivity_level": {
+                    "type": "string"
                 },
-                "price": {
+                "weekly_budget": {
                     "type": "number"
-                },
-                "suggestions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "menu.JSONB": {
+        "preferences.JSONB": {
             "type": "object",
             "additionalProperties": true
         },
-        "menu.RestaurantMenuItem": {
+        "preferences.RestaurantPreferences": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "ingredients": {
-                    "$ref": "#/definitions/menu.JSONB"
-                },
-                "margin": {
-                    "type": "number"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "predicted_waste_score": {
+                "cuisine_type": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
-                },
-                "suggestions": {
+                "donation_preferences": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
+                "id": {
+                    "type": "string"
+                },
+                "operating_hours": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -6469,139 +13607,144 @@ const docTemplate = `{
                 }
             }
         },
-        "menu.UpdateRestaurantMenuItemRequest": {
+        "price_comparisons.CreatePriceComparisonRequest": {
             "type": "object",
+            "required": [
+                "best_price",
+                "item_name",
+                "stores"
+            ],
             "properties": {
+                "best_price": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
                 "category": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
-                },
-                "ingredients": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "additionalProperties": true
-                    }
-                },
-                "margin": {
-                    "type": "number"
+                    "maxLength": 100
                 },
-                "name": {
+                "item_name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "predicted_waste_score": {
-                    "type": "string",
-                    "enum": [
-                        "low",
-                        "medium",
-                        "high"
-                    ]
-                },
-                "price": {
-                    "type": "number"
-                },
-                "suggestions": {
+                "stores": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "object",
+                        "additionalProperties": true
                     }
                 }
             }
         },
-        "nutrition.CreateNutritionDataRequest": {
+        "price_comparisons.JSONB": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "price_comparisons.PriceComparison": {
             "type": "object",
             "properties": {
-                "calcium": {
-                    "type": "number"
-                },
-                "calories": {
-                    "type": "number"
-                },
-                "carbs": {
-                    "type": "number"
+                "best_price": {
+                    "$ref": "#/definitions/price_comparisons.JSONB"
                 },
-                "date": {
+                "category": {
                     "type": "string"
                 },
-                "fats": {
-                    "type": "number"
-                },
-                "fiber": {
-                    "type": "number"
-                },
-                "iron": {
-                    "type": "number"
-                },
-                "nutrition_score": {
-                    "type": "integer"
-                },
-                "protein": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "sodium": {
-                    "type": "number"
+                "item_name": {
+                    "type": "string"
                 },
-                "sugar": {
-                    "type": "number"
+                "stores": {
+                    "$ref": "#/definitions/price_comparisons.JSONB"
                 },
-                "vitamin_a": {
-                    "type": "number"
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "price_comparisons.UpdatePriceComparisonRequest": {
+            "type": "object",
+            "properties": {
+                "best_price": {
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "vitamin_b": {
-                    "type": "number"
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "vitamin_c": {
-                    "type": "number"
+                "item_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "vitamin_d": {
-                    "type": "number"
+                "stores": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
                 }
             }
         },
-        "nutrition.NutritionData": {
+        "profiles.CommunityProfile": {
             "type": "object",
             "properties": {
-                "calcium": {
-                    "type": "number"
+                "accepts_hot_meals": {
+                    "type": "boolean"
                 },
-                "calories": {
-                    "type": "number"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "carbs": {
-                    "type": "number"
+                "avatar_url": {
+                    "type": "string"
                 },
-                "created_at": {
+                "avoid_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "bio": {
                     "type": "string"
                 },
-                "date": {
+                "community_role": {
                     "type": "string"
                 },
-                "fats": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "fiber": {
-                    "type": "number"
+                "dietary_restrictions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
+                "distance_preference": {
                     "type": "string"
                 },
-                "iron": {
-                    "type": "number"
+                "id": {
+                    "type": "string"
                 },
-                "nutrition_score": {
-                    "type": "integer"
+                "notifications_enabled": {
+                    "type": "boolean"
                 },
-                "protein": {
-                    "type": "number"
+                "notify_on_claim": {
+                    "type": "boolean"
                 },
-                "sodium": {
-                    "type": "number"
+                "notify_on_messages": {
+                    "type": "boolean"
                 },
-                "sugar": {
-                    "type": "number"
+                "preferred_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "updated_at": {
                     "type": "string"
@@ -6609,700 +13752,995 @@ const docTemplate = `{
                 "user_id": {
                     "type": "string"
                 },
-                "vitamin_a": {
-                    "type": "number"
-                },
-                "vitamin_b": {
-                    "type": "number"
-                },
-                "vitamin_c": {
-                    "type": "number"
+                "username": {
+                    "type": "string"
                 },
-                "vitamin_d": {
-                    "type": "number"
+                "visibility": {
+                    "type": "string"
                 }
             }
         },
-        "nutrition.NutritionStats": {
+        "profiles.CreateProfileRequest": {
             "type": "object",
+            "required": [
+                "username"
+            ],
             "properties": {
-                "avg_calories": {
-                    "type": "number"
+                "accepts_hot_meals": {
+                    "type": "boolean"
                 },
-                "avg_carbs": {
-                    "type": "number"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "avg_fats": {
-                    "type": "number"
+                "avatar_url": {
+                    "type": "string"
                 },
-                "avg_nutrition_score": {
-                    "type": "integer"
+                "avoid_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "avg_protein": {
-                    "type": "number"
+                "bio": {
+                    "type": "string"
                 },
-                "total_days": {
-                    "type": "integer"
+                "community_role": {
+                    "type": "string"
+                },
+                "dietary_restrictions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "distance_preference": {
+                    "type": "string"
+                },
+                "notifications_enabled": {
+                    "type": "boolean"
+                },
+                "notify_on_claim": {
+                    "type": "boolean"
+                },
+                "notify_on_messages": {
+                    "type": "boolean"
+                },
+                "preferred_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "visibility": {
+                    "type": "string"
                 }
             }
         },
-        "nutrition.UpdateNutritionDataRequest": {
+        "profiles.PatchProfileRequest": {
             "type": "object",
+            "required": [
+                "community_role",
+                "visibility"
+            ],
             "properties": {
-                "calcium": {
-                    "type": "number"
-                },
-                "calories": {
-                    "type": "number"
+                "accepts_hot_meals": {
+                    "type": "boolean"
                 },
-                "carbs": {
-                    "type": "number"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "fats": {
-                    "type": "number"
+                "avatar_url": {
+                    "type": "string"
                 },
-                "fiber": {
-                    "type": "number"
+                "avoid_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "iron": {
-                    "type": "number"
+                "bio": {
+                    "type": "string"
                 },
-                "nutrition_score": {
-                    "type": "integer"
+                "community_role": {
+                    "type": "string",
+                    "enum": [
+                        "member",
+                        "champion",
+                        "organizer"
+                    ]
                 },
-                "protein": {
-                    "type": "number"
+                "dietary_restrictions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "sodium": {
-                    "type": "number"
+                "distance_preference": {
+                    "type": "string",
+                    "enum": [
+                        "1km",
+                        "3km",
+                        "5km",
+                        "any"
+                    ]
                 },
-                "sugar": {
-                    "type": "number"
+                "notifications_enabled": {
+                    "type": "boolean"
                 },
-                "vitamin_a": {
-                    "type": "number"
+                "notify_on_claim": {
+                    "type": "boolean"
                 },
-                "vitamin_b": {
-                    "type": "number"
+                "notify_on_messages": {
+                    "type": "boolean"
                 },
-                "vitamin_c": {
-                    "type": "number"
+                "preferred_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "vitamin_d": {
-                    "type": "number"
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "community",
+                        "private"
+                    ]
                 }
             }
         },
-        "offers.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "offers.NGODonationOffer": {
+        "profiles.UpdateProfileRequest": {
             "type": "object",
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/offers.JSONB"
+                "accepts_hot_meals": {
+                    "type": "boolean"
                 },
-                "created_at": {
-                    "type": "string"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "dietary_notes": {
+                "avatar_url": {
                     "type": "string"
                 },
-                "distance_km": {
-                    "type": "number"
+                "avoid_items": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "donor_name": {
+                "bio": {
                     "type": "string"
                 },
-                "donor_type": {
+                "community_role": {
                     "type": "string"
                 },
-                "expires_at": {
+                "dietary_restrictions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "distance_preference": {
                     "type": "string"
                 },
-                "freshness_score": {
-                    "type": "integer"
+                "notifications_enabled": {
+                    "type": "boolean"
                 },
-                "geo_point": {
-                    "$ref": "#/definitions/offers.JSONB"
+                "notify_on_claim": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "notify_on_messages": {
+                    "type": "boolean"
                 },
-                "images": {
+                "preferred_items": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "items": {
-                    "$ref": "#/definitions/offers.JSONB"
-                },
-                "location_label": {
-                    "type": "string"
-                },
-                "match_reason": {
+                "visibility": {
                     "type": "string"
-                },
-                "meals_estimated": {
+                }
+            }
+        },
+        "recipes.ExpiringUse": {
+            "type": "object",
+            "properties": {
+                "days_left": {
                     "type": "integer"
                 },
-                "ngo_user_id": {
+                "expiry_date": {
                     "type": "string"
                 },
-                "offer_title": {
+                "inventory_item_id": {
                     "type": "string"
                 },
-                "partner_id": {
+                "name": {
                     "type": "string"
+                }
+            }
+        },
+        "recipes.ImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
                 },
-                "pickup_window": {
-                    "$ref": "#/definitions/offers.JSONB"
-                },
-                "safety_flags": {
+                "unlinked": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "status": {
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "recipes.Ingredient": {
+            "type": "object",
+            "properties": {
+                "food_item_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "id": {
                     "type": "string"
                 },
-                "urgency_level": {
+                "name": {
                     "type": "string"
                 },
-                "weight_kg": {
+                "optional": {
+                    "type": "boolean"
+                },
+                "quantity": {
                     "type": "number"
+                },
+                "unit": {
+                    "type": "string"
                 }
             }
         },
-        "preferences.CreatePreferencesRequest": {
+        "recipes.IngredientInput": {
             "type": "object",
             "required": [
-                "household_id",
-                "household_size"
+                "name"
             ],
             "properties": {
-                "age_groups": {
-                    "type": "object",
-                    "additionalProperties": true
+                "food_item": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "allergies": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "avoid_excess": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "optional": {
+                    "type": "boolean"
                 },
-                "budget_range": {
-                    "type": "object",
-                    "additionalProperties": true
+                "quantity": {
+                    "type": "number"
                 },
-                "cooking_frequency": {
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "recipes.PlanRequest": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "date": {
                     "type": "string"
                 },
-                "daily_calories": {
-                    "type": "integer"
+                "meal_type": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ]
                 },
-                "dietary_restrictions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
+        "recipes.Recipe": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "dietary_type": {
+                "cuisine": {
                     "type": "string"
                 },
-                "eating_schedule": {
-                    "type": "object",
-                    "additionalProperties": true
+                "description": {
+                    "type": "string"
                 },
-                "health_conditions": {
+                "id": {
+                    "type": "string"
+                },
+                "ingredients": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/recipes.Ingredient"
                     }
                 },
-                "household_id": {
+                "instructions": {
                     "type": "string"
                 },
-                "household_size": {
-                    "type": "integer",
-                    "minimum": 1
+                "meal_type": {
+                    "type": "string"
                 },
-                "leftover_comfort_level": {
+                "name": {
                     "type": "string"
                 },
-                "macro_goal": {
-                    "type": "object",
-                    "additionalProperties": true
+                "prep_minutes": {
+                    "type": "integer"
                 },
-                "meal_prep_preference": {
-                    "type": "string"
+                "servings": {
+                    "type": "integer"
                 },
-                "preferred_cuisines": {
+                "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "preferred_stores": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "recipes.RecipeInput": {
+            "type": "object",
+            "required": [
+                "ingredients",
+                "name"
+            ],
+            "properties": {
+                "cuisine": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "description": {
+                    "type": "string"
+                },
+                "ingredients": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/recipes.IngredientInput"
                     }
                 },
-                "price_sensitivity": {
+                "instructions": {
                     "type": "string"
                 },
-                "sustainability_preference": {
-                    "type": "string"
+                "meal_type": {
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner",
+                        "snack"
+                    ]
                 },
-                "vitamins_focus": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "prep_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "servings": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                },
-                "waste_sensitivity_level": {
-                    "type": "string"
-                },
-                "weekly_budget": {
-                    "type": "number"
                 }
             }
         },
-        "preferences.CreateRestaurantPreferencesRequest": {
+        "recipes.RecipeList": {
             "type": "object",
             "properties": {
-                "cuisine_type": {
-                    "type": "string",
-                    "maxLength": 100
+                "limit": {
+                    "type": "integer"
                 },
-                "donation_preferences": {
+                "page": {
+                    "type": "integer"
+                },
+                "recipes": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/recipes.Recipe"
                     }
                 },
-                "operating_hours": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "preferences.FamilyPreferences": {
+        "recipes.Suggestion": {
             "type": "object",
             "properties": {
-                "age_groups": {
-                    "$ref": "#/definitions/preferences.JSONB"
-                },
-                "allergies": {
+                "missing": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "avoid_excess": {
+                "reasons": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "budget_range": {
-                    "$ref": "#/definitions/preferences.JSONB"
+                "recipe": {
+                    "$ref": "#/definitions/recipes.Recipe"
                 },
-                "cooking_frequency": {
-                    "type": "string"
+                "score": {
+                    "type": "number"
                 },
-                "created_at": {
-                    "type": "string"
+                "uses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/recipes.ExpiringUse"
+                    }
                 },
-                "daily_calories": {
-                    "type": "integer"
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/meal_plans.Warning"
+                    }
+                }
+            }
+        },
+        "resources.CreateResourceRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "title"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "description": {
+                    "type": "string"
                 },
-                "dietary_restrictions": {
+                "tags": {
                     "type": "array",
+                    "maxItems": 20,
                     "items": {
                         "type": "string"
                     }
                 },
-                "dietary_type": {
-                    "type": "string"
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "eating_schedule": {
-                    "$ref": "#/definitions/preferences.JSONB"
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "resources.Facet": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "health_conditions": {
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "resources.Facets": {
+            "type": "object",
+            "properties": {
+                "categories": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/resources.Facet"
                     }
                 },
-                "household_id": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/resources.Facet"
+                    }
+                }
+            }
+        },
+        "resources.Recommendation": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Category is the food category the resource was picked for",
                     "type": "string"
                 },
-                "household_size": {
-                    "type": "integer"
-                },
-                "id": {
+                "reason": {
                     "type": "string"
                 },
-                "leftover_comfort_level": {
+                "resource": {
+                    "$ref": "#/definitions/resources.Resource"
+                },
+                "wasted_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "resources.Resource": {
+            "type": "object",
+            "properties": {
+                "category": {
                     "type": "string"
                 },
-                "macro_goal": {
-                    "$ref": "#/definitions/preferences.JSONB"
+                "created_at": {
+                    "type": "string"
                 },
-                "meal_prep_preference": {
+                "description": {
                     "type": "string"
                 },
-                "preferred_cuisines": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "id": {
+                    "type": "string"
                 },
-                "preferred_stores": {
+                "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "price_sensitivity": {
+                "title": {
                     "type": "string"
                 },
-                "sustainability_preference": {
+                "updated_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "url": {
                     "type": "string"
+                }
+            }
+        },
+        "resources.ResourceList": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/resources.Facets"
                 },
-                "vitamins_focus": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "resources": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/resources.Resource"
                     }
                 },
-                "waste_sensitivity_level": {
-                    "type": "string"
-                },
-                "weekly_budget": {
-                    "type": "number"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "preferences.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "preferences.RestaurantPreferences": {
+        "resources.UpdateResourceRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 },
-                "cuisine_type": {
+                "description": {
                     "type": "string"
                 },
-                "donation_preferences": {
+                "tags": {
                     "type": "array",
+                    "maxItems": 20,
                     "items": {
                         "type": "string"
                     }
                 },
-                "id": {
-                    "type": "string"
-                },
-                "operating_hours": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "user_id": {
-                    "type": "string"
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
                 }
             }
         },
-        "price_comparisons.CreatePriceComparisonRequest": {
+        "shopping_list.CreateShoppingListItemRequest": {
             "type": "object",
             "required": [
-                "best_price",
-                "item_name",
-                "stores"
+                "name",
+                "quantity"
             ],
             "properties": {
-                "best_price": {
-                    "type": "object",
-                    "additionalProperties": true
+                "aisle": {
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "category": {
                     "type": "string",
                     "maxLength": 100
                 },
-                "item_name": {
+                "estimated_price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "stores": {
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "store": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "shopping_list.GenerateRequest": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "shopping_list.GenerateResult": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "lines": {
                     "type": "array",
                     "items": {
-                        "type": "object",
-                        "additionalProperties": true
+                        "$ref": "#/definitions/shopping_list.GeneratedLine"
                     }
+                },
+                "list": {
+                    "$ref": "#/definitions/shopping_list.ShoppingList"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "price_comparisons.JSONB": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "price_comparisons.PriceComparison": {
+        "shopping_list.GeneratedLine": {
             "type": "object",
             "properties": {
-                "best_price": {
-                    "$ref": "#/definitions/price_comparisons.JSONB"
+                "estimated_price": {
+                    "type": "number"
                 },
-                "category": {
+                "in_stock": {
+                    "type": "number"
+                },
+                "item_id": {
+                    "description": "ItemID is the item added, or the pending item merged into",
                     "type": "string"
                 },
-                "id": {
+                "merged": {
+                    "type": "boolean"
+                },
+                "name": {
                     "type": "string"
                 },
-                "item_name": {
+                "needed": {
+                    "type": "number"
+                },
+                "needed_on": {
+                    "description": "NeededOn is the first date a meal needs it",
                     "type": "string"
                 },
-                "stores": {
-                    "$ref": "#/definitions/price_comparisons.JSONB"
+                "priority": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "store": {
+                    "description": "Store and EstimatedPrice are the cheapest offer of the price\ncomparisons, for ToBuy",
+                    "type": "string"
+                },
+                "to_buy": {
+                    "type": "number"
+                },
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "price_comparisons.UpdatePriceComparisonRequest": {
+        "shopping_list.ItemGroup": {
             "type": "object",
             "properties": {
-                "best_price": {
-                    "type": "object",
-                    "additionalProperties": true
+                "estimated_total": {
+                    "type": "number"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/shopping_list.ShoppingListItem"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "shopping_list.PatchShoppingListItemRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "priority",
+                "quantity"
+            ],
+            "properties": {
+                "aisle": {
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "category": {
                     "type": "string",
                     "maxLength": 100
                 },
-                "item_name": {
+                "estimated_price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 1
                 },
-                "stores": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "additionalProperties": true
-                    }
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "store": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "shopping_list.PurchaseRequest": {
+            "type": "object",
+            "properties": {
+                "add_to_inventory": {
+                    "type": "boolean"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
-        "profiles.CommunityProfile": {
+        "shopping_list.PurchaseResult": {
             "type": "object",
             "properties": {
-                "accepts_hot_meals": {
-                    "type": "boolean"
+                "inventory_item": {
+                    "$ref": "#/definitions/inventory.InventoryItem"
                 },
-                "allergens": {
+                "item": {
+                    "$ref": "#/definitions/shopping_list.ShoppingListItem"
+                }
+            }
+        },
+        "shopping_list.ShoppingList": {
+            "type": "object",
+            "properties": {
+                "groups": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/shopping_list.ItemGroup"
                     }
                 },
-                "avatar_url": {
-                    "type": "string"
-                },
-                "avoid_items": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/shopping_list.ShoppingListItem"
                     }
                 },
-                "bio": {
+                "totals": {
+                    "$ref": "#/definitions/shopping_list.Totals"
+                }
+            }
+        },
+        "shopping_list.ShoppingListItem": {
+            "type": "object",
+            "properties": {
+                "aisle": {
                     "type": "string"
                 },
-                "community_role": {
+                "category": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "dietary_restrictions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "estimated_price": {
+                    "type": "number"
                 },
-                "distance_preference": {
+                "household_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "notifications_enabled": {
-                    "type": "boolean"
-                },
-                "notify_on_claim": {
-                    "type": "boolean"
-                },
-                "notify_on_messages": {
-                    "type": "boolean"
-                },
-                "preferred_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "updated_at": {
+                "inventory_item_id": {
                     "type": "string"
                 },
-                "user_id": {
+                "name": {
                     "type": "string"
                 },
-                "username": {
+                "priority": {
                     "type": "string"
                 },
-                "visibility": {
-                    "type": "string"
-                }
-            }
-        },
-        "profiles.CreateProfileRequest": {
-            "type": "object",
-            "required": [
-                "username"
-            ],
-            "properties": {
-                "accepts_hot_meals": {
+                "purchased": {
                     "type": "boolean"
                 },
-                "allergens": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "purchased_at": {
+                    "type": "string"
                 },
-                "avatar_url": {
+                "purchased_by": {
                     "type": "string"
                 },
-                "avoid_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "quantity": {
+                    "type": "number"
                 },
-                "bio": {
+                "store": {
                     "type": "string"
                 },
-                "community_role": {
+                "unit": {
                     "type": "string"
                 },
-                "dietary_restrictions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "distance_preference": {
+                "updated_at": {
                     "type": "string"
                 },
-                "notifications_enabled": {
-                    "type": "boolean"
-                },
-                "notify_on_claim": {
-                    "type": "boolean"
-                },
-                "notify_on_messages": {
-                    "type": "boolean"
-                },
-                "preferred_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
-                },
-                "visibility": {
+                "user_id": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "profiles.UpdateProfileRequest": {
+        "shopping_list.Totals": {
             "type": "object",
             "properties": {
-                "accepts_hot_meals": {
+                "over_budget": {
                     "type": "boolean"
                 },
-                "allergens": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "pending_total": {
+                    "description": "PendingTotal is the estimated price of the items still to buy",
+                    "type": "number"
                 },
-                "avatar_url": {
-                    "type": "string"
+                "projected_total": {
+                    "type": "number"
                 },
-                "avoid_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "remaining_budget": {
+                    "type": "number"
                 },
-                "bio": {
-                    "type": "string"
+                "spent_this_week": {
+                    "description": "SpentThisWeek is the estimated price of the items bought this week",
+                    "type": "number"
                 },
-                "community_role": {
+                "unpriced_items": {
+                    "description": "UnpricedItems is the number of pending items without an estimated\nprice, left out of the totals",
+                    "type": "integer"
+                },
+                "week_start": {
                     "type": "string"
                 },
-                "dietary_restrictions": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "weekly_budget": {
+                    "description": "WeeklyBudget comes from the household's family preferences",
+                    "type": "number"
+                }
+            }
+        },
+        "shopping_list.UpdateShoppingListItemRequest": {
+            "type": "object",
+            "properties": {
+                "aisle": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "distance_preference": {
-                    "type": "string"
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "notifications_enabled": {
-                    "type": "boolean"
+                "estimated_price": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "notify_on_claim": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 },
-                "notify_on_messages": {
-                    "type": "boolean"
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
                 },
-                "preferred_items": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "quantity": {
+                    "type": "number"
                 },
-                "visibility": {
-                    "type": "string"
+                "store": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50
                 }
             }
         },
@@ -7496,7 +14934,7 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 1
                 },
-                "image": {
+                "image_upload_id": {
                     "type": "string"
                 },
                 "pickup_window": {
@@ -7539,20 +14977,88 @@ const docTemplate = `{
             ],
             "properties": {
                 "message": {
-                    "type": "string",
-                    "minLength": 1
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "surplus.CreateSurplusPostRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "description",
+                "expires_at",
+                "pickup_location",
+                "pickup_window",
+                "quantity",
+                "title",
+                "unit"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "image_upload_id": {
+                    "type": "string"
+                },
+                "pickup_location": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "pickup_window": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "surplus.CreateSurplusRequestRequest": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "surplus.CreateSurplusPostRequest": {
+        "surplus.PatchSurplusPostRequest": {
             "type": "object",
             "required": [
                 "category",
                 "description",
-                "expires_at",
                 "pickup_location",
                 "pickup_window",
                 "quantity",
+                "status",
                 "title",
                 "unit"
             ],
@@ -7569,10 +15075,7 @@ const docTemplate = `{
                 "distance_km": {
                     "type": "number"
                 },
-                "expires_at": {
-                    "type": "string"
-                },
-                "image": {
+                "image_upload_id": {
                     "type": "string"
                 },
                 "pickup_location": {
@@ -7586,6 +15089,14 @@ const docTemplate = `{
                 "quantity": {
                     "type": "number"
                 },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "claimed",
+                        "expired"
+                    ]
+                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -7604,14 +15115,6 @@ const docTemplate = `{
                 }
             }
         },
-        "surplus.CreateSurplusRequestRequest": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
         "surplus.RestaurantSurplusItem": {
             "type": "object",
             "properties": {
@@ -7630,7 +15133,7 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "image": {
+                "image_upload_id": {
                     "type": "string"
                 },
                 "pickup_window": {
@@ -7715,7 +15218,7 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "image": {
+                "image_upload_id": {
                     "type": "string"
                 },
                 "pickup_location": {
@@ -7791,7 +15294,7 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 1
                 },
-                "image": {
+                "image_upload_id": {
                     "type": "string"
                 },
                 "pickup_window": {
@@ -7842,7 +15345,7 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 1
                 },
-                "image": {
+                "image_upload_id": {
                     "type": "string"
                 },
                 "pickup_location": {
@@ -7893,6 +15396,319 @@ const docTemplate = `{
                 }
             }
         },
+        "uploads.Upload": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_size": {
+                    "description": "FileSize, Width and Height describe the stored file, after metadata\nwas stripped",
+                    "type": "integer"
+                },
+                "file_type": {
+                    "description": "FileType is the sniffed MIME type, never the one the client sent",
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL and ThumbnailURL are signed download links valid until\nURLExpiresAt. ThumbnailURL is empty for formats without thumbnails.",
+                    "type": "string"
+                },
+                "url_expires_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "uploads.UploadList": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/uploads.Upload"
+                    }
+                }
+            }
+        },
+        "utils.BatchItemResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.BatchOperation": {
+            "type": "object",
+            "required": [
+                "op"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "delete"
+                    ]
+                }
+            }
+        },
+        "utils.BatchRequest": {
+            "type": "object",
+            "required": [
+                "operations"
+            ],
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "transactional",
+                        "best_effort"
+                    ]
+                },
+                "operations": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/utils.BatchOperation"
+                    }
+                }
+            }
+        },
+        "utils.BatchResult": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.BatchItemResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "webhooks.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "webhooks.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.EventTypeInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.Subscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "disabled_reason": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "description": "EventTypes filters the events sent; empty means every supported type",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "Secret signs the payloads. It is only returned when the subscription\nis created and when the secret is rotated.",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.UpdateSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
         "xp.AddXPRequest": {
             "type": "object",
             "required": [
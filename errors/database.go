@@ -0,0 +1,34 @@
+package errors
+
+import (
+	stderrors "errors"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL error codes mapped to HTTP responses
+const (
+	PQUniqueViolation     = "23505"
+	PQForeignKeyViolation = "23503"
+	PQCheckViolation      = "23514"
+)
+
+// PQCode returns the PostgreSQL error code carried by err, or "" when err
+// does not come from the database
+func PQCode(err error) string {
+	var pqErr *pq.Error
+	if stderrors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+	return ""
+}
+
+// IsUniqueViolation reports whether err is a unique constraint violation
+func IsUniqueViolation(err error) bool {
+	return PQCode(err) == PQUniqueViolation
+}
+
+// IsForeignKeyViolation reports whether err is a foreign key violation
+func IsForeignKeyViolation(err error) bool {
+	return PQCode(err) == PQForeignKeyViolation
+}
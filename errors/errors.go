@@ -1,6 +1,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
 )
@@ -42,6 +43,10 @@ func NewAppErrorWithErr(code int, message string, err error) *AppError {
 	}
 }
 
+// StatusClientClosedRequest is the non-standard status used when the client
+// went away before the response was written
+const StatusClientClosedRequest = 499
+
 // Predefined errors
 var (
 	// 400 Bad Request
@@ -51,8 +56,14 @@ var (
 	ErrInvalidJSON         = NewAppError(http.StatusBadRequest, "Invalid JSON")
 	ErrMissingRequiredField = NewAppError(http.StatusBadRequest, "Missing required field")
 
+	ErrInvalidRequestBody   = NewAppError(http.StatusBadRequest, "Invalid request body")
+	ErrInvalidID            = NewAppError(http.StatusBadRequest, "Invalid ID format")
+	ErrInvalidPath          = NewAppError(http.StatusBadRequest, "Invalid path")
+	ErrConstraintViolation  = NewAppError(http.StatusBadRequest, "Value violates a data constraint")
+
 	// 401 Unauthorized
 	ErrUnauthorized       = NewAppError(http.StatusUnauthorized, "Unauthorized")
+	ErrAuthRequired       = NewAppError(http.StatusUnauthorized, "Authentication required")
 	ErrInvalidToken       = NewAppError(http.StatusUnauthorized, "Invalid token")
 	ErrTokenExpired       = NewAppError(http.StatusUnauthorized, "Token expired")
 	ErrInvalidCredentials = NewAppError(http.StatusUnauthorized, "Invalid credentials")
@@ -70,6 +81,26 @@ var (
 	ErrConflict     = NewAppError(http.StatusConflict, "Resource conflict")
 	ErrAlreadyExists = NewAppError(http.StatusConflict, "Resource already exists")
 	ErrDuplicateKey  = NewAppError(http.StatusConflict, "Duplicate key")
+	ErrReferenceConflict = NewAppError(http.StatusConflict, "Referenced resource does not exist or is still in use")
+
+	// 412 Precondition Failed
+	ErrPreconditionFailed = NewAppError(http.StatusPreconditionFailed, "Resource was changed since it was read")
+
+	// 405 Method Not Allowed
+	ErrMethodNotAllowed = NewAppError(http.StatusMethodNotAllowed, "Method not allowed")
+
+	// 413 Request Entity Too Large
+	ErrRequestTooLarge = NewAppError(http.StatusRequestEntityTooLarge, "Request body too large")
+
+	// 415 Unsupported Media Type
+	ErrUnsupportedMediaType = NewAppError(http.StatusUnsupportedMediaType, "Unsupported media type")
+
+	// 429 Too Many Requests
+	ErrTooManyRequests = NewAppError(http.StatusTooManyRequests, "Rate limit exceeded, retry later")
+
+	// 499 Client Closed Request and 504 Gateway Timeout
+	ErrRequestCanceled = NewAppError(StatusClientClosedRequest, "Request canceled by the client")
+	ErrRequestTimeout  = NewAppError(http.StatusGatewayTimeout, "Request timed out")
 
 	// 500 Internal Server Error
 	ErrInternalServer = NewAppError(http.StatusInternalServerError, "Internal server error")
@@ -84,3 +115,17 @@ func WrapError(err error, appErr *AppError) *AppError {
 	}
 	return NewAppErrorWithErr(appErr.Code, appErr.Message, err)
 }
+
+// Wrap returns err unchanged when it already is or wraps an AppError, and
+// otherwise wraps it in a 500 AppError with message. Handlers use it to
+// describe the failed operation without hiding typed errors from services.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
+		return err
+	}
+	return NewAppErrorWithErr(http.StatusInternalServerError, message, err)
+}
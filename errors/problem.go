@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response body
+type Problem struct {
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Status    int      `json:"status"`
+	Detail    string   `json:"detail,omitempty"`
+	Instance  string   `json:"instance,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+	// Cause is the underlying error, only included outside production
+	Cause string `json:"cause,omitempty"`
+}
+
+// Resolve maps any error to the AppError that describes it to clients.
+// Oversized bodies, cancelled or timed out requests and database constraint
+// violations are recognised even when wrapped in a generic 500 AppError,
+// since repositories wrap every database error as ErrDatabase. Other AppErrors
+// are returned as they are, and anything else becomes an internal error.
+func Resolve(err error) *AppError {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case stderrors.As(err, &maxBytesErr):
+		return WrapError(err, ErrRequestTooLarge)
+	case stderrors.Is(err, context.Canceled):
+		return WrapError(err, ErrRequestCanceled)
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return WrapError(err, ErrRequestTimeout)
+	}
+
+	var appErr *AppError
+	isAppErr := stderrors.As(err, &appErr)
+	if isAppErr && appErr.Code < http.StatusInternalServerError {
+		return appErr
+	}
+
+	switch PQCode(err) {
+	case PQUniqueViolation:
+		return WrapError(err, ErrAlreadyExists)
+	case PQForeignKeyViolation:
+		return WrapError(err, ErrReferenceConflict)
+	case PQCheckViolation:
+		return WrapError(err, ErrConstraintViolation)
+	}
+
+	if isAppErr {
+		return appErr
+	}
+	return WrapError(err, ErrInternalServer)
+}
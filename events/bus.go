@@ -0,0 +1,120 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Envelope is an outbox entry as delivered to a handler
+type Envelope struct {
+	EventID    uuid.UUID       `json:"event_id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	// Attempt counts deliveries to this subscriber, starting at 1
+	Attempt int `json:"attempt"`
+}
+
+// Decode unmarshals the payload into v, usually the event struct
+func (e *Envelope) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// Handler reacts to an event. It runs in tx, which also marks the delivery
+// done, so database side effects happen exactly once. A returned error
+// rolls tx back and the delivery is retried.
+type Handler func(ctx context.Context, tx *sql.Tx, event *Envelope) error
+
+// Bus routes events to subscribers through the event_outbox table
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string]map[string]Handler // event type -> subscriber -> handler
+}
+
+// NewBus creates a bus without subscribers
+func NewBus() *Bus {
+	return &Bus{handlers: map[string]map[string]Handler{}}
+}
+
+// Default is the bus used by the package-level functions
+var Default = NewBus()
+
+// Subscribe registers handler under the subscriber name for eventType. The
+// name identifies the subscriber's deliveries in the outbox, so it must be
+// unique per event type and stay stable across releases.
+func (b *Bus) Subscribe(eventType, subscriber string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handlers[eventType] == nil {
+		b.handlers[eventType] = map[string]Handler{}
+	}
+	if _, exists := b.handlers[eventType][subscriber]; exists {
+		panic(fmt.Sprintf("events: %s already subscribed to %s", subscriber, eventType))
+	}
+	b.handlers[eventType][subscriber] = handler
+}
+
+// Subscribers returns the subscriber names for eventType, sorted
+func (b *Bus) Subscribers(eventType string) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	names := make([]string, 0, len(b.handlers[eventType]))
+	for name := range b.handlers[eventType] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (b *Bus) handler(eventType, subscriber string) (Handler, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	handler, ok := b.handlers[eventType][subscriber]
+	return handler, ok
+}
+
+// Publish writes event to the outbox in tx, one entry per current
+// subscriber, so it is delivered only if tx commits. Events without
+// subscribers are not stored. A nil ctx means context.Background().
+func (b *Bus) Publish(ctx context.Context, tx *sql.Tx, event Event) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	subscribers := b.Subscribers(event.EventType())
+	if len(subscribers) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event.EventType(), err)
+	}
+
+	eventID := uuid.New()
+	for _, subscriber := range subscribers {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO event_outbox (event_id, event_type, subscriber, payload)
+			VALUES ($1, $2, $3, $4)
+		`, eventID, event.EventType(), subscriber, payload)
+		if err != nil {
+			return fmt.Errorf("failed to write %s event to outbox: %w", event.EventType(), err)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers handler on the Default bus
+func Subscribe(eventType, subscriber string, handler Handler) {
+	Default.Subscribe(eventType, subscriber, handler)
+}
+
+// Publish writes event to the outbox in tx through the Default bus
+func Publish(ctx context.Context, tx *sql.Tx, event Event) error {
+	return Default.Publish(ctx, tx, event)
+}
@@ -0,0 +1,246 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"foodlink_backend/metrics"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses in event_outbox
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusDelivered  = "delivered"
+	StatusDead       = "dead"
+)
+
+// DispatcherConfig tunes outbox delivery
+type DispatcherConfig struct {
+	// Interval is how often the outbox is polled when idle
+	Interval time.Duration
+	// BatchSize is how many deliveries are claimed at once
+	BatchSize int
+	// MaxAttempts is how often a delivery is tried before it is dead-lettered
+	MaxAttempts int
+	// Lease is how long a claimed delivery stays locked; deliveries from a
+	// crashed instance are picked up again once it expires
+	Lease time.Duration
+	// Retention is how long delivered entries are kept
+	Retention time.Duration
+}
+
+// retryBaseDelay and retryMaxDelay bound the exponential retry backoff
+const (
+	retryBaseDelay = 5 * time.Second
+	retryMaxDelay  = time.Hour
+)
+
+// cleanupInterval is how often delivered entries past retention are deleted
+const cleanupInterval = time.Hour
+
+// RetryDelay returns how long to wait before the next try after attempt
+// failed: 5s, 10s, 20s, ... capped at one hour
+func RetryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// Dispatcher delivers outbox entries to the bus handlers. Instances may run
+// concurrently: entries are claimed with FOR UPDATE SKIP LOCKED.
+type Dispatcher struct {
+	db  *sql.DB
+	bus *Bus
+	cfg DispatcherConfig
+
+	lastCleanup time.Time
+}
+
+// NewDispatcher creates a dispatcher delivering entries from db to bus
+func NewDispatcher(db *sql.DB, bus *Bus, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{db: db, bus: bus, cfg: cfg}
+}
+
+// delivery is a claimed outbox entry
+type delivery struct {
+	id         uuid.UUID
+	subscriber string
+	envelope   Envelope
+}
+
+// Start delivers entries in the background until ctx is done
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			// Keep going without waiting while full batches come back
+			n, err := d.RunOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.Warn("Failed to dispatch events", "error", err)
+			}
+			if n == d.cfg.BatchSize {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce claims one batch of due deliveries and delivers them, returning
+// how many were claimed
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	d.cleanup(ctx)
+
+	deliveries, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, delivery := range deliveries {
+		d.deliver(ctx, delivery)
+	}
+	return len(deliveries), nil
+}
+
+// claim locks a batch of due entries, including ones whose lease expired
+func (d *Dispatcher) claim(ctx context.Context) ([]delivery, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		UPDATE event_outbox
+		SET status = 'processing', attempts = attempts + 1,
+			locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM event_outbox
+			WHERE (status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP)
+				OR (status = 'processing' AND locked_until < CURRENT_TIMESTAMP)
+			ORDER BY occurred_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_id, event_type, subscriber, payload, attempts, occurred_at
+	`, d.cfg.BatchSize, d.cfg.Lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox entries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []delivery
+	for rows.Next() {
+		var dl delivery
+		env := &dl.envelope
+		if err := rows.Scan(&dl.id, &env.EventID, &env.Type, &dl.subscriber, &env.Payload, &env.Attempt, &env.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to read outbox entry: %w", err)
+		}
+		deliveries = append(deliveries, dl)
+	}
+	return deliveries, rows.Err()
+}
+
+// deliver runs the subscriber's handler and records the outcome
+func (d *Dispatcher) deliver(ctx context.Context, dl delivery) {
+	env := &dl.envelope
+	logger := slog.With("event_id", env.EventID, "event_type", env.Type, "subscriber", dl.subscriber, "attempt", env.Attempt)
+
+	handler, ok := d.bus.handler(env.Type, dl.subscriber)
+	if !ok {
+		d.fail(ctx, dl, fmt.Errorf("no subscriber %s for %s", dl.subscriber, env.Type), true)
+		logger.Error("Event subscriber no longer exists, dead-lettered")
+		return
+	}
+
+	err := d.handle(ctx, dl, handler)
+	if err == nil {
+		metrics.EventDeliveriesTotal.Inc(env.Type, dl.subscriber, StatusDelivered)
+		logger.Debug("Event delivered")
+		return
+	}
+
+	dead := env.Attempt >= d.cfg.MaxAttempts
+	d.fail(ctx, dl, err, dead)
+	if dead {
+		logger.Error("Event delivery failed, dead-lettered", "error", err)
+	} else {
+		logger.Warn("Event delivery failed, will retry", "error", err, "retry_in", RetryDelay(env.Attempt).String())
+	}
+}
+
+// handle runs handler and marks the entry delivered in one transaction
+func (d *Dispatcher) handle(ctx context.Context, dl delivery, handler Handler) (err error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin delivery transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+
+	if err := handler(ctx, tx, &dl.envelope); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE event_outbox
+		SET status = 'delivered', delivered_at = CURRENT_TIMESTAMP, locked_until = NULL, last_error = NULL
+		WHERE id = $1
+	`, dl.id)
+	if err != nil {
+		return fmt.Errorf("failed to mark event delivered: %w", err)
+	}
+	return tx.Commit()
+}
+
+// fail schedules a retry, or dead-letters the entry
+func (d *Dispatcher) fail(ctx context.Context, dl delivery, cause error, dead bool) {
+	status := StatusPending
+	if dead {
+		status = StatusDead
+	}
+	_, err := d.db.ExecContext(ctx, `
+		UPDATE event_outbox
+		SET status = $2, last_error = $3, locked_until = NULL,
+			next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $4)
+		WHERE id = $1
+	`, dl.id, status, cause.Error(), RetryDelay(dl.envelope.Attempt).Seconds())
+	if err != nil {
+		// The lease expires and the entry is claimed again
+		slog.Error("Failed to record event delivery failure", "event_id", dl.envelope.EventID, "error", err)
+	}
+	outcome := "retry"
+	if dead {
+		outcome = StatusDead
+	}
+	metrics.EventDeliveriesTotal.Inc(dl.envelope.Type, dl.subscriber, outcome)
+}
+
+// cleanup deletes delivered entries past retention, at most once per
+// cleanupInterval
+func (d *Dispatcher) cleanup(ctx context.Context) {
+	if time.Since(d.lastCleanup) < cleanupInterval {
+		return
+	}
+	d.lastCleanup = time.Now()
+	_, err := d.db.ExecContext(ctx, `
+		DELETE FROM event_outbox
+		WHERE status = 'delivered' AND delivered_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+	`, d.cfg.Retention.Seconds())
+	if err != nil {
+		slog.Warn("Failed to delete delivered events", "error", err)
+	}
+}
@@ -0,0 +1,207 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a domain event. Its JSON encoding is the outbox payload, so
+// fields may be added but not renamed.
+type Event interface {
+	EventType() string
+}
+
+// Event types
+const (
+	TypeLeftoverClaimed     = "LeftoverClaimed"
+	TypeSurplusClaimed      = "SurplusClaimed"
+	TypeSurplusRequested    = "SurplusRequested"
+	TypeSurplusCommented    = "SurplusCommented"
+	TypeOfferCreated        = "OfferCreated"
+	TypeOfferAccepted       = "OfferAccepted"
+	TypePickupDelivered     = "PickupDelivered"
+	TypePickupStatusChanged = "PickupStatusChanged"
+	TypeFeedbackSubmitted   = "FeedbackSubmitted"
+	TypeDonationLogged      = "DonationLogged"
+	TypeInventoryExpired    = "InventoryExpired"
+	TypeNotificationCreated = "NotificationCreated"
+	TypeMealPlansCreated    = "MealPlansCreated"
+)
+
+// LeftoverClaimed is published when someone claims a community leftover
+type LeftoverClaimed struct {
+	LeftoverID   uuid.UUID `json:"leftover_id"`
+	OwnerID      uuid.UUID `json:"owner_id"`
+	DishName     string    `json:"dish_name"`
+	ClaimID      uuid.UUID `json:"claim_id"`
+	ClaimantID   uuid.UUID `json:"claimant_id"`
+	ClaimantName string    `json:"claimant_name"`
+}
+
+func (LeftoverClaimed) EventType() string { return TypeLeftoverClaimed }
+
+// SurplusClaimed is published when a community surplus post becomes claimed.
+// ClaimantID is set when the post was claimed by approving a request.
+type SurplusClaimed struct {
+	PostID       uuid.UUID  `json:"post_id"`
+	OwnerID      uuid.UUID  `json:"owner_id"`
+	Title        string     `json:"title"`
+	RequestID    *uuid.UUID `json:"request_id,omitempty"`
+	ClaimantID   *uuid.UUID `json:"claimant_id,omitempty"`
+	ClaimantName string     `json:"claimant_name,omitempty"`
+}
+
+func (SurplusClaimed) EventType() string { return TypeSurplusClaimed }
+
+// SurplusRequested is published when someone requests a community surplus post
+type SurplusRequested struct {
+	PostID        uuid.UUID `json:"post_id"`
+	OwnerID       uuid.UUID `json:"owner_id"`
+	Title         string    `json:"title"`
+	RequestID     uuid.UUID `json:"request_id"`
+	RequesterID   uuid.UUID `json:"requester_id"`
+	RequesterName string    `json:"requester_name"`
+	Message       string    `json:"message,omitempty"`
+}
+
+func (SurplusRequested) EventType() string { return TypeSurplusRequested }
+
+// SurplusCommented is published when someone comments on a community surplus post
+type SurplusCommented struct {
+	PostID     uuid.UUID `json:"post_id"`
+	OwnerID    uuid.UUID `json:"owner_id"`
+	Title      string    `json:"title"`
+	CommentID  uuid.UUID `json:"comment_id"`
+	AuthorID   uuid.UUID `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Message    string    `json:"message"`
+}
+
+func (SurplusCommented) EventType() string { return TypeSurplusCommented }
+
+// OfferCreated is published when a donation offer reaches an NGO's queue
+type OfferCreated struct {
+	OfferID      uuid.UUID `json:"offer_id"`
+	NGOUserID    uuid.UUID `json:"ngo_user_id"`
+	OfferTitle   string    `json:"offer_title"`
+	DonorName    string    `json:"donor_name"`
+	DonorType    string    `json:"donor_type"`
+	WeightKg     float64   `json:"weight_kg"`
+	UrgencyLevel string    `json:"urgency_level"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (OfferCreated) EventType() string { return TypeOfferCreated }
+
+// OfferAccepted is published when an NGO accepts a donation offer
+type OfferAccepted struct {
+	OfferID        uuid.UUID `json:"offer_id"`
+	NGOUserID      uuid.UUID `json:"ngo_user_id"`
+	OfferTitle     string    `json:"offer_title"`
+	DonorName      string    `json:"donor_name"`
+	DonorType      string    `json:"donor_type"`
+	WeightKg       float64   `json:"weight_kg"`
+	MealsEstimated int       `json:"meals_estimated"`
+}
+
+func (OfferAccepted) EventType() string { return TypeOfferAccepted }
+
+// PickupDelivered is published when an NGO pickup reaches the delivered status
+type PickupDelivered struct {
+	PickupID    uuid.UUID `json:"pickup_id"`
+	OfferID     uuid.UUID `json:"offer_id"`
+	PickupTime  time.Time `json:"pickup_time"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
+
+func (PickupDelivered) EventType() string { return TypePickupDelivered }
+
+// PickupStatusChanged is published whenever an NGO pickup changes status
+type PickupStatusChanged struct {
+	PickupID       uuid.UUID `json:"pickup_id"`
+	OfferID        uuid.UUID `json:"offer_id"`
+	NGOUserID      uuid.UUID `json:"ngo_user_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+func (PickupStatusChanged) EventType() string { return TypePickupStatusChanged }
+
+// FeedbackSubmitted is published when an NGO files feedback on a delivery
+type FeedbackSubmitted struct {
+	FeedbackID    uuid.UUID `json:"feedback_id"`
+	NGOUserID     uuid.UUID `json:"ngo_user_id"`
+	PartnerName   string    `json:"partner_name"`
+	RecipientName string    `json:"recipient_name"`
+	DeliveryDate  time.Time `json:"delivery_date"`
+	Rating        *int      `json:"rating,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
+}
+
+func (FeedbackSubmitted) EventType() string { return TypeFeedbackSubmitted }
+
+// DonationLogged is published when a restaurant logs a donation
+type DonationLogged struct {
+	DonationID    uuid.UUID `json:"donation_id"`
+	RestaurantID  uuid.UUID `json:"restaurant_id"`
+	RecipientType string    `json:"recipient_type"`
+	RecipientName string    `json:"recipient_name"`
+	Quantity      float64   `json:"quantity"`
+	Unit          string    `json:"unit"`
+	MealsProvided int       `json:"meals_provided"`
+	CO2SavedKg    float64   `json:"co2_saved_kg"`
+}
+
+func (DonationLogged) EventType() string { return TypeDonationLogged }
+
+// Inventory sources for InventoryExpired
+const (
+	InventoryFamily     = "family"
+	InventoryRestaurant = "restaurant"
+	InventoryShop       = "shop"
+)
+
+// InventoryExpired is published when an inventory item passes its expiry date
+type InventoryExpired struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Source    string    `json:"source"`
+	Name      string    `json:"name"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+func (InventoryExpired) EventType() string { return TypeInventoryExpired }
+
+// Notification sources for NotificationCreated
+const (
+	NotificationsCommunity = "community"
+	NotificationsNGO       = "ngo"
+)
+
+// NotificationCreated is published when a community or NGO notification is
+// added for a user
+type NotificationCreated struct {
+	NotificationID uuid.UUID `json:"notification_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Source         string    `json:"source"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Message        string    `json:"message"`
+	// Severity is set for NGO notifications: info, warning or critical
+	Severity  string    `json:"severity,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (NotificationCreated) EventType() string { return TypeNotificationCreated }
+
+// MealPlansCreated is published when a user adds meals to their household's
+// plan, one at a time or by copying a week or repeating a rotation
+type MealPlansCreated struct {
+	UserID      uuid.UUID   `json:"user_id"`
+	HouseholdID uuid.UUID   `json:"household_id"`
+	MealPlanIDs []uuid.UUID `json:"meal_plan_ids"`
+}
+
+func (MealPlansCreated) EventType() string { return TypeMealPlansCreated }
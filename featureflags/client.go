@@ -0,0 +1,133 @@
+package featureflags
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Evaluator answers whether flags are on for the subject stored in ctx.
+// Services depend on it rather than on Client so they can be given a fixed
+// set of flags.
+type Evaluator interface {
+	// Enabled reports whether the flag key is on. Unknown flags are off.
+	Enabled(ctx context.Context, key string) bool
+	// All evaluates every flag
+	All(ctx context.Context) map[string]bool
+}
+
+// Client evaluates flags from an in-memory copy of the store, refreshed
+// periodically so flag checks never wait on the database. Until the first
+// successful refresh every flag is off.
+type Client struct {
+	store       Store
+	environment string
+
+	mu    sync.RWMutex
+	flags map[string]Flag
+}
+
+// NewClient creates a client evaluating flags from store in environment
+func NewClient(store Store, environment string) *Client {
+	return &Client{store: store, environment: environment, flags: map[string]Flag{}}
+}
+
+// Refresh reloads every flag from the store. On error the previous flags are
+// kept.
+func (c *Client) Refresh(ctx context.Context) error {
+	list, err := c.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	flags := make(map[string]Flag, len(list))
+	for _, flag := range list {
+		flags[flag.Key] = flag
+	}
+
+	c.mu.Lock()
+	c.flags = flags
+	c.mu.Unlock()
+	return nil
+}
+
+// Start loads the flags and keeps refreshing them every interval until ctx
+// is done
+func (c *Client) Start(ctx context.Context, interval time.Duration) {
+	if err := c.Refresh(ctx); err != nil {
+		slog.Warn("Failed to load feature flags, all flags are off until the next refresh", "error", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.Refresh(ctx); err != nil {
+					slog.Warn("Failed to refresh feature flags, keeping previous values", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Enabled implements Evaluator
+func (c *Client) Enabled(ctx context.Context, key string) bool {
+	c.mu.RLock()
+	flag, ok := c.flags[key]
+	c.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	return flag.Evaluate(SubjectFromContext(ctx), c.environment)
+}
+
+// All implements Evaluator
+func (c *Client) All(ctx context.Context) map[string]bool {
+	subject := SubjectFromContext(ctx)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make(map[string]bool, len(c.flags))
+	for key, flag := range c.flags {
+		result[key] = flag.Evaluate(subject, c.environment)
+	}
+	return result
+}
+
+type subjectKey struct{}
+
+// WithSubject returns a context carrying the subject flags are evaluated for
+func WithSubject(ctx context.Context, subject Subject) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// SubjectFromContext returns the subject set by WithSubject, or the
+// anonymous subject
+func SubjectFromContext(ctx context.Context) Subject {
+	if ctx == nil {
+		return Subject{}
+	}
+	subject, _ := ctx.Value(subjectKey{}).(Subject)
+	return subject
+}
+
+// Static is an Evaluator with fixed values, ignoring the subject
+type Static map[string]bool
+
+// Enabled implements Evaluator
+func (s Static) Enabled(ctx context.Context, key string) bool {
+	return s[key]
+}
+
+// All implements Evaluator
+func (s Static) All(ctx context.Context) map[string]bool {
+	result := make(map[string]bool, len(s))
+	for key, value := range s {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package featureflags
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// Flag keys used by the application
+const (
+	// MatchingEngine enables the donation matching engine
+	MatchingEngine = "matching_engine"
+	// LeaderboardV2 computes community leaderboards from live activity
+	// instead of the stored snapshots
+	LeaderboardV2 = "leaderboard_v2"
+	// ShopModule exposes the shop API
+	ShopModule = "shop_module"
+)
+
+// Flag is a feature flag and its targeting rules. A flag is on for a subject
+// when it is enabled and the subject passes every rule that is set: empty
+// lists match everyone, and Percentage selects a stable share of users.
+type Flag struct {
+	Key          string    `json:"key" example:"shop_module"`
+	Description  string    `json:"description" example:"Expose the shop API"`
+	Enabled      bool      `json:"enabled" example:"true"`
+	Roles        []string  `json:"roles" example:"shop,admin"`
+	OrgIDs       []string  `json:"org_ids"`
+	Percentage   int       `json:"percentage" example:"100"`
+	Environments []string  `json:"environments" example:"staging"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Subject is who a flag is evaluated for. OrgID is the household for family
+// accounts and the account itself for restaurants, shops and NGOs. The zero
+// Subject is an anonymous visitor.
+type Subject struct {
+	UserID string
+	Role   string
+	OrgID  string
+}
+
+// Evaluate reports whether the flag is on for subject in environment
+func (f *Flag) Evaluate(subject Subject, environment string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if len(f.Environments) > 0 && !contains(f.Environments, environment) {
+		return false
+	}
+	if len(f.Roles) > 0 && !contains(f.Roles, subject.Role) {
+		return false
+	}
+	if len(f.OrgIDs) > 0 && !contains(f.OrgIDs, subject.OrgID) {
+		return false
+	}
+	if f.Percentage >= 100 {
+		return true
+	}
+	// Partial rollouts need a stable identity
+	if subject.UserID == "" {
+		return false
+	}
+	return bucket(f.Key, subject.UserID) < f.Percentage
+}
+
+// bucket places a user in 0-99 for a flag. Hashing the key with the user
+// keeps a user's bucket stable as the percentage grows, while different flags
+// roll out to different users.
+func bucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{':'})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
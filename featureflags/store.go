@@ -0,0 +1,138 @@
+package featureflags
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/errors"
+
+	"github.com/lib/pq"
+)
+
+// Store persists feature flags
+type Store interface {
+	List(ctx context.Context) ([]Flag, error)
+	Get(ctx context.Context, key string) (*Flag, error)
+	Create(ctx context.Context, flag *Flag) error
+	Update(ctx context.Context, flag *Flag) error
+	Delete(ctx context.Context, key string) error
+}
+
+// PostgresStore keeps flags in the feature_flags table
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a store backed by db
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const flagColumns = `key, description, enabled, roles, org_ids, percentage, environments, created_at, updated_at`
+
+// List implements Store
+func (s *PostgresStore) List(ctx context.Context) ([]Flag, error) {
+	if s.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT `+flagColumns+` FROM feature_flags ORDER BY key`)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+
+	var flags []Flag
+	for rows.Next() {
+		var flag Flag
+		if err := scanFlag(rows, &flag); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return flags, nil
+}
+
+// Get implements Store
+func (s *PostgresStore) Get(ctx context.Context, key string) (*Flag, error) {
+	if s.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	var flag Flag
+	row := s.db.QueryRowContext(ctx, `SELECT `+flagColumns+` FROM feature_flags WHERE key = $1`, key)
+	if err := scanFlag(row, &flag); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return &flag, nil
+}
+
+// Create implements Store
+func (s *PostgresStore) Create(ctx context.Context, flag *Flag) error {
+	if s.db == nil {
+		return errors.ErrDatabase
+	}
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO feature_flags (key, description, enabled, roles, org_ids, percentage, environments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`, flag.Key, flag.Description, flag.Enabled, pq.Array(flag.Roles), pq.Array(flag.OrgIDs), flag.Percentage, pq.Array(flag.Environments)).
+		Scan(&flag.CreatedAt, &flag.UpdatedAt)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return errors.ErrAlreadyExists
+		}
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// Update implements Store
+func (s *PostgresStore) Update(ctx context.Context, flag *Flag) error {
+	if s.db == nil {
+		return errors.ErrDatabase
+	}
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE feature_flags
+		SET description = $2, enabled = $3, roles = $4, org_ids = $5, percentage = $6, environments = $7
+		WHERE key = $1
+		RETURNING created_at, updated_at
+	`, flag.Key, flag.Description, flag.Enabled, pq.Array(flag.Roles), pq.Array(flag.OrgIDs), flag.Percentage, pq.Array(flag.Environments)).
+		Scan(&flag.CreatedAt, &flag.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// Delete implements Store
+func (s *PostgresStore) Delete(ctx context.Context, key string) error {
+	if s.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := s.db.ExecContext(ctx, `DELETE FROM feature_flags WHERE key = $1`, key)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFlag(row scanner, flag *Flag) error {
+	var description sql.NullString
+	err := row.Scan(&flag.Key, &description, &flag.Enabled, pq.Array(&flag.Roles), pq.Array(&flag.OrgIDs), &flag.Percentage, pq.Array(&flag.Environments), &flag.CreatedAt, &flag.UpdatedAt)
+	flag.Description = description.String
+	return err
+}
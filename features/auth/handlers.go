@@ -27,32 +27,26 @@ func NewHandler(service *Service) *Handler {
 // @Produce      json
 // @Param        request  body      RegisterRequest  true  "Registration request"
 // @Success      201      {object}  AuthResponse
-// @Failure      400      {object}  errors.AppError
-// @Failure      409      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      409      {object}  errors.Problem
 // @Router       /auth/register [post]
-func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 
-	response, err := h.service.Register(&req)
+	response, err := h.service.WithContext(r.Context()).Register(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to register user", err.Error())
-		return
+		return errors.Wrap(err, "Failed to register user")
 	}
 
 	utils.CreatedResponse(w, "User registered successfully", response)
+	return nil
 }
 
 // Login handles user login
@@ -63,32 +57,26 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        request  body      LoginRequest  true  "Login request"
 // @Success      200      {object}  AuthResponse
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /auth/login [post]
-func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 
-	response, err := h.service.Login(&req)
+	response, err := h.service.WithContext(r.Context()).Login(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.UnauthorizedResponse(w, "Invalid credentials")
-		return
+		return errors.Wrap(err, "Failed to log in")
 	}
 
 	utils.OKResponse(w, "Login successful", response)
+	return nil
 }
 
 // Logout handles user logout
@@ -99,12 +87,11 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200      {object}  map[string]string
-// @Failure      401      {object}  errors.AppError
+// @Failure      401      {object}  errors.Problem
 // @Router       /auth/logout [post]
-func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	// In a stateless JWT system, logout is handled client-side
@@ -112,6 +99,7 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 	utils.OKResponse(w, "Logged out successfully", map[string]string{
 		"message": "Logged out successfully",
 	})
+	return nil
 }
 
 // RefreshToken handles token refresh
@@ -122,26 +110,23 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200      {object}  AuthResponse
-// @Failure      401      {object}  errors.AppError
+// @Failure      401      {object}  errors.Problem
 // @Router       /auth/refresh [post]
-func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	// Get user from context (set by auth middleware)
 	user, ok := r.Context().Value("user").(*User)
 	if !ok {
-		utils.UnauthorizedResponse(w, "Invalid token")
-		return
+		return errors.NewAppError(http.StatusUnauthorized, "Invalid token")
 	}
 
 	// Generate new token
 	token, err := utils.GenerateToken(user.ID, user.Email, user.Role, h.service.jwtExpiry)
 	if err != nil {
-		utils.InternalServerErrorResponse(w, "Failed to generate token", err.Error())
-		return
+		return errors.Wrap(err, "Failed to generate token")
 	}
 
 	response := &AuthResponse{
@@ -152,6 +137,7 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	}
 
 	utils.OKResponse(w, "Token refreshed successfully", response)
+	return nil
 }
 
 // GetMe handles getting current user info
@@ -162,20 +148,19 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200      {object}  UserResponse
-// @Failure      401      {object}  errors.AppError
+// @Failure      401      {object}  errors.Problem
 // @Router       /auth/me [get]
-func (h *Handler) GetMe(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetMe(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	// Get user from context (set by auth middleware)
 	user, ok := r.Context().Value("user").(*User)
 	if !ok {
-		utils.UnauthorizedResponse(w, "Invalid token")
-		return
+		return errors.NewAppError(http.StatusUnauthorized, "Invalid token")
 	}
 
 	utils.OKResponse(w, "User retrieved successfully", user.ToUserResponse())
+	return nil
 }
@@ -3,7 +3,10 @@ package auth
 import (
 	"context"
 	"foodlink_backend/errors"
-	"foodlink_backend/utils"
+	"foodlink_backend/featureflags"
+	"foodlink_backend/logger"
+	"foodlink_backend/middleware"
+	"foodlink_backend/tracing"
 	"net/http"
 	"strings"
 )
@@ -15,32 +18,31 @@ func AuthMiddleware(service *Service) func(http.Handler) http.Handler {
 			// Get token from Authorization header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				utils.UnauthorizedResponse(w, "Authorization header required")
+				middleware.RenderError(w, r, errors.NewAppError(http.StatusUnauthorized, "Authorization header required"))
 				return
 			}
 
 			// Check Bearer token format
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				utils.UnauthorizedResponse(w, "Invalid authorization header format")
+				middleware.RenderError(w, r, errors.NewAppError(http.StatusUnauthorized, "Invalid authorization header format"))
 				return
 			}
 
 			tokenString := parts[1]
 
 			// Validate token and get user
-			user, err := service.ValidateToken(tokenString)
+			user, err := service.WithContext(r.Context()).ValidateToken(tokenString)
 			if err != nil {
-				if appErr, ok := err.(*errors.AppError); ok {
-					utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-					return
-				}
-				utils.UnauthorizedResponse(w, "Invalid token")
+				middleware.RenderError(w, r, errors.WrapError(err, errors.ErrInvalidToken))
 				return
 			}
 
 			// Add user to context
 			ctx := context.WithValue(r.Context(), "user", user)
+			ctx = logger.With(ctx, "user_id", user.ID.String(), "role", user.Role)
+			ctx = featureflags.WithSubject(ctx, flagSubject(user))
+			tracing.SetUser(ctx, user.ID.String(), user.Role)
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
@@ -54,7 +56,7 @@ func RequireRole(requiredRoles ...string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			user, ok := r.Context().Value("user").(*User)
 			if !ok {
-				utils.UnauthorizedResponse(w, "Authentication required")
+				middleware.RenderError(w, r, errors.ErrAuthRequired)
 				return
 			}
 
@@ -68,7 +70,7 @@ func RequireRole(requiredRoles ...string) func(http.Handler) http.Handler {
 			}
 
 			if !hasRole {
-				utils.ForbiddenResponse(w, "Insufficient permissions")
+				middleware.RenderError(w, r, errors.ErrInsufficientPerms)
 				return
 			}
 
@@ -85,9 +87,12 @@ func OptionalAuth(service *Service) func(http.Handler) http.Handler {
 			if authHeader != "" {
 				parts := strings.Split(authHeader, " ")
 				if len(parts) == 2 && parts[0] == "Bearer" {
-					user, err := service.ValidateToken(parts[1])
+					user, err := service.WithContext(r.Context()).ValidateToken(parts[1])
 					if err == nil {
 						ctx := context.WithValue(r.Context(), "user", user)
+						ctx = logger.With(ctx, "user_id", user.ID.String(), "role", user.Role)
+						ctx = featureflags.WithSubject(ctx, flagSubject(user))
+						tracing.SetUser(ctx, user.ID.String(), user.Role)
 						r = r.WithContext(ctx)
 					}
 				}
@@ -97,3 +102,13 @@ func OptionalAuth(service *Service) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// flagSubject describes user for feature flag targeting. Family accounts
+// belong to their household; other accounts are their own organization.
+func flagSubject(user *User) featureflags.Subject {
+	orgID := user.ID.String()
+	if user.HouseholdID != nil {
+		orgID = user.HouseholdID.String()
+	}
+	return featureflags.Subject{UserID: user.ID.String(), Role: user.Role, OrgID: orgID}
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -11,7 +12,8 @@ import (
 
 // Repository handles database operations for authentication
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 // NewRepository creates a new auth repository
@@ -21,6 +23,16 @@ func NewRepository() *Repository {
 	}
 }
 
+// WithContext returns a copy of the repository that runs its queries with ctx
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+// conn returns the connection pool bound to the repository context
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 // CreateUser creates a new user in the database
 func (r *Repository) CreateUser(user *User) error {
 	if r.db == nil {
@@ -34,7 +46,7 @@ func (r *Repository) CreateUser(user *User) error {
 	`
 
 	now := time.Now()
-	err := r.db.QueryRow(
+	err := r.conn().QueryRow(
 		query,
 		user.ID,
 		user.Email,
@@ -56,7 +68,7 @@ func (r *Repository) CreateUser(user *User) error {
 	)
 
 	if err != nil {
-		if err.Error() == "pq: duplicate key value violates unique constraint \"users_email_key\"" {
+		if errors.IsUniqueViolation(err) {
 			return errors.ErrAlreadyExists
 		}
 		return errors.WrapError(err, errors.ErrDatabase)
@@ -78,7 +90,7 @@ func (r *Repository) GetUserByEmail(email string) (*User, error) {
 		WHERE email = $1
 	`
 
-	err := r.db.QueryRow(query, email).Scan(
+	err := r.conn().QueryRow(query, email).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Name,
@@ -112,7 +124,7 @@ func (r *Repository) GetUserByID(id uuid.UUID) (*User, error) {
 		WHERE id = $1
 	`
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.conn().QueryRow(query, id).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Name,
@@ -146,7 +158,7 @@ func (r *Repository) UpdateUser(user *User) error {
 		RETURNING id, email, name, password_hash, household_id, role, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(
+	err := r.conn().QueryRow(
 		query,
 		user.Name,
 		user.HouseholdID,
@@ -182,7 +194,7 @@ func (r *Repository) EmailExists(email string) (bool, error) {
 
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
-	err := r.db.QueryRow(query, email).Scan(&exists)
+	err := r.conn().QueryRow(query, email).Scan(&exists)
 	if err != nil {
 		return false, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -10,14 +10,14 @@ func SetupRoutes(service *Service, handler *Handler) http.Handler {
 	mux := http.NewServeMux()
 
 	// Public routes
-	mux.HandleFunc("/register", handler.Register)
-	mux.HandleFunc("/login", handler.Login)
+	mux.Handle("/register", middleware.Handle(handler.Register))
+	mux.Handle("/login", middleware.Handle(handler.Login))
 
 	// Protected routes
 	protectedMux := http.NewServeMux()
-	protectedMux.HandleFunc("/logout", handler.Logout)
-	protectedMux.HandleFunc("/refresh", handler.RefreshToken)
-	protectedMux.HandleFunc("/me", handler.GetMe)
+	protectedMux.Handle("/logout", middleware.Handle(handler.Logout))
+	protectedMux.Handle("/refresh", middleware.Handle(handler.RefreshToken))
+	protectedMux.Handle("/me", middleware.Handle(handler.GetMe))
 
 	// Apply auth middleware to protected routes
 	protectedHandler := middleware.Chain(
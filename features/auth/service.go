@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"foodlink_backend/config"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
@@ -19,18 +20,20 @@ type Service struct {
 
 // NewService creates a new auth service
 func NewService(cfg *config.Config) *Service {
-	expiry, _ := utils.ParseExpiry(cfg.JWTExpiry)
-	if expiry == 0 {
-		expiry = 24 * time.Hour // Default 24 hours
-	}
-
 	return &Service{
 		repo:      NewRepository(),
 		cfg:       cfg,
-		jwtExpiry: expiry,
+		jwtExpiry: cfg.Auth.JWTExpiry,
 	}
 }
 
+// WithContext returns a copy of the service whose queries run with ctx
+func (s *Service) WithContext(ctx context.Context) *Service {
+	scoped := *s
+	scoped.repo = s.repo.WithContext(ctx)
+	return &scoped
+}
+
 // Register registers a new user
 func (s *Service) Register(req *RegisterRequest) (*AuthResponse, error) {
 	// Validate input
@@ -38,7 +41,7 @@ func (s *Service) Register(req *RegisterRequest) (*AuthResponse, error) {
 		return nil, errors.NewAppErrorWithErr(
 			errors.ErrValidationFailed.Code,
 			"Validation failed: "+validationErrors[0],
-			nil,
+			utils.ValidationErrors(validationErrors),
 		)
 	}
 
@@ -97,7 +100,7 @@ func (s *Service) Login(req *LoginRequest) (*AuthResponse, error) {
 		return nil, errors.NewAppErrorWithErr(
 			errors.ErrValidationFailed.Code,
 			"Validation failed: "+validationErrors[0],
-			nil,
+			utils.ValidationErrors(validationErrors),
 		)
 	}
 
@@ -34,28 +34,22 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   Badge
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /badges [get]
-func (h *Handler) GetUserBadges(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetUserBadges(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	badges, err := h.service.GetByUserID(userID)
+	badges, err := h.service.WithContext(r.Context()).GetByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve badges", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve badges")
 	}
 	utils.OKResponse(w, "Badges retrieved successfully", badges)
+	return nil
 }
 
 // GetAvailableBadges handles GET /api/v1/badges/available
@@ -66,15 +60,15 @@ func (h *Handler) GetUserBadges(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   AvailableBadge
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /badges/available [get]
-func (h *Handler) GetAvailableBadges(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAvailableBadges(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
-	badges := h.service.GetAvailableBadges()
+	badges := h.service.WithContext(r.Context()).GetAvailableBadges()
 	utils.OKResponse(w, "Available badges retrieved successfully", badges)
+	return nil
 }
 
 // UnlockBadge handles POST /api/v1/badges/unlock
@@ -86,33 +80,26 @@ func (h *Handler) GetAvailableBadges(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      UnlockBadgeRequest  true  "Badge unlock data"
 // @Success      201      {object}  Badge
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      409      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      409      {object}  errors.Problem
 // @Router       /badges/unlock [post]
-func (h *Handler) UnlockBadge(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UnlockBadge(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req UnlockBadgeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	badge, err := h.service.UnlockBadge(userID, &req)
+	badge, err := h.service.WithContext(r.Context()).UnlockBadge(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to unlock badge", err.Error())
-		return
+		return errors.Wrap(err, "Failed to unlock badge")
 	}
 	utils.CreatedResponse(w, "Badge unlocked successfully", badge)
+	return nil
 }
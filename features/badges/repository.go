@@ -1,6 +1,7 @@
 package badges
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -10,19 +11,33 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetByUserID(userID uuid.UUID) ([]*Badge, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, user_id, badge_id, name, description, icon, unlocked_at, xp_reward, created_at FROM badges WHERE user_id = $1 ORDER BY unlocked_at DESC`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -44,7 +59,7 @@ func (r *Repository) GetByUserIDAndBadgeID(userID uuid.UUID, badgeID string) (*B
 	}
 	b := &Badge{}
 	query := `SELECT id, user_id, badge_id, name, description, icon, unlocked_at, xp_reward, created_at FROM badges WHERE user_id = $1 AND badge_id = $2`
-	err := r.db.QueryRow(query, userID, badgeID).Scan(&b.ID, &b.UserID, &b.BadgeID, &b.Name, &b.Description, &b.Icon, &b.UnlockedAt, &b.XPReward, &b.CreatedAt)
+	err := r.conn().QueryRow(query, userID, badgeID).Scan(&b.ID, &b.UserID, &b.BadgeID, &b.Name, &b.Description, &b.Icon, &b.UnlockedAt, &b.XPReward, &b.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -60,7 +75,7 @@ func (r *Repository) Create(badge *Badge) error {
 	}
 	query := `INSERT INTO badges (id, user_id, badge_id, name, description, icon, unlocked_at, xp_reward, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (user_id, badge_id) DO NOTHING RETURNING id, user_id, badge_id, name, description, icon, unlocked_at, xp_reward, created_at`
 	now := time.Now()
-	err := r.db.QueryRow(query, badge.ID, badge.UserID, badge.BadgeID, badge.Name, badge.Description, badge.Icon, badge.UnlockedAt, badge.XPReward, now).Scan(&badge.ID, &badge.UserID, &badge.BadgeID, &badge.Name, &badge.Description, &badge.Icon, &badge.UnlockedAt, &badge.XPReward, &badge.CreatedAt)
+	err := r.conn().QueryRow(query, badge.ID, badge.UserID, badge.BadgeID, badge.Name, badge.Description, badge.Icon, badge.UnlockedAt, badge.XPReward, now).Scan(&badge.ID, &badge.UserID, &badge.BadgeID, &badge.Name, &badge.Description, &badge.Icon, &badge.UnlockedAt, &badge.XPReward, &badge.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.ErrAlreadyExists
@@ -69,3 +84,16 @@ func (r *Repository) Create(badge *Badge) error {
 	}
 	return nil
 }
+
+// CountMealPlans returns the number of meal plans the user created that
+// still exist
+func (r *Repository) CountMealPlans(userID uuid.UUID) (int, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var count int
+	if err := r.conn().QueryRow(`SELECT COUNT(*) FROM meal_plans WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return count, nil
+}
@@ -1,33 +1,34 @@
 package badges
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 )
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		switch r.Method {
 		case http.MethodGet:
-			handler.GetUserBadges(w, r)
+			return handler.GetUserBadges(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/available", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/available", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodGet {
-			handler.GetAvailableBadges(w, r)
+			return handler.GetAvailableBadges(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/unlock", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/unlock", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodPost {
-			handler.UnlockBadge(w, r)
+			return handler.UnlockBadge(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
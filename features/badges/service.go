@@ -1,6 +1,7 @@
 package badges
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 	"time"
@@ -16,6 +17,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetByUserID(userID uuid.UUID) ([]*Badge, error) {
 	return s.repo.GetByUserID(userID)
 }
@@ -36,7 +41,7 @@ func (s *Service) GetAvailableBadges() []*AvailableBadge {
 
 func (s *Service) UnlockBadge(userID uuid.UUID, req *UnlockBadgeRequest) (*Badge, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	
 	// Check if badge already exists
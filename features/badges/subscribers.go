@@ -0,0 +1,63 @@
+package badges
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// mealPlannerGoal is the number of meal plans that earns the meal-planner
+// badge
+const mealPlannerGoal = 10
+
+// RegisterSubscribers subscribes the badges feature to domain events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypeMealPlansCreated, "badges.meal_planner", awardMealPlanner)
+}
+
+// awardMealPlanner unlocks the meal-planner badge once the user has created
+// enough meal plans. Plans they deleted since do not count.
+func awardMealPlanner(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var created events.MealPlansCreated
+	if err := event.Decode(&created); err != nil {
+		return err
+	}
+	repo := NewRepository().WithContext(ctx).WithTx(tx)
+	if _, err := repo.GetByUserIDAndBadgeID(created.UserID, "meal-planner"); err != errors.ErrNotFound {
+		return err
+	}
+	count, err := repo.CountMealPlans(created.UserID)
+	if err != nil || count < mealPlannerGoal {
+		return err
+	}
+	return award(repo, created.UserID, "meal-planner")
+}
+
+// award unlocks one of the available badges for the user, unless they
+// already have it
+func award(repo *Repository, userID uuid.UUID, badgeID string) error {
+	for _, available := range NewService().GetAvailableBadges() {
+		if available.BadgeID != badgeID {
+			continue
+		}
+		err := repo.Create(&Badge{
+			ID:          uuid.New(),
+			UserID:      userID,
+			BadgeID:     available.BadgeID,
+			Name:        available.Name,
+			Description: available.Description,
+			Icon:        available.Icon,
+			UnlockedAt:  time.Now(),
+			XPReward:    available.XPReward,
+		})
+		if err == errors.ErrAlreadyExists {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package calendar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) getUser(r *http.Request) (*auth.User, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return nil, errors.ErrUnauthorized
+	}
+	return user, nil
+}
+
+// pathParts splits a /calendar/... path relative to /api/v1
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/calendar"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// ListFeeds handles GET /api/v1/calendar/feeds
+// @Summary      List calendar feeds
+// @Description  List the user's calendar feeds that are not revoked. Feed URLs are only returned when feeds are created.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   Feed
+// @Failure      401  {object}  errors.Problem
+// @Router       /calendar/feeds [get]
+func (h *Handler) ListFeeds(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	user, err := h.getUser(r)
+	if err != nil {
+		return err
+	}
+	feeds, err := h.service.WithContext(r.Context()).ListFeeds(user.ID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve calendar feeds")
+	}
+	utils.OKResponse(w, "Calendar feeds retrieved successfully", feeds)
+	return nil
+}
+
+// CreateFeed handles POST /api/v1/calendar/feeds
+// @Summary      Create calendar feed
+// @Description  Create an iCalendar feed to subscribe to from a calendar app: the household's meal plans (meal-plans), the community kitchen events the user volunteers for (kitchen-events), or, for NGO accounts, their scheduled pickups with volunteer and donor contact details (pickups). The returned url holds a secret token and is shown only once; anyone with it can read the feed until it is revoked.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateFeedRequest  true  "Feed kind and name"
+// @Success      201      {object}  Feed
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Router       /calendar/feeds [post]
+func (h *Handler) CreateFeed(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	user, err := h.getUser(r)
+	if err != nil {
+		return err
+	}
+	var req CreateFeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	feed, err := h.service.WithContext(r.Context()).CreateFeed(user.ID, user.Role, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create calendar feed")
+	}
+	utils.CreatedResponse(w, "Calendar feed created successfully", feed)
+	return nil
+}
+
+// RevokeFeed handles DELETE /api/v1/calendar/feeds/:id
+// @Summary      Revoke calendar feed
+// @Description  Revoke a calendar feed. Its URL stops working at once; calendar apps subscribed to it keep the events they last fetched.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Feed ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /calendar/feeds/{id} [delete]
+func (h *Handler) RevokeFeed(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	user, err := h.getUser(r)
+	if err != nil {
+		return err
+	}
+	parts := pathParts(r)
+	if len(parts) != 2 {
+		return errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	if err := h.service.WithContext(r.Context()).RevokeFeed(user.ID, id); err != nil {
+		return errors.Wrap(err, "Failed to revoke calendar feed")
+	}
+	utils.OKResponse(w, "Calendar feed revoked successfully", map[string]string{"message": "Revoked"})
+	return nil
+}
+
+// Feed handles GET /api/v1/calendar/:token.ics
+// @Summary      Get calendar feed
+// @Description  Get an iCalendar (RFC 5545) feed by the secret token of its URL, without signing in. Events keep their UID across updates, and their SEQUENCE goes up when their time, title, details, location or status change. Events from the last 30 days are included. Meals and kitchen events are in local time; pickups are in UTC. Responses carry an ETag for conditional requests.
+// @Tags         calendar
+// @Produce      text/calendar
+// @Param        token  path      string  true  "Feed token"
+// @Success      200    {string}  string  "iCalendar object"
+// @Success      304    {string}  string  "Not modified"
+// @Failure      404    {object}  errors.Problem
+// @Router       /calendar/{token}.ics [get]
+func (h *Handler) Feed(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return errors.ErrMethodNotAllowed
+	}
+	parts := pathParts(r)
+	if len(parts) != 1 || !strings.HasSuffix(parts[0], ".ics") {
+		return errors.ErrNotFound
+	}
+	body, err := h.service.WithContext(r.Context()).Feed(strings.TrimSuffix(parts[0], ".ics"))
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve calendar feed")
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="foodlink.ics"`)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		w.Write(body)
+	}
+	return nil
+}
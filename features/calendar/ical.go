@@ -0,0 +1,114 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	prodID = "-//Foodlink//Foodlink Backend//EN"
+	// refreshInterval is how often calendar apps are asked to fetch feeds
+	refreshInterval = "PT1H"
+	// maxLineOctets is the longest content line before folding
+	maxLineOctets = 75
+)
+
+// render writes the events as an RFC 5545 iCalendar object named name
+func render(name string, events []*Event) []byte {
+	var w icalWriter
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", prodID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("NAME", escapeText(name))
+	w.line("X-WR-CALNAME", escapeText(name))
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", refreshInterval)
+	w.line("X-PUBLISHED-TTL", refreshInterval)
+	for _, e := range events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", e.UID)
+		w.line("SEQUENCE", strconv.Itoa(e.Sequence))
+		w.line("DTSTAMP", formatUTC(e.LastModified))
+		w.line("LAST-MODIFIED", formatUTC(e.LastModified))
+		if e.Floating {
+			w.line("DTSTART", e.Start.Format("20060102T150405"))
+		} else {
+			w.line("DTSTART", formatUTC(e.Start))
+		}
+		w.line("DURATION", formatDuration(e.Duration))
+		w.line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			w.line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.Location != "" {
+			w.line("LOCATION", escapeText(e.Location))
+		}
+		if len(e.Categories) > 0 {
+			categories := make([]string, len(e.Categories))
+			for i, c := range e.Categories {
+				categories[i] = escapeText(c)
+			}
+			w.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if e.Status != "" {
+			w.line("STATUS", e.Status)
+		}
+		w.line("END", "VEVENT")
+	}
+	w.line("END", "VCALENDAR")
+	return []byte(w.b.String())
+}
+
+// icalWriter writes content lines ending in CRLF, folded at 75 octets
+type icalWriter struct {
+	b strings.Builder
+}
+
+func (w *icalWriter) line(name, value string) {
+	s := name + ":" + value
+	limit := maxLineOctets
+	for len(s) > limit {
+		// Never split a UTF-8 sequence
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.b.WriteString(s[:cut])
+		w.b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space
+		limit = maxLineOctets - 1
+	}
+	w.b.WriteString(s)
+	w.b.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatDuration writes a positive duration in whole minutes, such as PT1H30M
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes <= 0 {
+		return "PT0M"
+	}
+	s := "PT"
+	if h := minutes / 60; h > 0 {
+		s += strconv.Itoa(h) + "H"
+	}
+	if m := minutes % 60; m > 0 {
+		s += strconv.Itoa(m) + "M"
+	}
+	return s
+}
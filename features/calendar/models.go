@@ -0,0 +1,66 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Feed kinds in calendar_feeds
+const (
+	KindMealPlans     = "meal-plans"
+	KindKitchenEvents = "kitchen-events"
+	KindPickups       = "pickups"
+)
+
+// Feed is an iCalendar feed a user subscribes to from their calendar app
+type Feed struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	Kind   string    `json:"kind"`
+	Name   string    `json:"name,omitempty"`
+	// URL holds the feed's secret token. It is only returned when the feed
+	// is created; a lost URL is replaced by revoking the feed and creating
+	// another.
+	URL        string     `json:"url,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateFeedRequest creates a feed. Pickup feeds are for NGO accounts.
+type CreateFeedRequest struct {
+	Kind string `json:"kind" validate:"required,oneof=meal-plans kitchen-events pickups"`
+	Name string `json:"name" validate:"max=100"`
+}
+
+// Event is a VEVENT of a feed. Floating events, such as meals, happen at
+// the same wall-clock time wherever the calendar is; the others are in UTC.
+type Event struct {
+	UID          string
+	Sequence     int
+	Start        time.Time
+	Floating     bool
+	Duration     time.Duration
+	Summary      string
+	Description  string
+	Location     string
+	Categories   []string
+	Status       string
+	LastModified time.Time
+}
+
+// Event statuses (RFC 5545 section 3.8.1.11)
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// feedOwner is the user a feed token belongs to
+type feedOwner struct {
+	feedID      uuid.UUID
+	kind        string
+	name        string
+	userID      uuid.UUID
+	householdID uuid.UUID
+	role        string
+}
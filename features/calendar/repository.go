@@ -0,0 +1,286 @@
+package calendar
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+// CreateFeed saves the feed with the hash of its token
+func (r *Repository) CreateFeed(feed *Feed, tokenHash string) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO calendar_feeds (user_id, kind, name, token_hash) VALUES ($1, $2, NULLIF($3, ''), $4) RETURNING id, created_at`
+	if err := r.conn().QueryRow(query, feed.UserID, feed.Kind, feed.Name, tokenHash).Scan(&feed.ID, &feed.CreatedAt); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// ListFeeds returns the user's feeds that are not revoked, newest first
+func (r *Repository) ListFeeds(userID uuid.UUID) ([]*Feed, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT id, user_id, kind, COALESCE(name, ''), last_used_at, created_at
+		FROM calendar_feeds WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC`
+	rows, err := r.conn().Query(query, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	feeds := []*Feed{}
+	for rows.Next() {
+		feed := &Feed{}
+		if err := rows.Scan(&feed.ID, &feed.UserID, &feed.Kind, &feed.Name, &feed.LastUsedAt, &feed.CreatedAt); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		feeds = append(feeds, feed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return feeds, nil
+}
+
+// RevokeFeed stops the user's feed from serving
+func (r *Repository) RevokeFeed(userID, id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`UPDATE calendar_feeds SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`, id, userID)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// UseFeed returns the owner of the feed whose token has the hash, unless
+// it is revoked, and records that the feed was fetched
+func (r *Repository) UseFeed(tokenHash string) (*feedOwner, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `UPDATE calendar_feeds f SET last_used_at = CURRENT_TIMESTAMP
+		FROM users u
+		WHERE u.id = f.user_id AND f.token_hash = $1 AND f.revoked_at IS NULL
+		RETURNING f.id, f.kind, COALESCE(f.name, ''), u.id, COALESCE(u.household_id, u.id), u.role`
+	owner := &feedOwner{}
+	err := r.conn().QueryRow(query, tokenHash).Scan(&owner.feedID, &owner.kind, &owner.name, &owner.userID, &owner.householdID, &owner.role)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return owner, nil
+}
+
+// kitchenShift is a community kitchen event a user volunteers for
+type kitchenShift struct {
+	id          uuid.UUID
+	title       string
+	description string
+	date        time.Time
+	clock       string
+	location    string
+	tags        []string
+	status      string
+	role        string
+	updatedAt   time.Time
+}
+
+// GetKitchenShifts returns the kitchen events since the given date that
+// the user volunteers for, with their volunteer role
+func (r *Repository) GetKitchenShifts(userID uuid.UUID, since time.Time) ([]*kitchenShift, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	// Volunteers are stored as {"volunteers": [...]}, or as a bare array
+	volunteer, _ := json.Marshal([]map[string]string{{"userId": userID.String()}})
+	query := `SELECT id, title, description, date, to_char(time, 'HH24:MI:SS'), location, COALESCE(tags, '{}'), COALESCE(status, 'upcoming'), volunteers, updated_at
+		FROM community_kitchen_events
+		WHERE date >= $2::date AND (volunteers->'volunteers' @> $1::jsonb OR volunteers @> $1::jsonb)
+		ORDER BY date, time`
+	rows, err := r.conn().Query(query, string(volunteer), since.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var shifts []*kitchenShift
+	for rows.Next() {
+		shift := &kitchenShift{}
+		var volunteersJSON []byte
+		if err := rows.Scan(&shift.id, &shift.title, &shift.description, &shift.date, &shift.clock, &shift.location, pq.Array(&shift.tags), &shift.status, &volunteersJSON, &shift.updatedAt); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		shift.role = volunteerRole(volunteersJSON, userID)
+		shifts = append(shifts, shift)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return shifts, nil
+}
+
+// volunteerRole returns the role of the user among the event's volunteers
+func volunteerRole(volunteersJSON []byte, userID uuid.UUID) string {
+	type volunteer struct {
+		UserID string `json:"userId"`
+		Role   string `json:"role"`
+	}
+	var volunteers []volunteer
+	var wrapped struct {
+		Volunteers []volunteer `json:"volunteers"`
+	}
+	if json.Unmarshal(volunteersJSON, &wrapped) == nil {
+		volunteers = wrapped.Volunteers
+	} else {
+		json.Unmarshal(volunteersJSON, &volunteers)
+	}
+	for _, v := range volunteers {
+		if v.UserID == userID.String() {
+			return v.Role
+		}
+	}
+	return ""
+}
+
+// pickup is a scheduled pickup of a donation offer made to an NGO
+type pickup struct {
+	id               uuid.UUID
+	scheduledFor     time.Time
+	etaMinutes       *int
+	volunteerName    string
+	volunteerContact string
+	vehicleType      string
+	status           string
+	notes            string
+	offerTitle       string
+	donorName        string
+	location         string
+	weightKg         float64
+	contact          pickupContact
+	updatedAt        time.Time
+}
+
+// pickupContact is the donor's contact of an offer
+type pickupContact struct {
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email"`
+	Channel string `json:"channel"`
+}
+
+// GetPickups returns the NGO's pickups scheduled since the given time
+func (r *Repository) GetPickups(ngoUserID uuid.UUID, since time.Time) ([]*pickup, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT p.id, p.scheduled_for, p.eta_minutes, p.volunteer_name, p.volunteer_contact, COALESCE(p.vehicle_type, ''),
+			COALESCE(p.status, 'scheduled'), COALESCE(p.notes, ''), o.offer_title, o.donor_name, o.location_label, o.weight_kg, o.contact, p.updated_at
+		FROM ngo_pickup_schedules p
+		JOIN ngo_donation_offers o ON o.id = p.offer_id
+		WHERE o.ngo_user_id = $1 AND p.scheduled_for >= $2
+		ORDER BY p.scheduled_for`
+	rows, err := r.conn().Query(query, ngoUserID, since)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var pickups []*pickup
+	for rows.Next() {
+		p := &pickup{}
+		var etaMinutes sql.NullInt64
+		var contactJSON []byte
+		if err := rows.Scan(&p.id, &p.scheduledFor, &etaMinutes, &p.volunteerName, &p.volunteerContact, &p.vehicleType, &p.status, &p.notes, &p.offerTitle, &p.donorName, &p.location, &p.weightKg, &contactJSON, &p.updatedAt); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		if etaMinutes.Valid {
+			eta := int(etaMinutes.Int64)
+			p.etaMinutes = &eta
+		}
+		json.Unmarshal(contactJSON, &p.contact)
+		pickups = append(pickups, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return pickups, nil
+}
+
+// Sequences records the fingerprint of each event by UID and returns their
+// SEQUENCE numbers: 0 for new events, bumped each time the fingerprint
+// differs from the one recorded
+func (r *Repository) Sequences(fingerprints map[string]string) (map[string]int, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	sequences := make(map[string]int, len(fingerprints))
+	if len(fingerprints) == 0 {
+		return sequences, nil
+	}
+	uids := make([]string, 0, len(fingerprints))
+	values := make([]string, 0, len(fingerprints))
+	for uid, fingerprint := range fingerprints {
+		uids = append(uids, uid)
+		values = append(values, fingerprint)
+	}
+	upsert := `INSERT INTO calendar_event_sequences (uid, fingerprint)
+		SELECT * FROM unnest($1::text[], $2::text[])
+		ON CONFLICT (uid) DO UPDATE SET
+			sequence = calendar_event_sequences.sequence + 1, fingerprint = EXCLUDED.fingerprint, updated_at = CURRENT_TIMESTAMP
+		WHERE calendar_event_sequences.fingerprint <> EXCLUDED.fingerprint`
+	if _, err := r.conn().Exec(upsert, pq.Array(uids), pq.Array(values)); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	rows, err := r.conn().Query(`SELECT uid, sequence FROM calendar_event_sequences WHERE uid = ANY($1)`, pq.Array(uids))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var uid string
+		var sequence int
+		if err := rows.Scan(&uid, &sequence); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		sequences[uid] = sequence
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return sequences, nil
+}
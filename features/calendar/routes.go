@@ -0,0 +1,31 @@
+package calendar
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the calendar routes, mounted under /api/v1. Feeds are
+// read by the secret token in their URL, without signing in.
+func SetupRoutes(handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	feeds := authMiddleware(middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.ListFeeds(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPost:
+			return handler.CreateFeed(w, r)
+		case len(parts) == 2 && r.Method == http.MethodDelete:
+			return handler.RevokeFeed(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	}))
+
+	mux := http.NewServeMux()
+	mux.Handle("/calendar/feeds", feeds)
+	mux.Handle("/calendar/feeds/", feeds)
+	mux.Handle("/calendar/", middleware.Handle(handler.Feed))
+	return mux
+}
@@ -0,0 +1,335 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/meal_plans"
+	"foodlink_backend/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// tokenPrefix marks feed tokens so they are easy to recognize
+	tokenPrefix = "cal_"
+	// maxFeeds is how many feeds a user may have at once
+	maxFeeds = 20
+	// pastDays is how far back feeds go
+	pastDays = 30
+	// mealPlanDays is how many days of meal plans feeds hold, at most the
+	// range meal plans list at once
+	mealPlanDays = 366
+	// uidDomain ends every event UID, so UIDs are unique across calendars
+	uidDomain = "foodlink"
+
+	kitchenShiftDuration = 2 * time.Hour
+	pickupDuration       = 30 * time.Minute
+)
+
+// mealSlot is when a meal of a type shows in calendars, local time
+type mealSlot struct {
+	hour, minute int
+	duration     time.Duration
+}
+
+var mealSlots = map[string]mealSlot{
+	"breakfast": {8, 0, 30 * time.Minute},
+	"lunch":     {12, 30, 45 * time.Minute},
+	"snack":     {15, 30, 15 * time.Minute},
+	"dinner":    {18, 30, time.Hour},
+}
+
+var (
+	errPickupsForNGOs = errors.NewAppError(errors.ErrForbidden.Code, "Pickup feeds are for NGO accounts")
+	errTooManyFeeds   = errors.NewAppError(errors.ErrBadRequest.Code, fmt.Sprintf("A user may have at most %d calendar feeds; revoke one first", maxFeeds))
+)
+
+type Service struct {
+	repo *Repository
+	cfg  *config.Config
+}
+
+func NewService(cfg *config.Config) *Service {
+	return &Service{repo: NewRepository(), cfg: cfg}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx), cfg: s.cfg}
+}
+
+func (s *Service) ListFeeds(userID uuid.UUID) ([]*Feed, error) {
+	return s.repo.ListFeeds(userID)
+}
+
+// CreateFeed creates a feed and returns it with its secret URL
+func (s *Service) CreateFeed(userID uuid.UUID, role string, req *CreateFeedRequest) (*Feed, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if req.Kind == KindPickups && role != "ngo" {
+		return nil, errPickupsForNGOs
+	}
+	feeds, err := s.repo.ListFeeds(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(feeds) >= maxFeeds {
+		return nil, errTooManyFeeds
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrInternalServer)
+	}
+	feed := &Feed{UserID: userID, Kind: req.Kind, Name: strings.TrimSpace(req.Name)}
+	if err := s.repo.CreateFeed(feed, hashToken(token)); err != nil {
+		return nil, err
+	}
+	feed.URL = strings.TrimSuffix(s.cfg.Calendar.BaseURL, "/") + "/api/v1/calendar/" + token + ".ics"
+	return feed, nil
+}
+
+// RevokeFeed stops the user's feed from serving; its URL cannot be reused
+func (s *Service) RevokeFeed(userID, id uuid.UUID) error {
+	return s.repo.RevokeFeed(userID, id)
+}
+
+// Feed renders the iCalendar object of the feed with the token
+func (s *Service) Feed(token string) ([]byte, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, errors.ErrNotFound
+	}
+	owner, err := s.repo.UseFeed(hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	since := time.Now().UTC().AddDate(0, 0, -pastDays)
+	var events []*Event
+	switch owner.kind {
+	case KindMealPlans:
+		events, err = s.mealPlanEvents(owner, since)
+	case KindKitchenEvents:
+		events, err = s.kitchenEvents(owner, since)
+	case KindPickups:
+		events, err = s.pickupEvents(owner, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sequence(events); err != nil {
+		return nil, err
+	}
+	return render(feedName(owner), events), nil
+}
+
+func (s *Service) mealPlanEvents(owner *feedOwner, since time.Time) ([]*Event, error) {
+	from := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	filter := meal_plans.ListFilter{From: from, To: from.AddDate(0, 0, mealPlanDays-1)}
+	member := meal_plans.Member{UserID: owner.userID, HouseholdID: owner.householdID}
+	plans, err := meal_plans.NewService().WithContext(s.repo.ctx).List(member, filter)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(plans))
+	for _, plan := range plans {
+		day, err := time.Parse(meal_plans.DateLayout, plan.Date)
+		if err != nil {
+			continue
+		}
+		slot, ok := mealSlots[plan.MealType]
+		if !ok {
+			slot = mealSlots["dinner"]
+		}
+		var description []string
+		if plan.Description != "" {
+			description = append(description, plan.Description)
+		}
+		ingredients := plan.Ingredients
+		if plan.Scaled != nil {
+			description = append(description, fmt.Sprintf("Servings: %d", plan.Scaled.Servings))
+			ingredients = plan.Scaled.Ingredients
+		}
+		if len(ingredients) > 0 {
+			description = append(description, "Ingredients:\n- "+strings.Join(ingredients, "\n- "))
+		}
+		for _, warning := range plan.Warnings {
+			description = append(description, "Warning: "+warning.Message)
+		}
+		events = append(events, &Event{
+			UID:          uid("meal-plan", plan.ID),
+			Start:        time.Date(day.Year(), day.Month(), day.Day(), slot.hour, slot.minute, 0, 0, time.UTC),
+			Floating:     true,
+			Duration:     slot.duration,
+			Summary:      strings.ToUpper(plan.MealType[:1]) + plan.MealType[1:] + ": " + plan.Name,
+			Description:  strings.Join(description, "\n\n"),
+			Categories:   []string{plan.MealType},
+			Status:       StatusConfirmed,
+			LastModified: plan.UpdatedAt,
+		})
+	}
+	return events, nil
+}
+
+func (s *Service) kitchenEvents(owner *feedOwner, since time.Time) ([]*Event, error) {
+	shifts, err := s.repo.GetKitchenShifts(owner.userID, since)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(shifts))
+	for _, shift := range shifts {
+		clock, err := time.Parse("15:04:05", shift.clock)
+		if err != nil {
+			continue
+		}
+		description := shift.description
+		if shift.role != "" {
+			description += "\n\nYour role: " + shift.role
+		}
+		events = append(events, &Event{
+			// Each volunteer's copy of the event shows their role, so it
+			// has its own UID, and its own SEQUENCE
+			UID:          uid("kitchen-event", shift.id, owner.userID),
+			Start:        time.Date(shift.date.Year(), shift.date.Month(), shift.date.Day(), clock.Hour(), clock.Minute(), 0, 0, time.UTC),
+			Floating:     true,
+			Duration:     kitchenShiftDuration,
+			Summary:      "Volunteering: " + shift.title,
+			Description:  description,
+			Location:     shift.location,
+			Categories:   shift.tags,
+			Status:       StatusConfirmed,
+			LastModified: shift.updatedAt,
+		})
+	}
+	return events, nil
+}
+
+func (s *Service) pickupEvents(owner *feedOwner, since time.Time) ([]*Event, error) {
+	if owner.role != "ngo" {
+		return nil, nil
+	}
+	pickups, err := s.repo.GetPickups(owner.userID, since)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(pickups))
+	for _, p := range pickups {
+		description := []string{
+			fmt.Sprintf("Donor: %s", p.donorName),
+			fmt.Sprintf("Weight: %s kg", strconv.FormatFloat(p.weightKg, 'f', -1, 64)),
+			fmt.Sprintf("Volunteer: %s (%s)", p.volunteerName, p.volunteerContact),
+		}
+		if p.vehicleType != "" {
+			description = append(description, "Vehicle: "+p.vehicleType)
+		}
+		if p.etaMinutes != nil {
+			description = append(description, fmt.Sprintf("ETA: %d min", *p.etaMinutes))
+		}
+		if contact := joinNonEmpty(", ", p.contact.Name, p.contact.Phone, p.contact.Email); contact != "" {
+			description = append(description, "Donor contact: "+contact)
+		}
+		description = append(description, "Status: "+p.status)
+		if p.notes != "" {
+			description = append(description, "\n"+p.notes)
+		}
+		status := StatusConfirmed
+		if p.status == "failed" {
+			status = StatusCancelled
+		}
+		events = append(events, &Event{
+			UID:          uid("pickup", p.id),
+			Start:        p.scheduledFor,
+			Duration:     pickupDuration,
+			Summary:      "Pickup: " + p.offerTitle,
+			Description:  strings.Join(description, "\n"),
+			Location:     p.location,
+			Status:       status,
+			LastModified: p.updatedAt,
+		})
+	}
+	return events, nil
+}
+
+// sequence sets the SEQUENCE of the events, bumped whenever what calendars
+// show of an event changes
+func (s *Service) sequence(events []*Event) error {
+	fingerprints := make(map[string]string, len(events))
+	for _, e := range events {
+		fingerprints[e.UID] = fingerprint(e)
+	}
+	sequences, err := s.repo.Sequences(fingerprints)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		e.Sequence = sequences[e.UID]
+	}
+	return nil
+}
+
+// fingerprint hashes the properties of the event that calendars show
+func fingerprint(e *Event) string {
+	h := sha256.New()
+	for _, part := range []string{
+		e.Start.Format(time.RFC3339), strconv.FormatBool(e.Floating), e.Duration.String(),
+		e.Summary, e.Description, e.Location, strings.Join(e.Categories, ","), e.Status,
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// uid returns the stable UID of the event of a row, or of a user's copy of
+// it
+func uid(kind string, ids ...uuid.UUID) string {
+	s := kind
+	for _, id := range ids {
+		s += "-" + id.String()
+	}
+	return s + "@" + uidDomain
+}
+
+func feedName(owner *feedOwner) string {
+	if owner.name != "" {
+		return owner.name
+	}
+	switch owner.kind {
+	case KindKitchenEvents:
+		return "Foodlink kitchen volunteering"
+	case KindPickups:
+		return "Foodlink pickups"
+	}
+	return "Foodlink meal plans"
+}
+
+// newToken returns a random feed token
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+	return tokenPrefix + hex.EncodeToString(b), nil
+}
+
+// hashToken returns the hash feeds are found by, so tokens are never stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}
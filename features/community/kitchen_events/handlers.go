@@ -36,24 +36,19 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, string, error) {
 // @Security     BearerAuth
 // @Param        status  query     string  false  "Filter by status (upcoming, in-progress, completed)"
 // @Success      200     {array}   KitchenEvent
-// @Failure      401     {object}  errors.AppError
+// @Failure      401     {object}  errors.Problem
 // @Router       /community/kitchen-events [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	status := r.URL.Query().Get("status")
-	events, err := h.service.GetAll(status)
+	events, err := h.service.WithContext(r.Context()).GetAll(status)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve kitchen events", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve kitchen events")
 	}
 	utils.OKResponse(w, "Kitchen events retrieved successfully", events)
+	return nil
 }
 
 // GetByID handles GET /api/v1/community/kitchen-events/:id
@@ -65,30 +60,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Kitchen Event ID"
 // @Success      200  {object}  KitchenEvent
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /community/kitchen-events/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/kitchen-events/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	event, err := h.service.GetByID(id)
+	event, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve kitchen event", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve kitchen event")
 	}
 	utils.OKResponse(w, "Kitchen event retrieved successfully", event)
+	return nil
 }
 
 // Create handles POST /api/v1/community/kitchen-events
@@ -100,29 +89,23 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateKitchenEventRequest  true  "Kitchen event data"
 // @Success      201      {object}  KitchenEvent
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/kitchen-events [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	var req CreateKitchenEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	event, err := h.service.Create(&req)
+	event, err := h.service.WithContext(r.Context()).Create(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create kitchen event", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create kitchen event")
 	}
 	utils.CreatedResponse(w, "Kitchen event created successfully", event)
+	return nil
 }
 
 // Update handles PUT /api/v1/community/kitchen-events/:id
@@ -135,36 +118,29 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                    true  "Kitchen Event ID"
 // @Param        request  body      UpdateKitchenEventRequest  true  "Kitchen event data"
 // @Success      200      {object}  KitchenEvent
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /community/kitchen-events/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/kitchen-events/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateKitchenEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	event, err := h.service.Update(id, &req)
+	event, err := h.service.WithContext(r.Context()).Update(id, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update kitchen event", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update kitchen event")
 	}
 	utils.OKResponse(w, "Kitchen event updated successfully", event)
+	return nil
 }
 
 // Volunteer handles POST /api/v1/community/kitchen-events/:id/volunteer
@@ -177,42 +153,33 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string            true  "Kitchen Event ID"
 // @Param        request  body      VolunteerRequest  true  "Volunteer data"
 // @Success      200      {object}  KitchenEvent
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/kitchen-events/{id}/volunteer [post]
-func (h *Handler) Volunteer(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Volunteer(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, userName, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/kitchen-events/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "volunteer" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	eventID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid event ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid event ID")
 	}
 	var req VolunteerRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	event, err := h.service.AddVolunteer(eventID, userID, userName, req.Role)
+	event, err := h.service.WithContext(r.Context()).AddVolunteer(eventID, userID, userName, req.Role)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to volunteer for event", err.Error())
-		return
+		return errors.Wrap(err, "Failed to volunteer for event")
 	}
 	utils.OKResponse(w, "Volunteered successfully", event)
+	return nil
 }
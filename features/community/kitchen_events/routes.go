@@ -1,6 +1,7 @@
 package kitchen_events
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 	"strings"
@@ -8,24 +9,24 @@ import (
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		path := strings.TrimPrefix(r.URL.Path, "/api/v1/community/kitchen-events/")
 		pathParts := strings.Split(path, "/")
 		
 		switch {
 		case path == "" && r.Method == http.MethodGet:
-			handler.GetAll(w, r)
+			return handler.GetAll(w, r)
 		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodGet:
-			handler.GetByID(w, r)
+			return handler.GetByID(w, r)
 		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodPut:
-			handler.Update(w, r)
+			return handler.Update(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "volunteer" && r.Method == http.MethodPost:
-			handler.Volunteer(w, r)
+			return handler.Volunteer(w, r)
 		case r.Method == http.MethodPost:
-			handler.Create(w, r)
+			return handler.Create(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
@@ -1,6 +1,7 @@
 package kitchen_events
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,6 +16,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAll(status string) ([]*KitchenEvent, error) {
 	return s.repo.GetAll(status)
 }
@@ -25,7 +30,7 @@ func (s *Service) GetByID(id uuid.UUID) (*KitchenEvent, error) {
 
 func (s *Service) Create(req *CreateKitchenEventRequest) (*KitchenEvent, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	event := &KitchenEvent{
 		ID:              uuid.New(),
@@ -53,7 +58,7 @@ func (s *Service) Update(id uuid.UUID, req *UpdateKitchenEventRequest) (*Kitchen
 		return nil, err
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.Title != "" {
 		event.Title = req.Title
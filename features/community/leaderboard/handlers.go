@@ -34,24 +34,19 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Security     BearerAuth
 // @Param        type  query     string  false  "Leaderboard type (top-sharers, zero-waste, volunteer-stars, building-impact, weekly-xp)"
 // @Success      200   {object}  Leaderboard
-// @Failure      401   {object}  errors.AppError
+// @Failure      401   {object}  errors.Problem
 // @Router       /community/leaderboard [get]
-func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	leaderboardType := r.URL.Query().Get("type")
-	leaderboard, err := h.service.GetLeaderboard(leaderboardType)
+	leaderboard, err := h.service.WithContext(r.Context()).GetLeaderboard(leaderboardType)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve leaderboard", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve leaderboard")
 	}
 	utils.OKResponse(w, "Leaderboard retrieved successfully", leaderboard)
+	return nil
 }
 
 // GetImpact handles GET /api/v1/community/impact
@@ -62,23 +57,18 @@ func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  Impact
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /community/impact [get]
-func (h *Handler) GetImpact(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetImpact(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
-	impact, err := h.service.GetImpact()
+	impact, err := h.service.WithContext(r.Context()).GetImpact()
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve impact", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve impact")
 	}
 	utils.OKResponse(w, "Impact retrieved successfully", impact)
+	return nil
 }
 
 // GetPersonalImpact handles GET /api/v1/community/impact/personal
@@ -89,26 +79,20 @@ func (h *Handler) GetImpact(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  Impact
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /community/impact/personal [get]
-func (h *Handler) GetPersonalImpact(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetPersonalImpact(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	impact, err := h.service.GetPersonalImpact(userID)
+	impact, err := h.service.WithContext(r.Context()).GetPersonalImpact(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve personal impact", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve personal impact")
 	}
 	utils.OKResponse(w, "Personal impact retrieved successfully", impact)
+	return nil
 }
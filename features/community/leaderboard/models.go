@@ -32,7 +32,7 @@ func (j *JSONB) Scan(value interface{}) error {
 type Leaderboard struct {
 	ID        uuid.UUID `json:"id" db:"id"`
 	Type      string    `json:"type" db:"type"`
-	Entries   JSONB     `json:"entries" db:"entries"`
+	Entries   []JSONB   `json:"entries" db:"entries"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
 
@@ -1,22 +1,33 @@
 package leaderboard
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 func (r *Repository) GetByType(leaderboardType string) (*Leaderboard, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
@@ -24,7 +35,7 @@ func (r *Repository) GetByType(leaderboardType string) (*Leaderboard, error) {
 	lb := &Leaderboard{}
 	var entriesJSON []byte
 	query := `SELECT id, type, entries, updated_at FROM community_leaderboard WHERE type = $1 ORDER BY updated_at DESC LIMIT 1`
-	err := r.db.QueryRow(query, leaderboardType).Scan(&lb.ID, &lb.Type, &entriesJSON, &lb.UpdatedAt)
+	err := r.conn().QueryRow(query, leaderboardType).Scan(&lb.ID, &lb.Type, &entriesJSON, &lb.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -37,6 +48,83 @@ func (r *Repository) GetByType(leaderboardType string) (*Leaderboard, error) {
 	return lb, nil
 }
 
+// leaderboardSize is how many entries a computed leaderboard holds
+const leaderboardSize = 20
+
+// computedTypes are the leaderboard types Compute supports
+var computedTypes = map[string]bool{
+	"top-sharers": true,
+	"zero-waste":  true,
+}
+
+// computeQueries rank users over the last 30 days. top-sharers counts
+// surplus posts and leftovers shared; zero-waste is the share of logged
+// consumption not wasted, among users with at least 5 logs.
+var computeQueries = map[string]string{
+	"top-sharers": `
+		SELECT u.id, u.name, COUNT(*)::float8 AS value
+		FROM (
+			SELECT user_id FROM community_surplus_posts WHERE created_at >= CURRENT_TIMESTAMP - INTERVAL '30 days'
+			UNION ALL
+			SELECT user_id FROM leftover_items WHERE created_at >= CURRENT_TIMESTAMP - INTERVAL '30 days'
+		) shares
+		JOIN users u ON u.id = shares.user_id
+		GROUP BY u.id, u.name
+		ORDER BY value DESC, u.name
+		LIMIT $1`,
+	"zero-waste": `
+		SELECT u.id, u.name, ROUND(100.0 * AVG(CASE WHEN l.was_wasted THEN 0 ELSE 1 END), 1)::float8 AS value
+		FROM consumption_logs l
+		JOIN users u ON u.id = l.user_id
+		WHERE l.consumed_at >= CURRENT_TIMESTAMP - INTERVAL '30 days'
+		GROUP BY u.id, u.name
+		HAVING COUNT(*) >= 5
+		ORDER BY value DESC, COUNT(*) DESC, u.name
+		LIMIT $1`,
+}
+
+var computeUnits = map[string]string{
+	"top-sharers": "shares",
+	"zero-waste":  "%",
+}
+
+// Compute builds a leaderboard of the given type from live activity
+func (r *Repository) Compute(leaderboardType string) (*Leaderboard, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query, ok := computeQueries[leaderboardType]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	rows, err := r.conn().Query(query, leaderboardSize)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+
+	lb := &Leaderboard{Type: leaderboardType, Entries: []JSONB{}, UpdatedAt: time.Now()}
+	for rows.Next() {
+		var id uuid.UUID
+		var name string
+		var value float64
+		if err := rows.Scan(&id, &name, &value); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		lb.Entries = append(lb.Entries, JSONB{
+			"id":    id,
+			"rank":  len(lb.Entries) + 1,
+			"name":  name,
+			"value": value,
+			"unit":  computeUnits[leaderboardType],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return lb, nil
+}
+
 func (r *Repository) GetImpact() (*Impact, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
@@ -44,7 +132,7 @@ func (r *Repository) GetImpact() (*Impact, error) {
 	impact := &Impact{}
 	var weeklyTrendJSON, personalContributionJSON []byte
 	query := `SELECT id, total_surplus_kg, donations, co2_prevented_kg, water_saved_liters, meals_provided, weekly_trend, personal_contribution, updated_at FROM community_impact ORDER BY updated_at DESC LIMIT 1`
-	err := r.db.QueryRow(query).Scan(&impact.ID, &impact.TotalSurplusKg, &impact.Donations, &impact.CO2PreventedKg, &impact.WaterSavedLiters, &impact.MealsProvided, &weeklyTrendJSON, &personalContributionJSON, &impact.UpdatedAt)
+	err := r.conn().QueryRow(query).Scan(&impact.ID, &impact.TotalSurplusKg, &impact.Donations, &impact.CO2PreventedKg, &impact.WaterSavedLiters, &impact.MealsProvided, &weeklyTrendJSON, &personalContributionJSON, &impact.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -1,32 +1,33 @@
 package leaderboard
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 )
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/leaderboard", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/leaderboard", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodGet {
-			handler.GetLeaderboard(w, r)
+			return handler.GetLeaderboard(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/impact", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/impact", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodGet {
-			handler.GetImpact(w, r)
+			return handler.GetImpact(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/impact/personal", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/impact/personal", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodGet {
-			handler.GetPersonalImpact(w, r)
+			return handler.GetPersonalImpact(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
@@ -1,21 +1,36 @@
 package leaderboard
 
 import (
+	"context"
+	"foodlink_backend/featureflags"
+
 	"github.com/google/uuid"
 )
 
 type Service struct {
-	repo *Repository
+	repo  *Repository
+	flags featureflags.Evaluator
+	ctx   context.Context
+}
+
+func NewService(flags featureflags.Evaluator) *Service {
+	return &Service{repo: NewRepository(), flags: flags, ctx: context.Background()}
 }
 
-func NewService() *Service {
-	return &Service{repo: NewRepository()}
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx), flags: s.flags, ctx: ctx}
 }
 
+// GetLeaderboard returns the stored leaderboard snapshot, or with the
+// leaderboard_v2 flag one computed from live activity for the types that
+// support it
 func (s *Service) GetLeaderboard(leaderboardType string) (*Leaderboard, error) {
 	if leaderboardType == "" {
 		leaderboardType = "top-sharers"
 	}
+	if s.flags.Enabled(s.ctx, featureflags.LeaderboardV2) && computedTypes[leaderboardType] {
+		return s.repo.Compute(leaderboardType)
+	}
 	return s.repo.GetByType(leaderboardType)
 }
 
@@ -5,6 +5,7 @@ import (
 	"foodlink_backend/errors"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/utils"
+	"io"
 	"net/http"
 	"strings"
 
@@ -36,24 +37,19 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, string, error) {
 // @Security     BearerAuth
 // @Param        status  query     string  false  "Filter by status (available, claimed)"
 // @Success      200     {array}   LeftoverItem
-// @Failure      401     {object}  errors.AppError
+// @Failure      401     {object}  errors.Problem
 // @Router       /community/leftovers [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	status := r.URL.Query().Get("status")
-	items, err := h.service.GetAll(status)
+	items, err := h.service.WithContext(r.Context()).GetAll(status)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve leftover items", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve leftover items")
 	}
 	utils.OKResponse(w, "Leftover items retrieved successfully", items)
+	return nil
 }
 
 // GetByID handles GET /api/v1/community/leftovers/:id
@@ -65,30 +61,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Leftover Item ID"
 // @Success      200  {object}  LeftoverItem
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /community/leftovers/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/leftovers/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	item, err := h.service.GetByID(id)
+	item, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve leftover item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve leftover item")
 	}
 	utils.OKResponse(w, "Leftover item retrieved successfully", item)
+	return nil
 }
 
 // Create handles POST /api/v1/community/leftovers
@@ -100,34 +90,27 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateLeftoverItemRequest  true  "Leftover item data"
 // @Success      201      {object}  LeftoverItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/leftovers [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, userName, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateLeftoverItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	item, err := h.service.Create(userID, userName, &req)
+	item, err := h.service.WithContext(r.Context()).Create(userID, userName, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create leftover item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create leftover item")
 	}
 	utils.CreatedResponse(w, "Leftover item created successfully", item)
+	return nil
 }
 
 // Update handles PUT /api/v1/community/leftovers/:id
@@ -140,42 +123,78 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                    true  "Leftover Item ID"
 // @Param        request  body      UpdateLeftoverItemRequest true  "Leftover item data"
 // @Success      200      {object}  LeftoverItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /community/leftovers/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/leftovers/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateLeftoverItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	item, err := h.service.Update(id, userID, &req)
+	item, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update leftover item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update leftover item")
 	}
 	utils.OKResponse(w, "Leftover item updated successfully", item)
+	return nil
+}
+
+// Patch handles PATCH /api/v1/community/leftovers/:id
+// @Summary      Patch leftover item
+// @Description  Partially update a leftover item with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.
+// @Tags         community-leftovers
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true  "Leftover Item ID"
+// @Param        request  body      PatchLeftoverItemRequest  true  "Merge patch document"
+// @Success      200      {object}  LeftoverItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Failure      415      {object}  errors.Problem
+// @Router       /community/leftovers/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+	userID, _, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/leftovers/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Patch(id, userID, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update leftover item")
+	}
+	utils.OKResponse(w, "Leftover item updated successfully", item)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/community/leftovers/:id
@@ -187,35 +206,28 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Leftover Item ID"
 // @Success      200  {object}  map[string]string
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /community/leftovers/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/leftovers/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	if err := h.service.Delete(id, userID); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete leftover item", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete leftover item")
 	}
 	utils.OKResponse(w, "Leftover item deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
 }
 
 // CreateClaim handles POST /api/v1/community/leftovers/:id/claim
@@ -228,44 +240,35 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                    true  "Leftover Item ID"
 // @Param        request  body      CreateLeftoverClaimRequest true  "Claim data"
 // @Success      201      {object}  LeftoverClaim
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/leftovers/{id}/claim [post]
-func (h *Handler) CreateClaim(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateClaim(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, userName, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/leftovers/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "claim" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	leftoverID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid leftover ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid leftover ID")
 	}
 	var req CreateLeftoverClaimRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	claim, err := h.service.CreateClaim(leftoverID, userID, userName, &req)
+	claim, err := h.service.WithContext(r.Context()).CreateClaim(leftoverID, userID, userName, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create claim", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create claim")
 	}
 	utils.CreatedResponse(w, "Claim created successfully", claim)
+	return nil
 }
 
 // GetClaims handles GET /api/v1/community/leftovers/:id/claims
@@ -277,31 +280,24 @@ func (h *Handler) CreateClaim(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Leftover Item ID"
 // @Success      200  {array}   LeftoverClaim
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /community/leftovers/{id}/claims [get]
-func (h *Handler) GetClaims(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetClaims(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/leftovers/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "claims" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	leftoverID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid leftover ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid leftover ID")
 	}
-	claims, err := h.service.GetClaimsByLeftoverID(leftoverID)
+	claims, err := h.service.WithContext(r.Context()).GetClaimsByLeftoverID(leftoverID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve claims", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve claims")
 	}
 	utils.OKResponse(w, "Claims retrieved successfully", claims)
+	return nil
 }
@@ -1,7 +1,12 @@
 package leftovers
 
 import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/features/uploads"
 	"foodlink_backend/utils"
 
 	"github.com/google/uuid"
@@ -15,6 +20,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAll(status string) ([]*LeftoverItem, error) {
 	return s.repo.GetAll(status)
 }
@@ -25,21 +34,24 @@ func (s *Service) GetByID(id uuid.UUID) (*LeftoverItem, error) {
 
 func (s *Service) Create(userID uuid.UUID, userName string, req *CreateLeftoverItemRequest) (*LeftoverItem, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
 	}
 	item := &LeftoverItem{
-		ID:           uuid.New(),
-		UserID:       userID,
-		UserName:     userName,
-		DishName:     req.DishName,
-		Description:  req.Description,
-		Portions:     req.Portions,
-		DistanceKm:   req.DistanceKm,
-		DietaryTags:  req.DietaryTags,
-		Allergens:    req.Allergens,
-		PickupWindow: req.PickupWindow,
-		Status:       "available",
-		Image:        req.Image,
+		ID:            uuid.New(),
+		UserID:        userID,
+		UserName:      userName,
+		DishName:      req.DishName,
+		Description:   req.Description,
+		Portions:      req.Portions,
+		DistanceKm:    req.DistanceKm,
+		DietaryTags:   req.DietaryTags,
+		Allergens:     req.Allergens,
+		PickupWindow:  req.PickupWindow,
+		Status:        "available",
+		ImageUploadID: req.ImageUploadID,
 	}
 	if err := s.repo.Create(item); err != nil {
 		return nil, err
@@ -56,7 +68,10 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateLeftoverItem
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
 	}
 	if req.DishName != "" {
 		item.DishName = req.DishName
@@ -82,8 +97,8 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateLeftoverItem
 	if req.Status != "" {
 		item.Status = req.Status
 	}
-	if req.Image != "" {
-		item.Image = req.Image
+	if req.ImageUploadID != nil {
+		item.ImageUploadID = req.ImageUploadID
 	}
 	if err := s.repo.Update(item); err != nil {
 		return nil, err
@@ -91,6 +106,49 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateLeftoverItem
 	return item, nil
 }
 
+func (s *Service) Patch(id uuid.UUID, userID uuid.UUID, patch []byte) (*LeftoverItem, error) {
+	item, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	doc := &PatchLeftoverItemRequest{
+		DishName:      item.DishName,
+		Description:   item.Description,
+		Portions:      item.Portions,
+		DistanceKm:    item.DistanceKm,
+		DietaryTags:   item.DietaryTags,
+		Allergens:     item.Allergens,
+		PickupWindow:  item.PickupWindow,
+		Status:        item.Status,
+		ImageUploadID: item.ImageUploadID,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, doc.ImageUploadID); err != nil {
+		return nil, err
+	}
+	item.DishName = doc.DishName
+	item.Description = doc.Description
+	item.Portions = doc.Portions
+	item.DistanceKm = doc.DistanceKm
+	item.DietaryTags = doc.DietaryTags
+	item.Allergens = doc.Allergens
+	item.PickupWindow = doc.PickupWindow
+	item.Status = doc.Status
+	item.ImageUploadID = doc.ImageUploadID
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
 func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	item, err := s.repo.GetByID(id)
 	if err != nil {
@@ -103,7 +161,7 @@ func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 }
 
 func (s *Service) CreateClaim(leftoverID uuid.UUID, userID uuid.UUID, userName string, req *CreateLeftoverClaimRequest) (*LeftoverClaim, error) {
-	_, err := s.repo.GetByID(leftoverID)
+	item, err := s.repo.GetByID(leftoverID)
 	if err != nil {
 		return nil, err
 	}
@@ -114,8 +172,23 @@ func (s *Service) CreateClaim(leftoverID uuid.UUID, userID uuid.UUID, userName s
 		UserName:      userName,
 		Message:       req.Message,
 	}
-	if err := s.repo.CreateClaim(claim); err != nil {
-		return nil, err
+	// The owner is notified through the LeftoverClaimed event, written in
+	// the same transaction as the claim
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).CreateClaim(claim); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.LeftoverClaimed{
+			LeftoverID:   item.ID,
+			OwnerID:      item.UserID,
+			DishName:     item.DishName,
+			ClaimID:      claim.ID,
+			ClaimantID:   claim.UserID,
+			ClaimantName: claim.UserName,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create claim")
 	}
 	return claim, nil
 }
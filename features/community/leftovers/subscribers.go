@@ -0,0 +1,32 @@
+package leftovers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/events"
+	"foodlink_backend/features/notifications"
+)
+
+// RegisterSubscribers subscribes the leftovers feature to domain events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypeLeftoverClaimed, "leftovers.notify_owner", notifyOwner)
+}
+
+// notifyOwner tells the owner of a leftover that someone claimed it
+func notifyOwner(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var claimed events.LeftoverClaimed
+	if err := event.Decode(&claimed); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("%s claimed your %s.", claimed.ClaimantName, claimed.DishName)
+	_, err := notifications.NewService().WithContext(ctx).Notify(tx, &notifications.NewNotification{
+		UserID:     claimed.OwnerID,
+		Source:     notifications.SourceCommunity,
+		Type:       "claim",
+		Title:      "Leftover claimed",
+		Message:    message,
+		Preference: notifications.PreferenceClaim,
+	})
+	return err
+}
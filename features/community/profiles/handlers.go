@@ -5,6 +5,7 @@ import (
 	"foodlink_backend/errors"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/utils"
+	"io"
 	"net/http"
 	"strings"
 
@@ -35,29 +36,23 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  CommunityProfile
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /community/profile [get]
-func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	profile, err := h.service.GetByUserID(userID)
+	profile, err := h.service.WithContext(r.Context()).GetByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve profile", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve profile")
 	}
 	utils.OKResponse(w, "Profile retrieved successfully", profile)
+	return nil
 }
 
 // GetProfileByUsername handles GET /api/v1/community/profile/:username
@@ -69,25 +64,20 @@ func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        username  path      string  true  "Username"
 // @Success      200       {object}  CommunityProfile
-// @Failure      401       {object}  errors.AppError
-// @Failure      404       {object}  errors.AppError
+// @Failure      401       {object}  errors.Problem
+// @Failure      404       {object}  errors.Problem
 // @Router       /community/profile/{username} [get]
-func (h *Handler) GetProfileByUsername(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetProfileByUsername(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	username := strings.TrimPrefix(r.URL.Path, "/api/v1/community/profile/")
-	profile, err := h.service.GetByUsername(username)
+	profile, err := h.service.WithContext(r.Context()).GetByUsername(username)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve profile", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve profile")
 	}
 	utils.OKResponse(w, "Profile retrieved successfully", profile)
+	return nil
 }
 
 // CreateProfile handles POST /api/v1/community/profile
@@ -99,34 +89,27 @@ func (h *Handler) GetProfileByUsername(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateProfileRequest  true  "Profile data"
 // @Success      201      {object}  CommunityProfile
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/profile [post]
-func (h *Handler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateProfile(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	profile, err := h.service.Create(userID, &req)
+	profile, err := h.service.WithContext(r.Context()).Create(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create profile", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create profile")
 	}
 	utils.CreatedResponse(w, "Profile created successfully", profile)
+	return nil
 }
 
 // UpdateProfile handles PUT /api/v1/community/profile
@@ -138,33 +121,63 @@ func (h *Handler) CreateProfile(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      UpdateProfileRequest  true  "Profile data"
 // @Success      200      {object}  CommunityProfile
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /community/profile [put]
-func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req UpdateProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	profile, err := h.service.Update(userID, &req)
+	profile, err := h.service.WithContext(r.Context()).Update(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update profile", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update profile")
 	}
 	utils.OKResponse(w, "Profile updated successfully", profile)
+	return nil
+}
+
+// Patch handles PATCH /api/v1/community/profile
+// @Summary      Patch community profile
+// @Description  Partially update the authenticated user's community profile with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.
+// @Tags         community-profiles
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      PatchProfileRequest  true  "Merge patch document"
+// @Success      200      {object}  CommunityProfile
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Failure      415      {object}  errors.Problem
+// @Router       /community/profile [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	profile, err := h.service.WithContext(r.Context()).Patch(userID, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update profile")
+	}
+	utils.OKResponse(w, "Profile updated successfully", profile)
+	return nil
 }
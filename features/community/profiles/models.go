@@ -59,3 +59,21 @@ type UpdateProfileRequest struct {
 	NotifyOnClaim       *bool    `json:"notify_on_claim,omitempty"`
 	NotifyOnMessages    *bool    `json:"notify_on_messages,omitempty"`
 }
+
+// PatchProfileRequest holds the mutable fields of a community profile.
+// PATCH requests merge a JSON Merge Patch document into it and validate the result.
+type PatchProfileRequest struct {
+	AvatarURL            string   `json:"avatar_url,omitempty"`
+	CommunityRole        string   `json:"community_role" validate:"required,oneof=member champion organizer"`
+	Bio                  string   `json:"bio,omitempty"`
+	PreferredItems       []string `json:"preferred_items,omitempty"`
+	AvoidItems           []string `json:"avoid_items,omitempty"`
+	DietaryRestrictions  []string `json:"dietary_restrictions,omitempty"`
+	Allergens            []string `json:"allergens,omitempty"`
+	AcceptsHotMeals      bool     `json:"accepts_hot_meals"`
+	DistancePreference   string   `json:"distance_preference,omitempty" validate:"omitempty,oneof=1km 3km 5km any"`
+	Visibility           string   `json:"visibility" validate:"required,oneof=public community private"`
+	NotificationsEnabled bool     `json:"notifications_enabled"`
+	NotifyOnClaim        bool     `json:"notify_on_claim"`
+	NotifyOnMessages     bool     `json:"notify_on_messages"`
+}
@@ -1,6 +1,7 @@
 package profiles
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 	"strings"
@@ -8,21 +9,23 @@ import (
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		path := strings.TrimPrefix(r.URL.Path, "/api/v1/community/profile/")
 		
 		switch {
 		case path == "" && r.Method == http.MethodGet:
-			handler.GetProfile(w, r)
+			return handler.GetProfile(w, r)
 		case path == "" && r.Method == http.MethodPost:
-			handler.CreateProfile(w, r)
+			return handler.CreateProfile(w, r)
 		case path == "" && r.Method == http.MethodPut:
-			handler.UpdateProfile(w, r)
+			return handler.UpdateProfile(w, r)
+		case path == "" && r.Method == http.MethodPatch:
+			return handler.Patch(w, r)
 		case path != "" && r.Method == http.MethodGet:
-			handler.GetProfileByUsername(w, r)
+			return handler.GetProfileByUsername(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
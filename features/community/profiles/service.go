@@ -1,6 +1,7 @@
 package profiles
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,6 +16,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetByUserID(userID uuid.UUID) (*CommunityProfile, error) {
 	return s.repo.GetByUserID(userID)
 }
@@ -25,7 +30,7 @@ func (s *Service) GetByUsername(username string) (*CommunityProfile, error) {
 
 func (s *Service) Create(userID uuid.UUID, req *CreateProfileRequest) (*CommunityProfile, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	notificationsEnabled := true
 	if req.NotificationsEnabled != nil {
@@ -75,7 +80,7 @@ func (s *Service) Update(userID uuid.UUID, req *UpdateProfileRequest) (*Communit
 		return nil, err
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.AvatarURL != "" {
 		profile.AvatarURL = req.AvatarURL
@@ -121,3 +126,48 @@ func (s *Service) Update(userID uuid.UUID, req *UpdateProfileRequest) (*Communit
 	}
 	return profile, nil
 }
+
+func (s *Service) Patch(userID uuid.UUID, patch []byte) (*CommunityProfile, error) {
+	profile, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	doc := &PatchProfileRequest{
+		AvatarURL:            profile.AvatarURL,
+		CommunityRole:        profile.CommunityRole,
+		Bio:                  profile.Bio,
+		PreferredItems:       profile.PreferredItems,
+		AvoidItems:           profile.AvoidItems,
+		DietaryRestrictions:  profile.DietaryRestrictions,
+		Allergens:            profile.Allergens,
+		AcceptsHotMeals:      profile.AcceptsHotMeals,
+		DistancePreference:   profile.DistancePreference,
+		Visibility:           profile.Visibility,
+		NotificationsEnabled: profile.NotificationsEnabled,
+		NotifyOnClaim:        profile.NotifyOnClaim,
+		NotifyOnMessages:     profile.NotifyOnMessages,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	profile.AvatarURL = doc.AvatarURL
+	profile.CommunityRole = doc.CommunityRole
+	profile.Bio = doc.Bio
+	profile.PreferredItems = doc.PreferredItems
+	profile.AvoidItems = doc.AvoidItems
+	profile.DietaryRestrictions = doc.DietaryRestrictions
+	profile.Allergens = doc.Allergens
+	profile.AcceptsHotMeals = doc.AcceptsHotMeals
+	profile.DistancePreference = doc.DistancePreference
+	profile.Visibility = doc.Visibility
+	profile.NotificationsEnabled = doc.NotificationsEnabled
+	profile.NotifyOnClaim = doc.NotifyOnClaim
+	profile.NotifyOnMessages = doc.NotifyOnMessages
+	if err := s.repo.Update(profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
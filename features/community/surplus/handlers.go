@@ -5,6 +5,7 @@ import (
 	"foodlink_backend/errors"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/utils"
+	"io"
 	"net/http"
 	"strings"
 
@@ -36,24 +37,19 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, string, string, error)
 // @Security     BearerAuth
 // @Param        status  query     string  false  "Filter by status (available, claimed, expired)"
 // @Success      200     {array}   SurplusPost
-// @Failure      401     {object}  errors.AppError
+// @Failure      401     {object}  errors.Problem
 // @Router       /community/surplus [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	status := r.URL.Query().Get("status")
-	posts, err := h.service.GetAll(status)
+	posts, err := h.service.WithContext(r.Context()).GetAll(status)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve surplus posts", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve surplus posts")
 	}
 	utils.OKResponse(w, "Surplus posts retrieved successfully", posts)
+	return nil
 }
 
 // GetByID handles GET /api/v1/community/surplus/:id
@@ -65,30 +61,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Surplus Post ID"
 // @Success      200  {object}  SurplusPost
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /community/surplus/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	post, err := h.service.GetByID(id)
+	post, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve surplus post", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve surplus post")
 	}
 	utils.OKResponse(w, "Surplus post retrieved successfully", post)
+	return nil
 }
 
 // Create handles POST /api/v1/community/surplus
@@ -100,34 +90,27 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateSurplusPostRequest  true  "Surplus post data"
 // @Success      201      {object}  SurplusPost
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/surplus [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, userName, avatarURL, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateSurplusPostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	post, err := h.service.Create(userID, userName, avatarURL, &req)
+	post, err := h.service.WithContext(r.Context()).Create(userID, userName, avatarURL, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create surplus post", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create surplus post")
 	}
 	utils.CreatedResponse(w, "Surplus post created successfully", post)
+	return nil
 }
 
 // Update handles PUT /api/v1/community/surplus/:id
@@ -140,42 +123,78 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                    true  "Surplus Post ID"
 // @Param        request  body      UpdateSurplusPostRequest  true  "Surplus post data"
 // @Success      200      {object}  SurplusPost
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /community/surplus/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, _, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateSurplusPostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	post, err := h.service.Update(id, userID, &req)
+	post, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update surplus post", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update surplus post")
 	}
 	utils.OKResponse(w, "Surplus post updated successfully", post)
+	return nil
+}
+
+// Patch handles PATCH /api/v1/community/surplus/:id
+// @Summary      Patch surplus post
+// @Description  Partially update a surplus post with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.
+// @Tags         community-surplus
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                   true  "Surplus Post ID"
+// @Param        request  body      PatchSurplusPostRequest  true  "Merge patch document"
+// @Success      200      {object}  SurplusPost
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Failure      415      {object}  errors.Problem
+// @Router       /community/surplus/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+	userID, _, _, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	post, err := h.service.WithContext(r.Context()).Patch(id, userID, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update surplus post")
+	}
+	utils.OKResponse(w, "Surplus post updated successfully", post)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/community/surplus/:id
@@ -187,35 +206,28 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Surplus Post ID"
 // @Success      200  {object}  map[string]string
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /community/surplus/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, _, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	if err := h.service.Delete(id, userID); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete surplus post", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete surplus post")
 	}
 	utils.OKResponse(w, "Surplus post deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
 }
 
 // CreateRequest handles POST /api/v1/community/surplus/:id/request
@@ -228,44 +240,35 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                        true  "Surplus Post ID"
 // @Param        request  body      CreateSurplusRequestRequest   true  "Request data"
 // @Success      201      {object}  SurplusRequest
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/surplus/{id}/request [post]
-func (h *Handler) CreateRequest(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateRequest(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, userName, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "request" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	postID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid post ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid post ID")
 	}
 	var req CreateSurplusRequestRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	request, err := h.service.CreateRequest(postID, userID, userName, &req)
+	request, err := h.service.WithContext(r.Context()).CreateRequest(postID, userID, userName, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create request", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create request")
 	}
 	utils.CreatedResponse(w, "Request created successfully", request)
+	return nil
 }
 
 // GetRequests handles GET /api/v1/community/surplus/:id/requests
@@ -277,39 +280,31 @@ func (h *Handler) CreateRequest(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Surplus Post ID"
 // @Success      200  {array}   SurplusRequest
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
 // @Router       /community/surplus/{id}/requests [get]
-func (h *Handler) GetRequests(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetRequests(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, _, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "requests" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	postID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid post ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid post ID")
 	}
-	requests, err := h.service.GetRequestsByPostID(postID, userID)
+	requests, err := h.service.WithContext(r.Context()).GetRequestsByPostID(postID, userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve requests", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve requests")
 	}
 	utils.OKResponse(w, "Requests retrieved successfully", requests)
+	return nil
 }
 
 // UpdateRequest handles PUT /api/v1/community/surplus/:id/requests/:requestId
@@ -323,50 +318,40 @@ func (h *Handler) GetRequests(w http.ResponseWriter, r *http.Request) {
 // @Param        requestId  path      string                        true  "Request ID"
 // @Param        request    body      UpdateSurplusRequestRequest   true  "Request status"
 // @Success      200        {object}  SurplusRequest
-// @Failure      400        {object}  errors.AppError
-// @Failure      401        {object}  errors.AppError
-// @Failure      403        {object}  errors.AppError
+// @Failure      400        {object}  errors.Problem
+// @Failure      401        {object}  errors.Problem
+// @Failure      403        {object}  errors.Problem
 // @Router       /community/surplus/{id}/requests/{requestId} [put]
-func (h *Handler) UpdateRequest(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateRequest(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, _, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/"), "/")
 	if len(pathParts) < 3 || pathParts[1] != "requests" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	postID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid post ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid post ID")
 	}
 	requestID, err := uuid.Parse(pathParts[2])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid request ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid request ID")
 	}
 	var req UpdateSurplusRequestRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	request, err := h.service.UpdateRequest(requestID, postID, userID, &req)
+	request, err := h.service.WithContext(r.Context()).UpdateRequest(requestID, postID, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update request", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update request")
 	}
 	utils.OKResponse(w, "Request updated successfully", request)
+	return nil
 }
 
 // CreateComment handles POST /api/v1/community/surplus/:id/comments
@@ -379,44 +364,35 @@ func (h *Handler) UpdateRequest(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                      true  "Surplus Post ID"
 // @Param        request  body      CreateSurplusCommentRequest true  "Comment data"
 // @Success      201      {object}  SurplusComment
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /community/surplus/{id}/comments [post]
-func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, userName, _, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "comments" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	postID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid post ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid post ID")
 	}
 	var req CreateSurplusCommentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	comment, err := h.service.CreateComment(postID, userID, userName, &req)
+	comment, err := h.service.WithContext(r.Context()).CreateComment(postID, userID, userName, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create comment", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create comment")
 	}
 	utils.CreatedResponse(w, "Comment created successfully", comment)
+	return nil
 }
 
 // GetComments handles GET /api/v1/community/surplus/:id/comments
@@ -428,31 +404,24 @@ func (h *Handler) CreateComment(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Surplus Post ID"
 // @Success      200  {array}   SurplusComment
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /community/surplus/{id}/comments [get]
-func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetComments(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "comments" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	postID, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid post ID", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid post ID")
 	}
-	comments, err := h.service.GetCommentsByPostID(postID)
+	comments, err := h.service.WithContext(r.Context()).GetCommentsByPostID(postID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve comments", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve comments")
 	}
 	utils.OKResponse(w, "Comments retrieved successfully", comments)
+	return nil
 }
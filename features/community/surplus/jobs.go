@@ -0,0 +1,24 @@
+package surplus
+
+import (
+	"context"
+	"foodlink_backend/jobs"
+	"log/slog"
+)
+
+// RegisterJobs registers the surplus background jobs
+func RegisterJobs(scheduler *jobs.Scheduler) {
+	scheduler.Schedule("community_surplus.expire_posts", "*/5 * * * *", expirePosts)
+}
+
+// expirePosts marks available posts past expires_at as expired
+func expirePosts(ctx context.Context, job *jobs.Job) error {
+	count, err := NewRepository().WithContext(ctx).ExpireDue()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		slog.Info("Expired community surplus posts", "count", count)
+	}
+	return nil
+}
@@ -30,24 +30,24 @@ func (j *JSONB) Scan(value interface{}) error {
 }
 
 type SurplusPost struct {
-	ID           uuid.UUID `json:"id" db:"id"`
-	UserID       uuid.UUID `json:"user_id" db:"user_id"`
-	UserName     string    `json:"user_name" db:"user_name"`
-	AvatarURL    string    `json:"avatar_url,omitempty" db:"avatar_url"`
-	Title        string    `json:"title" db:"title"`
-	Description  string   `json:"description" db:"description"`
-	Category     string    `json:"category" db:"category"`
-	Tags         []string  `json:"tags,omitempty" db:"tags"`
-	Quantity     float64   `json:"quantity" db:"quantity"`
-	Unit         string    `json:"unit" db:"unit"`
-	PickupWindow JSONB     `json:"pickup_window" db:"pickup_window"`
-	PickupLocation string  `json:"pickup_location" db:"pickup_location"`
-	DistanceKm   *float64  `json:"distance_km,omitempty" db:"distance_km"`
-	Image        string    `json:"image,omitempty" db:"image"`
-	Status       string    `json:"status" db:"status"`
-	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	UserName       string     `json:"user_name" db:"user_name"`
+	AvatarURL      string     `json:"avatar_url,omitempty" db:"avatar_url"`
+	Title          string     `json:"title" db:"title"`
+	Description    string     `json:"description" db:"description"`
+	Category       string     `json:"category" db:"category"`
+	Tags           []string   `json:"tags,omitempty" db:"tags"`
+	Quantity       float64    `json:"quantity" db:"quantity"`
+	Unit           string     `json:"unit" db:"unit"`
+	PickupWindow   JSONB      `json:"pickup_window" db:"pickup_window"`
+	PickupLocation string     `json:"pickup_location" db:"pickup_location"`
+	DistanceKm     *float64   `json:"distance_km,omitempty" db:"distance_km"`
+	ImageUploadID  *uuid.UUID `json:"image_upload_id,omitempty" db:"image_upload_id"`
+	Status         string     `json:"status" db:"status"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 type SurplusRequest struct {
@@ -70,30 +70,30 @@ type SurplusComment struct {
 }
 
 type CreateSurplusPostRequest struct {
-	Title         string                 `json:"title" validate:"required,min=1,max=255"`
-	Description   string                 `json:"description" validate:"required,min=1"`
-	Category      string                 `json:"category" validate:"required,min=1,max=100"`
-	Tags          []string               `json:"tags,omitempty"`
-	Quantity      float64                `json:"quantity" validate:"required,gt=0"`
-	Unit          string                 `json:"unit" validate:"required,min=1,max=50"`
-	PickupWindow  map[string]interface{} `json:"pickup_window" validate:"required"`
-	PickupLocation string                `json:"pickup_location" validate:"required,min=1"`
-	DistanceKm    *float64               `json:"distance_km,omitempty"`
-	Image         string                 `json:"image,omitempty"`
-	ExpiresAt     time.Time              `json:"expires_at" validate:"required"`
+	Title          string                 `json:"title" validate:"required,min=1,max=255"`
+	Description    string                 `json:"description" validate:"required,min=1"`
+	Category       string                 `json:"category" validate:"required,min=1,max=100"`
+	Tags           []string               `json:"tags,omitempty"`
+	Quantity       float64                `json:"quantity" validate:"required,gt=0"`
+	Unit           string                 `json:"unit" validate:"required,min=1,max=50"`
+	PickupWindow   map[string]interface{} `json:"pickup_window" validate:"required"`
+	PickupLocation string                 `json:"pickup_location" validate:"required,min=1"`
+	DistanceKm     *float64               `json:"distance_km,omitempty"`
+	ImageUploadID  *uuid.UUID             `json:"image_upload_id,omitempty"`
+	ExpiresAt      time.Time              `json:"expires_at" validate:"required"`
 }
 
 type UpdateSurplusPostRequest struct {
-	Title         string                 `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
-	Description   string                 `json:"description,omitempty" validate:"omitempty,min=1"`
-	Category      string                 `json:"category,omitempty" validate:"omitempty,min=1,max=100"`
-	Tags          []string               `json:"tags,omitempty"`
-	Quantity      *float64               `json:"quantity,omitempty" validate:"omitempty,gt=0"`
-	Unit          string                 `json:"unit,omitempty" validate:"omitempty,min=1,max=50"`
-	PickupWindow  map[string]interface{} `json:"pickup_window,omitempty"`
-	PickupLocation string                `json:"pickup_location,omitempty" validate:"omitempty,min=1"`
-	Status        string                 `json:"status,omitempty"`
-	Image         string                 `json:"image,omitempty"`
+	Title          string                 `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Description    string                 `json:"description,omitempty" validate:"omitempty,min=1"`
+	Category       string                 `json:"category,omitempty" validate:"omitempty,min=1,max=100"`
+	Tags           []string               `json:"tags,omitempty"`
+	Quantity       *float64               `json:"quantity,omitempty" validate:"omitempty,gt=0"`
+	Unit           string                 `json:"unit,omitempty" validate:"omitempty,min=1,max=50"`
+	PickupWindow   map[string]interface{} `json:"pickup_window,omitempty"`
+	PickupLocation string                 `json:"pickup_location,omitempty" validate:"omitempty,min=1"`
+	Status         string                 `json:"status,omitempty"`
+	ImageUploadID  *uuid.UUID             `json:"image_upload_id,omitempty"`
 }
 
 type CreateSurplusRequestRequest struct {
@@ -107,3 +107,19 @@ type UpdateSurplusRequestRequest struct {
 type CreateSurplusCommentRequest struct {
 	Message string `json:"message" validate:"required,min=1"`
 }
+
+// PatchSurplusPostRequest holds the mutable fields of a surplus post.
+// PATCH requests merge a JSON Merge Patch document into it and validate the result.
+type PatchSurplusPostRequest struct {
+	Title          string                 `json:"title" validate:"required,min=1,max=255"`
+	Description    string                 `json:"description" validate:"required,min=1"`
+	Category       string                 `json:"category" validate:"required,min=1,max=100"`
+	Tags           []string               `json:"tags,omitempty"`
+	Quantity       float64                `json:"quantity" validate:"required,gt=0"`
+	Unit           string                 `json:"unit" validate:"required,min=1,max=50"`
+	PickupWindow   map[string]interface{} `json:"pickup_window" validate:"required"`
+	PickupLocation string                 `json:"pickup_location" validate:"required,min=1"`
+	DistanceKm     *float64               `json:"distance_km,omitempty"`
+	Status         string                 `json:"status" validate:"required,oneof=available claimed expired"`
+	ImageUploadID  *uuid.UUID             `json:"image_upload_id,omitempty"`
+}
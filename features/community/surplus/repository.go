@@ -1,6 +1,7 @@
 package surplus
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
@@ -12,13 +13,27 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAll(status string) ([]*SurplusPost, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
@@ -27,11 +42,11 @@ func (r *Repository) GetAll(status string) ([]*SurplusPost, error) {
 	var rows *sql.Rows
 	var err error
 	if status != "" {
-		query = `SELECT id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image, status, expires_at, created_at, updated_at FROM community_surplus_posts WHERE status = $1 ORDER BY created_at DESC`
-		rows, err = r.db.Query(query, status)
+		query = `SELECT id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image_upload_id, status, expires_at, created_at, updated_at FROM community_surplus_posts WHERE status = $1 ORDER BY created_at DESC`
+		rows, err = r.conn().Query(query, status)
 	} else {
-		query = `SELECT id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image, status, expires_at, created_at, updated_at FROM community_surplus_posts ORDER BY created_at DESC`
-		rows, err = r.db.Query(query)
+		query = `SELECT id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image_upload_id, status, expires_at, created_at, updated_at FROM community_surplus_posts ORDER BY created_at DESC`
+		rows, err = r.conn().Query(query)
 	}
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
@@ -41,7 +56,7 @@ func (r *Repository) GetAll(status string) ([]*SurplusPost, error) {
 	for rows.Next() {
 		post := &SurplusPost{}
 		var pickupWindowJSON []byte
-		if err := rows.Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSON, &post.PickupLocation, &post.DistanceKm, &post.Image, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt); err != nil {
+		if err := rows.Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSON, &post.PickupLocation, &post.DistanceKm, &post.ImageUploadID, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt); err != nil {
 			return nil, errors.WrapError(err, errors.ErrDatabase)
 		}
 		if len(pickupWindowJSON) > 0 {
@@ -58,8 +73,8 @@ func (r *Repository) GetByID(id uuid.UUID) (*SurplusPost, error) {
 	}
 	post := &SurplusPost{}
 	var pickupWindowJSON []byte
-	query := `SELECT id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image, status, expires_at, created_at, updated_at FROM community_surplus_posts WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSON, &post.PickupLocation, &post.DistanceKm, &post.Image, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt)
+	query := `SELECT id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image_upload_id, status, expires_at, created_at, updated_at FROM community_surplus_posts WHERE id = $1`
+	err := r.conn().QueryRow(query, id).Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSON, &post.PickupLocation, &post.DistanceKm, &post.ImageUploadID, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -77,10 +92,10 @@ func (r *Repository) Create(post *SurplusPost) error {
 		return errors.ErrDatabase
 	}
 	pickupWindowJSON, _ := json.Marshal(post.PickupWindow)
-	query := `INSERT INTO community_surplus_posts (id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image, status, expires_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image, status, expires_at, created_at, updated_at`
+	query := `INSERT INTO community_surplus_posts (id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image_upload_id, status, expires_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image_upload_id, status, expires_at, created_at, updated_at`
 	now := time.Now()
 	var pickupWindowJSONOut []byte
-	err := r.db.QueryRow(query, post.ID, post.UserID, post.UserName, post.AvatarURL, post.Title, post.Description, post.Category, pq.Array(post.Tags), post.Quantity, post.Unit, pickupWindowJSON, post.PickupLocation, post.DistanceKm, post.Image, post.Status, post.ExpiresAt, now, now).Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSONOut, &post.PickupLocation, &post.DistanceKm, &post.Image, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt)
+	err := r.conn().QueryRow(query, post.ID, post.UserID, post.UserName, post.AvatarURL, post.Title, post.Description, post.Category, pq.Array(post.Tags), post.Quantity, post.Unit, pickupWindowJSON, post.PickupLocation, post.DistanceKm, post.ImageUploadID, post.Status, post.ExpiresAt, now, now).Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSONOut, &post.PickupLocation, &post.DistanceKm, &post.ImageUploadID, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -95,9 +110,9 @@ func (r *Repository) Update(post *SurplusPost) error {
 		return errors.ErrDatabase
 	}
 	pickupWindowJSON, _ := json.Marshal(post.PickupWindow)
-	query := `UPDATE community_surplus_posts SET title=$1, description=$2, category=$3, tags=$4, quantity=$5, unit=$6, pickup_window=$7, pickup_location=$8, distance_km=$9, image=$10, status=$11, updated_at=$12 WHERE id=$13 RETURNING id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image, status, expires_at, created_at, updated_at`
+	query := `UPDATE community_surplus_posts SET title=$1, description=$2, category=$3, tags=$4, quantity=$5, unit=$6, pickup_window=$7, pickup_location=$8, distance_km=$9, image_upload_id=$10, status=$11, updated_at=$12 WHERE id=$13 RETURNING id, user_id, user_name, avatar_url, title, description, category, tags, quantity, unit, pickup_window, pickup_location, distance_km, image_upload_id, status, expires_at, created_at, updated_at`
 	var pickupWindowJSONOut []byte
-	err := r.db.QueryRow(query, post.Title, post.Description, post.Category, pq.Array(post.Tags), post.Quantity, post.Unit, pickupWindowJSON, post.PickupLocation, post.DistanceKm, post.Image, post.Status, time.Now(), post.ID).Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSONOut, &post.PickupLocation, &post.DistanceKm, &post.Image, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt)
+	err := r.conn().QueryRow(query, post.Title, post.Description, post.Category, pq.Array(post.Tags), post.Quantity, post.Unit, pickupWindowJSON, post.PickupLocation, post.DistanceKm, post.ImageUploadID, post.Status, time.Now(), post.ID).Scan(&post.ID, &post.UserID, &post.UserName, &post.AvatarURL, &post.Title, &post.Description, &post.Category, pq.Array(&post.Tags), &post.Quantity, &post.Unit, &pickupWindowJSONOut, &post.PickupLocation, &post.DistanceKm, &post.ImageUploadID, &post.Status, &post.ExpiresAt, &post.CreatedAt, &post.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.ErrNotFound
@@ -114,7 +129,7 @@ func (r *Repository) Delete(id uuid.UUID) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	result, err := r.db.Exec(`DELETE FROM community_surplus_posts WHERE id = $1`, id)
+	result, err := r.conn().Exec(`DELETE FROM community_surplus_posts WHERE id = $1`, id)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -130,7 +145,7 @@ func (r *Repository) CreateRequest(req *SurplusRequest) error {
 		return errors.ErrDatabase
 	}
 	query := `INSERT INTO surplus_requests (id, post_id, user_id, user_name, message, status, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, post_id, user_id, user_name, message, status, created_at`
-	return r.db.QueryRow(query, req.ID, req.PostID, req.UserID, req.UserName, req.Message, req.Status, time.Now()).Scan(&req.ID, &req.PostID, &req.UserID, &req.UserName, &req.Message, &req.Status, &req.CreatedAt)
+	return r.conn().QueryRow(query, req.ID, req.PostID, req.UserID, req.UserName, req.Message, req.Status, time.Now()).Scan(&req.ID, &req.PostID, &req.UserID, &req.UserName, &req.Message, &req.Status, &req.CreatedAt)
 }
 
 func (r *Repository) GetRequestsByPostID(postID uuid.UUID) ([]*SurplusRequest, error) {
@@ -138,7 +153,7 @@ func (r *Repository) GetRequestsByPostID(postID uuid.UUID) ([]*SurplusRequest, e
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, post_id, user_id, user_name, message, status, created_at FROM surplus_requests WHERE post_id = $1 ORDER BY created_at DESC`
-	rows, err := r.db.Query(query, postID)
+	rows, err := r.conn().Query(query, postID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -160,7 +175,7 @@ func (r *Repository) GetRequestByID(id uuid.UUID) (*SurplusRequest, error) {
 	}
 	req := &SurplusRequest{}
 	query := `SELECT id, post_id, user_id, user_name, message, status, created_at FROM surplus_requests WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&req.ID, &req.PostID, &req.UserID, &req.UserName, &req.Message, &req.Status, &req.CreatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&req.ID, &req.PostID, &req.UserID, &req.UserName, &req.Message, &req.Status, &req.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -175,7 +190,7 @@ func (r *Repository) UpdateRequest(req *SurplusRequest) error {
 		return errors.ErrDatabase
 	}
 	query := `UPDATE surplus_requests SET status=$1 WHERE id=$2 RETURNING id, post_id, user_id, user_name, message, status, created_at`
-	return r.db.QueryRow(query, req.Status, req.ID).Scan(&req.ID, &req.PostID, &req.UserID, &req.UserName, &req.Message, &req.Status, &req.CreatedAt)
+	return r.conn().QueryRow(query, req.Status, req.ID).Scan(&req.ID, &req.PostID, &req.UserID, &req.UserName, &req.Message, &req.Status, &req.CreatedAt)
 }
 
 func (r *Repository) CreateComment(comment *SurplusComment) error {
@@ -183,7 +198,7 @@ func (r *Repository) CreateComment(comment *SurplusComment) error {
 		return errors.ErrDatabase
 	}
 	query := `INSERT INTO surplus_comments (id, post_id, user_id, user_name, message, created_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, post_id, user_id, user_name, message, created_at`
-	return r.db.QueryRow(query, comment.ID, comment.PostID, comment.UserID, comment.UserName, comment.Message, time.Now()).Scan(&comment.ID, &comment.PostID, &comment.UserID, &comment.UserName, &comment.Message, &comment.CreatedAt)
+	return r.conn().QueryRow(query, comment.ID, comment.PostID, comment.UserID, comment.UserName, comment.Message, time.Now()).Scan(&comment.ID, &comment.PostID, &comment.UserID, &comment.UserName, &comment.Message, &comment.CreatedAt)
 }
 
 func (r *Repository) GetCommentsByPostID(postID uuid.UUID) ([]*SurplusComment, error) {
@@ -191,7 +206,7 @@ func (r *Repository) GetCommentsByPostID(postID uuid.UUID) ([]*SurplusComment, e
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, post_id, user_id, user_name, message, created_at FROM surplus_comments WHERE post_id = $1 ORDER BY created_at ASC`
-	rows, err := r.db.Query(query, postID)
+	rows, err := r.conn().Query(query, postID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -206,3 +221,17 @@ func (r *Repository) GetCommentsByPostID(postID uuid.UUID) ([]*SurplusComment, e
 	}
 	return comments, nil
 }
+
+// ExpireDue marks available posts past their expiry as expired and returns
+// how many changed
+func (r *Repository) ExpireDue() (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	query := `UPDATE community_surplus_posts SET status = 'expired', updated_at = CURRENT_TIMESTAMP WHERE status = 'available' AND expires_at < CURRENT_TIMESTAMP`
+	result, err := r.conn().Exec(query)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
@@ -1,6 +1,7 @@
 package surplus
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 	"strings"
@@ -8,34 +9,36 @@ import (
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		path := strings.TrimPrefix(r.URL.Path, "/api/v1/community/surplus/")
 		pathParts := strings.Split(path, "/")
 		
 		switch {
 		case path == "" && r.Method == http.MethodGet:
-			handler.GetAll(w, r)
+			return handler.GetAll(w, r)
 		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodGet:
-			handler.GetByID(w, r)
+			return handler.GetByID(w, r)
 		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodPut:
-			handler.Update(w, r)
+			return handler.Update(w, r)
+		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodPatch:
+			return handler.Patch(w, r)
 		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodDelete:
-			handler.Delete(w, r)
+			return handler.Delete(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "request" && r.Method == http.MethodPost:
-			handler.CreateRequest(w, r)
+			return handler.CreateRequest(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "requests" && r.Method == http.MethodGet:
-			handler.GetRequests(w, r)
+			return handler.GetRequests(w, r)
 		case len(pathParts) == 3 && pathParts[1] == "requests" && r.Method == http.MethodPut:
-			handler.UpdateRequest(w, r)
+			return handler.UpdateRequest(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "comments" && r.Method == http.MethodPost:
-			handler.CreateComment(w, r)
+			return handler.CreateComment(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "comments" && r.Method == http.MethodGet:
-			handler.GetComments(w, r)
+			return handler.GetComments(w, r)
 		case r.Method == http.MethodPost:
-			handler.Create(w, r)
+			return handler.Create(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
@@ -1,7 +1,13 @@
 package surplus
 
 import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/features/uploads"
+	"foodlink_backend/metrics"
 	"foodlink_backend/utils"
 
 	"github.com/google/uuid"
@@ -15,6 +21,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAll(status string) ([]*SurplusPost, error) {
 	return s.repo.GetAll(status)
 }
@@ -25,29 +35,33 @@ func (s *Service) GetByID(id uuid.UUID) (*SurplusPost, error) {
 
 func (s *Service) Create(userID uuid.UUID, userName string, avatarURL string, req *CreateSurplusPostRequest) (*SurplusPost, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
 	}
 	post := &SurplusPost{
-		ID:            uuid.New(),
-		UserID:        userID,
-		UserName:      userName,
-		AvatarURL:     avatarURL,
-		Title:         req.Title,
-		Description:   req.Description,
-		Category:      req.Category,
-		Tags:          req.Tags,
-		Quantity:      req.Quantity,
-		Unit:          req.Unit,
-		PickupWindow:  JSONB(req.PickupWindow),
+		ID:             uuid.New(),
+		UserID:         userID,
+		UserName:       userName,
+		AvatarURL:      avatarURL,
+		Title:          req.Title,
+		Description:    req.Description,
+		Category:       req.Category,
+		Tags:           req.Tags,
+		Quantity:       req.Quantity,
+		Unit:           req.Unit,
+		PickupWindow:   JSONB(req.PickupWindow),
 		PickupLocation: req.PickupLocation,
-		DistanceKm:    req.DistanceKm,
-		Image:         req.Image,
-		Status:        "available",
-		ExpiresAt:     req.ExpiresAt,
+		DistanceKm:     req.DistanceKm,
+		ImageUploadID:  req.ImageUploadID,
+		Status:         "available",
+		ExpiresAt:      req.ExpiresAt,
 	}
 	if err := s.repo.Create(post); err != nil {
 		return nil, err
 	}
+	metrics.SurplusPostsCreated.Inc(metrics.SourceCommunity)
 	return post, nil
 }
 
@@ -60,7 +74,10 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateSurplusPostR
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
 	}
 	if req.Title != "" {
 		post.Title = req.Title
@@ -86,18 +103,95 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateSurplusPostR
 	if req.PickupLocation != "" {
 		post.PickupLocation = req.PickupLocation
 	}
+	previousStatus := post.Status
 	if req.Status != "" {
 		post.Status = req.Status
 	}
-	if req.Image != "" {
-		post.Image = req.Image
+	if req.ImageUploadID != nil {
+		post.ImageUploadID = req.ImageUploadID
+	}
+	if err := s.updatePost(post, previousStatus); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *Service) Patch(id uuid.UUID, userID uuid.UUID, patch []byte) (*SurplusPost, error) {
+	post, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	doc := &PatchSurplusPostRequest{
+		Title:          post.Title,
+		Description:    post.Description,
+		Category:       post.Category,
+		Tags:           post.Tags,
+		Quantity:       post.Quantity,
+		Unit:           post.Unit,
+		PickupWindow:   post.PickupWindow,
+		PickupLocation: post.PickupLocation,
+		DistanceKm:     post.DistanceKm,
+		Status:         post.Status,
+		ImageUploadID:  post.ImageUploadID,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, doc.ImageUploadID); err != nil {
+		return nil, err
 	}
-	if err := s.repo.Update(post); err != nil {
+	post.Title = doc.Title
+	post.Description = doc.Description
+	post.Category = doc.Category
+	post.Tags = doc.Tags
+	post.Quantity = doc.Quantity
+	post.Unit = doc.Unit
+	post.PickupWindow = JSONB(doc.PickupWindow)
+	post.PickupLocation = doc.PickupLocation
+	post.DistanceKm = doc.DistanceKm
+	previousStatus := post.Status
+	post.Status = doc.Status
+	post.ImageUploadID = doc.ImageUploadID
+	if err := s.updatePost(post, previousStatus); err != nil {
 		return nil, err
 	}
 	return post, nil
 }
 
+// updatePost saves post. When it moves into the claimed status, the
+// SurplusClaimed event is written in the same transaction.
+func (s *Service) updatePost(post *SurplusPost, previousStatus string) error {
+	if !isNewClaim(previousStatus, post.Status) {
+		return s.repo.Update(post)
+	}
+	err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).Update(post); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.SurplusClaimed{
+			PostID:  post.ID,
+			OwnerID: post.UserID,
+			Title:   post.Title,
+		})
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to update surplus post")
+	}
+	metrics.SurplusPostsClaimed.Inc(metrics.SourceCommunity)
+	return nil
+}
+
+// isNewClaim reports whether a post moves into the claimed status
+func isNewClaim(previousStatus, status string) bool {
+	return status == "claimed" && previousStatus != "claimed"
+}
+
 func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	post, err := s.repo.GetByID(id)
 	if err != nil {
@@ -110,7 +204,7 @@ func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 }
 
 func (s *Service) CreateRequest(postID uuid.UUID, userID uuid.UUID, userName string, req *CreateSurplusRequestRequest) (*SurplusRequest, error) {
-	_, err := s.repo.GetByID(postID)
+	post, err := s.repo.GetByID(postID)
 	if err != nil {
 		return nil, err
 	}
@@ -122,8 +216,22 @@ func (s *Service) CreateRequest(postID uuid.UUID, userID uuid.UUID, userName str
 		Message:  req.Message,
 		Status:   "pending",
 	}
-	if err := s.repo.CreateRequest(request); err != nil {
-		return nil, err
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).CreateRequest(request); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.SurplusRequested{
+			PostID:        post.ID,
+			OwnerID:       post.UserID,
+			Title:         post.Title,
+			RequestID:     request.ID,
+			RequesterID:   request.UserID,
+			RequesterName: request.UserName,
+			Message:       request.Message,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create request")
 	}
 	return request, nil
 }
@@ -155,17 +263,44 @@ func (s *Service) UpdateRequest(requestID uuid.UUID, postID uuid.UUID, userID uu
 		return nil, errors.ErrNotFound
 	}
 	request.Status = req.Status
-	if err := s.repo.UpdateRequest(request); err != nil {
-		return nil, err
+	if request.Status != "approved" || post.Status == "claimed" {
+		if err := s.repo.UpdateRequest(request); err != nil {
+			return nil, err
+		}
+		return request, nil
 	}
+
+	// Approving a request claims the post for the requester
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		txRepo := s.repo.WithTx(tx)
+		if err := txRepo.UpdateRequest(request); err != nil {
+			return err
+		}
+		post.Status = "claimed"
+		if err := txRepo.Update(post); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.SurplusClaimed{
+			PostID:       post.ID,
+			OwnerID:      post.UserID,
+			Title:        post.Title,
+			RequestID:    &request.ID,
+			ClaimantID:   &request.UserID,
+			ClaimantName: request.UserName,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to update request")
+	}
+	metrics.SurplusPostsClaimed.Inc(metrics.SourceCommunity)
 	return request, nil
 }
 
 func (s *Service) CreateComment(postID uuid.UUID, userID uuid.UUID, userName string, req *CreateSurplusCommentRequest) (*SurplusComment, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
-	_, err := s.repo.GetByID(postID)
+	post, err := s.repo.GetByID(postID)
 	if err != nil {
 		return nil, err
 	}
@@ -176,8 +311,22 @@ func (s *Service) CreateComment(postID uuid.UUID, userID uuid.UUID, userName str
 		UserName: userName,
 		Message:  req.Message,
 	}
-	if err := s.repo.CreateComment(comment); err != nil {
-		return nil, err
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).CreateComment(comment); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.SurplusCommented{
+			PostID:     post.ID,
+			OwnerID:    post.UserID,
+			Title:      post.Title,
+			CommentID:  comment.ID,
+			AuthorID:   comment.UserID,
+			AuthorName: comment.UserName,
+			Message:    comment.Message,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create comment")
 	}
 	return comment, nil
 }
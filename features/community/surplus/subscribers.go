@@ -0,0 +1,77 @@
+package surplus
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/events"
+	"foodlink_backend/features/notifications"
+)
+
+// RegisterSubscribers subscribes the surplus feature to domain events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypeSurplusClaimed, "surplus.notify_claimant", notifyClaimant)
+	bus.Subscribe(events.TypeSurplusRequested, "surplus.notify_owner_of_request", notifyOwnerOfRequest)
+	bus.Subscribe(events.TypeSurplusCommented, "surplus.notify_owner_of_comment", notifyOwnerOfComment)
+}
+
+// notifyClaimant tells the requester that their request claimed the post.
+// Posts marked claimed by the owner have no known claimant.
+func notifyClaimant(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var claimed events.SurplusClaimed
+	if err := event.Decode(&claimed); err != nil {
+		return err
+	}
+	if claimed.ClaimantID == nil {
+		return nil
+	}
+	message := fmt.Sprintf("Your request for %s was approved. Check the post for pickup details.", claimed.Title)
+	_, err := notifications.NewService().WithContext(ctx).Notify(tx, &notifications.NewNotification{
+		UserID:     *claimed.ClaimantID,
+		Source:     notifications.SourceCommunity,
+		Type:       "surplus",
+		Title:      "Request approved",
+		Message:    message,
+		Preference: notifications.PreferenceClaim,
+	})
+	return err
+}
+
+// notifyOwnerOfRequest tells the poster that someone requested their post
+func notifyOwnerOfRequest(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var requested events.SurplusRequested
+	if err := event.Decode(&requested); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("%s requested %s.", requested.RequesterName, requested.Title)
+	_, err := notifications.NewService().WithContext(ctx).Notify(tx, &notifications.NewNotification{
+		UserID:     requested.OwnerID,
+		Source:     notifications.SourceCommunity,
+		Type:       "surplus",
+		Title:      "New request",
+		Message:    message,
+		Preference: notifications.PreferenceClaim,
+	})
+	return err
+}
+
+// notifyOwnerOfComment tells the poster about comments by others
+func notifyOwnerOfComment(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var commented events.SurplusCommented
+	if err := event.Decode(&commented); err != nil {
+		return err
+	}
+	if commented.AuthorID == commented.OwnerID {
+		return nil
+	}
+	message := fmt.Sprintf("%s commented on %s.", commented.AuthorName, commented.Title)
+	_, err := notifications.NewService().WithContext(ctx).Notify(tx, &notifications.NewNotification{
+		UserID:     commented.OwnerID,
+		Source:     notifications.SourceCommunity,
+		Type:       "surplus",
+		Title:      "New comment",
+		Message:    message,
+		Preference: notifications.PreferenceMessages,
+	})
+	return err
+}
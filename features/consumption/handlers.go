@@ -35,28 +35,22 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   ConsumptionLog
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /consumption [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	logs, err := h.service.GetAllByUserID(userID)
+	logs, err := h.service.WithContext(r.Context()).GetAllByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve consumption logs", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve consumption logs")
 	}
 	utils.OKResponse(w, "Consumption logs retrieved successfully", logs)
+	return nil
 }
 
 // GetByID handles GET /api/v1/consumption/:id
@@ -68,30 +62,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Consumption Log ID"
 // @Success      200  {object}  ConsumptionLog
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /consumption/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/consumption/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	log, err := h.service.GetByID(id)
+	log, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve consumption log", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve consumption log")
 	}
 	utils.OKResponse(w, "Consumption log retrieved successfully", log)
+	return nil
 }
 
 // Create handles POST /api/v1/consumption
@@ -103,34 +91,27 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateConsumptionLogRequest  true  "Consumption log data"
 // @Success      201      {object}  ConsumptionLog
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /consumption [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateConsumptionLogRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	log, err := h.service.Create(userID, &req)
+	log, err := h.service.WithContext(r.Context()).Create(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create consumption log", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create consumption log")
 	}
 	utils.CreatedResponse(w, "Consumption log created successfully", log)
+	return nil
 }
 
 // Update handles PUT /api/v1/consumption/:id
@@ -143,42 +124,34 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                      true  "Consumption Log ID"
 // @Param        request  body      UpdateConsumptionLogRequest true  "Consumption log data"
 // @Success      200      {object}  ConsumptionLog
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /consumption/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/consumption/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateConsumptionLogRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	log, err := h.service.Update(id, userID, &req)
+	log, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update consumption log", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update consumption log")
 	}
 	utils.OKResponse(w, "Consumption log updated successfully", log)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/consumption/:id
@@ -190,35 +163,28 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Consumption Log ID"
 // @Success      200  {object}  map[string]string
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /consumption/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/consumption/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	if err := h.service.Delete(id, userID); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete consumption log", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete consumption log")
 	}
 	utils.OKResponse(w, "Consumption log deleted successfully", nil)
+	return nil
 }
 
 // GetStats handles GET /api/v1/consumption/stats
@@ -229,26 +195,54 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  ConsumptionStats
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /consumption/stats [get]
-func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	stats, err := h.service.GetStats(userID)
+	stats, err := h.service.WithContext(r.Context()).GetStats(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve stats", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve stats")
 	}
 	utils.OKResponse(w, "Stats retrieved successfully", stats)
+	return nil
+}
+
+// Batch handles POST /api/v1/consumption/batch
+// @Summary      Batch create, update and delete consumption logs
+// @Description  Apply up to 500 create, update and delete operations to the authenticated user's consumption logs. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.
+// @Tags         consumption
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      utils.BatchRequest  true  "Batch operations"
+// @Success      200      {object}  utils.BatchResult
+// @Success      207      {object}  utils.BatchResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      422      {object}  utils.BatchResult
+// @Router       /consumption/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req utils.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Batch(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to process batch")
+	}
+	utils.BatchResponse(w, result)
+	return nil
 }
@@ -1,6 +1,7 @@
 package consumption
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -10,19 +11,33 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*ConsumptionLog, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, user_id, inventory_item_id, food_name, quantity, unit, category, consumed_at, was_wasted, notes, created_at, updated_at FROM consumption_logs WHERE user_id = $1 ORDER BY consumed_at DESC`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -44,7 +59,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*ConsumptionLog, error) {
 	}
 	log := &ConsumptionLog{}
 	query := `SELECT id, user_id, inventory_item_id, food_name, quantity, unit, category, consumed_at, was_wasted, notes, created_at, updated_at FROM consumption_logs WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&log.ID, &log.UserID, &log.InventoryItemID, &log.FoodName, &log.Quantity, &log.Unit, &log.Category, &log.ConsumedAt, &log.WasWasted, &log.Notes, &log.CreatedAt, &log.UpdatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&log.ID, &log.UserID, &log.InventoryItemID, &log.FoodName, &log.Quantity, &log.Unit, &log.Category, &log.ConsumedAt, &log.WasWasted, &log.Notes, &log.CreatedAt, &log.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -60,7 +75,7 @@ func (r *Repository) Create(log *ConsumptionLog) error {
 	}
 	query := `INSERT INTO consumption_logs (id, user_id, inventory_item_id, food_name, quantity, unit, category, consumed_at, was_wasted, notes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, user_id, inventory_item_id, food_name, quantity, unit, category, consumed_at, was_wasted, notes, created_at, updated_at`
 	now := time.Now()
-	return r.db.QueryRow(query, log.ID, log.UserID, log.InventoryItemID, log.FoodName, log.Quantity, log.Unit, log.Category, log.ConsumedAt, log.WasWasted, log.Notes, now, now).Scan(&log.ID, &log.UserID, &log.InventoryItemID, &log.FoodName, &log.Quantity, &log.Unit, &log.Category, &log.ConsumedAt, &log.WasWasted, &log.Notes, &log.CreatedAt, &log.UpdatedAt)
+	return r.conn().QueryRow(query, log.ID, log.UserID, log.InventoryItemID, log.FoodName, log.Quantity, log.Unit, log.Category, log.ConsumedAt, log.WasWasted, log.Notes, now, now).Scan(&log.ID, &log.UserID, &log.InventoryItemID, &log.FoodName, &log.Quantity, &log.Unit, &log.Category, &log.ConsumedAt, &log.WasWasted, &log.Notes, &log.CreatedAt, &log.UpdatedAt)
 }
 
 func (r *Repository) Update(log *ConsumptionLog) error {
@@ -68,14 +83,14 @@ func (r *Repository) Update(log *ConsumptionLog) error {
 		return errors.ErrDatabase
 	}
 	query := `UPDATE consumption_logs SET food_name=$1, quantity=$2, unit=$3, category=$4, consumed_at=$5, was_wasted=$6, notes=$7, updated_at=$8 WHERE id=$9 RETURNING id, user_id, inventory_item_id, food_name, quantity, unit, category, consumed_at, was_wasted, notes, created_at, updated_at`
-	return r.db.QueryRow(query, log.FoodName, log.Quantity, log.Unit, log.Category, log.ConsumedAt, log.WasWasted, log.Notes, time.Now(), log.ID).Scan(&log.ID, &log.UserID, &log.InventoryItemID, &log.FoodName, &log.Quantity, &log.Unit, &log.Category, &log.ConsumedAt, &log.WasWasted, &log.Notes, &log.CreatedAt, &log.UpdatedAt)
+	return r.conn().QueryRow(query, log.FoodName, log.Quantity, log.Unit, log.Category, log.ConsumedAt, log.WasWasted, log.Notes, time.Now(), log.ID).Scan(&log.ID, &log.UserID, &log.InventoryItemID, &log.FoodName, &log.Quantity, &log.Unit, &log.Category, &log.ConsumedAt, &log.WasWasted, &log.Notes, &log.CreatedAt, &log.UpdatedAt)
 }
 
 func (r *Repository) Delete(id uuid.UUID) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	result, err := r.db.Exec(`DELETE FROM consumption_logs WHERE id = $1`, id)
+	result, err := r.conn().Exec(`DELETE FROM consumption_logs WHERE id = $1`, id)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -92,7 +107,7 @@ func (r *Repository) GetStats(userID uuid.UUID) (*ConsumptionStats, error) {
 	}
 	stats := &ConsumptionStats{}
 	query := `SELECT COALESCE(SUM(quantity), 0), COALESCE(SUM(CASE WHEN was_wasted THEN quantity ELSE 0 END), 0), COUNT(*) FROM consumption_logs WHERE user_id = $1`
-	err := r.db.QueryRow(query, userID).Scan(&stats.TotalConsumed, &stats.TotalWasted, &stats.TotalLogs)
+	err := r.conn().QueryRow(query, userID).Scan(&stats.TotalConsumed, &stats.TotalWasted, &stats.TotalLogs)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
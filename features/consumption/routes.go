@@ -1,6 +1,7 @@
 package consumption
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 	"strings"
@@ -8,24 +9,26 @@ import (
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		path := strings.TrimPrefix(r.URL.Path, "/api/v1/consumption/")
 		switch {
 		case path == "" && r.Method == http.MethodGet:
-			handler.GetAll(w, r)
+			return handler.GetAll(w, r)
 		case path == "stats" && r.Method == http.MethodGet:
-			handler.GetStats(w, r)
+			return handler.GetStats(w, r)
+		case path == "batch" && r.Method == http.MethodPost:
+			return handler.Batch(w, r)
 		case len(path) == 36 && r.Method == http.MethodGet:
-			handler.GetByID(w, r)
+			return handler.GetByID(w, r)
 		case len(path) == 36 && r.Method == http.MethodPut:
-			handler.Update(w, r)
+			return handler.Update(w, r)
 		case len(path) == 36 && r.Method == http.MethodDelete:
-			handler.Delete(w, r)
+			return handler.Delete(w, r)
 		case r.Method == http.MethodPost:
-			handler.Create(w, r)
+			return handler.Create(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
@@ -1,6 +1,8 @@
 package consumption
 
 import (
+	"context"
+	"database/sql"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 	"time"
@@ -16,6 +18,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*ConsumptionLog, error) {
 	return s.repo.GetAllByUserID(userID)
 }
@@ -26,7 +32,7 @@ func (s *Service) GetByID(id uuid.UUID) (*ConsumptionLog, error) {
 
 func (s *Service) Create(userID uuid.UUID, req *CreateConsumptionLogRequest) (*ConsumptionLog, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	log := &ConsumptionLog{
 		ID:              uuid.New(),
@@ -58,7 +64,7 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateConsumptionL
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.FoodName != "" {
 		log.FoodName = req.FoodName
@@ -98,6 +104,28 @@ func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	return s.repo.Delete(id)
 }
 
+func (s *Service) Batch(userID uuid.UUID, req *utils.BatchRequest) (*utils.BatchResult, error) {
+	return utils.RunBatch(s.repo.ctx, s.repo.db, req, func(tx *sql.Tx, op *utils.BatchOperation) (interface{}, error) {
+		txService := &Service{repo: s.repo.WithTx(tx)}
+		switch op.Op {
+		case utils.BatchOpCreate:
+			var createReq CreateConsumptionLogRequest
+			if err := op.Decode(&createReq); err != nil {
+				return nil, err
+			}
+			return txService.Create(userID, &createReq)
+		case utils.BatchOpUpdate:
+			var updateReq UpdateConsumptionLogRequest
+			if err := op.Decode(&updateReq); err != nil {
+				return nil, err
+			}
+			return txService.Update(*op.ID, userID, &updateReq)
+		default:
+			return nil, txService.Delete(*op.ID, userID)
+		}
+	})
+}
+
 func (s *Service) GetStats(userID uuid.UUID) (*ConsumptionStats, error) {
 	return s.repo.GetStats(userID)
 }
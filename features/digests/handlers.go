@@ -0,0 +1,113 @@
+package digests
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) getUser(r *http.Request) (*auth.User, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return nil, errors.ErrUnauthorized
+	}
+	return user, nil
+}
+
+// GetSubscriptions handles GET /api/v1/digests/subscriptions
+// @Summary      Get email digest subscriptions
+// @Description  Get which email digests the user receives. Until changed, families get the weekly digest and restaurants and NGOs the daily one.
+// @Tags         digests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  Subscriptions
+// @Failure      401  {object}  errors.Problem
+// @Router       /digests/subscriptions [get]
+func (h *Handler) GetSubscriptions(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	user, err := h.getUser(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	subs, err := h.service.WithContext(r.Context()).GetSubscriptions(user.ID, user.Role)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve digest subscriptions")
+	}
+	utils.OKResponse(w, "Digest subscriptions retrieved successfully", subs)
+	return nil
+}
+
+// UpdateSubscriptions handles PUT /api/v1/digests/subscriptions
+// @Summary      Update email digest subscriptions
+// @Description  Subscribe to or unsubscribe from the daily and weekly email digests; fields left out are unchanged
+// @Tags         digests
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subscriptions  body      UpdateSubscriptionsRequest  true  "Subscriptions to change"
+// @Success      200            {object}  Subscriptions
+// @Failure      400            {object}  errors.Problem
+// @Failure      401            {object}  errors.Problem
+// @Router       /digests/subscriptions [put]
+func (h *Handler) UpdateSubscriptions(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	user, err := h.getUser(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req UpdateSubscriptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	subs, err := h.service.WithContext(r.Context()).UpdateSubscriptions(user.ID, user.Role, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update digest subscriptions")
+	}
+	utils.OKResponse(w, "Digest subscriptions updated successfully", subs)
+	return nil
+}
+
+// Unsubscribe handles GET and POST /api/v1/digests/unsubscribe
+// @Summary      Unsubscribe from an email digest
+// @Description  Target of the unsubscribe link in every digest; no sign-in needed. The link is signed for one user and frequency. POST serves one-click unsubscribe from mail clients (RFC 8058).
+// @Tags         digests
+// @Produce      json
+// @Param        user_id    query     string  true  "User ID"
+// @Param        frequency  query     string  true  "daily or weekly"
+// @Param        signature  query     string  true  "Link signature"
+// @Success      200        {object}  map[string]string
+// @Failure      400        {object}  errors.Problem
+// @Failure      403        {object}  errors.Problem
+// @Router       /digests/unsubscribe [get]
+// @Router       /digests/unsubscribe [post]
+func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	query := r.URL.Query()
+	req := &UnsubscribeRequest{
+		UserID:    query.Get("user_id"),
+		Frequency: query.Get("frequency"),
+		Signature: query.Get("signature"),
+	}
+	if err := h.service.WithContext(r.Context()).Unsubscribe(req); err != nil {
+		return errors.Wrap(err, "Failed to unsubscribe")
+	}
+	utils.OKResponse(w, "Unsubscribed from the "+req.Frequency+" digest", map[string]string{"frequency": req.Frequency})
+	return nil
+}
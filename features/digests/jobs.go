@@ -0,0 +1,23 @@
+package digests
+
+import (
+	"context"
+	"foodlink_backend/config"
+	"foodlink_backend/jobs"
+	"foodlink_backend/mailer"
+	"time"
+)
+
+// RegisterJobs registers the daily and weekly digest jobs. Each run covers
+// the time since the previous one, so a missed run is folded into the next.
+func RegisterJobs(scheduler *jobs.Scheduler, cfg *config.Config, mail mailer.Mailer) {
+	service := NewService(cfg, mail)
+	scheduler.Schedule("digests.send_daily", cfg.Digests.DailySchedule, func(ctx context.Context, job *jobs.Job) error {
+		from, to := job.Window(24 * time.Hour)
+		return service.WithContext(ctx).SendDue(FrequencyDaily, from, to)
+	})
+	scheduler.Schedule("digests.send_weekly", cfg.Digests.WeeklySchedule, func(ctx context.Context, job *jobs.Job) error {
+		from, to := job.Window(7 * 24 * time.Hour)
+		return service.WithContext(ctx).SendDue(FrequencyWeekly, from, to)
+	})
+}
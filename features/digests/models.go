@@ -0,0 +1,169 @@
+package digests
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Digest frequencies; each is its own subscription
+const (
+	FrequencyDaily  = "daily"
+	FrequencyWeekly = "weekly"
+)
+
+// Frequencies lists every digest frequency
+var Frequencies = []string{FrequencyDaily, FrequencyWeekly}
+
+// Roles that receive digests
+const (
+	RoleFamily     = "family"
+	RoleRestaurant = "restaurant"
+	RoleNGO        = "ngo"
+)
+
+// defaultSubscriptions lists, per frequency, the roles subscribed until they
+// change it: families get the weekly digest, restaurants and NGOs the daily
+// one
+var defaultSubscriptions = map[string][]string{
+	FrequencyDaily:  {RoleRestaurant, RoleNGO},
+	FrequencyWeekly: {RoleFamily},
+}
+
+// maxListedItems bounds each list in a digest; the rest are counted
+const maxListedItems = 10
+
+// Subscriptions are the digests a user receives
+type Subscriptions struct {
+	Daily  bool `json:"daily"`
+	Weekly bool `json:"weekly"`
+}
+
+// UpdateSubscriptionsRequest changes the digests that are set
+type UpdateSubscriptionsRequest struct {
+	Daily  *bool `json:"daily,omitempty"`
+	Weekly *bool `json:"weekly,omitempty"`
+}
+
+// UnsubscribeRequest is the signed query of an unsubscribe link
+type UnsubscribeRequest struct {
+	UserID    string `json:"user_id" validate:"required,uuid"`
+	Frequency string `json:"frequency" validate:"required,oneof=daily weekly"`
+	Signature string `json:"signature" validate:"required,hexadecimal"`
+}
+
+// Recipient is a user due a digest
+type Recipient struct {
+	UserID uuid.UUID
+	Name   string
+	Email  string
+	Role   string
+}
+
+// ExpiringItem is stock expiring soon
+type ExpiringItem struct {
+	Name       string
+	Quantity   float64
+	Unit       string
+	ExpiryDate time.Time
+}
+
+// ExpiringList is the first expiring items and how many there are in all
+type ExpiringList struct {
+	Items []ExpiringItem
+	Count int
+}
+
+// FamilyDigest summarizes a household's food
+type FamilyDigest struct {
+	// Expiring is inventory expiring within the coming week
+	Expiring ExpiringList
+	// WastePercent is the share of last week's consumption logs marked
+	// wasted; nil without logs
+	WastePercent *float64
+	// XPEarned is the XP gained since the previous digest of the same
+	// frequency; nil for the first one
+	XPEarned *int
+	TotalXP  int
+}
+
+// SurplusItem is a restaurant surplus item awaiting pickup
+type SurplusItem struct {
+	Title    string
+	Quantity float64
+	Unit     string
+}
+
+// RestaurantDigest summarizes a restaurant's stock and donations
+type RestaurantDigest struct {
+	// Expiring is stock expiring within 48 hours
+	Expiring       ExpiringList
+	PendingSurplus []SurplusItem
+	SurplusCount   int
+	// DonationsLogged and MealsDonated cover the digest period
+	DonationsLogged int
+	MealsDonated    int
+}
+
+// Offer is a pending donation offer
+type Offer struct {
+	Title     string
+	DonorName string
+	WeightKg  float64
+	ExpiresAt time.Time
+}
+
+// Pickup is a scheduled pickup
+type Pickup struct {
+	OfferTitle    string
+	ScheduledFor  time.Time
+	VolunteerName string
+}
+
+// Feedback is a feedback entry
+type Feedback struct {
+	PartnerName string
+	Rating      *int
+	Comment     string
+}
+
+// NGODigest summarizes an NGO's offers, pickups and feedback
+type NGODigest struct {
+	PendingOffers []Offer
+	OfferCount    int
+	// Pickups are tomorrow's (UTC) scheduled pickups
+	Pickups     []Pickup
+	PickupCount int
+	// NewFeedback was received during the digest period
+	NewFeedback   []Feedback
+	FeedbackCount int
+}
+
+// Digest is the data the templates render
+type Digest struct {
+	Name      string
+	Frequency string
+	// PeriodStart and PeriodEnd bound the activity reported
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	UnsubscribeURL string
+	Family         *FamilyDigest
+	Restaurant     *RestaurantDigest
+	NGO            *NGODigest
+}
+
+// Empty reports whether the digest has nothing to tell
+func (d *Digest) Empty() bool {
+	switch {
+	case d.Family != nil:
+		f := d.Family
+		return f.Expiring.Count == 0 && f.WastePercent == nil && (f.XPEarned == nil || *f.XPEarned == 0)
+	case d.Restaurant != nil:
+		r := d.Restaurant
+		return r.Expiring.Count == 0 && r.SurplusCount == 0 && r.DonationsLogged == 0
+	case d.NGO != nil:
+		n := d.NGO
+		return n.OfferCount == 0 && n.PickupCount == 0 && n.FeedbackCount == 0
+	}
+	return true
+}
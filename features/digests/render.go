@@ -0,0 +1,66 @@
+package digests
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"foodlink_backend/mailer"
+	htmltemplate "html/template"
+	"strconv"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// templateFuncs format values the same way in the HTML and text templates
+var templateFuncs = map[string]interface{}{
+	"date": func(t time.Time) string {
+		return t.UTC().Format("Mon 2 Jan")
+	},
+	"datetime": func(t time.Time) string {
+		return t.UTC().Format("Mon 2 Jan 15:04 UTC")
+	},
+	"quantity": func(q float64, unit string) string {
+		s := strconv.FormatFloat(q, 'f', -1, 64)
+		if unit != "" {
+			s += " " + unit
+		}
+		return s
+	},
+	"percent": func(p *float64) string {
+		return fmt.Sprintf("%.0f%%", *p)
+	},
+	"minus": func(a, b int) int {
+		return a - b
+	},
+}
+
+// Templates are parsed at startup so a broken template fails fast. Each
+// role has <role>.html and <role>.txt; layout files hold the shared parts.
+var (
+	htmlTemplates = htmltemplate.Must(htmltemplate.New("digests").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.html"))
+	textTemplates = texttemplate.Must(texttemplate.New("digests").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.txt"))
+)
+
+// Render builds the email of a digest for the role
+func Render(role, to string, d *Digest) (*mailer.Message, error) {
+	var html, text bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&html, role+".html", d); err != nil {
+		return nil, fmt.Errorf("failed to render %s digest: %w", role, err)
+	}
+	if err := textTemplates.ExecuteTemplate(&text, role+".txt", d); err != nil {
+		return nil, fmt.Errorf("failed to render %s digest: %w", role, err)
+	}
+	return &mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("Your %s Foodlink digest", d.Frequency),
+		Text:    text.String(),
+		HTML:    html.String(),
+		Headers: map[string]string{
+			"List-Unsubscribe":      "<" + d.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		},
+	}, nil
+}
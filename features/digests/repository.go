@@ -0,0 +1,407 @@
+package digests
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
+// GetSubscriptions returns the user's subscriptions, falling back to the
+// role's defaults
+func (r *Repository) GetSubscriptions(userID uuid.UUID, role string) (*Subscriptions, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	subs := &Subscriptions{Daily: isDefault(FrequencyDaily, role), Weekly: isDefault(FrequencyWeekly, role)}
+	rows, err := r.conn().Query(`SELECT frequency, subscribed FROM email_digest_subscriptions WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var frequency string
+		var subscribed bool
+		if err := rows.Scan(&frequency, &subscribed); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		switch frequency {
+		case FrequencyDaily:
+			subs.Daily = subscribed
+		case FrequencyWeekly:
+			subs.Weekly = subscribed
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return subs, nil
+}
+
+// SetSubscription saves one subscription; it does nothing for a deleted
+// user, whose unsubscribe links may still be followed
+func (r *Repository) SetSubscription(userID uuid.UUID, frequency string, subscribed bool) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `
+		INSERT INTO email_digest_subscriptions (user_id, frequency, subscribed, updated_at)
+		SELECT id, $2, $3, $4 FROM users WHERE id = $1
+		ON CONFLICT (user_id, frequency) DO UPDATE SET subscribed = EXCLUDED.subscribed, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.conn().Exec(query, userID, frequency, subscribed, time.Now()); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// GetDue returns up to limit subscribed users, after afterID in ID order,
+// who have not been sent the digest of the run ending at periodEnd
+func (r *Repository) GetDue(frequency string, periodEnd time.Time, afterID uuid.UUID, limit int) ([]*Recipient, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `
+		SELECT u.id, u.name, u.email, u.role
+		FROM users u
+		LEFT JOIN email_digest_subscriptions s ON s.user_id = u.id AND s.frequency = $1
+		WHERE u.role = ANY($2) AND u.id > $3
+			AND COALESCE(s.subscribed, u.role = ANY($4))
+			AND NOT EXISTS (
+				SELECT 1 FROM email_digest_sends d
+				WHERE d.user_id = u.id AND d.frequency = $1 AND d.period_end = $5
+			)
+		ORDER BY u.id
+		LIMIT $6
+	`
+	roles := pq.Array([]string{RoleFamily, RoleRestaurant, RoleNGO})
+	rows, err := r.conn().Query(query, frequency, roles, afterID, pq.Array(defaultSubscriptions[frequency]), periodEnd, limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var recipients []*Recipient
+	for rows.Next() {
+		rc := &Recipient{}
+		if err := rows.Scan(&rc.UserID, &rc.Name, &rc.Email, &rc.Role); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		recipients = append(recipients, rc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return recipients, nil
+}
+
+// RecordSend marks the digest of the run ending at periodEnd as sent.
+// totalXP is nil for users without XP.
+func (r *Repository) RecordSend(userID uuid.UUID, frequency string, periodEnd time.Time, totalXP *int) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `
+		INSERT INTO email_digest_sends (user_id, frequency, period_end, total_xp)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, frequency, period_end) DO NOTHING
+	`
+	if _, err := r.conn().Exec(query, userID, frequency, periodEnd, totalXP); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// GetTotalXP returns the user's XP, 0 without an XP record
+func (r *Repository) GetTotalXP(userID uuid.UUID) (int, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var total int
+	err := r.conn().QueryRow(`SELECT total_xp FROM user_xp WHERE user_id = $1`, userID).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return total, nil
+}
+
+// GetPreviousXP returns the XP recorded with the user's latest digest of the
+// frequency sent before periodEnd, or nil if there is none
+func (r *Repository) GetPreviousXP(userID uuid.UUID, frequency string, periodEnd time.Time) (*int, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	var total sql.NullInt64
+	query := `
+		SELECT total_xp FROM email_digest_sends
+		WHERE user_id = $1 AND frequency = $2 AND period_end < $3
+		ORDER BY period_end DESC
+		LIMIT 1
+	`
+	err := r.conn().QueryRow(query, userID, frequency, periodEnd).Scan(&total)
+	if err == sql.ErrNoRows || (err == nil && !total.Valid) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	value := int(total.Int64)
+	return &value, nil
+}
+
+// GetExpiringInventory returns household inventory expiring between from and
+// until, soonest first, with the total count
+func (r *Repository) GetExpiringInventory(userID uuid.UUID, from, until time.Time) (ExpiringList, error) {
+	query := `
+		SELECT name, quantity, COALESCE(unit, ''), expiry_date, COUNT(*) OVER ()
+		FROM inventory_items
+		WHERE user_id = $1 AND expiry_date >= $2 AND expiry_date < $3
+		ORDER BY expiry_date
+		LIMIT $4
+	`
+	return r.getExpiring(query, userID, from, until)
+}
+
+// GetExpiringStock returns restaurant stock expiring between from and until,
+// soonest first, with the total count
+func (r *Repository) GetExpiringStock(userID uuid.UUID, from, until time.Time) (ExpiringList, error) {
+	query := `
+		SELECT name, quantity, unit, expiry_date, COUNT(*) OVER ()
+		FROM restaurant_inventory_items
+		WHERE user_id = $1 AND expiry_date >= $2 AND expiry_date < $3
+		ORDER BY expiry_date
+		LIMIT $4
+	`
+	return r.getExpiring(query, userID, from, until)
+}
+
+func (r *Repository) getExpiring(query string, userID uuid.UUID, from, until time.Time) (ExpiringList, error) {
+	var list ExpiringList
+	if r.db == nil {
+		return list, errors.ErrDatabase
+	}
+	rows, err := r.conn().Query(query, userID, from, until, maxListedItems)
+	if err != nil {
+		return list, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item ExpiringItem
+		if err := rows.Scan(&item.Name, &item.Quantity, &item.Unit, &item.ExpiryDate, &list.Count); err != nil {
+			return list, errors.WrapError(err, errors.ErrDatabase)
+		}
+		list.Items = append(list.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return list, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return list, nil
+}
+
+// GetWastePercent returns the share of the user's consumption logs between
+// from and to marked wasted, or nil without logs
+func (r *Repository) GetWastePercent(userID uuid.UUID, from, to time.Time) (*float64, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	var wasted, total int
+	query := `
+		SELECT COUNT(*) FILTER (WHERE was_wasted), COUNT(*)
+		FROM consumption_logs
+		WHERE user_id = $1 AND consumed_at >= $2 AND consumed_at < $3
+	`
+	if err := r.conn().QueryRow(query, userID, from, to).Scan(&wasted, &total); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	percent := float64(wasted) * 100 / float64(total)
+	return &percent, nil
+}
+
+// GetPendingSurplus returns the restaurant's surplus awaiting pickup, oldest
+// first, with the total count
+func (r *Repository) GetPendingSurplus(userID uuid.UUID) ([]SurplusItem, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	query := `
+		SELECT title, quantity, unit, COUNT(*) OVER ()
+		FROM restaurant_surplus_items
+		WHERE user_id = $1 AND status = 'pending'
+		ORDER BY created_at
+		LIMIT $2
+	`
+	rows, err := r.conn().Query(query, userID, maxListedItems)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var items []SurplusItem
+	var count int
+	for rows.Next() {
+		var item SurplusItem
+		if err := rows.Scan(&item.Title, &item.Quantity, &item.Unit, &count); err != nil {
+			return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return items, count, nil
+}
+
+// GetDonationsLogged counts the restaurant's donations logged between from
+// and to and the meals they provided
+func (r *Repository) GetDonationsLogged(userID uuid.UUID, from, to time.Time) (count int, meals int, err error) {
+	if r.db == nil {
+		return 0, 0, errors.ErrDatabase
+	}
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(meals_provided), 0)
+		FROM restaurant_donation_logs
+		WHERE user_id = $1 AND created_at >= $2 AND created_at < $3
+	`
+	if err := r.conn().QueryRow(query, userID, from, to).Scan(&count, &meals); err != nil {
+		return 0, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return count, meals, nil
+}
+
+// GetPendingOffers returns the NGO's open offers, expiring soonest first,
+// with the total count
+func (r *Repository) GetPendingOffers(userID uuid.UUID, now time.Time) ([]Offer, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	query := `
+		SELECT offer_title, donor_name, weight_kg, expires_at, COUNT(*) OVER ()
+		FROM ngo_donation_offers
+		WHERE ngo_user_id = $1 AND status = 'pending' AND expires_at > $2
+		ORDER BY expires_at
+		LIMIT $3
+	`
+	rows, err := r.conn().Query(query, userID, now, maxListedItems)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var offers []Offer
+	var count int
+	for rows.Next() {
+		var o Offer
+		if err := rows.Scan(&o.Title, &o.DonorName, &o.WeightKg, &o.ExpiresAt, &count); err != nil {
+			return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		offers = append(offers, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return offers, count, nil
+}
+
+// GetPickups returns the NGO's scheduled pickups between from and to, in
+// time order, with the total count
+func (r *Repository) GetPickups(userID uuid.UUID, from, to time.Time) ([]Pickup, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	query := `
+		SELECT o.offer_title, p.scheduled_for, p.volunteer_name, COUNT(*) OVER ()
+		FROM ngo_pickup_schedules p
+		JOIN ngo_donation_offers o ON o.id = p.offer_id
+		WHERE o.ngo_user_id = $1 AND p.status = 'scheduled' AND p.scheduled_for >= $2 AND p.scheduled_for < $3
+		ORDER BY p.scheduled_for
+		LIMIT $4
+	`
+	rows, err := r.conn().Query(query, userID, from, to, maxListedItems)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var pickups []Pickup
+	var count int
+	for rows.Next() {
+		var p Pickup
+		if err := rows.Scan(&p.OfferTitle, &p.ScheduledFor, &p.VolunteerName, &count); err != nil {
+			return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		pickups = append(pickups, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return pickups, count, nil
+}
+
+// GetFeedback returns the NGO's feedback received between from and to,
+// newest first, with the total count
+func (r *Repository) GetFeedback(userID uuid.UUID, from, to time.Time) ([]Feedback, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	query := `
+		SELECT partner_name, rating, comment, COUNT(*) OVER ()
+		FROM ngo_feedback_entries
+		WHERE ngo_user_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at DESC
+		LIMIT $4
+	`
+	rows, err := r.conn().Query(query, userID, from, to, maxListedItems)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var entries []Feedback
+	var count int
+	for rows.Next() {
+		var f Feedback
+		var rating sql.NullInt64
+		if err := rows.Scan(&f.PartnerName, &rating, &f.Comment, &count); err != nil {
+			return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		if rating.Valid {
+			value := int(rating.Int64)
+			f.Rating = &value
+		}
+		entries = append(entries, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return entries, count, nil
+}
+
+// isDefault reports whether the role receives the frequency's digest
+// without choosing it
+func isDefault(frequency, role string) bool {
+	for _, r := range defaultSubscriptions[frequency] {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package digests
+
+import (
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the digest routes, mounted under /api/v1. Unsubscribe
+// links are signed and work without signing in.
+func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/digests/unsubscribe", middleware.Handle(handler.Unsubscribe))
+
+	protected := http.NewServeMux()
+	protected.Handle("/digests/subscriptions", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method == http.MethodPut {
+			return handler.UpdateSubscriptions(w, r)
+		}
+		return handler.GetSubscriptions(w, r)
+	}))
+	mux.Handle("/digests/", middleware.Chain(authMiddleware)(protected))
+	return mux
+}
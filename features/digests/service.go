@@ -0,0 +1,241 @@
+package digests
+
+import (
+	"context"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/errors"
+	"foodlink_backend/mailer"
+	"foodlink_backend/metrics"
+	"foodlink_backend/utils"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Digest look-ahead and look-back periods
+const (
+	familyExpiringWithin     = 7 * 24 * time.Hour
+	restaurantExpiringWithin = 48 * time.Hour
+	wastePeriod              = 7 * 24 * time.Hour
+)
+
+type Service struct {
+	repo *Repository
+	cfg  *config.Config
+	mail mailer.Mailer
+}
+
+// NewService creates the digest service sending with mail
+func NewService(cfg *config.Config, mail mailer.Mailer) *Service {
+	return &Service{repo: NewRepository(), cfg: cfg, mail: mail}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx), cfg: s.cfg, mail: s.mail}
+}
+
+func (s *Service) context() context.Context {
+	if s.repo.ctx == nil {
+		return context.Background()
+	}
+	return s.repo.ctx
+}
+
+// secret signs unsubscribe links
+func (s *Service) secret() string {
+	return s.cfg.Auth.JWTSecret
+}
+
+func (s *Service) GetSubscriptions(userID uuid.UUID, role string) (*Subscriptions, error) {
+	return s.repo.GetSubscriptions(userID, role)
+}
+
+// UpdateSubscriptions changes the subscriptions that are set
+func (s *Service) UpdateSubscriptions(userID uuid.UUID, role string, req *UpdateSubscriptionsRequest) (*Subscriptions, error) {
+	if req.Daily != nil {
+		if err := s.repo.SetSubscription(userID, FrequencyDaily, *req.Daily); err != nil {
+			return nil, err
+		}
+	}
+	if req.Weekly != nil {
+		if err := s.repo.SetSubscription(userID, FrequencyWeekly, *req.Weekly); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.GetSubscriptions(userID, role)
+}
+
+// Unsubscribe turns a digest off for the user of a signed unsubscribe link
+func (s *Service) Unsubscribe(req *UnsubscribeRequest) error {
+	if v := utils.ValidateStruct(req); len(v) > 0 {
+		return errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+v[0], utils.ValidationErrors(v))
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	if !VerifyUnsubscribe(s.secret(), userID, req.Frequency, req.Signature) {
+		return errors.NewAppError(errors.ErrForbidden.Code, "Invalid unsubscribe link")
+	}
+	return s.repo.SetSubscription(userID, req.Frequency, false)
+}
+
+// SendDue sends the frequency's digest of the period from..to to every
+// subscribed user not yet sent it. A digest with nothing to report is
+// skipped. Failed sends are counted and reported once all users were tried,
+// so a retry only sends the missing ones.
+func (s *Service) SendDue(frequency string, from, to time.Time) error {
+	if s.mail == nil {
+		return fmt.Errorf("mail is not configured")
+	}
+	ctx := s.context()
+	var after uuid.UUID
+	var sent, failed int
+	for {
+		recipients, err := s.repo.GetDue(frequency, to, after, s.cfg.Digests.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, rc := range recipients {
+			after = rc.UserID
+			ok, err := s.send(rc, frequency, from, to)
+			switch {
+			case err != nil:
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				failed++
+				metrics.EmailDigestsTotal.Inc(frequency, "failed")
+				slog.WarnContext(ctx, "Failed to send email digest", "user_id", rc.UserID, "frequency", frequency, "error", err)
+			case ok:
+				sent++
+				metrics.EmailDigestsTotal.Inc(frequency, "sent")
+			default:
+				metrics.EmailDigestsTotal.Inc(frequency, "empty")
+			}
+		}
+		if len(recipients) < s.cfg.Digests.BatchSize {
+			break
+		}
+	}
+	if sent > 0 {
+		slog.InfoContext(ctx, "Sent email digests", "frequency", frequency, "count", sent)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d %s digests failed to send", failed, frequency)
+	}
+	return nil
+}
+
+// send builds, sends and records one digest, reporting whether it had
+// anything to send
+func (s *Service) send(rc *Recipient, frequency string, from, to time.Time) (bool, error) {
+	d, totalXP, err := s.Build(rc, frequency, from, to)
+	if err != nil {
+		return false, err
+	}
+	if !d.Empty() {
+		msg, err := Render(rc.Role, rc.Email, d)
+		if err != nil {
+			return false, err
+		}
+		if err := s.mail.Send(s.context(), msg); err != nil {
+			return false, err
+		}
+	}
+	// Empty digests are recorded too, so a retry does not query them again
+	// and the XP baseline moves on
+	if err := s.repo.RecordSend(rc.UserID, frequency, to, totalXP); err != nil {
+		return false, err
+	}
+	return !d.Empty(), nil
+}
+
+// Build gathers the digest of the period from..to for the recipient's role.
+// It also returns the user's XP for families, the baseline of their next
+// digest.
+func (s *Service) Build(rc *Recipient, frequency string, from, to time.Time) (*Digest, *int, error) {
+	d := &Digest{
+		Name:           rc.Name,
+		Frequency:      frequency,
+		PeriodStart:    from,
+		PeriodEnd:      to,
+		UnsubscribeURL: unsubscribeURL(s.cfg.Digests.BaseURL, s.secret(), rc.UserID, frequency),
+	}
+	var err error
+	switch rc.Role {
+	case RoleFamily:
+		var total int
+		d.Family, total, err = s.buildFamily(rc.UserID, frequency, to)
+		if err != nil {
+			return nil, nil, err
+		}
+		return d, &total, nil
+	case RoleRestaurant:
+		d.Restaurant, err = s.buildRestaurant(rc.UserID, from, to)
+	case RoleNGO:
+		d.NGO, err = s.buildNGO(rc.UserID, from, to)
+	default:
+		err = fmt.Errorf("no digest for role %q", rc.Role)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, nil, nil
+}
+
+func (s *Service) buildFamily(userID uuid.UUID, frequency string, to time.Time) (*FamilyDigest, int, error) {
+	f := &FamilyDigest{}
+	var err error
+	if f.Expiring, err = s.repo.GetExpiringInventory(userID, to, to.Add(familyExpiringWithin)); err != nil {
+		return nil, 0, err
+	}
+	if f.WastePercent, err = s.repo.GetWastePercent(userID, to.Add(-wastePeriod), to); err != nil {
+		return nil, 0, err
+	}
+	if f.TotalXP, err = s.repo.GetTotalXP(userID); err != nil {
+		return nil, 0, err
+	}
+	previous, err := s.repo.GetPreviousXP(userID, frequency, to)
+	if err != nil {
+		return nil, 0, err
+	}
+	if previous != nil && f.TotalXP >= *previous {
+		earned := f.TotalXP - *previous
+		f.XPEarned = &earned
+	}
+	return f, f.TotalXP, nil
+}
+
+func (s *Service) buildRestaurant(userID uuid.UUID, from, to time.Time) (*RestaurantDigest, error) {
+	r := &RestaurantDigest{}
+	var err error
+	if r.Expiring, err = s.repo.GetExpiringStock(userID, to, to.Add(restaurantExpiringWithin)); err != nil {
+		return nil, err
+	}
+	if r.PendingSurplus, r.SurplusCount, err = s.repo.GetPendingSurplus(userID); err != nil {
+		return nil, err
+	}
+	if r.DonationsLogged, r.MealsDonated, err = s.repo.GetDonationsLogged(userID, from, to); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Service) buildNGO(userID uuid.UUID, from, to time.Time) (*NGODigest, error) {
+	n := &NGODigest{}
+	var err error
+	if n.PendingOffers, n.OfferCount, err = s.repo.GetPendingOffers(userID, to); err != nil {
+		return nil, err
+	}
+	tomorrow := to.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	if n.Pickups, n.PickupCount, err = s.repo.GetPickups(userID, tomorrow, tomorrow.Add(24*time.Hour)); err != nil {
+		return nil, err
+	}
+	if n.NewFeedback, n.FeedbackCount, err = s.repo.GetFeedback(userID, from, to); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
@@ -0,0 +1,40 @@
+package digests
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// unsubscribePurpose keeps unsubscribe signatures apart from other uses of
+// the signing secret
+const unsubscribePurpose = "email-digest-unsubscribe"
+
+// SignUnsubscribe returns the hex HMAC-SHA256 authorizing the user to
+// unsubscribe from the frequency's digest. Links stay valid until the
+// secret changes.
+func SignUnsubscribe(secret string, userID uuid.UUID, frequency string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsubscribePurpose + ":" + userID.String() + ":" + frequency))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyUnsubscribe checks an unsubscribe signature in constant time
+func VerifyUnsubscribe(secret string, userID uuid.UUID, frequency, signature string) bool {
+	expected := SignUnsubscribe(secret, userID, frequency)
+	return hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected))
+}
+
+// unsubscribeURL returns the public link that unsubscribes the user from the
+// frequency's digest
+func unsubscribeURL(baseURL, secret string, userID uuid.UUID, frequency string) string {
+	query := url.Values{}
+	query.Set("user_id", userID.String())
+	query.Set("frequency", frequency)
+	query.Set("signature", SignUnsubscribe(secret, userID, frequency))
+	return strings.TrimSuffix(baseURL, "/") + "/api/v1/digests/unsubscribe?" + query.Encode()
+}
@@ -0,0 +1,173 @@
+package flags
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/utils"
+	"net/http"
+	"strings"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// flagKey returns the key from a /flags/{key} path relative to /api/v1/admin
+func flagKey(r *http.Request) string {
+	return strings.Trim(strings.TrimPrefix(r.URL.Path, "/flags"), "/")
+}
+
+// GetFlags handles GET /api/v1/flags
+// @Summary      Get feature flags
+// @Description  Get every feature flag evaluated for the caller. Anonymous callers get the flags that apply to everyone.
+// @Tags         feature-flags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  EvaluatedFlags
+// @Router       /flags [get]
+func (h *Handler) GetFlags(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	w.Header().Set("Cache-Control", "private, no-store")
+	utils.OKResponse(w, "Feature flags retrieved successfully", h.service.WithContext(r.Context()).Evaluate())
+	return nil
+}
+
+// List handles GET /api/v1/admin/flags
+// @Summary      List feature flags
+// @Description  List every feature flag with its targeting rules (admin only)
+// @Tags         feature-flags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   featureflags.Flag
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Router       /admin/flags [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	flags, err := h.service.WithContext(r.Context()).List()
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve feature flags")
+	}
+	utils.OKResponse(w, "Feature flags retrieved successfully", flags)
+	return nil
+}
+
+// Get handles GET /api/v1/admin/flags/:key
+// @Summary      Get feature flag
+// @Description  Get a feature flag with its targeting rules (admin only)
+// @Tags         feature-flags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key  path      string  true  "Flag key"
+// @Success      200  {object}  featureflags.Flag
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /admin/flags/{key} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	flag, err := h.service.WithContext(r.Context()).Get(flagKey(r))
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve feature flag")
+	}
+	utils.OKResponse(w, "Feature flag retrieved successfully", flag)
+	return nil
+}
+
+// Create handles POST /api/v1/admin/flags
+// @Summary      Create feature flag
+// @Description  Create a feature flag. Percentage defaults to 100; empty role, organization and environment lists match everyone (admin only).
+// @Tags         feature-flags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateFlagRequest  true  "Feature flag"
+// @Success      201      {object}  featureflags.Flag
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      409      {object}  errors.Problem
+// @Router       /admin/flags [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	var req CreateFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	flag, err := h.service.WithContext(r.Context()).Create(&req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create feature flag")
+	}
+	utils.CreatedResponse(w, "Feature flag created successfully", flag)
+	return nil
+}
+
+// Update handles PUT /api/v1/admin/flags/:key
+// @Summary      Update feature flag
+// @Description  Replace a feature flag's settings and targeting rules (admin only)
+// @Tags         feature-flags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key      path      string             true  "Flag key"
+// @Param        request  body      UpdateFlagRequest  true  "Feature flag"
+// @Success      200      {object}  featureflags.Flag
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Router       /admin/flags/{key} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	var req UpdateFlagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	flag, err := h.service.WithContext(r.Context()).Update(flagKey(r), &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update feature flag")
+	}
+	utils.OKResponse(w, "Feature flag updated successfully", flag)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/admin/flags/:key
+// @Summary      Delete feature flag
+// @Description  Delete a feature flag; it evaluates as off afterwards (admin only)
+// @Tags         feature-flags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key  path      string  true  "Flag key"
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /admin/flags/{key} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	if err := h.service.WithContext(r.Context()).Delete(flagKey(r)); err != nil {
+		return errors.Wrap(err, "Failed to delete feature flag")
+	}
+	utils.OKResponse(w, "Feature flag deleted successfully", nil)
+	return nil
+}
@@ -0,0 +1,27 @@
+package flags
+
+// CreateFlagRequest represents a request to create a feature flag
+type CreateFlagRequest struct {
+	Key          string   `json:"key" validate:"required,max=100" example:"shop_module"`
+	Description  string   `json:"description" validate:"max=500" example:"Expose the shop API"`
+	Enabled      bool     `json:"enabled" example:"true"`
+	Roles        []string `json:"roles" validate:"dive,oneof=family restaurant shop ngo admin" example:"shop,admin"`
+	OrgIDs       []string `json:"org_ids" validate:"dive,uuid"`
+	Percentage   *int     `json:"percentage,omitempty" validate:"omitempty,min=0,max=100" example:"100"`
+	Environments []string `json:"environments" validate:"dive,oneof=development staging production test" example:"staging"`
+}
+
+// UpdateFlagRequest replaces a feature flag's settings
+type UpdateFlagRequest struct {
+	Description  string   `json:"description" validate:"max=500" example:"Expose the shop API"`
+	Enabled      bool     `json:"enabled" example:"true"`
+	Roles        []string `json:"roles" validate:"dive,oneof=family restaurant shop ngo admin" example:"shop,admin"`
+	OrgIDs       []string `json:"org_ids" validate:"dive,uuid"`
+	Percentage   *int     `json:"percentage,omitempty" validate:"omitempty,min=0,max=100" example:"100"`
+	Environments []string `json:"environments" validate:"dive,oneof=development staging production test" example:"staging"`
+}
+
+// EvaluatedFlags is the set of flags evaluated for the caller
+type EvaluatedFlags struct {
+	Flags map[string]bool `json:"flags"`
+}
@@ -0,0 +1,45 @@
+package flags
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the client-facing flag routes, mounted under /api/v1
+func SetupRoutes(handler *Handler, optionalAuth func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/flags", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		if r.Method == http.MethodGet {
+			return handler.GetFlags(w, r)
+		} else {
+			return errors.ErrMethodNotAllowed
+		}
+	}))
+	return middleware.Chain(optionalAuth)(mux)
+}
+
+// SetupAdminRoutes sets up flag management routes, mounted under /api/v1/admin
+func SetupAdminRoutes(handler *Handler, authMiddleware, requireAdmin func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		key := flagKey(r)
+		switch {
+		case key == "" && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case key == "" && r.Method == http.MethodPost:
+			return handler.Create(w, r)
+		case key != "" && r.Method == http.MethodGet:
+			return handler.Get(w, r)
+		case key != "" && r.Method == http.MethodPut:
+			return handler.Update(w, r)
+		case key != "" && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/flags", routes)
+	mux.Handle("/flags/", routes)
+	return middleware.Chain(authMiddleware, requireAdmin)(mux)
+}
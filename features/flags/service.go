@@ -0,0 +1,127 @@
+package flags
+
+import (
+	"context"
+	"foodlink_backend/errors"
+	"foodlink_backend/featureflags"
+	"foodlink_backend/utils"
+	"log/slog"
+	"regexp"
+)
+
+// keyPattern restricts flag keys to lower snake case
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Service manages feature flags and evaluates them for callers
+type Service struct {
+	store  featureflags.Store
+	client *featureflags.Client
+	ctx    context.Context
+}
+
+// NewService creates a flags service. Changes are applied to client right
+// away; other instances pick them up on their next refresh.
+func NewService(store featureflags.Store, client *featureflags.Client) *Service {
+	return &Service{store: store, client: client, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the service whose queries run with ctx
+func (s *Service) WithContext(ctx context.Context) *Service {
+	scoped := *s
+	scoped.ctx = ctx
+	return &scoped
+}
+
+// List returns every stored flag
+func (s *Service) List() ([]featureflags.Flag, error) {
+	flags, err := s.store.List(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if flags == nil {
+		flags = []featureflags.Flag{}
+	}
+	return flags, nil
+}
+
+// Get returns the flag key
+func (s *Service) Get(key string) (*featureflags.Flag, error) {
+	return s.store.Get(s.ctx, key)
+}
+
+// Create stores a new flag
+func (s *Service) Create(req *CreateFlagRequest) (*featureflags.Flag, error) {
+	validationErrors := utils.ValidateStruct(req)
+	if req.Key != "" && !keyPattern.MatchString(req.Key) {
+		validationErrors = append(validationErrors, "key must be lower snake case, e.g. shop_module")
+	}
+	if len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+
+	flag := &featureflags.Flag{
+		Key:          req.Key,
+		Description:  req.Description,
+		Enabled:      req.Enabled,
+		Roles:        req.Roles,
+		OrgIDs:       req.OrgIDs,
+		Percentage:   percentage(req.Percentage),
+		Environments: req.Environments,
+	}
+	if err := s.store.Create(s.ctx, flag); err != nil {
+		return nil, err
+	}
+	s.refresh()
+	return flag, nil
+}
+
+// Update replaces the settings of the flag key
+func (s *Service) Update(key string, req *UpdateFlagRequest) (*featureflags.Flag, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+
+	flag := &featureflags.Flag{
+		Key:          key,
+		Description:  req.Description,
+		Enabled:      req.Enabled,
+		Roles:        req.Roles,
+		OrgIDs:       req.OrgIDs,
+		Percentage:   percentage(req.Percentage),
+		Environments: req.Environments,
+	}
+	if err := s.store.Update(s.ctx, flag); err != nil {
+		return nil, err
+	}
+	s.refresh()
+	return flag, nil
+}
+
+// Delete removes the flag key, turning it off
+func (s *Service) Delete(key string) error {
+	if err := s.store.Delete(s.ctx, key); err != nil {
+		return err
+	}
+	s.refresh()
+	return nil
+}
+
+// Evaluate returns every flag evaluated for the subject in the context
+func (s *Service) Evaluate() *EvaluatedFlags {
+	return &EvaluatedFlags{Flags: s.client.All(s.ctx)}
+}
+
+// refresh reloads the local flag cache after a change
+func (s *Service) refresh() {
+	if err := s.client.Refresh(s.ctx); err != nil {
+		slog.WarnContext(s.ctx, "Failed to refresh feature flags after change", "error", err)
+	}
+}
+
+// percentage defaults an omitted rollout percentage to everyone
+func percentage(p *int) int {
+	if p == nil {
+		return 100
+	}
+	return *p
+}
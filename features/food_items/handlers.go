@@ -30,23 +30,18 @@ func NewHandler(service *Service) *Handler {
 // @Produce      json
 // @Success      200  {array}   FoodItem
 // @Router       /food-items [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
-	items, err := h.service.GetAll()
+	items, err := h.service.WithContext(r.Context()).GetAll()
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve food items", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve food items")
 	}
 
 	utils.OKResponse(w, "Food items retrieved successfully", items)
+	return nil
 }
 
 // GetByID handles GET /api/v1/food-items/:id
@@ -57,32 +52,26 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      string  true  "Food Item ID"
 // @Success      200  {object}  FoodItem
-// @Failure      404  {object}  errors.AppError
+// @Failure      404  {object}  errors.Problem
 // @Router       /food-items/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/food-items/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 
-	item, err := h.service.GetByID(id)
+	item, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve food item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve food item")
 	}
 
 	utils.OKResponse(w, "Food item retrieved successfully", item)
+	return nil
 }
 
 // Create handles POST /api/v1/food-items
@@ -93,31 +82,25 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        request  body      CreateFoodItemRequest  true  "Food item data"
 // @Success      201      {object}  FoodItem
-// @Failure      400      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
 // @Router       /food-items [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	var req CreateFoodItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 
-	item, err := h.service.Create(&req)
+	item, err := h.service.WithContext(r.Context()).Create(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create food item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create food item")
 	}
 
 	utils.CreatedResponse(w, "Food item created successfully", item)
+	return nil
 }
 
 // Update handles PUT /api/v1/food-items/:id
@@ -129,39 +112,32 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                  true  "Food Item ID"
 // @Param        request  body      UpdateFoodItemRequest   true  "Food item data"
 // @Success      200      {object}  FoodItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /food-items/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/food-items/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 
 	var req UpdateFoodItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 
-	item, err := h.service.Update(id, &req)
+	item, err := h.service.WithContext(r.Context()).Update(id, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update food item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update food item")
 	}
 
 	utils.OKResponse(w, "Food item updated successfully", item)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/food-items/:id
@@ -172,29 +148,23 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      string  true  "Food Item ID"
 // @Success      200  {object}  map[string]string
-// @Failure      404  {object}  errors.AppError
+// @Failure      404  {object}  errors.Problem
 // @Router       /food-items/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/food-items/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 
-	if err := h.service.Delete(id); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete food item", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id); err != nil {
+		return errors.Wrap(err, "Failed to delete food item")
 	}
 
 	utils.OKResponse(w, "Food item deleted successfully", nil)
+	return nil
 }
@@ -1,6 +1,7 @@
 package food_items
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -11,7 +12,8 @@ import (
 
 // Repository handles database operations for food items
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 // NewRepository creates a new food items repository
@@ -21,6 +23,16 @@ func NewRepository() *Repository {
 	}
 }
 
+// WithContext returns a copy of the repository that runs its queries with ctx
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+// conn returns the connection pool bound to the repository context
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 // GetAll retrieves all food items
 func (r *Repository) GetAll() ([]*FoodItem, error) {
 	if r.db == nil {
@@ -33,7 +45,7 @@ func (r *Repository) GetAll() ([]*FoodItem, error) {
 		ORDER BY name
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.conn().Query(query)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -73,7 +85,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*FoodItem, error) {
 		WHERE id = $1
 	`
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.conn().QueryRow(query, id).Scan(
 		&item.ID,
 		&item.Name,
 		&item.Category,
@@ -106,7 +118,7 @@ func (r *Repository) Create(item *FoodItem) error {
 	`
 
 	now := time.Now()
-	err := r.db.QueryRow(
+	err := r.conn().QueryRow(
 		query,
 		item.ID,
 		item.Name,
@@ -145,7 +157,7 @@ func (r *Repository) Update(item *FoodItem) error {
 		RETURNING id, name, category, typical_expiry_days, storage_tips, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(
+	err := r.conn().QueryRow(
 		query,
 		item.Name,
 		item.Category,
@@ -180,7 +192,7 @@ func (r *Repository) Delete(id uuid.UUID) error {
 	}
 
 	query := `DELETE FROM food_items WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	result, err := r.conn().Exec(query, id)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -1,6 +1,8 @@
 package food_items
 
 import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
 	"net/http"
 )
 
@@ -8,24 +10,24 @@ import (
 func SetupRoutes(handler *Handler) http.Handler {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		switch r.Method {
 		case http.MethodGet:
 			if r.URL.Path == "/" {
-				handler.GetAll(w, r)
+				return handler.GetAll(w, r)
 			} else {
-				handler.GetByID(w, r)
+				return handler.GetByID(w, r)
 			}
 		case http.MethodPost:
-			handler.Create(w, r)
+			return handler.Create(w, r)
 		case http.MethodPut:
-			handler.Update(w, r)
+			return handler.Update(w, r)
 		case http.MethodDelete:
-			handler.Delete(w, r)
+			return handler.Delete(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 
 	return mux
 }
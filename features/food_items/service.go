@@ -1,6 +1,7 @@
 package food_items
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -19,6 +20,11 @@ func NewService() *Service {
 	}
 }
 
+// WithContext returns a copy of the service whose queries run with ctx
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 // GetAll retrieves all food items
 func (s *Service) GetAll() ([]*FoodItem, error) {
 	return s.repo.GetAll()
@@ -35,7 +41,7 @@ func (s *Service) Create(req *CreateFoodItemRequest) (*FoodItem, error) {
 		return nil, errors.NewAppErrorWithErr(
 			errors.ErrValidationFailed.Code,
 			"Validation failed: "+validationErrors[0],
-			nil,
+			utils.ValidationErrors(validationErrors),
 		)
 	}
 
@@ -65,7 +71,7 @@ func (s *Service) Update(id uuid.UUID, req *UpdateFoodItemRequest) (*FoodItem, e
 		return nil, errors.NewAppErrorWithErr(
 			errors.ErrValidationFailed.Code,
 			"Validation failed: "+validationErrors[0],
-			nil,
+			utils.ValidationErrors(validationErrors),
 		)
 	}
 
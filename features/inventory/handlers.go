@@ -5,6 +5,7 @@ import (
 	"foodlink_backend/errors"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/utils"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -41,31 +42,25 @@ func (h *Handler) getUserIDFromContext(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   InventoryItem
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /inventory [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	userID, err := h.getUserIDFromContext(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 
-	items, err := h.service.GetAllByUserID(userID)
+	items, err := h.service.WithContext(r.Context()).GetAllByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve inventory", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve inventory")
 	}
 
 	utils.OKResponse(w, "Inventory retrieved successfully", items)
+	return nil
 }
 
 // GetByID handles GET /api/v1/inventory/:id
@@ -77,33 +72,27 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Inventory Item ID"
 // @Success      200  {object}  InventoryItem
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /inventory/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/inventory/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 
-	item, err := h.service.GetByID(id)
+	item, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve inventory item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve inventory item")
 	}
 
 	utils.OKResponse(w, "Inventory item retrieved successfully", item)
+	return nil
 }
 
 // Create handles POST /api/v1/inventory
@@ -115,38 +104,31 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateInventoryItemRequest  true  "Inventory item data"
 // @Success      201      {object}  InventoryItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /inventory [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	userID, err := h.getUserIDFromContext(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 
 	var req CreateInventoryItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 
-	item, err := h.service.Create(userID, &req)
+	item, err := h.service.WithContext(r.Context()).Create(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create inventory item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create inventory item")
 	}
 
 	utils.CreatedResponse(w, "Inventory item created successfully", item)
+	return nil
 }
 
 // Update handles PUT /api/v1/inventory/:id
@@ -159,47 +141,89 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                    true  "Inventory Item ID"
 // @Param        request  body      UpdateInventoryItemRequest true  "Inventory item data"
 // @Success      200      {object}  InventoryItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /inventory/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	userID, err := h.getUserIDFromContext(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/inventory/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 
 	var req UpdateInventoryItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 
-	item, err := h.service.Update(id, userID, &req)
+	item, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update inventory item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update inventory item")
+	}
+
+	utils.OKResponse(w, "Inventory item updated successfully", item)
+	return nil
+}
+
+// Patch handles PATCH /api/v1/inventory/:id
+// @Summary      Patch inventory item
+// @Description  Partially update an inventory item with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.
+// @Tags         inventory
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                     true  "Inventory Item ID"
+// @Param        request  body      PatchInventoryItemRequest  true  "Merge patch document"
+// @Success      200      {object}  InventoryItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Failure      415      {object}  errors.Problem
+// @Router       /inventory/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+
+	userID, err := h.getUserIDFromContext(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/inventory/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+
+	item, err := h.service.WithContext(r.Context()).Patch(id, userID, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update inventory item")
 	}
 
 	utils.OKResponse(w, "Inventory item updated successfully", item)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/inventory/:id
@@ -211,39 +235,32 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Inventory Item ID"
 // @Success      200  {object}  map[string]string
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /inventory/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	userID, err := h.getUserIDFromContext(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/inventory/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 
-	if err := h.service.Delete(id, userID); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete inventory item", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete inventory item")
 	}
 
 	utils.OKResponse(w, "Inventory item deleted successfully", nil)
+	return nil
 }
 
 // GetExpiring handles GET /api/v1/inventory/expiring
@@ -255,18 +272,16 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        days  query     int  false  "Number of days (default: 7)"
 // @Success      200   {array}   InventoryItem
-// @Failure      401   {object}  errors.AppError
+// @Failure      401   {object}  errors.Problem
 // @Router       /inventory/expiring [get]
-func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	userID, err := h.getUserIDFromContext(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 
 	days := 7 // default
@@ -276,17 +291,13 @@ func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	items, err := h.service.GetExpiring(userID, days)
+	items, err := h.service.WithContext(r.Context()).GetExpiring(userID, days)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve expiring items", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve expiring items")
 	}
 
 	utils.OKResponse(w, "Expiring items retrieved successfully", items)
+	return nil
 }
 
 // GetExpired handles GET /api/v1/inventory/expired
@@ -297,29 +308,57 @@ func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   InventoryItem
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /inventory/expired [get]
-func (h *Handler) GetExpired(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetExpired(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	userID, err := h.getUserIDFromContext(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 
-	items, err := h.service.GetExpired(userID)
+	items, err := h.service.WithContext(r.Context()).GetExpired(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve expired items", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve expired items")
 	}
 
 	utils.OKResponse(w, "Expired items retrieved successfully", items)
+	return nil
+}
+
+// Batch handles POST /api/v1/inventory/batch
+// @Summary      Batch create, update and delete inventory items
+// @Description  Apply up to 500 create, update and delete operations to the authenticated user's inventory. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.
+// @Tags         inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      utils.BatchRequest  true  "Batch operations"
+// @Success      200      {object}  utils.BatchResult
+// @Success      207      {object}  utils.BatchResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      422      {object}  utils.BatchResult
+// @Router       /inventory/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserIDFromContext(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req utils.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Batch(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to process batch")
+	}
+	utils.BatchResponse(w, result)
+	return nil
 }
@@ -0,0 +1,44 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/events"
+	"foodlink_backend/jobs"
+	"time"
+)
+
+// expiredLookback is how far back the first run looks for expired items
+const expiredLookback = 24 * time.Hour
+
+// RegisterJobs registers the inventory background jobs
+func RegisterJobs(scheduler *jobs.Scheduler) {
+	scheduler.Schedule("inventory.publish_expired", "*/15 * * * *", publishExpired)
+}
+
+// publishExpired publishes InventoryExpired for items that expired since the
+// previous run
+func publishExpired(ctx context.Context, job *jobs.Job) error {
+	from, to := job.Window(expiredLookback)
+	repo := NewRepository().WithContext(ctx)
+	return database.WithTransaction(ctx, repo.db, func(tx *sql.Tx) error {
+		items, err := repo.WithTx(tx).GetExpiredBetween(from, to)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err := events.Publish(ctx, tx, events.InventoryExpired{
+				ItemID:    item.ID,
+				OwnerID:   item.UserID,
+				Source:    events.InventoryFamily,
+				Name:      item.Name,
+				ExpiredAt: *item.ExpiryDate,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
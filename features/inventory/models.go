@@ -42,3 +42,15 @@ type UpdateInventoryItemRequest struct {
 	Location   string     `json:"location,omitempty" validate:"omitempty,max=100"`
 	FoodItemID *uuid.UUID `json:"food_item_id,omitempty"`
 }
+
+// PatchInventoryItemRequest holds the mutable fields of an inventory item.
+// PATCH requests merge a JSON Merge Patch document into it and validate the result.
+type PatchInventoryItemRequest struct {
+	Name       string     `json:"name" validate:"required,min=1,max=255"`
+	Quantity   float64    `json:"quantity" validate:"required,gt=0"`
+	Unit       string     `json:"unit,omitempty" validate:"omitempty,max=50"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty"`
+	Category   string     `json:"category,omitempty" validate:"omitempty,max=100"`
+	Location   string     `json:"location,omitempty" validate:"omitempty,max=100"`
+	FoodItemID *uuid.UUID `json:"food_item_id,omitempty"`
+}
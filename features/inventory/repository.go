@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -11,7 +12,9 @@ import (
 
 // Repository handles database operations for inventory
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 // NewRepository creates a new inventory repository
@@ -21,6 +24,22 @@ func NewRepository() *Repository {
 	}
 }
 
+// WithContext returns a copy of the repository that runs its queries with ctx
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+// conn returns the active transaction, or the connection pool when there is none,
+// bound to the repository context
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 // GetAllByUserID retrieves all inventory items for a user
 func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*InventoryItem, error) {
 	if r.db == nil {
@@ -34,7 +53,7 @@ func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*InventoryItem, error)
 		ORDER BY expiry_date NULLS LAST, created_at DESC
 	`
 
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -78,7 +97,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*InventoryItem, error) {
 		WHERE id = $1
 	`
 
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.conn().QueryRow(query, id).Scan(
 		&item.ID,
 		&item.UserID,
 		&item.Name,
@@ -115,7 +134,7 @@ func (r *Repository) Create(item *InventoryItem) error {
 	`
 
 	now := time.Now()
-	err := r.db.QueryRow(
+	err := r.conn().QueryRow(
 		query,
 		item.ID,
 		item.UserID,
@@ -162,7 +181,7 @@ func (r *Repository) Update(item *InventoryItem) error {
 		RETURNING id, user_id, name, quantity, unit, expiry_date, category, location, food_item_id, created_at, updated_at
 	`
 
-	err := r.db.QueryRow(
+	err := r.conn().QueryRow(
 		query,
 		item.Name,
 		item.Quantity,
@@ -204,7 +223,7 @@ func (r *Repository) Delete(id uuid.UUID) error {
 	}
 
 	query := `DELETE FROM inventory_items WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+	result, err := r.conn().Exec(query, id)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -236,7 +255,7 @@ func (r *Repository) GetExpiring(userID uuid.UUID, days int) ([]*InventoryItem,
 		ORDER BY expiry_date ASC
 	`
 
-	rows, err := r.db.Query(query, userID, days)
+	rows, err := r.conn().Query(query, userID, days)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -282,7 +301,7 @@ func (r *Repository) GetExpired(userID uuid.UUID) ([]*InventoryItem, error) {
 		ORDER BY expiry_date ASC
 	`
 
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -312,3 +331,26 @@ func (r *Repository) GetExpired(userID uuid.UUID) ([]*InventoryItem, error) {
 
 	return items, nil
 }
+
+// GetExpiredBetween returns the items whose expiry date falls in (from, to],
+// with only their ID, owner, name and expiry date set
+func (r *Repository) GetExpiredBetween(from, to time.Time) ([]*InventoryItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT id, user_id, name, expiry_date FROM inventory_items WHERE expiry_date > $1 AND expiry_date <= $2 ORDER BY expiry_date`
+	rows, err := r.conn().Query(query, from, to)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var items []*InventoryItem
+	for rows.Next() {
+		item := &InventoryItem{}
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.ExpiryDate); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
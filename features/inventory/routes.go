@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 	"strings"
@@ -10,39 +11,42 @@ import (
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		path := r.URL.Path
 		
 		switch {
 		case path == "/" && r.Method == http.MethodGet:
-			handler.GetAll(w, r)
+			return handler.GetAll(w, r)
 		case path == "/expiring" && r.Method == http.MethodGet:
-			handler.GetExpiring(w, r)
+			return handler.GetExpiring(w, r)
 		case path == "/expired" && r.Method == http.MethodGet:
-			handler.GetExpired(w, r)
+			return handler.GetExpired(w, r)
+		case path == "/batch" && r.Method == http.MethodPost:
+			return handler.Batch(w, r)
 		case strings.HasPrefix(path, "/") && len(path) > 1:
 			idPath := strings.TrimPrefix(path, "/")
 			// Check if it's a UUID (not a special path)
 			if len(idPath) == 36 { // UUID length
 				switch r.Method {
 				case http.MethodGet:
-					handler.GetByID(w, r)
+					return handler.GetByID(w, r)
 				case http.MethodPut:
-					handler.Update(w, r)
+					return handler.Update(w, r)
+				case http.MethodPatch:
+					return handler.Patch(w, r)
 				case http.MethodDelete:
-					handler.Delete(w, r)
+					return handler.Delete(w, r)
 				default:
-					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					return errors.ErrMethodNotAllowed
 				}
-			} else {
-				http.NotFound(w, r)
 			}
+			return errors.ErrNotFound
 		case r.Method == http.MethodPost:
-			handler.Create(w, r)
+			return handler.Create(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 
 	return middleware.Chain(authMiddleware)(mux)
 }
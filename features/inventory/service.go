@@ -1,6 +1,8 @@
 package inventory
 
 import (
+	"context"
+	"database/sql"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -19,6 +21,11 @@ func NewService() *Service {
 	}
 }
 
+// WithContext returns a copy of the service whose queries run with ctx
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 // GetAllByUserID retrieves all inventory items for a user
 func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*InventoryItem, error) {
 	return s.repo.GetAllByUserID(userID)
@@ -35,7 +42,7 @@ func (s *Service) Create(userID uuid.UUID, req *CreateInventoryItemRequest) (*In
 		return nil, errors.NewAppErrorWithErr(
 			errors.ErrValidationFailed.Code,
 			"Validation failed: "+validationErrors[0],
-			nil,
+			utils.ValidationErrors(validationErrors),
 		)
 	}
 
@@ -74,7 +81,7 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateInventoryIte
 		return nil, errors.NewAppErrorWithErr(
 			errors.ErrValidationFailed.Code,
 			"Validation failed: "+validationErrors[0],
-			nil,
+			utils.ValidationErrors(validationErrors),
 		)
 	}
 
@@ -107,6 +114,54 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateInventoryIte
 	return item, nil
 }
 
+// Patch applies a JSON Merge Patch document to an inventory item
+func (s *Service) Patch(id uuid.UUID, userID uuid.UUID, patch []byte) (*InventoryItem, error) {
+	item, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check ownership
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+
+	doc := &PatchInventoryItemRequest{
+		Name:       item.Name,
+		Quantity:   item.Quantity,
+		Unit:       item.Unit,
+		ExpiryDate: item.ExpiryDate,
+		Category:   item.Category,
+		Location:   item.Location,
+		FoodItemID: item.FoodItemID,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(
+			errors.ErrValidationFailed.Code,
+			"Validation failed: "+validationErrors[0],
+			utils.ValidationErrors(validationErrors),
+		)
+	}
+
+	item.Name = doc.Name
+	item.Quantity = doc.Quantity
+	item.Unit = doc.Unit
+	item.ExpiryDate = doc.ExpiryDate
+	item.Category = doc.Category
+	item.Location = doc.Location
+	item.FoodItemID = doc.FoodItemID
+
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
 // Delete deletes an inventory item
 func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	item, err := s.repo.GetByID(id)
@@ -122,6 +177,29 @@ func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	return s.repo.Delete(id)
 }
 
+// Batch applies a list of create, update and delete operations to the user's inventory items
+func (s *Service) Batch(userID uuid.UUID, req *utils.BatchRequest) (*utils.BatchResult, error) {
+	return utils.RunBatch(s.repo.ctx, s.repo.db, req, func(tx *sql.Tx, op *utils.BatchOperation) (interface{}, error) {
+		txService := &Service{repo: s.repo.WithTx(tx)}
+		switch op.Op {
+		case utils.BatchOpCreate:
+			var createReq CreateInventoryItemRequest
+			if err := op.Decode(&createReq); err != nil {
+				return nil, err
+			}
+			return txService.Create(userID, &createReq)
+		case utils.BatchOpUpdate:
+			var updateReq UpdateInventoryItemRequest
+			if err := op.Decode(&updateReq); err != nil {
+				return nil, err
+			}
+			return txService.Update(*op.ID, userID, &updateReq)
+		default:
+			return nil, txService.Delete(*op.ID, userID)
+		}
+	})
+}
+
 // GetExpiring retrieves items expiring within specified days (default 7)
 func (s *Service) GetExpiring(userID uuid.UUID, days int) ([]*InventoryItem, error) {
 	if days <= 0 {
@@ -0,0 +1,151 @@
+package jobruns
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// pathParts splits a /jobs/... path relative to /api/v1/admin
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/jobs"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// Overview handles GET /api/v1/admin/jobs
+// @Summary      List background jobs
+// @Description  List the registered background jobs with their cron schedule, next fire time and latest run, and whether the answering instance is the scheduler leader (admin only)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  Overview
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Router       /admin/jobs [get]
+func (h *Handler) Overview(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	overview, err := h.service.WithContext(r.Context()).Overview()
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve jobs")
+	}
+	utils.OKResponse(w, "Jobs retrieved successfully", overview)
+	return nil
+}
+
+// ListRuns handles GET /api/v1/admin/jobs/runs
+// @Summary      List job runs
+// @Description  List background job runs, most recent first. Use status=failed for runs that used up their attempts (admin only).
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "Run status"  Enums(pending, running, succeeded, failed)
+// @Param        name    query     string  false  "Job name, e.g. ngo_offers.expire_offers"
+// @Param        limit   query     int     false  "Number of runs (default: 50, max: 200)"
+// @Success      200     {array}   Run
+// @Failure      400     {object}  errors.Problem
+// @Failure      401     {object}  errors.Problem
+// @Failure      403     {object}  errors.Problem
+// @Router       /admin/jobs/runs [get]
+func (h *Handler) ListRuns(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	filter := RunFilter{
+		Status: r.URL.Query().Get("status"),
+		Name:   r.URL.Query().Get("name"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	runs, err := h.service.WithContext(r.Context()).ListRuns(filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve job runs")
+	}
+	utils.OKResponse(w, "Job runs retrieved successfully", runs)
+	return nil
+}
+
+// RunNow handles POST /api/v1/admin/jobs/:name/run
+// @Summary      Run job now
+// @Description  Enqueue a one-off run of a registered job, due immediately (admin only)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        name  path      string  true  "Job name"
+// @Success      201   {object}  Run
+// @Failure      401   {object}  errors.Problem
+// @Failure      403   {object}  errors.Problem
+// @Failure      404   {object}  errors.Problem
+// @Router       /admin/jobs/{name}/run [post]
+func (h *Handler) RunNow(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	parts := pathParts(r)
+	if len(parts) != 2 || parts[1] != "run" {
+		return errors.ErrInvalidPath
+	}
+	run, err := h.service.WithContext(r.Context()).RunNow(parts[0])
+	if err != nil {
+		return errors.Wrap(err, "Failed to enqueue job")
+	}
+	utils.CreatedResponse(w, "Job enqueued successfully", run)
+	return nil
+}
+
+// Retry handles POST /api/v1/admin/jobs/runs/:id/retry
+// @Summary      Retry failed job run
+// @Description  Requeue a failed run with a fresh set of attempts (admin only)
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Run ID"
+// @Success      200  {object}  Run
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Failure      409  {object}  errors.Problem
+// @Router       /admin/jobs/runs/{id}/retry [post]
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	parts := pathParts(r)
+	if len(parts) != 3 || parts[0] != "runs" || parts[2] != "retry" {
+		return errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	run, err := h.service.WithContext(r.Context()).Retry(id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retry job run")
+	}
+	utils.OKResponse(w, "Job run requeued successfully", run)
+	return nil
+}
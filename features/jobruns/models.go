@@ -0,0 +1,45 @@
+package jobruns
+
+import (
+	"encoding/json"
+	"foodlink_backend/jobs"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Run is one run of a background job
+type Run struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"`
+	ScheduledAt *time.Time      `json:"scheduled_at,omitempty"`
+	LastError   *string         `json:"last_error,omitempty"`
+	StartedAt   *time.Time      `json:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// JobSummary is a registered job with its latest run
+type JobSummary struct {
+	jobs.Definition
+	LastRun *Run `json:"last_run,omitempty"`
+}
+
+// Overview lists the registered jobs as seen by the instance answering
+type Overview struct {
+	// Leader reports whether this instance enqueues scheduled runs
+	Leader bool         `json:"leader"`
+	Jobs   []JobSummary `json:"jobs"`
+}
+
+// RunFilter narrows the runs returned by ListRuns
+type RunFilter struct {
+	Status string
+	Name   string
+	Limit  int
+}
@@ -0,0 +1,118 @@
+package jobruns
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
+const runColumns = `id, name, payload, status, attempts, max_attempts, run_at, scheduled_at, last_error, started_at, finished_at, created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRun(row rowScanner) (*Run, error) {
+	run := &Run{}
+	err := row.Scan(&run.ID, &run.Name, &run.Payload, &run.Status, &run.Attempts, &run.MaxAttempts, &run.RunAt, &run.ScheduledAt, &run.LastError, &run.StartedAt, &run.FinishedAt, &run.CreatedAt)
+	return run, err
+}
+
+// ListRuns returns runs matching filter, most recent first
+func (r *Repository) ListRuns(filter RunFilter) ([]*Run, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + runColumns + ` FROM job_runs
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR name = $2)
+		ORDER BY created_at DESC LIMIT $3`
+	rows, err := r.conn().Query(query, filter.Status, filter.Name, filter.Limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	runs := []*Run{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// LatestRuns returns the most recent run of every job, keyed by name
+func (r *Repository) LatestRuns() (map[string]*Run, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT DISTINCT ON (name) ` + runColumns + ` FROM job_runs ORDER BY name, created_at DESC`
+	rows, err := r.conn().Query(query)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	runs := map[string]*Run{}
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		runs[run.Name] = run
+	}
+	return runs, nil
+}
+
+func (r *Repository) GetRunByID(id uuid.UUID) (*Run, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	run, err := scanRun(r.conn().QueryRow(`SELECT `+runColumns+` FROM job_runs WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return run, nil
+}
+
+// Requeue makes a failed run due again with a fresh set of attempts. It
+// returns ErrNotFound when the run doesn't exist or hasn't failed.
+func (r *Repository) Requeue(id uuid.UUID) (*Run, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `UPDATE job_runs SET status = 'pending', attempts = 0, run_at = CURRENT_TIMESTAMP, locked_until = NULL, finished_at = NULL
+		WHERE id = $1 AND status = 'failed'
+		RETURNING ` + runColumns
+	run, err := scanRun(r.conn().QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return run, nil
+}
@@ -0,0 +1,30 @@
+package jobruns
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupAdminRoutes sets up the background job routes, mounted under /api/v1/admin
+func SetupAdminRoutes(handler *Handler, authMiddleware, requireAdmin func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.Overview(w, r)
+		case len(parts) == 1 && parts[0] == "runs" && r.Method == http.MethodGet:
+			return handler.ListRuns(w, r)
+		case len(parts) == 3 && parts[0] == "runs" && parts[2] == "retry" && r.Method == http.MethodPost:
+			return handler.Retry(w, r)
+		case len(parts) == 2 && parts[1] == "run" && r.Method == http.MethodPost:
+			return handler.RunNow(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/jobs", routes)
+	mux.Handle("/jobs/", routes)
+	return middleware.Chain(authMiddleware, requireAdmin)(mux)
+}
@@ -0,0 +1,93 @@
+package jobruns
+
+import (
+	"context"
+	"foodlink_backend/errors"
+	"foodlink_backend/jobs"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default and maximum number of runs returned by ListRuns
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// Service lets admins inspect background jobs, trigger them and retry
+// failed runs
+type Service struct {
+	scheduler *jobs.Scheduler
+	repo      *Repository
+}
+
+func NewService(scheduler *jobs.Scheduler) *Service {
+	return &Service{scheduler: scheduler, repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{scheduler: s.scheduler, repo: s.repo.WithContext(ctx)}
+}
+
+// Overview returns the registered jobs with their latest runs
+func (s *Service) Overview() (*Overview, error) {
+	latest, err := s.repo.LatestRuns()
+	if err != nil {
+		return nil, err
+	}
+	overview := &Overview{Leader: s.scheduler.IsLeader(), Jobs: []JobSummary{}}
+	for _, def := range s.scheduler.Definitions() {
+		overview.Jobs = append(overview.Jobs, JobSummary{Definition: def, LastRun: latest[def.Name]})
+	}
+	return overview, nil
+}
+
+// ListRuns returns runs matching filter. An empty status matches every
+// status.
+func (s *Service) ListRuns(filter RunFilter) ([]*Run, error) {
+	switch filter.Status {
+	case "", jobs.StatusPending, jobs.StatusRunning, jobs.StatusSucceeded, jobs.StatusFailed:
+	default:
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Status must be one of pending, running, succeeded or failed")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	return s.repo.ListRuns(filter)
+}
+
+// RunNow enqueues a one-off run of the job name, due immediately
+func (s *Service) RunNow(name string) (*Run, error) {
+	if !s.scheduler.Registered(name) {
+		return nil, errors.ErrNotFound
+	}
+	if s.repo.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	ctx := s.repo.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	id, err := jobs.Enqueue(ctx, s.repo.db, name, nil, time.Now())
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return s.repo.GetRunByID(id)
+}
+
+// Retry requeues a failed run. Runs in any other status are rejected with a
+// conflict.
+func (s *Service) Retry(id uuid.UUID) (*Run, error) {
+	run, err := s.repo.GetRunByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != jobs.StatusFailed {
+		return nil, errors.NewAppError(errors.ErrConflict.Code, "Only failed runs can be retried")
+	}
+	return s.repo.Requeue(id)
+}
@@ -0,0 +1,168 @@
+package meal_plans
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// allergens expands common allergies to the ingredients that contain them.
+// Allergies not listed here are matched by name only.
+var allergens = map[string][]string{
+	"nuts":      {"nut", "peanut", "almond", "walnut", "cashew", "pecan", "hazelnut", "pistachio", "macadamia"},
+	"tree nuts": {"almond", "walnut", "cashew", "pecan", "hazelnut", "pistachio", "macadamia"},
+	"peanuts":   {"peanut", "peanut butter"},
+	"dairy":     {"milk", "cheese", "butter", "cream", "yogurt", "yoghurt", "ghee", "whey"},
+	"milk":      {"milk", "cheese", "butter", "cream", "yogurt", "yoghurt", "ghee", "whey"},
+	"lactose":   {"milk", "cheese", "butter", "cream", "yogurt", "yoghurt", "whey"},
+	"eggs":      {"egg", "mayonnaise", "meringue"},
+	"gluten":    {"wheat", "flour", "bread", "pasta", "barley", "rye", "couscous", "semolina", "noodle"},
+	"wheat":     {"wheat", "flour", "bread", "pasta", "couscous", "semolina"},
+	"fish":      {"fish", "salmon", "tuna", "cod", "anchovy", "sardine", "mackerel", "tilapia"},
+	"shellfish": {"shrimp", "prawn", "crab", "lobster", "mussel", "oyster", "clam", "scallop"},
+	"soy":       {"soy", "soya", "tofu", "edamame", "tempeh", "miso"},
+	"sesame":    {"sesame", "tahini"},
+}
+
+var (
+	meat      = []string{"meat", "beef", "pork", "chicken", "lamb", "mutton", "turkey", "duck", "bacon", "ham", "sausage", "salami", "veal", "goat", "mince", "gelatin"}
+	seafood   = []string{"fish", "salmon", "tuna", "cod", "anchovy", "sardine", "shrimp", "prawn", "crab", "lobster", "mussel", "oyster", "squid"}
+	animalBy  = []string{"milk", "cheese", "butter", "cream", "yogurt", "yoghurt", "ghee", "whey", "egg", "honey", "mayonnaise"}
+	haram     = []string{"pork", "bacon", "ham", "lard", "salami", "pepperoni", "gelatin", "wine", "beer", "rum", "brandy", "alcohol"}
+	highCarb  = []string{"sugar", "rice", "pasta", "bread", "potato", "flour", "oat", "corn", "noodle", "couscous", "honey", "banana"}
+	highSalt  = []string{"salt", "soy sauce", "bacon", "ham", "salami", "stock cube", "bouillon", "anchovy", "pickle", "olive", "feta"}
+	forbidden = map[string][]string{
+		"vegan":      append(append(append([]string{}, meat...), seafood...), animalBy...),
+		"vegetarian": append(append([]string{}, meat...), seafood...),
+		"halal":      haram,
+		"keto":       highCarb,
+		"low-sodium": highSalt,
+	}
+)
+
+// Conflicts checks the plan's name and ingredients against the household's
+// allergies and dietary type
+func Conflicts(plan *MealPlan, diet *Diet) []Warning {
+	if diet == nil {
+		return nil
+	}
+	items := append([]string{plan.Name}, plan.Ingredients...)
+	warnings := []Warning{}
+	for _, allergy := range diet.Allergies {
+		allergy = strings.ToLower(strings.TrimSpace(allergy))
+		if allergy == "" {
+			continue
+		}
+		terms, ok := allergens[allergy]
+		if !ok {
+			terms = []string{allergy}
+		}
+		for _, item := range items {
+			if matchesAny(item, terms) {
+				warnings = append(warnings, Warning{
+					Type:       WarningAllergy,
+					Ingredient: item,
+					Conflict:   allergy,
+					Message:    fmt.Sprintf("%s may contain %s, which the household is allergic to", item, allergy),
+				})
+			}
+		}
+	}
+	if terms := forbidden[diet.DietaryType]; len(terms) > 0 {
+		for _, item := range items {
+			if matchesAny(item, terms) {
+				warnings = append(warnings, Warning{
+					Type:       WarningDiet,
+					Ingredient: item,
+					Conflict:   diet.DietaryType,
+					Message:    fmt.Sprintf("%s does not fit the household's %s diet", item, diet.DietaryType),
+				})
+			}
+		}
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+var nonLetters = regexp.MustCompile(`[^a-z]+`)
+
+// matchesAny reports whether the text names one of the terms, as whole
+// words, singular or plural: "eggs" matches egg, "eggplant" does not
+func matchesAny(text string, terms []string) bool {
+	words := " " + nonLetters.ReplaceAllString(strings.ToLower(text), " ") + " "
+	for _, term := range terms {
+		for _, form := range []string{term, term + "s", term + "es"} {
+			if strings.Contains(words, " "+form+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quantity is a leading amount: "2", "1.5", "1/2" or "1 1/2"
+var quantity = regexp.MustCompile(`^\s*(\d+/\d+|\d+(?:[.,]\d+)?(?:\s+\d+/\d+)?)(.*)$`)
+
+// scale scales the plan from its servings to the given servings, nil when
+// either is unknown
+func scale(plan *MealPlan, servings int) *Scaled {
+	if plan.Servings == nil || *plan.Servings < 1 || servings < 1 {
+		return nil
+	}
+	factor := float64(servings) / float64(*plan.Servings)
+	scaled := &Scaled{Servings: servings, Factor: math.Round(factor*100) / 100, Ingredients: make([]string, len(plan.Ingredients))}
+	for i, ingredient := range plan.Ingredients {
+		scaled.Ingredients[i] = scaleIngredient(ingredient, factor)
+	}
+	return scaled
+}
+
+// scaleIngredient multiplies the leading quantity of the ingredient by
+// factor, rounded to two decimals
+func scaleIngredient(ingredient string, factor float64) string {
+	amount, rest, ok := SplitQuantity(ingredient)
+	if !ok {
+		return ingredient
+	}
+	return strconv.FormatFloat(math.Round(amount*factor*100)/100, 'f', -1, 64) + rest
+}
+
+// SplitQuantity splits the leading quantity off an ingredient such as
+// "1 1/2 cups milk", returning 1.5 and " cups milk". ok is false when the
+// ingredient does not start with one.
+func SplitQuantity(ingredient string) (amount float64, rest string, ok bool) {
+	match := quantity.FindStringSubmatch(ingredient)
+	if match == nil {
+		return 0, ingredient, false
+	}
+	amount, ok = parseAmount(match[1])
+	if !ok {
+		return 0, ingredient, false
+	}
+	return amount, match[2], true
+}
+
+func parseAmount(s string) (float64, bool) {
+	total := 0.0
+	for _, part := range strings.Fields(s) {
+		if num, den, ok := strings.Cut(part, "/"); ok {
+			n, err1 := strconv.ParseFloat(num, 64)
+			d, err2 := strconv.ParseFloat(den, 64)
+			if err1 != nil || err2 != nil || d == 0 {
+				return 0, false
+			}
+			total += n / d
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.Replace(part, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		total += n
+	}
+	return total, true
+}
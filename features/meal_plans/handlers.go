@@ -0,0 +1,415 @@
+package meal_plans
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// getMember returns the signed-in user and their household, the user's own
+// ID when they have none, as family preferences do
+func (h *Handler) getMember(r *http.Request) (Member, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return Member{}, errors.ErrAuthRequired
+	}
+	member := Member{UserID: user.ID, HouseholdID: user.ID}
+	if user.HouseholdID != nil {
+		member.HouseholdID = *user.HouseholdID
+	}
+	return member, nil
+}
+
+// pathParts splits a /meal-plans/... path relative to /api/v1
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/meal-plans"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// planID parses the plan ID at the start of the path
+func planID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// dateParam parses the YYYY-MM-DD date in the query parameter, today (UTC)
+// without one
+func dateParam(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return time.Time{}, errors.NewAppError(errors.ErrBadRequest.Code, name+" must be a date (YYYY-MM-DD)")
+	}
+	return t, nil
+}
+
+// servingsParam parses the servings query parameter, 0 without one
+func servingsParam(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("servings")
+	if value == "" {
+		return 0, nil
+	}
+	servings, err := strconv.Atoi(value)
+	if err != nil || servings < 1 || servings > 100 {
+		return 0, errors.NewAppError(errors.ErrBadRequest.Code, "servings must be between 1 and 100")
+	}
+	return servings, nil
+}
+
+// List handles GET /api/v1/meal-plans
+// @Summary      List meal plans
+// @Description  List the household's meal plans between two dates, by default the current week. Each plan carries warnings for conflicts with the household's allergies and dietary type, and its ingredients scaled to the household size of the family preferences or to servings.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from       query     string  false  "First date (YYYY-MM-DD), Monday of the current week by default"
+// @Param        to         query     string  false  "Last date (YYYY-MM-DD), six days after from by default"
+// @Param        meal_type  query     string  false  "breakfast, lunch, dinner or snack"
+// @Param        servings   query     int     false  "Servings to scale ingredients to"
+// @Success      200        {array}   MealPlan
+// @Failure      400        {object}  errors.Problem
+// @Failure      401        {object}  errors.Problem
+// @Router       /meal-plans [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	from, err := dateParam(r, "from")
+	if err != nil {
+		return err
+	}
+	if r.URL.Query().Get("from") == "" {
+		from = weekStart(from)
+	}
+	to := from.AddDate(0, 0, 6)
+	if r.URL.Query().Get("to") != "" {
+		if to, err = dateParam(r, "to"); err != nil {
+			return err
+		}
+	}
+	servings, err := servingsParam(r)
+	if err != nil {
+		return err
+	}
+	filter := ListFilter{From: from, To: to, MealType: r.URL.Query().Get("meal_type"), Servings: servings}
+	plans, err := h.service.WithContext(r.Context()).List(member, filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve meal plans")
+	}
+	utils.OKResponse(w, "Meal plans retrieved successfully", plans)
+	return nil
+}
+
+// Week handles GET /api/v1/meal-plans/week
+// @Summary      Get meal plan week
+// @Description  Get the household's meal plans of a week, Monday to Sunday, by day. Plans carry warnings and scaled ingredients as in the list.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        date      query     string  false  "Any date of the week (YYYY-MM-DD), today by default"
+// @Param        servings  query     int     false  "Servings to scale ingredients to"
+// @Success      200       {object}  Calendar
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Router       /meal-plans/week [get]
+func (h *Handler) Week(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	day, err := dateParam(r, "date")
+	if err != nil {
+		return err
+	}
+	servings, err := servingsParam(r)
+	if err != nil {
+		return err
+	}
+	calendar, err := h.service.WithContext(r.Context()).Week(member, day, servings)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve meal plan week")
+	}
+	utils.OKResponse(w, "Meal plan week retrieved successfully", calendar)
+	return nil
+}
+
+// Month handles GET /api/v1/meal-plans/month
+// @Summary      Get meal plan month
+// @Description  Get the household's meal plans of a month, by day. Plans carry warnings and scaled ingredients as in the list.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        month     query     string  false  "Month (YYYY-MM), the current one by default"
+// @Param        servings  query     int     false  "Servings to scale ingredients to"
+// @Success      200       {object}  Calendar
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Router       /meal-plans/month [get]
+func (h *Handler) Month(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	month := time.Now().UTC()
+	if value := r.URL.Query().Get("month"); value != "" {
+		if month, err = time.Parse("2006-01", value); err != nil {
+			return errors.NewAppError(errors.ErrBadRequest.Code, "month must be a month (YYYY-MM)")
+		}
+	}
+	servings, err := servingsParam(r)
+	if err != nil {
+		return err
+	}
+	calendar, err := h.service.WithContext(r.Context()).Month(member, month, servings)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve meal plan month")
+	}
+	utils.OKResponse(w, "Meal plan month retrieved successfully", calendar)
+	return nil
+}
+
+// Get handles GET /api/v1/meal-plans/:id
+// @Summary      Get meal plan
+// @Description  Get a meal plan of the household, with its ingredients scaled to the household size or to servings
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string  true   "Meal plan ID"
+// @Param        servings  query     int     false  "Servings to scale ingredients to"
+// @Success      200       {object}  MealPlan
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Failure      404       {object}  errors.Problem
+// @Router       /meal-plans/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := planID(r)
+	if err != nil {
+		return err
+	}
+	servings, err := servingsParam(r)
+	if err != nil {
+		return err
+	}
+	plan, err := h.service.WithContext(r.Context()).Get(member, id, servings)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve meal plan")
+	}
+	utils.OKResponse(w, "Meal plan retrieved successfully", plan)
+	return nil
+}
+
+// Create handles POST /api/v1/meal-plans
+// @Summary      Create meal plan
+// @Description  Plan a meal for the household. Meals conflicting with the household's allergies or dietary type are saved with warnings.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateMealPlanRequest  true  "Meal plan"
+// @Success      201      {object}  MealPlan
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Router       /meal-plans [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	var req CreateMealPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	plan, err := h.service.WithContext(r.Context()).Create(member, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create meal plan")
+	}
+	utils.CreatedResponse(w, "Meal plan created successfully", plan)
+	return nil
+}
+
+// Update handles PUT /api/v1/meal-plans/:id
+// @Summary      Update meal plan
+// @Description  Update the given fields of a meal plan; an empty ingredients list clears them. Conflicts come back as warnings as on create.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                 true  "Meal plan ID"
+// @Param        request  body      UpdateMealPlanRequest  true  "Fields to update"
+// @Success      200      {object}  MealPlan
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Router       /meal-plans/{id} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := planID(r)
+	if err != nil {
+		return err
+	}
+	var req UpdateMealPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	plan, err := h.service.WithContext(r.Context()).Update(member, id, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update meal plan")
+	}
+	utils.OKResponse(w, "Meal plan updated successfully", plan)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/meal-plans/:id
+// @Summary      Delete meal plan
+// @Description  Remove a meal plan of the household
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Meal plan ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /meal-plans/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := planID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(member, id); err != nil {
+		return errors.Wrap(err, "Failed to delete meal plan")
+	}
+	utils.OKResponse(w, "Meal plan deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// CopyWeek handles POST /api/v1/meal-plans/copy-week
+// @Summary      Copy meal plan week
+// @Description  Copy the household's meal plans of one week (Monday to Sunday) to another, keeping weekdays and meal types. With replace, the target week's plans are removed first; otherwise the copies are added to them.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CopyWeekRequest  true  "Weeks, by any of their dates"
+// @Success      201      {array}   MealPlan
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Router       /meal-plans/copy-week [post]
+func (h *Handler) CopyWeek(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	var req CopyWeekRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	plans, err := h.service.WithContext(r.Context()).CopyWeek(member, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to copy meal plan week")
+	}
+	utils.CreatedResponse(w, "Meal plan week copied successfully", plans)
+	return nil
+}
+
+// Repeat handles POST /api/v1/meal-plans/repeat
+// @Summary      Repeat meal plan rotation
+// @Description  Repeat the household's meal plans from from to to, as a rotation of that many days, back to back until until (at most a year after to). With replace, the plans already after to up to until are removed first.
+// @Tags         meal-plans
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      RepeatRequest  true  "Rotation and end date"
+// @Success      201      {array}   MealPlan
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Router       /meal-plans/repeat [post]
+func (h *Handler) Repeat(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	var req RepeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	plans, err := h.service.WithContext(r.Context()).Repeat(member, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to repeat meal plans")
+	}
+	utils.CreatedResponse(w, "Meal plans repeated successfully", plans)
+	return nil
+}
@@ -0,0 +1,126 @@
+package meal_plans
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DateLayout is the layout of meal plan dates, in requests and responses
+const DateLayout = "2006-01-02"
+
+// Warning types
+const (
+	WarningAllergy = "allergy"
+	WarningDiet    = "diet"
+)
+
+// MealPlan is a meal planned for a household on a date. Warnings and Scaled
+// are computed from the household's family preferences when it is read.
+type MealPlan struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	HouseholdID uuid.UUID `json:"household_id" db:"household_id"`
+	// Date is YYYY-MM-DD
+	Date        string    `json:"date" db:"date"`
+	MealType    string    `json:"meal_type" db:"meal_type"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description,omitempty" db:"description"`
+	Ingredients []string  `json:"ingredients" db:"ingredients"`
+	Servings    *int      `json:"servings,omitempty" db:"servings"`
+	Scaled      *Scaled   `json:"scaled,omitempty"`
+	Warnings    []Warning `json:"warnings,omitempty"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Scaled is the plan's ingredients scaled from the servings it was planned
+// for to the servings asked for, by default the household size. Leading
+// quantities such as "200 g" or "1 1/2 cups" are multiplied by Factor;
+// ingredients without one are kept as they are.
+type Scaled struct {
+	Servings    int      `json:"servings"`
+	Factor      float64  `json:"factor"`
+	Ingredients []string `json:"ingredients"`
+}
+
+// Warning is a conflict of the plan with the household's allergies or
+// dietary type. Plans are saved anyway; the conflict is for the household
+// to resolve.
+type Warning struct {
+	Type string `json:"type"`
+	// Ingredient is the ingredient, or the meal name, that conflicts
+	Ingredient string `json:"ingredient"`
+	// Conflict is the allergy or dietary type it conflicts with
+	Conflict string `json:"conflict"`
+	Message  string `json:"message"`
+}
+
+type CreateMealPlanRequest struct {
+	Date        string   `json:"date" validate:"required,datetime=2006-01-02"`
+	MealType    string   `json:"meal_type" validate:"required,oneof=breakfast lunch dinner snack"`
+	Name        string   `json:"name" validate:"required,min=1,max=255"`
+	Description string   `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Ingredients []string `json:"ingredients,omitempty" validate:"omitempty,max=100,dive,min=1,max=255"`
+	Servings    *int     `json:"servings,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+type UpdateMealPlanRequest struct {
+	Date        string   `json:"date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	MealType    string   `json:"meal_type,omitempty" validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Name        string   `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Ingredients []string `json:"ingredients,omitempty" validate:"omitempty,max=100,dive,min=1,max=255"`
+	Servings    *int     `json:"servings,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// CopyWeekRequest copies the plans of the week of FromWeek to the week of
+// ToWeek, keeping weekdays and meal types. Any date of a week stands for
+// the week, which starts on Monday. With Replace, the plans already in the
+// target week are removed first.
+type CopyWeekRequest struct {
+	FromWeek string `json:"from_week" validate:"required,datetime=2006-01-02"`
+	ToWeek   string `json:"to_week" validate:"required,datetime=2006-01-02"`
+	Replace  bool   `json:"replace,omitempty"`
+}
+
+// RepeatRequest repeats the plans from From to To, a rotation of that many
+// days, back to back after To until Until. Repeats are cut off at Until.
+// With Replace, the plans already between the day after To and Until are
+// removed first.
+type RepeatRequest struct {
+	From    string `json:"from" validate:"required,datetime=2006-01-02"`
+	To      string `json:"to" validate:"required,datetime=2006-01-02"`
+	Until   string `json:"until" validate:"required,datetime=2006-01-02"`
+	Replace bool   `json:"replace,omitempty"`
+}
+
+// ListFilter selects the household's plans between two dates, inclusive
+type ListFilter struct {
+	From     time.Time
+	To       time.Time
+	MealType string
+	// Servings scales the ingredients to that many servings instead of the
+	// household size
+	Servings int
+}
+
+// CalendarDay is the plans of a day, by meal type
+type CalendarDay struct {
+	Date  string      `json:"date"`
+	Meals []*MealPlan `json:"meals"`
+}
+
+// Calendar is a week or month of plans, every day of it included
+type Calendar struct {
+	From string         `json:"from"`
+	To   string         `json:"to"`
+	Days []*CalendarDay `json:"days"`
+}
+
+// Diet is what the household's family preferences say about its meals
+type Diet struct {
+	HouseholdSize int
+	DietaryType   string
+	Allergies     []string
+}
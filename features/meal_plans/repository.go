@@ -0,0 +1,204 @@
+package meal_plans
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+const planColumns = `id, user_id, COALESCE(household_id, user_id), to_char(date, 'YYYY-MM-DD'), meal_type, name, COALESCE(description, ''), COALESCE(ingredients, '{}'), servings, created_at, updated_at`
+
+// planOrder lists plans by day, then in the order meals are eaten
+const planOrder = `date, CASE meal_type WHEN 'breakfast' THEN 0 WHEN 'lunch' THEN 1 WHEN 'dinner' THEN 2 ELSE 3 END, created_at, id`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPlan(row rowScanner) (*MealPlan, error) {
+	plan := &MealPlan{}
+	var servings sql.NullInt64
+	err := row.Scan(&plan.ID, &plan.UserID, &plan.HouseholdID, &plan.Date, &plan.MealType, &plan.Name, &plan.Description, pq.Array(&plan.Ingredients), &servings, &plan.CreatedAt, &plan.UpdatedAt)
+	if servings.Valid {
+		n := int(servings.Int64)
+		plan.Servings = &n
+	}
+	if plan.Ingredients == nil {
+		plan.Ingredients = []string{}
+	}
+	return plan, err
+}
+
+func scanPlans(rows *sql.Rows) ([]*MealPlan, error) {
+	defer rows.Close()
+	plans := []*MealPlan{}
+	for rows.Next() {
+		plan, err := scanPlan(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		plans = append(plans, plan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return plans, nil
+}
+
+// GetByRange returns the household's plans between the two dates,
+// inclusive, of one meal type unless mealType is empty
+func (r *Repository) GetByRange(householdID uuid.UUID, from, to time.Time, mealType string) ([]*MealPlan, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + planColumns + ` FROM meal_plans
+		WHERE household_id = $1 AND date BETWEEN $2::date AND $3::date AND ($4 = '' OR meal_type = $4)
+		ORDER BY ` + planOrder
+	rows, err := r.conn().Query(query, householdID, from.Format(DateLayout), to.Format(DateLayout), mealType)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return scanPlans(rows)
+}
+
+// GetByID returns the plan if it belongs to the household
+func (r *Repository) GetByID(householdID, id uuid.UUID) (*MealPlan, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	plan, err := scanPlan(r.conn().QueryRow(`SELECT `+planColumns+` FROM meal_plans WHERE id = $1 AND household_id = $2`, id, householdID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return plan, nil
+}
+
+func (r *Repository) Create(plan *MealPlan) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO meal_plans (id, user_id, household_id, date, meal_type, name, description, ingredients, servings)
+		VALUES ($1, $2, $3, $4::date, $5, $6, NULLIF($7, ''), $8, $9)
+		RETURNING ` + planColumns
+	created, err := scanPlan(r.conn().QueryRow(query, plan.ID, plan.UserID, plan.HouseholdID, plan.Date, plan.MealType, plan.Name, plan.Description, pq.Array(plan.Ingredients), plan.Servings))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*plan = *created
+	return nil
+}
+
+func (r *Repository) Update(plan *MealPlan) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE meal_plans
+		SET date = $1::date, meal_type = $2, name = $3, description = NULLIF($4, ''), ingredients = $5, servings = $6, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $7 AND household_id = $8
+		RETURNING ` + planColumns
+	updated, err := scanPlan(r.conn().QueryRow(query, plan.Date, plan.MealType, plan.Name, plan.Description, pq.Array(plan.Ingredients), plan.Servings, plan.ID, plan.HouseholdID))
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*plan = *updated
+	return nil
+}
+
+// Delete removes the plan if it belongs to the household
+func (r *Repository) Delete(householdID, id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM meal_plans WHERE id = $1 AND household_id = $2`, id, householdID)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteRange removes the household's plans between the two dates,
+// inclusive
+func (r *Repository) DeleteRange(householdID uuid.UUID, from, to time.Time) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	_, err := r.conn().Exec(`DELETE FROM meal_plans WHERE household_id = $1 AND date BETWEEN $2::date AND $3::date`, householdID, from.Format(DateLayout), to.Format(DateLayout))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// Copy copies the household's plans between from and to, inclusive, times
+// times, each copy step days after the previous one, as plans of the user.
+// Copies dated after until are left out.
+func (r *Repository) Copy(householdID, userID uuid.UUID, from, to time.Time, step, times int, until time.Time) ([]*MealPlan, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `INSERT INTO meal_plans (user_id, household_id, date, meal_type, name, description, ingredients, servings)
+		SELECT $2, household_id, date + k * $5::int, meal_type, name, description, ingredients, servings
+		FROM meal_plans, generate_series(1, $6::int) AS k
+		WHERE household_id = $1 AND date BETWEEN $3::date AND $4::date AND date + k * $5::int <= $7::date
+		ORDER BY k, ` + planOrder + `
+		RETURNING ` + planColumns
+	rows, err := r.conn().Query(query, householdID, userID, from.Format(DateLayout), to.Format(DateLayout), step, times, until.Format(DateLayout))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return scanPlans(rows)
+}
+
+// GetDiet returns the household size, dietary type and allergies of the
+// household's family preferences, nil when it has none
+func (r *Repository) GetDiet(householdID uuid.UUID) (*Diet, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	diet := &Diet{}
+	err := r.conn().QueryRow(`SELECT household_size, COALESCE(dietary_type, ''), COALESCE(allergies, '{}') FROM family_preferences WHERE household_id = $1`, householdID).
+		Scan(&diet.HouseholdSize, &diet.DietaryType, pq.Array(&diet.Allergies))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return diet, nil
+}
@@ -0,0 +1,41 @@
+package meal_plans
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the meal plan routes, mounted under /api/v1
+func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 0 && r.Method == http.MethodPost:
+			return handler.Create(w, r)
+		case len(parts) == 1 && parts[0] == "week" && r.Method == http.MethodGet:
+			return handler.Week(w, r)
+		case len(parts) == 1 && parts[0] == "month" && r.Method == http.MethodGet:
+			return handler.Month(w, r)
+		case len(parts) == 1 && parts[0] == "copy-week" && r.Method == http.MethodPost:
+			return handler.CopyWeek(w, r)
+		case len(parts) == 1 && parts[0] == "repeat" && r.Method == http.MethodPost:
+			return handler.Repeat(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.Get(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			return handler.Update(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/meal-plans", routes)
+	mux.Handle("/meal-plans/", routes)
+	return middleware.Chain(authMiddleware)(mux)
+}
@@ -0,0 +1,260 @@
+package meal_plans
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxRange is the longest range of days listed, copied or repeated at once
+const maxRange = 366
+
+var (
+	errInvalidRange = errors.NewAppError(errors.ErrBadRequest.Code, "from must not be after to, and the range must not exceed a year")
+	errSameWeek     = errors.NewAppError(errors.ErrBadRequest.Code, "from_week and to_week must be different weeks")
+	errUntil        = errors.NewAppError(errors.ErrBadRequest.Code, "until must be after to, at most a year later")
+)
+
+// Member is a signed-in user planning their household's meals. Users
+// without a household plan for themselves.
+type Member struct {
+	UserID      uuid.UUID
+	HouseholdID uuid.UUID
+}
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+// List returns the household's plans in the filter's range
+func (s *Service) List(member Member, filter ListFilter) ([]*MealPlan, error) {
+	if filter.To.Before(filter.From) || days(filter.From, filter.To) > maxRange {
+		return nil, errInvalidRange
+	}
+	plans, err := s.repo.GetByRange(member.HouseholdID, filter.From, filter.To, filter.MealType)
+	if err != nil {
+		return nil, err
+	}
+	return plans, s.annotate(member, plans, filter.Servings)
+}
+
+// Week returns the calendar of the week of the given day, Monday to Sunday
+func (s *Service) Week(member Member, day time.Time, servings int) (*Calendar, error) {
+	from := weekStart(day)
+	return s.calendar(member, from, from.AddDate(0, 0, 6), servings)
+}
+
+// Month returns the calendar of the month of the given day
+func (s *Service) Month(member Member, day time.Time, servings int) (*Calendar, error) {
+	from := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return s.calendar(member, from, from.AddDate(0, 1, -1), servings)
+}
+
+func (s *Service) calendar(member Member, from, to time.Time, servings int) (*Calendar, error) {
+	plans, err := s.List(member, ListFilter{From: from, To: to, Servings: servings})
+	if err != nil {
+		return nil, err
+	}
+	calendar := &Calendar{From: from.Format(DateLayout), To: to.Format(DateLayout), Days: []*CalendarDay{}}
+	byDate := make(map[string]*CalendarDay)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		cd := &CalendarDay{Date: day.Format(DateLayout), Meals: []*MealPlan{}}
+		byDate[cd.Date] = cd
+		calendar.Days = append(calendar.Days, cd)
+	}
+	for _, plan := range plans {
+		if cd, ok := byDate[plan.Date]; ok {
+			cd.Meals = append(cd.Meals, plan)
+		}
+	}
+	return calendar, nil
+}
+
+// Get returns the plan, scaled to servings or, when 0, the household size
+func (s *Service) Get(member Member, id uuid.UUID, servings int) (*MealPlan, error) {
+	plan, err := s.repo.GetByID(member.HouseholdID, id)
+	if err != nil {
+		return nil, err
+	}
+	return plan, s.annotate(member, []*MealPlan{plan}, servings)
+}
+
+// Create adds a plan. Conflicts with the household's allergies or diet do
+// not stop it from being saved; they come back as warnings.
+func (s *Service) Create(member Member, req *CreateMealPlanRequest) (*MealPlan, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	plan := &MealPlan{
+		ID:          uuid.New(),
+		UserID:      member.UserID,
+		HouseholdID: member.HouseholdID,
+		Date:        req.Date,
+		MealType:    req.MealType,
+		Name:        req.Name,
+		Description: req.Description,
+		Ingredients: req.Ingredients,
+		Servings:    req.Servings,
+	}
+	err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).Create(plan); err != nil {
+			return err
+		}
+		return s.publishCreated(tx, member, []*MealPlan{plan})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plan, s.annotate(member, []*MealPlan{plan}, 0)
+}
+
+// Update changes the given fields, warning about conflicts like Create
+func (s *Service) Update(member Member, id uuid.UUID, req *UpdateMealPlanRequest) (*MealPlan, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	plan, err := s.repo.GetByID(member.HouseholdID, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Date != "" {
+		plan.Date = req.Date
+	}
+	if req.MealType != "" {
+		plan.MealType = req.MealType
+	}
+	if req.Name != "" {
+		plan.Name = req.Name
+	}
+	if req.Description != nil {
+		plan.Description = *req.Description
+	}
+	if req.Ingredients != nil {
+		plan.Ingredients = req.Ingredients
+	}
+	if req.Servings != nil {
+		plan.Servings = req.Servings
+	}
+	if err := s.repo.Update(plan); err != nil {
+		return nil, err
+	}
+	return plan, s.annotate(member, []*MealPlan{plan}, 0)
+}
+
+func (s *Service) Delete(member Member, id uuid.UUID) error {
+	return s.repo.Delete(member.HouseholdID, id)
+}
+
+// CopyWeek copies the plans of one week to another and returns the copies
+func (s *Service) CopyWeek(member Member, req *CopyWeekRequest) ([]*MealPlan, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	fromDay, _ := time.Parse(DateLayout, req.FromWeek)
+	toDay, _ := time.Parse(DateLayout, req.ToWeek)
+	from, to := weekStart(fromDay), weekStart(toDay)
+	if from.Equal(to) {
+		return nil, errSameWeek
+	}
+	return s.copy(member, from, from.AddDate(0, 0, 6), days(from, to)-1, 1, to.AddDate(0, 0, 6), req.Replace)
+}
+
+// Repeat repeats a rotation of plans until a date and returns the copies
+func (s *Service) Repeat(member Member, req *RepeatRequest) ([]*MealPlan, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	from, _ := time.Parse(DateLayout, req.From)
+	to, _ := time.Parse(DateLayout, req.To)
+	until, _ := time.Parse(DateLayout, req.Until)
+	if to.Before(from) || days(from, to) > maxRange {
+		return nil, errInvalidRange
+	}
+	if !until.After(to) || days(to, until) > maxRange {
+		return nil, errUntil
+	}
+	rotation := days(from, to)
+	times := (days(to, until) + rotation - 2) / rotation
+	return s.copy(member, from, to, rotation, times, until, req.Replace)
+}
+
+// copy copies the plans between from and to times times, step days apart,
+// cut off at until. With replace, the plans already after to, or in the
+// target week when copying backwards, are removed first.
+func (s *Service) copy(member Member, from, to time.Time, step, times int, until time.Time, replace bool) ([]*MealPlan, error) {
+	var plans []*MealPlan
+	err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		repo := s.repo.WithTx(tx)
+		if replace {
+			if err := repo.DeleteRange(member.HouseholdID, from.AddDate(0, 0, step), until); err != nil {
+				return err
+			}
+		}
+		var err error
+		plans, err = repo.Copy(member.HouseholdID, member.UserID, from, to, step, times, until)
+		if err != nil {
+			return err
+		}
+		return s.publishCreated(tx, member, plans)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return plans, s.annotate(member, plans, 0)
+}
+
+func (s *Service) publishCreated(tx *sql.Tx, member Member, plans []*MealPlan) error {
+	if len(plans) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(plans))
+	for i, plan := range plans {
+		ids[i] = plan.ID
+	}
+	return events.Publish(s.repo.ctx, tx, events.MealPlansCreated{UserID: member.UserID, HouseholdID: member.HouseholdID, MealPlanIDs: ids})
+}
+
+// annotate adds the household's allergy and diet warnings to the plans and
+// scales them to servings or, when 0, the household size
+func (s *Service) annotate(member Member, plans []*MealPlan, servings int) error {
+	if len(plans) == 0 {
+		return nil
+	}
+	diet, err := s.repo.GetDiet(member.HouseholdID)
+	if err != nil {
+		return err
+	}
+	if servings == 0 && diet != nil {
+		servings = diet.HouseholdSize
+	}
+	for _, plan := range plans {
+		plan.Warnings = Conflicts(plan, diet)
+		plan.Scaled = scale(plan, servings)
+	}
+	return nil
+}
+
+// days returns the number of days from from to to, both included
+func days(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24) + 1
+}
+
+// weekStart returns the Monday of the week of t
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -34,29 +34,23 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  NGOCapacitySettings
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /ngo/capacity [get]
-func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	settings, err := h.service.GetByUserID(userID)
+	settings, err := h.service.WithContext(r.Context()).GetByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve capacity settings", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve capacity settings")
 	}
 	utils.OKResponse(w, "Capacity settings retrieved successfully", settings)
+	return nil
 }
 
 // CreateOrUpdate handles POST /api/v1/ngo/capacity
@@ -69,32 +63,25 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 // @Param        request  body      CreateNGOCapacitySettingsRequest  true  "Capacity settings data"
 // @Success      200      {object}  NGOCapacitySettings
 // @Success      201      {object}  NGOCapacitySettings
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /ngo/capacity [post]
-func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateNGOCapacitySettingsRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	settings, err := h.service.CreateOrUpdate(userID, &req)
+	settings, err := h.service.WithContext(r.Context()).CreateOrUpdate(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to save capacity settings", err.Error())
-		return
+		return errors.Wrap(err, "Failed to save capacity settings")
 	}
 	utils.OKResponse(w, "Capacity settings saved successfully", settings)
+	return nil
 }
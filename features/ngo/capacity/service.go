@@ -1,6 +1,8 @@
 package capacity
 
 import (
+	"context"
+	"foodlink_backend/config"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -9,10 +11,18 @@ import (
 
 type Service struct {
 	repo *Repository
+	// defaultPickupRadiusKm applies when settings don't set a radius
+	defaultPickupRadiusKm float64
 }
 
-func NewService() *Service {
-	return &Service{repo: NewRepository()}
+func NewService(cfg *config.Config) *Service {
+	return &Service{repo: NewRepository(), defaultPickupRadiusKm: cfg.NGO.DefaultPickupRadiusKm}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	scoped := *s
+	scoped.repo = s.repo.WithContext(ctx)
+	return &scoped
 }
 
 func (s *Service) GetByUserID(userID uuid.UUID) (*NGOCapacitySettings, error) {
@@ -21,7 +31,7 @@ func (s *Service) GetByUserID(userID uuid.UUID) (*NGOCapacitySettings, error) {
 
 func (s *Service) CreateOrUpdate(userID uuid.UUID, req *CreateNGOCapacitySettingsRequest) (*NGOCapacitySettings, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	settings := &NGOCapacitySettings{
 		ID:                      uuid.New(),
@@ -49,7 +59,7 @@ func (s *Service) CreateOrUpdate(userID uuid.UUID, req *CreateNGOCapacitySetting
 		PreferredPickupRadiusKm: req.PreferredPickupRadiusKm,
 	}
 	if settings.PreferredPickupRadiusKm == 0 {
-		settings.PreferredPickupRadiusKm = 5.0
+		settings.PreferredPickupRadiusKm = s.defaultPickupRadiusKm
 	}
 	if err := s.repo.CreateOrUpdate(settings); err != nil {
 		return nil, err
@@ -26,100 +26,74 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 	return user.ID, nil
 }
 
-func (h *Handler) GetAllFeedback(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAllFeedback(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	feedbacks, err := h.service.GetAllFeedback(ngoUserID)
+	feedbacks, err := h.service.WithContext(r.Context()).GetAllFeedback(ngoUserID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve feedback", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve feedback")
 	}
 	utils.OKResponse(w, "Feedback retrieved successfully", feedbacks)
+	return nil
 }
 
-func (h *Handler) CreateFeedback(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateFeedback(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateNGOFeedbackRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	feedback, err := h.service.CreateFeedback(ngoUserID, &req)
+	feedback, err := h.service.WithContext(r.Context()).CreateFeedback(ngoUserID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create feedback", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create feedback")
 	}
 	utils.CreatedResponse(w, "Feedback submitted successfully", feedback)
+	return nil
 }
 
-func (h *Handler) GetAllStories(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAllStories(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	stories, err := h.service.GetAllStories(ngoUserID)
+	stories, err := h.service.WithContext(r.Context()).GetAllStories(ngoUserID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve impact stories", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve impact stories")
 	}
 	utils.OKResponse(w, "Impact stories retrieved successfully", stories)
+	return nil
 }
 
-func (h *Handler) CreateStory(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateStory(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateNGOImpactStoryRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	story, err := h.service.CreateStory(ngoUserID, &req)
+	story, err := h.service.WithContext(r.Context()).CreateStory(ngoUserID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create impact story", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create impact story")
 	}
 	utils.CreatedResponse(w, "Impact story created successfully", story)
+	return nil
 }
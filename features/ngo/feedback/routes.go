@@ -1,31 +1,32 @@
 package feedback
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 )
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/feedback", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/feedback", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		switch {
 		case r.Method == http.MethodGet:
-			handler.GetAllFeedback(w, r)
+			return handler.GetAllFeedback(w, r)
 		case r.Method == http.MethodPost:
-			handler.CreateFeedback(w, r)
+			return handler.CreateFeedback(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/stories", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/stories", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		switch {
 		case r.Method == http.MethodGet:
-			handler.GetAllStories(w, r)
+			return handler.GetAllStories(w, r)
 		case r.Method == http.MethodPost:
-			handler.CreateStory(w, r)
+			return handler.CreateStory(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
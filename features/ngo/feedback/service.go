@@ -1,7 +1,12 @@
 package feedback
 
 import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/features/uploads"
 	"foodlink_backend/utils"
 
 	"github.com/google/uuid"
@@ -15,13 +20,20 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllFeedback(ngoUserID uuid.UUID) ([]*NGOFeedbackEntry, error) {
 	return s.repo.GetAllFeedbackByNGOUserID(ngoUserID)
 }
 
 func (s *Service) CreateFeedback(ngoUserID uuid.UUID, req *CreateNGOFeedbackRequest) (*NGOFeedbackEntry, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, ngoUserID, req.PhotoUploadID); err != nil {
+		return nil, err
 	}
 	feedback := &NGOFeedbackEntry{
 		ID:            uuid.New(),
@@ -32,11 +44,25 @@ func (s *Service) CreateFeedback(ngoUserID uuid.UUID, req *CreateNGOFeedbackRequ
 		Rating:        req.Rating,
 		Comment:       req.Comment,
 		Tags:          req.Tags,
-		Photo:         req.Photo,
+		PhotoUploadID: req.PhotoUploadID,
 		Status:        "pending",
 	}
-	if err := s.repo.CreateFeedback(feedback); err != nil {
-		return nil, err
+	err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).CreateFeedback(feedback); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.FeedbackSubmitted{
+			FeedbackID:    feedback.ID,
+			NGOUserID:     feedback.NGOUserID,
+			PartnerName:   feedback.PartnerName,
+			RecipientName: feedback.RecipientName,
+			DeliveryDate:  feedback.DeliveryDate,
+			Rating:        feedback.Rating,
+			Tags:          feedback.Tags,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create feedback")
 	}
 	return feedback, nil
 }
@@ -47,7 +73,10 @@ func (s *Service) GetAllStories(ngoUserID uuid.UUID) ([]*NGOImpactStory, error)
 
 func (s *Service) CreateStory(ngoUserID uuid.UUID, req *CreateNGOImpactStoryRequest) (*NGOImpactStory, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, ngoUserID, req.PhotoUploadID); err != nil {
+		return nil, err
 	}
 	story := &NGOImpactStory{
 		ID:            uuid.New(),
@@ -57,7 +86,7 @@ func (s *Service) CreateStory(ngoUserID uuid.UUID, req *CreateNGOImpactStoryRequ
 		Beneficiaries: req.Beneficiaries,
 		MealsProvided: req.MealsProvided,
 		Tags:          req.Tags,
-		Photo:         req.Photo,
+		PhotoUploadID: req.PhotoUploadID,
 	}
 	if err := s.repo.CreateStory(story); err != nil {
 		return nil, err
@@ -26,47 +26,35 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 	return user.ID, nil
 }
 
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	histories, err := h.service.GetAllByNGOUserID(ngoUserID)
+	histories, err := h.service.WithContext(r.Context()).GetAllByNGOUserID(ngoUserID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve donation history", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve donation history")
 	}
 	utils.OKResponse(w, "Donation history retrieved successfully", histories)
+	return nil
 }
 
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/history/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	history, err := h.service.GetByID(id)
+	history, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve donation history", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve donation history")
 	}
 	utils.OKResponse(w, "Donation history retrieved successfully", history)
+	return nil
 }
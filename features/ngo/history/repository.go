@@ -1,28 +1,44 @@
 package history
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByNGOUserID(ngoUserID uuid.UUID) ([]*NGODonationHistory, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, ngo_user_id, offer_id, donor_name, donor_type, items_summary, weight_kg, meals_provided, co2_prevented_kg, beneficiaries, pickup_time, delivered_at, status, tags, photo, created_at FROM ngo_donation_history WHERE ngo_user_id = $1 ORDER BY pickup_time DESC, created_at DESC`
-	rows, err := r.db.Query(query, ngoUserID)
+	rows, err := r.conn().Query(query, ngoUserID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -44,7 +60,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*NGODonationHistory, error) {
 	}
 	history := &NGODonationHistory{}
 	query := `SELECT id, ngo_user_id, offer_id, donor_name, donor_type, items_summary, weight_kg, meals_provided, co2_prevented_kg, beneficiaries, pickup_time, delivered_at, status, tags, photo, created_at FROM ngo_donation_history WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&history.ID, &history.NGOUserID, &history.OfferID, &history.DonorName, &history.DonorType, &history.ItemsSummary, &history.WeightKg, &history.MealsProvided, &history.CO2PreventedKg, &history.Beneficiaries, &history.PickupTime, &history.DeliveredAt, &history.Status, pq.Array(&history.Tags), &history.Photo, &history.CreatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&history.ID, &history.NGOUserID, &history.OfferID, &history.DonorName, &history.DonorType, &history.ItemsSummary, &history.WeightKg, &history.MealsProvided, &history.CO2PreventedKg, &history.Beneficiaries, &history.PickupTime, &history.DeliveredAt, &history.Status, pq.Array(&history.Tags), &history.Photo, &history.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -53,3 +69,21 @@ func (r *Repository) GetByID(id uuid.UUID) (*NGODonationHistory, error) {
 	}
 	return history, nil
 }
+
+// CreateFromOffer records a delivered pickup of offerID, copying the donor and
+// quantities from the offer. An offer is recorded at most once.
+func (r *Repository) CreateFromOffer(offerID uuid.UUID, pickupTime, deliveredAt time.Time) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO ngo_donation_history (ngo_user_id, offer_id, donor_name, donor_type, items_summary, weight_kg, meals_provided, pickup_time, delivered_at, status)
+		SELECT o.ngo_user_id, o.id, o.donor_name, o.donor_type,
+			COALESCE((SELECT string_agg(item->>'name', ', ') FROM jsonb_array_elements(o.items) AS item), o.offer_title),
+			o.weight_kg, o.meals_estimated, $2, $3, 'delivered'
+		FROM ngo_donation_offers o
+		WHERE o.id = $1 AND NOT EXISTS (SELECT 1 FROM ngo_donation_history h WHERE h.offer_id = o.id)`
+	if _, err := r.conn().Exec(query, offerID, pickupTime, deliveredAt); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
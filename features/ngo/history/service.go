@@ -1,6 +1,7 @@
 package history
 
 import (
+	"context"
 	"github.com/google/uuid"
 )
 
@@ -12,6 +13,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByNGOUserID(ngoUserID uuid.UUID) ([]*NGODonationHistory, error) {
 	return s.repo.GetAllByNGOUserID(ngoUserID)
 }
@@ -0,0 +1,21 @@
+package history
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/events"
+)
+
+// RegisterSubscribers subscribes the history feature to domain events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypePickupDelivered, "ngo_history.record_delivery", recordDelivery)
+}
+
+// recordDelivery adds a delivered pickup to the NGO's donation history
+func recordDelivery(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var delivered events.PickupDelivered
+	if err := event.Decode(&delivered); err != nil {
+		return err
+	}
+	return NewRepository().WithContext(ctx).WithTx(tx).CreateFromOffer(delivered.OfferID, delivered.PickupTime, delivered.DeliveredAt)
+}
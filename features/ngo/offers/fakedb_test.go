@@ -0,0 +1,118 @@
+package offers
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// offerColumns are the columns GetByID selects, in order
+var offerColumns = []string{"id", "ngo_user_id", "donor_name", "donor_type", "partner_id", "distance_km", "location_label", "geo_point", "offer_title", "items", "weight_kg", "meals_estimated", "freshness_score", "pickup_window", "expires_at", "urgency_level", "dietary_notes", "safety_flags", "contact", "images", "status", "match_reason", "created_at", "updated_at"}
+
+// fakeDB is a database/sql driver holding one offer row. Status updates
+// honour a status='pending' guard the way Postgres would, and outbox
+// inserts are recorded.
+type fakeDB struct {
+	mu        sync.Mutex
+	offer     NGODonationOffer
+	published []string
+}
+
+func newFakeDB(offer NGODonationOffer) (*fakeDB, *sql.DB) {
+	f := &fakeDB{offer: offer}
+	return f, sql.OpenDB(f)
+}
+
+// outbox returns the event types written to the outbox
+func (f *fakeDB) outbox() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.published...)
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeConn) Commit() error             { return nil }
+func (c *fakeConn) Rollback() error           { return nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !strings.Contains(query, "FROM ngo_donation_offers WHERE id = $1") || args[0].Value != f.offer.ID.String() {
+		return &fakeRows{}, nil
+	}
+	o := f.offer
+	return &fakeRows{rows: [][]driver.Value{{
+		o.ID.String(), o.NGOUserID.String(), o.DonorName, o.DonorType, nil, o.DistanceKm, o.LocationLabel, nil, o.OfferTitle,
+		[]byte("{}"), o.WeightKg, int64(o.MealsEstimated), int64(o.FreshnessScore), []byte("{}"), o.ExpiresAt, o.UrgencyLevel,
+		o.DietaryNotes, []byte("{}"), []byte("{}"), []byte("{}"), o.Status, o.MatchReason, o.CreatedAt, o.UpdatedAt,
+	}}}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	f := c.db
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case strings.HasPrefix(query, "UPDATE ngo_donation_offers SET status"):
+		if args[1].Value != f.offer.ID.String() || (strings.Contains(query, "status='pending'") && f.offer.Status != "pending") {
+			return driver.RowsAffected(0), nil
+		}
+		f.offer.Status = args[0].Value.(string)
+		return driver.RowsAffected(1), nil
+	case strings.Contains(query, "INSERT INTO event_outbox"):
+		f.published = append(f.published, args[1].Value.(string))
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected statement %q", query)
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return offerColumns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func testOffer(ngoUserID uuid.UUID) NGODonationOffer {
+	now := time.Now()
+	return NGODonationOffer{
+		ID:         uuid.New(),
+		NGOUserID:  ngoUserID,
+		DonorName:  "Corner Bistro",
+		DonorType:  "restaurant",
+		OfferTitle: "Vegetable soup",
+		WeightKg:   12.5,
+		ExpiresAt:  now.Add(time.Hour),
+		Status:     "pending",
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
@@ -35,29 +35,23 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Security     BearerAuth
 // @Param        status  query     string  false  "Filter by status"
 // @Success      200     {array}   NGODonationOffer
-// @Failure      401     {object}  errors.AppError
+// @Failure      401     {object}  errors.Problem
 // @Router       /ngo/offers [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	status := r.URL.Query().Get("status")
-	offers, err := h.service.GetAllByNGOUserID(ngoUserID, status)
+	offers, err := h.service.WithContext(r.Context()).GetAllByNGOUserID(ngoUserID, status)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve offers", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve offers")
 	}
 	utils.OKResponse(w, "Offers retrieved successfully", offers)
+	return nil
 }
 
 // GetByID handles GET /api/v1/ngo/offers/:id
@@ -69,30 +63,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Offer ID"
 // @Success      200  {object}  NGODonationOffer
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /ngo/offers/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/offers/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	offer, err := h.service.GetByID(id)
+	offer, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve offer", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve offer")
 	}
 	utils.OKResponse(w, "Offer retrieved successfully", offer)
+	return nil
 }
 
 // Accept handles PUT /api/v1/ngo/offers/:id/accept
@@ -104,40 +92,32 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Offer ID"
 // @Success      200  {object}  NGODonationOffer
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /ngo/offers/{id}/accept [put]
-func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/offers/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "accept" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	id, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	offer, err := h.service.Accept(id, ngoUserID)
+	offer, err := h.service.WithContext(r.Context()).Accept(id, ngoUserID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to accept offer", err.Error())
-		return
+		return errors.Wrap(err, "Failed to accept offer")
 	}
 	utils.OKResponse(w, "Offer accepted successfully", offer)
+	return nil
 }
 
 // Decline handles PUT /api/v1/ngo/offers/:id/decline
@@ -149,38 +129,30 @@ func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Offer ID"
 // @Success      200  {object}  NGODonationOffer
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /ngo/offers/{id}/decline [put]
-func (h *Handler) Decline(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Decline(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	ngoUserID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/offers/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "decline" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	id, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	offer, err := h.service.Decline(id, ngoUserID)
+	offer, err := h.service.WithContext(r.Context()).Decline(id, ngoUserID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to decline offer", err.Error())
-		return
+		return errors.Wrap(err, "Failed to decline offer")
 	}
 	utils.OKResponse(w, "Offer declined successfully", offer)
+	return nil
 }
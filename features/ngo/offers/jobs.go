@@ -0,0 +1,65 @@
+package offers
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/events"
+	"foodlink_backend/jobs"
+	"foodlink_backend/metrics"
+	"log/slog"
+	"time"
+)
+
+// newOffersLookback is how far back the first run looks for new offers
+const newOffersLookback = time.Hour
+
+// RegisterJobs registers the offers background jobs
+func RegisterJobs(scheduler *jobs.Scheduler) {
+	scheduler.Schedule("ngo_offers.expire_offers", "*/5 * * * *", expireOffers)
+	scheduler.Schedule("ngo_offers.publish_new", "* * * * *", publishNewOffers)
+}
+
+// publishNewOffers publishes OfferCreated for offers added since the
+// previous run. Offers are inserted by the matching pipeline outside this
+// API, so they are picked up from created_at.
+func publishNewOffers(ctx context.Context, job *jobs.Job) error {
+	from, to := job.Window(newOffersLookback)
+	repo := NewRepository().WithContext(ctx)
+	return database.WithTransaction(ctx, repo.db, func(tx *sql.Tx) error {
+		offers, err := repo.WithTx(tx).GetCreatedBetween(from, to)
+		if err != nil {
+			return err
+		}
+		for _, offer := range offers {
+			err := events.Publish(ctx, tx, events.OfferCreated{
+				OfferID:      offer.ID,
+				NGOUserID:    offer.NGOUserID,
+				OfferTitle:   offer.OfferTitle,
+				DonorName:    offer.DonorName,
+				DonorType:    offer.DonorType,
+				WeightKg:     offer.WeightKg,
+				UrgencyLevel: offer.UrgencyLevel,
+				ExpiresAt:    offer.ExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// expireOffers declines pending offers nobody accepted before expires_at.
+// Offers have no expired status; declined keeps them out of the queue.
+func expireOffers(ctx context.Context, job *jobs.Job) error {
+	count, err := NewRepository().WithContext(ctx).ExpireDue()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		metrics.OffersTotal.Add(float64(count), "expired")
+		slog.Info("Declined expired NGO offers", "count", count)
+	}
+	return nil
+}
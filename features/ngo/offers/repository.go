@@ -1,23 +1,39 @@
 package offers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByNGOUserID(ngoUserID uuid.UUID, status string) ([]*NGODonationOffer, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
@@ -27,10 +43,10 @@ func (r *Repository) GetAllByNGOUserID(ngoUserID uuid.UUID, status string) ([]*N
 	var err error
 	if status != "" {
 		query = `SELECT id, ngo_user_id, donor_name, donor_type, partner_id, distance_km, location_label, geo_point, offer_title, items, weight_kg, meals_estimated, freshness_score, pickup_window, expires_at, urgency_level, dietary_notes, safety_flags, contact, images, status, match_reason, created_at, updated_at FROM ngo_donation_offers WHERE ngo_user_id = $1 AND status = $2 ORDER BY created_at DESC`
-		rows, err = r.db.Query(query, ngoUserID, status)
+		rows, err = r.conn().Query(query, ngoUserID, status)
 	} else {
 		query = `SELECT id, ngo_user_id, donor_name, donor_type, partner_id, distance_km, location_label, geo_point, offer_title, items, weight_kg, meals_estimated, freshness_score, pickup_window, expires_at, urgency_level, dietary_notes, safety_flags, contact, images, status, match_reason, created_at, updated_at FROM ngo_donation_offers WHERE ngo_user_id = $1 ORDER BY created_at DESC`
-		rows, err = r.db.Query(query, ngoUserID)
+		rows, err = r.conn().Query(query, ngoUserID)
 	}
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
@@ -67,7 +83,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*NGODonationOffer, error) {
 	offer := &NGODonationOffer{}
 	var geoPointJSON, itemsJSON, pickupWindowJSON, contactJSON []byte
 	query := `SELECT id, ngo_user_id, donor_name, donor_type, partner_id, distance_km, location_label, geo_point, offer_title, items, weight_kg, meals_estimated, freshness_score, pickup_window, expires_at, urgency_level, dietary_notes, safety_flags, contact, images, status, match_reason, created_at, updated_at FROM ngo_donation_offers WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&offer.ID, &offer.NGOUserID, &offer.DonorName, &offer.DonorType, &offer.PartnerID, &offer.DistanceKm, &offer.LocationLabel, &geoPointJSON, &offer.OfferTitle, &itemsJSON, &offer.WeightKg, &offer.MealsEstimated, &offer.FreshnessScore, &pickupWindowJSON, &offer.ExpiresAt, &offer.UrgencyLevel, &offer.DietaryNotes, pq.Array(&offer.SafetyFlags), &contactJSON, pq.Array(&offer.Images), &offer.Status, &offer.MatchReason, &offer.CreatedAt, &offer.UpdatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&offer.ID, &offer.NGOUserID, &offer.DonorName, &offer.DonorType, &offer.PartnerID, &offer.DistanceKm, &offer.LocationLabel, &geoPointJSON, &offer.OfferTitle, &itemsJSON, &offer.WeightKg, &offer.MealsEstimated, &offer.FreshnessScore, &pickupWindowJSON, &offer.ExpiresAt, &offer.UrgencyLevel, &offer.DietaryNotes, pq.Array(&offer.SafetyFlags), &contactJSON, pq.Array(&offer.Images), &offer.Status, &offer.MatchReason, &offer.CreatedAt, &offer.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -89,18 +105,59 @@ func (r *Repository) GetByID(id uuid.UUID) (*NGODonationOffer, error) {
 	return offer, nil
 }
 
+// UpdateStatus resolves a pending offer. It returns ErrConflict when the
+// offer is no longer pending, so each offer is accepted or declined once.
 func (r *Repository) UpdateStatus(id uuid.UUID, status string) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	query := `UPDATE ngo_donation_offers SET status=$1, updated_at=CURRENT_TIMESTAMP WHERE id=$2`
-	result, err := r.db.Exec(query, status, id)
+	query := `UPDATE ngo_donation_offers SET status=$1, updated_at=CURRENT_TIMESTAMP WHERE id=$2 AND status='pending'`
+	result, err := r.conn().Exec(query, status, id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
-	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return errors.ErrNotFound
+		return errors.ErrConflict
 	}
 	return nil
 }
+
+// GetCreatedBetween returns pending offers created after from, up to to
+func (r *Repository) GetCreatedBetween(from, to time.Time) ([]*NGODonationOffer, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT id, ngo_user_id, offer_title, donor_name, donor_type, weight_kg, urgency_level, expires_at FROM ngo_donation_offers WHERE status = 'pending' AND created_at > $1 AND created_at <= $2 ORDER BY created_at`
+	rows, err := r.conn().Query(query, from, to)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var offers []*NGODonationOffer
+	for rows.Next() {
+		offer := &NGODonationOffer{}
+		if err := rows.Scan(&offer.ID, &offer.NGOUserID, &offer.OfferTitle, &offer.DonorName, &offer.DonorType, &offer.WeightKg, &offer.UrgencyLevel, &offer.ExpiresAt); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		offers = append(offers, offer)
+	}
+	return offers, nil
+}
+
+// ExpireDue declines pending offers past their expiry and returns how many
+// changed
+func (r *Repository) ExpireDue() (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	query := `UPDATE ngo_donation_offers SET status = 'declined', updated_at = CURRENT_TIMESTAMP WHERE status = 'pending' AND expires_at < CURRENT_TIMESTAMP`
+	result, err := r.conn().Exec(query)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
@@ -1,6 +1,7 @@
 package offers
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 	"strings"
@@ -8,21 +9,21 @@ import (
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		path := strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/offers/")
 		pathParts := strings.Split(path, "/")
 		switch {
 		case path == "" && r.Method == http.MethodGet:
-			handler.GetAll(w, r)
+			return handler.GetAll(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "accept" && r.Method == http.MethodPut:
-			handler.Accept(w, r)
+			return handler.Accept(w, r)
 		case len(pathParts) == 2 && pathParts[1] == "decline" && r.Method == http.MethodPut:
-			handler.Decline(w, r)
+			return handler.Decline(w, r)
 		case len(pathParts) == 1 && len(pathParts[0]) == 36 && r.Method == http.MethodGet:
-			handler.GetByID(w, r)
+			return handler.GetByID(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
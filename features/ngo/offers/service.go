@@ -1,7 +1,12 @@
 package offers
 
 import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/metrics"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +19,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByNGOUserID(ngoUserID uuid.UUID, status string) ([]*NGODonationOffer, error) {
 	return s.repo.GetAllByNGOUserID(ngoUserID, status)
 }
@@ -30,10 +39,30 @@ func (s *Service) Accept(id uuid.UUID, ngoUserID uuid.UUID) (*NGODonationOffer,
 	if offer.NGOUserID != ngoUserID {
 		return nil, errors.ErrForbidden
 	}
-	if err := s.repo.UpdateStatus(id, "accepted"); err != nil {
-		return nil, err
+	// OfferAccepted is published only when this call moved the offer out of
+	// pending, so repeated accepts reach no subscriber
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).UpdateStatus(id, "accepted"); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.OfferAccepted{
+			OfferID:        offer.ID,
+			NGOUserID:      offer.NGOUserID,
+			OfferTitle:     offer.OfferTitle,
+			DonorName:      offer.DonorName,
+			DonorType:      offer.DonorType,
+			WeightKg:       offer.WeightKg,
+			MealsEstimated: offer.MealsEstimated,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to accept offer")
 	}
+	// Counted here, after the guarded update changed the offer, so repeated
+	// calls are not
 	offer.Status = "accepted"
+	metrics.OffersTotal.Inc("accepted")
+	metrics.DonatedKg.Add(offer.WeightKg, "ngo_offer")
 	return offer, nil
 }
 
@@ -49,5 +78,6 @@ func (s *Service) Decline(id uuid.UUID, ngoUserID uuid.UUID) (*NGODonationOffer,
 		return nil, err
 	}
 	offer.Status = "declined"
+	metrics.OffersTotal.Inc("declined")
 	return offer, nil
 }
@@ -0,0 +1,107 @@
+package offers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/metrics"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func init() {
+	RegisterSubscribers(events.Default)
+}
+
+// counterValue returns the value of a counter series, as exported
+func counterValue(t *testing.T, c *metrics.CounterVec, series string) float64 {
+	t.Helper()
+	var buf bytes.Buffer
+	c.Collect(&buf)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			var v float64
+			fmt.Sscan(value, &v)
+			return v
+		}
+	}
+	return 0
+}
+
+func TestAcceptPublishesOnce(t *testing.T) {
+	ngoUserID := uuid.New()
+	offer := testOffer(ngoUserID)
+	f, db := newFakeDB(offer)
+	s := (&Service{repo: &Repository{db: db}}).WithContext(context.Background())
+	acceptedSeries := `foodlink_ngo_offers_total{outcome="accepted"}`
+	declinedSeries := `foodlink_ngo_offers_total{outcome="declined"}`
+	donatedSeries := `foodlink_donated_kg_total{source="ngo_offer"}`
+	acceptedBefore := counterValue(t, metrics.OffersTotal, acceptedSeries)
+	declinedBefore := counterValue(t, metrics.OffersTotal, declinedSeries)
+	donatedBefore := counterValue(t, metrics.DonatedKg, donatedSeries)
+
+	accepted, err := s.Accept(offer.ID, ngoUserID)
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if accepted.Status != "accepted" {
+		t.Errorf("status = %q, want accepted", accepted.Status)
+	}
+	if got := f.outbox(); len(got) != 1 || got[0] != events.TypeOfferAccepted {
+		t.Fatalf("outbox = %v, want one %s", got, events.TypeOfferAccepted)
+	}
+
+	// Neither accepting again nor declining resolves the offer a second time
+	if _, err := s.Accept(offer.ID, ngoUserID); errors.Resolve(err).Code != errors.ErrConflict.Code {
+		t.Errorf("second Accept: err = %v, want a conflict", err)
+	}
+	if _, err := s.Decline(offer.ID, ngoUserID); errors.Resolve(err).Code != errors.ErrConflict.Code {
+		t.Errorf("Decline after Accept: err = %v, want a conflict", err)
+	}
+	if got := f.outbox(); len(got) != 1 {
+		t.Errorf("outbox = %v, want the first OfferAccepted only", got)
+	}
+	if got := counterValue(t, metrics.OffersTotal, acceptedSeries) - acceptedBefore; got != 1 {
+		t.Errorf("accepted offers counted %v times, want once", got)
+	}
+	if got := counterValue(t, metrics.OffersTotal, declinedSeries) - declinedBefore; got != 0 {
+		t.Errorf("rejected decline counted %v times", got)
+	}
+	if got := counterValue(t, metrics.DonatedKg, donatedSeries) - donatedBefore; got != offer.WeightKg {
+		t.Errorf("donated %v kg counted, want %v", got, offer.WeightKg)
+	}
+}
+
+func TestDeclineThenAccept(t *testing.T) {
+	ngoUserID := uuid.New()
+	offer := testOffer(ngoUserID)
+	f, db := newFakeDB(offer)
+	s := (&Service{repo: &Repository{db: db}}).WithContext(context.Background())
+
+	if _, err := s.Decline(offer.ID, ngoUserID); err != nil {
+		t.Fatalf("Decline: %v", err)
+	}
+	if _, err := s.Accept(offer.ID, ngoUserID); errors.Resolve(err).Code != errors.ErrConflict.Code {
+		t.Errorf("Accept after Decline: err = %v, want a conflict", err)
+	}
+	if got := f.outbox(); len(got) != 0 {
+		t.Errorf("outbox = %v, want nothing published for a declined offer", got)
+	}
+}
+
+func TestAcceptOtherNGO(t *testing.T) {
+	offer := testOffer(uuid.New())
+	f, db := newFakeDB(offer)
+	s := &Service{repo: &Repository{db: db}}
+
+	if _, err := s.Accept(offer.ID, uuid.New()); err != errors.ErrForbidden {
+		t.Errorf("err = %v, want ErrForbidden", err)
+	}
+	if len(f.outbox()) != 0 || f.offer.Status != "pending" {
+		t.Error("another NGO resolved the offer")
+	}
+}
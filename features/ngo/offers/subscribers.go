@@ -0,0 +1,32 @@
+package offers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/events"
+	"foodlink_backend/features/notifications"
+)
+
+// RegisterSubscribers subscribes the offers feature to domain events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypeOfferAccepted, "ngo_offers.prompt_pickup", promptPickup)
+}
+
+// promptPickup reminds the NGO to schedule a pickup for an accepted offer
+func promptPickup(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var accepted events.OfferAccepted
+	if err := event.Decode(&accepted); err != nil {
+		return err
+	}
+	description := fmt.Sprintf("Schedule a pickup for %s from %s (%.1f kg).", accepted.OfferTitle, accepted.DonorName, accepted.WeightKg)
+	_, err := notifications.NewService().WithContext(ctx).Notify(tx, &notifications.NewNotification{
+		UserID:          accepted.NGOUserID,
+		Source:          notifications.SourceNGO,
+		Type:            "pickup",
+		Title:           "Offer accepted",
+		Message:         description,
+		RelatedEntityID: &accepted.OfferID,
+	})
+	return err
+}
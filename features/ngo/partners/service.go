@@ -1,6 +1,7 @@
 package partners
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,6 +16,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByNGOUserID(ngoUserID uuid.UUID) ([]*NGOPartnerProfile, error) {
 	return s.repo.GetAllByNGOUserID(ngoUserID)
 }
@@ -25,7 +30,7 @@ func (s *Service) GetByID(id uuid.UUID) (*NGOPartnerProfile, error) {
 
 func (s *Service) Create(ngoUserID uuid.UUID, req *CreateNGOPartnerRequest) (*NGOPartnerProfile, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	partner := &NGOPartnerProfile{
 		ID:                 uuid.New(),
@@ -59,7 +64,7 @@ func (s *Service) Update(id uuid.UUID, ngoUserID uuid.UUID, req *UpdateNGOPartne
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.Name != "" {
 		partner.Name = req.Name
@@ -5,6 +5,7 @@ import (
 	"foodlink_backend/errors"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/utils"
+	"io"
 	"net/http"
 	"strings"
 
@@ -27,134 +28,128 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 	return user.ID, nil
 }
 
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	offerIDStr := r.URL.Query().Get("offer_id")
 	if offerIDStr == "" {
-		utils.BadRequestResponse(w, "offer_id query parameter is required", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "offer_id query parameter is required")
 	}
 	offerID, err := uuid.Parse(offerIDStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid offer_id format", nil)
-		return
+		return errors.NewAppError(http.StatusBadRequest, "Invalid offer_id format")
 	}
-	schedules, err := h.service.GetAllByOfferID(offerID)
+	schedules, err := h.service.WithContext(r.Context()).GetAllByOfferID(offerID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve pickup schedules", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve pickup schedules")
 	}
 	utils.OKResponse(w, "Pickup schedules retrieved successfully", schedules)
+	return nil
 }
 
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/pickups/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	schedule, err := h.service.GetByID(id)
+	schedule, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve pickup schedule", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve pickup schedule")
 	}
 	utils.OKResponse(w, "Pickup schedule retrieved successfully", schedule)
+	return nil
 }
 
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	var req CreateNGOPickupScheduleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	schedule, err := h.service.Create(&req)
+	schedule, err := h.service.WithContext(r.Context()).Create(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create pickup schedule", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create pickup schedule")
 	}
 	utils.CreatedResponse(w, "Pickup schedule created successfully", schedule)
+	return nil
 }
 
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/pickups/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateNGOPickupScheduleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	schedule, err := h.service.Update(id, &req)
+	schedule, err := h.service.WithContext(r.Context()).Update(id, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update pickup schedule", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update pickup schedule")
 	}
 	utils.OKResponse(w, "Pickup schedule updated successfully", schedule)
+	return nil
 }
 
-func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return err
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/pickups/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	schedule, err := h.service.WithContext(r.Context()).Patch(id, userID, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update pickup schedule")
+	}
+	utils.OKResponse(w, "Pickup schedule updated successfully", schedule)
+	return nil
+}
+
+func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/ngo/pickups/"), "/")
 	if len(pathParts) < 2 || pathParts[1] != "status" {
-		utils.BadRequestResponse(w, "Invalid path", nil)
-		return
+		return errors.ErrInvalidPath
 	}
 	id, err := uuid.Parse(pathParts[0])
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdatePickupStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	schedule, err := h.service.UpdateStatus(id, &req)
+	schedule, err := h.service.WithContext(r.Context()).UpdateStatus(id, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update pickup status", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update pickup status")
 	}
 	utils.OKResponse(w, "Pickup status updated successfully", schedule)
+	return nil
 }
@@ -0,0 +1,84 @@
+package pickups
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/database"
+	"foodlink_backend/features/notifications"
+	"foodlink_backend/jobs"
+	"time"
+)
+
+// defaultReminderLead is when pickups without reminders are reminded
+const defaultReminderLead = time.Hour
+
+// RegisterJobs registers the pickups background jobs
+func RegisterJobs(scheduler *jobs.Scheduler) {
+	scheduler.Schedule("ngo_pickups.send_reminders", "* * * * *", sendReminders)
+}
+
+// sendReminders notifies NGOs of upcoming pickups. Each reminder in a
+// pickup's reminders array ({time, type, delivered}) is sent once its time
+// has passed and then marked delivered; pickups without reminders get one
+// defaultReminderLead before pickup.
+func sendReminders(ctx context.Context, job *jobs.Job) error {
+	repo := NewRepository().WithContext(ctx)
+	notifier := notifications.NewService().WithContext(ctx)
+	now := time.Now()
+	return database.WithTransaction(ctx, repo.db, func(tx *sql.Tx) error {
+		txRepo := repo.WithTx(tx)
+		targets, err := txRepo.LockReminderTargets(now.Add(defaultReminderLead))
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			reminders := target.Reminders
+			if reminders == nil {
+				reminders = []map[string]interface{}{{
+					"time":      target.ScheduledFor.Add(-defaultReminderLead).UTC().Format(time.RFC3339),
+					"type":      "pickup",
+					"delivered": false,
+				}}
+			}
+
+			sent := false
+			for _, reminder := range reminders {
+				if !reminderDue(reminder, now) {
+					continue
+				}
+				description := fmt.Sprintf("Pickup of %s with %s is scheduled at %s UTC.", target.OfferTitle, target.VolunteerName, target.ScheduledFor.UTC().Format("15:04"))
+				_, err := notifier.Notify(tx, &notifications.NewNotification{
+					UserID:          target.NGOUserID,
+					Source:          notifications.SourceNGO,
+					Type:            "pickup",
+					Title:           "Pickup reminder",
+					Message:         description,
+					RelatedEntityID: &target.PickupID,
+				})
+				if err != nil {
+					return err
+				}
+				reminder["delivered"] = true
+				sent = true
+			}
+			if sent {
+				if err := txRepo.SetReminders(target.PickupID, reminders); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// reminderDue reports whether a reminder is undelivered and its RFC 3339
+// time has passed. Malformed reminders are never due.
+func reminderDue(reminder map[string]interface{}, now time.Time) bool {
+	if delivered, _ := reminder["delivered"].(bool); delivered {
+		return false
+	}
+	value, _ := reminder["time"].(string)
+	at, err := time.Parse(time.RFC3339, value)
+	return err == nil && !at.After(now)
+}
@@ -72,3 +72,28 @@ type UpdateNGOPickupScheduleRequest struct {
 type UpdatePickupStatusRequest struct {
 	Status string `json:"status" validate:"required,oneof=scheduled en-route picked-up delivered failed"`
 }
+
+// PatchNGOPickupScheduleRequest holds the mutable fields of a pickup schedule.
+// PATCH requests merge a JSON Merge Patch document into it and validate the result.
+// Status changes go through the dedicated status endpoint.
+type PatchNGOPickupScheduleRequest struct {
+	ScheduledFor     time.Time              `json:"scheduled_for" validate:"required"`
+	ETAMinutes       *int                   `json:"eta_minutes,omitempty"`
+	VolunteerName    string                 `json:"volunteer_name" validate:"required,min=1"`
+	VolunteerContact string                 `json:"volunteer_contact" validate:"required,min=1"`
+	VehicleType      string                 `json:"vehicle_type,omitempty" validate:"omitempty,oneof=van bike car on-foot"`
+	Checkpoints      map[string]interface{} `json:"checkpoints,omitempty"`
+	Reminders        map[string]interface{} `json:"reminders,omitempty"`
+	Notes            string                 `json:"notes,omitempty"`
+}
+
+// ReminderTarget is an upcoming scheduled pickup that may have reminders due
+type ReminderTarget struct {
+	PickupID      uuid.UUID
+	NGOUserID     uuid.UUID
+	OfferTitle    string
+	ScheduledFor  time.Time
+	VolunteerName string
+	// Reminders is the stored reminders array, nil when none were set
+	Reminders []map[string]interface{}
+}
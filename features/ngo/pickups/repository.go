@@ -1,6 +1,7 @@
 package pickups
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
@@ -11,19 +12,33 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByOfferID(offerID uuid.UUID) ([]*NGOPickupSchedule, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, offer_id, route_id, scheduled_for, eta_minutes, volunteer_name, volunteer_contact, vehicle_type, status, checkpoints, reminders, notes, created_at, updated_at FROM ngo_pickup_schedules WHERE offer_id = $1 ORDER BY scheduled_for ASC`
-	rows, err := r.db.Query(query, offerID)
+	rows, err := r.conn().Query(query, offerID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -53,7 +68,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*NGOPickupSchedule, error) {
 	schedule := &NGOPickupSchedule{}
 	var checkpointsJSON, remindersJSON []byte
 	query := `SELECT id, offer_id, route_id, scheduled_for, eta_minutes, volunteer_name, volunteer_contact, vehicle_type, status, checkpoints, reminders, notes, created_at, updated_at FROM ngo_pickup_schedules WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&schedule.ID, &schedule.OfferID, &schedule.RouteID, &schedule.ScheduledFor, &schedule.ETAMinutes, &schedule.VolunteerName, &schedule.VolunteerContact, &schedule.VehicleType, &schedule.Status, &checkpointsJSON, &remindersJSON, &schedule.Notes, &schedule.CreatedAt, &schedule.UpdatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&schedule.ID, &schedule.OfferID, &schedule.RouteID, &schedule.ScheduledFor, &schedule.ETAMinutes, &schedule.VolunteerName, &schedule.VolunteerContact, &schedule.VehicleType, &schedule.Status, &checkpointsJSON, &remindersJSON, &schedule.Notes, &schedule.CreatedAt, &schedule.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -78,7 +93,7 @@ func (r *Repository) Create(schedule *NGOPickupSchedule) error {
 	query := `INSERT INTO ngo_pickup_schedules (id, offer_id, route_id, scheduled_for, eta_minutes, volunteer_name, volunteer_contact, vehicle_type, status, checkpoints, reminders, notes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id, offer_id, route_id, scheduled_for, eta_minutes, volunteer_name, volunteer_contact, vehicle_type, status, checkpoints, reminders, notes, created_at, updated_at`
 	now := time.Now()
 	var checkpointsJSONOut, remindersJSONOut []byte
-	err := r.db.QueryRow(query, schedule.ID, schedule.OfferID, schedule.RouteID, schedule.ScheduledFor, schedule.ETAMinutes, schedule.VolunteerName, schedule.VolunteerContact, schedule.VehicleType, schedule.Status, checkpointsJSON, remindersJSON, schedule.Notes, now, now).Scan(&schedule.ID, &schedule.OfferID, &schedule.RouteID, &schedule.ScheduledFor, &schedule.ETAMinutes, &schedule.VolunteerName, &schedule.VolunteerContact, &schedule.VehicleType, &schedule.Status, &checkpointsJSONOut, &remindersJSONOut, &schedule.Notes, &schedule.CreatedAt, &schedule.UpdatedAt)
+	err := r.conn().QueryRow(query, schedule.ID, schedule.OfferID, schedule.RouteID, schedule.ScheduledFor, schedule.ETAMinutes, schedule.VolunteerName, schedule.VolunteerContact, schedule.VehicleType, schedule.Status, checkpointsJSON, remindersJSON, schedule.Notes, now, now).Scan(&schedule.ID, &schedule.OfferID, &schedule.RouteID, &schedule.ScheduledFor, &schedule.ETAMinutes, &schedule.VolunteerName, &schedule.VolunteerContact, &schedule.VehicleType, &schedule.Status, &checkpointsJSONOut, &remindersJSONOut, &schedule.Notes, &schedule.CreatedAt, &schedule.UpdatedAt)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -99,7 +114,7 @@ func (r *Repository) Update(schedule *NGOPickupSchedule) error {
 	remindersJSON, _ := json.Marshal(schedule.Reminders)
 	query := `UPDATE ngo_pickup_schedules SET scheduled_for=$1, eta_minutes=$2, volunteer_name=$3, volunteer_contact=$4, vehicle_type=$5, status=$6, checkpoints=$7, reminders=$8, notes=$9, updated_at=$10 WHERE id=$11 RETURNING id, offer_id, route_id, scheduled_for, eta_minutes, volunteer_name, volunteer_contact, vehicle_type, status, checkpoints, reminders, notes, created_at, updated_at`
 	var checkpointsJSONOut, remindersJSONOut []byte
-	err := r.db.QueryRow(query, schedule.ScheduledFor, schedule.ETAMinutes, schedule.VolunteerName, schedule.VolunteerContact, schedule.VehicleType, schedule.Status, checkpointsJSON, remindersJSON, schedule.Notes, time.Now(), schedule.ID).Scan(&schedule.ID, &schedule.OfferID, &schedule.RouteID, &schedule.ScheduledFor, &schedule.ETAMinutes, &schedule.VolunteerName, &schedule.VolunteerContact, &schedule.VehicleType, &schedule.Status, &checkpointsJSONOut, &remindersJSONOut, &schedule.Notes, &schedule.CreatedAt, &schedule.UpdatedAt)
+	err := r.conn().QueryRow(query, schedule.ScheduledFor, schedule.ETAMinutes, schedule.VolunteerName, schedule.VolunteerContact, schedule.VehicleType, schedule.Status, checkpointsJSON, remindersJSON, schedule.Notes, time.Now(), schedule.ID).Scan(&schedule.ID, &schedule.OfferID, &schedule.RouteID, &schedule.ScheduledFor, &schedule.ETAMinutes, &schedule.VolunteerName, &schedule.VolunteerContact, &schedule.VehicleType, &schedule.Status, &checkpointsJSONOut, &remindersJSONOut, &schedule.Notes, &schedule.CreatedAt, &schedule.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.ErrNotFound
@@ -114,3 +129,73 @@ func (r *Repository) Update(schedule *NGOPickupSchedule) error {
 	}
 	return nil
 }
+
+// LockReminderTargets returns the upcoming scheduled pickups that may have
+// reminders due: those with a reminders array, and those without reminders
+// scheduled before defaultCutoff. Run it in a transaction: the pickups stay
+// locked until it ends, and pickups locked elsewhere are skipped.
+func (r *Repository) LockReminderTargets(defaultCutoff time.Time) ([]*ReminderTarget, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT p.id, o.ngo_user_id, o.offer_title, p.scheduled_for, p.volunteer_name, p.reminders
+		FROM ngo_pickup_schedules p
+		JOIN ngo_donation_offers o ON o.id = p.offer_id
+		WHERE p.status = 'scheduled' AND p.scheduled_for > CURRENT_TIMESTAMP
+			AND ((p.reminders IS NULL AND p.scheduled_for <= $1) OR jsonb_typeof(p.reminders) = 'array')
+		ORDER BY p.scheduled_for
+		FOR UPDATE OF p SKIP LOCKED`
+	rows, err := r.conn().Query(query, defaultCutoff)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var targets []*ReminderTarget
+	for rows.Next() {
+		target := &ReminderTarget{}
+		var remindersJSON []byte
+		if err := rows.Scan(&target.PickupID, &target.NGOUserID, &target.OfferTitle, &target.ScheduledFor, &target.VolunteerName, &remindersJSON); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		if len(remindersJSON) > 0 {
+			// Arrays of anything but reminder objects can't be processed
+			if err := json.Unmarshal(remindersJSON, &target.Reminders); err != nil {
+				continue
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// SetReminders replaces the reminders of a pickup
+func (r *Repository) SetReminders(id uuid.UUID, reminders []map[string]interface{}) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	remindersJSON, err := json.Marshal(reminders)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE ngo_pickup_schedules SET reminders = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	if _, err := r.conn().Exec(query, remindersJSON, id); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// GetNGOUserID returns the NGO that owns the offer being picked up
+func (r *Repository) GetNGOUserID(offerID uuid.UUID) (uuid.UUID, error) {
+	if r.db == nil {
+		return uuid.Nil, errors.ErrDatabase
+	}
+	var ngoUserID uuid.UUID
+	err := r.conn().QueryRow(`SELECT ngo_user_id FROM ngo_donation_offers WHERE id = $1`, offerID).Scan(&ngoUserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, errors.ErrNotFound
+		}
+		return uuid.Nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return ngoUserID, nil
+}
@@ -1,8 +1,14 @@
 package pickups
 
 import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/metrics"
 	"foodlink_backend/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +21,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByOfferID(offerID uuid.UUID) ([]*NGOPickupSchedule, error) {
 	return s.repo.GetAllByOfferID(offerID)
 }
@@ -25,7 +35,7 @@ func (s *Service) GetByID(id uuid.UUID) (*NGOPickupSchedule, error) {
 
 func (s *Service) Create(req *CreateNGOPickupScheduleRequest) (*NGOPickupSchedule, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	schedule := &NGOPickupSchedule{
 		ID:              uuid.New(),
@@ -43,6 +53,7 @@ func (s *Service) Create(req *CreateNGOPickupScheduleRequest) (*NGOPickupSchedul
 	if err := s.repo.Create(schedule); err != nil {
 		return nil, err
 	}
+	metrics.PickupsTotal.Inc(schedule.Status)
 	return schedule, nil
 }
 
@@ -52,7 +63,7 @@ func (s *Service) Update(id uuid.UUID, req *UpdateNGOPickupScheduleRequest) (*NG
 		return nil, err
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.ScheduledFor != nil {
 		schedule.ScheduledFor = *req.ScheduledFor
@@ -84,17 +95,99 @@ func (s *Service) Update(id uuid.UUID, req *UpdateNGOPickupScheduleRequest) (*NG
 	return schedule, nil
 }
 
+// Patch merges a JSON Merge Patch document into a pickup schedule of one of
+// the NGO's offers
+func (s *Service) Patch(id uuid.UUID, userID uuid.UUID, patch []byte) (*NGOPickupSchedule, error) {
+	schedule, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	ngoUserID, err := s.repo.GetNGOUserID(schedule.OfferID)
+	if err != nil {
+		return nil, err
+	}
+	if ngoUserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	doc := &PatchNGOPickupScheduleRequest{
+		ScheduledFor:     schedule.ScheduledFor,
+		ETAMinutes:       schedule.ETAMinutes,
+		VolunteerName:    schedule.VolunteerName,
+		VolunteerContact: schedule.VolunteerContact,
+		VehicleType:      schedule.VehicleType,
+		Checkpoints:      schedule.Checkpoints,
+		Reminders:        schedule.Reminders,
+		Notes:            schedule.Notes,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	schedule.ScheduledFor = doc.ScheduledFor
+	schedule.ETAMinutes = doc.ETAMinutes
+	schedule.VolunteerName = doc.VolunteerName
+	schedule.VolunteerContact = doc.VolunteerContact
+	schedule.VehicleType = doc.VehicleType
+	schedule.Checkpoints = JSONB(doc.Checkpoints)
+	schedule.Reminders = JSONB(doc.Reminders)
+	schedule.Notes = doc.Notes
+	if err := s.repo.Update(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
 func (s *Service) UpdateStatus(id uuid.UUID, req *UpdatePickupStatusRequest) (*NGOPickupSchedule, error) {
 	schedule, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
+	previousStatus := schedule.Status
 	schedule.Status = req.Status
-	if err := s.repo.Update(schedule); err != nil {
+	if schedule.Status != previousStatus {
+		// Delivery is recorded in the NGO's donation history through the
+		// PickupDelivered event
+		err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+			repo := s.repo.WithTx(tx)
+			if err := repo.Update(schedule); err != nil {
+				return err
+			}
+			ngoUserID, err := repo.GetNGOUserID(schedule.OfferID)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			err = events.Publish(s.repo.ctx, tx, events.PickupStatusChanged{
+				PickupID:       schedule.ID,
+				OfferID:        schedule.OfferID,
+				NGOUserID:      ngoUserID,
+				PreviousStatus: previousStatus,
+				Status:         schedule.Status,
+				ChangedAt:      now,
+			})
+			if err != nil || schedule.Status != "delivered" {
+				return err
+			}
+			return events.Publish(s.repo.ctx, tx, events.PickupDelivered{
+				PickupID:    schedule.ID,
+				OfferID:     schedule.OfferID,
+				PickupTime:  schedule.ScheduledFor,
+				DeliveredAt: now,
+			})
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to update pickup status")
+		}
+	} else if err := s.repo.Update(schedule); err != nil {
 		return nil, err
 	}
+	if schedule.Status != previousStatus {
+		metrics.PickupsTotal.Inc(schedule.Status)
+	}
 	return schedule, nil
 }
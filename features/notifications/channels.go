@@ -0,0 +1,171 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"foodlink_backend/config"
+	"foodlink_backend/mailer"
+	"foodlink_backend/tracing"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errNoAddress is returned by channels when the user has no address on
+// them; the delivery fails without retrying
+var errNoAddress = errors.New("no address for this channel")
+
+// Recipient is who a delivery goes to, with their address on each channel
+type Recipient struct {
+	UserID     uuid.UUID
+	Name       string
+	Email      string
+	Phone      string
+	PushTokens []string
+}
+
+// Message is the content sent on a channel
+type Message struct {
+	Title string
+	Body  string
+}
+
+// Channel sends notifications outside the app
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, to *Recipient, msg *Message) error
+}
+
+// SMSProvider sends text messages
+type SMSProvider interface {
+	SendSMS(ctx context.Context, phone, text string) error
+}
+
+// PushProvider sends push notifications to devices
+type PushProvider interface {
+	Push(ctx context.Context, tokens []string, title, body string) error
+}
+
+// NewSMSProvider returns the configured SMS provider, or nil when there is
+// none. Only the log driver exists so far; configuration validation rejects
+// others.
+func NewSMSProvider(cfg *config.Config) SMSProvider {
+	switch cfg.Notifications.SMSDriver {
+	case "log":
+		return LogSMSProvider{}
+	default:
+		return nil
+	}
+}
+
+// NewPushProvider returns the configured push provider, or nil when there is
+// none. Only the log driver exists so far; configuration validation rejects
+// others.
+func NewPushProvider(cfg *config.Config) PushProvider {
+	switch cfg.Notifications.PushDriver {
+	case "log":
+		return LogPushProvider{}
+	default:
+		return nil
+	}
+}
+
+// NewChannels returns the channels, by name, whose mailer or provider is
+// configured. Deliveries on the others fail without retrying.
+func NewChannels(cfg *config.Config, mail mailer.Mailer, sms SMSProvider, push PushProvider) map[string]Channel {
+	channels := map[string]Channel{}
+	if mail != nil {
+		channels[ChannelEmail] = &EmailChannel{mailer: mail, driver: cfg.Mail.Driver}
+	}
+	if sms != nil {
+		channels[ChannelSMS] = &SMSChannel{provider: sms, driver: cfg.Notifications.SMSDriver}
+	}
+	if push != nil {
+		channels[ChannelPush] = &PushChannel{provider: push, driver: cfg.Notifications.PushDriver}
+	}
+	return channels
+}
+
+// startSpan starts the span of a send on the channel's provider
+func startSpan(ctx context.Context, channel, driver string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "notification send",
+		attribute.String("notification.channel", channel),
+		attribute.String("notification.driver", driver),
+	)
+}
+
+// EmailChannel sends notifications by email
+type EmailChannel struct {
+	mailer mailer.Mailer
+	driver string
+}
+
+func (c *EmailChannel) Name() string { return ChannelEmail }
+
+func (c *EmailChannel) Send(ctx context.Context, to *Recipient, msg *Message) error {
+	if to.Email == "" {
+		return errNoAddress
+	}
+	ctx, span := startSpan(ctx, ChannelEmail, c.driver)
+	err := c.mailer.Send(ctx, &mailer.Message{
+		To:      to.Email,
+		Subject: msg.Title,
+		Text:    msg.Body,
+	})
+	tracing.End(span, err)
+	return err
+}
+
+// SMSChannel sends notifications as text messages
+type SMSChannel struct {
+	provider SMSProvider
+	driver   string
+}
+
+func (c *SMSChannel) Name() string { return ChannelSMS }
+
+func (c *SMSChannel) Send(ctx context.Context, to *Recipient, msg *Message) error {
+	if to.Phone == "" {
+		return errNoAddress
+	}
+	ctx, span := startSpan(ctx, ChannelSMS, c.driver)
+	err := c.provider.SendSMS(ctx, to.Phone, msg.Title+": "+msg.Body)
+	tracing.End(span, err)
+	return err
+}
+
+// PushChannel sends notifications to the user's registered devices
+type PushChannel struct {
+	provider PushProvider
+	driver   string
+}
+
+func (c *PushChannel) Name() string { return ChannelPush }
+
+func (c *PushChannel) Send(ctx context.Context, to *Recipient, msg *Message) error {
+	if len(to.PushTokens) == 0 {
+		return errNoAddress
+	}
+	ctx, span := startSpan(ctx, ChannelPush, c.driver)
+	err := c.provider.Push(ctx, to.PushTokens, msg.Title, msg.Body)
+	tracing.End(span, err)
+	return err
+}
+
+// LogSMSProvider only logs text messages, for development
+type LogSMSProvider struct{}
+
+func (LogSMSProvider) SendSMS(ctx context.Context, phone, text string) error {
+	slog.InfoContext(ctx, "SMS not sent (log driver)", "phone", phone, "text", text)
+	return nil
+}
+
+// LogPushProvider only logs push notifications, for development
+type LogPushProvider struct{}
+
+func (LogPushProvider) Push(ctx context.Context, tokens []string, title, body string) error {
+	slog.InfoContext(ctx, "Push notification not sent (log driver)", "devices", len(tokens), "title", title, "body", body)
+	return nil
+}
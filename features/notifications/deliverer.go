@@ -0,0 +1,264 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/database"
+	"foodlink_backend/events"
+	"foodlink_backend/metrics"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// retryBaseDelay and retryMaxDelay bound the exponential retry backoff
+const (
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = time.Hour
+)
+
+// sendTimeout bounds one attempt on a channel
+const sendTimeout = 30 * time.Second
+
+// maxDigestLines is how many notifications a digest lists before summing
+// up the rest
+const maxDigestLines = 20
+
+// RetryDelay returns how long to wait before the next attempt after attempt
+// failed: 1m, 2m, 4m, ... capped at one hour
+func RetryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// enqueueDeliveries queues a new notification on the channels its recipient
+// chose for its type. Critical notifications go out right away; others wait
+// for the end of the recipient's quiet hours, and are batched into a digest
+// once the recipient got DigestThreshold deliveries on the channel within
+// DigestWindow.
+func enqueueDeliveries(ctx context.Context, tx *sql.Tx, cfg config.NotificationsConfig, n *events.NotificationCreated) error {
+	repo := NewRepository().WithContext(ctx).WithTx(tx)
+	channels, err := repo.GetChannels(n.UserID, n.Source, n.Type)
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+	settings, err := repo.GetSettings(n.UserID)
+	if err != nil {
+		return err
+	}
+	quiet := newQuietHours(settings.Timezone, settings.QuietHoursStart, settings.QuietHoursEnd)
+	urgent := n.Severity == SeverityCritical
+	now := time.Now()
+	for _, channel := range channels {
+		d := &Delivery{
+			UserID:         n.UserID,
+			NotificationID: &n.NotificationID,
+			Channel:        channel,
+			Title:          n.Title,
+			Message:        n.Message,
+			Status:         DeliveryPending,
+			NextAttemptAt:  now,
+		}
+		if !urgent {
+			recent, err := repo.CountSince(n.UserID, channel, now.Add(-cfg.DigestWindow))
+			if err != nil {
+				return err
+			}
+			if recent >= cfg.DigestThreshold {
+				d.Status = DeliveryBatched
+			}
+			d.NextAttemptAt = quiet.NextAllowed(now)
+		}
+		if err := repo.CreateDelivery(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliverer sends queued deliveries and merges batched ones into digests.
+// Instances may run concurrently: rows are claimed with FOR UPDATE SKIP
+// LOCKED.
+type Deliverer struct {
+	repo     *Repository
+	channels map[string]Channel
+	cfg      config.NotificationsConfig
+}
+
+// NewDeliverer creates a deliverer sending on channels
+func NewDeliverer(cfg *config.Config, channels map[string]Channel) *Deliverer {
+	return &Deliverer{repo: NewRepository(), channels: channels, cfg: cfg.Notifications}
+}
+
+// Start sends deliveries in the background until ctx is done
+func (d *Deliverer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.cfg.DeliveryInterval)
+		defer ticker.Stop()
+		for {
+			if err := d.flushDigests(ctx); err != nil && ctx.Err() == nil {
+				slog.Warn("Failed to create notification digests", "error", err)
+			}
+			// Keep going without waiting while full batches come back
+			n, err := d.RunOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.Warn("Failed to send notifications", "error", err)
+			}
+			if n == d.cfg.BatchSize {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Check implements health.Check: it fails when more than the backlog
+// threshold of deliveries are overdue
+func (d *Deliverer) Check(ctx context.Context) error {
+	overdue, err := d.repo.WithContext(ctx).CountOverdue(d.cfg.BacklogAge)
+	if err != nil {
+		return err
+	}
+	if overdue > d.cfg.BacklogThreshold {
+		return fmt.Errorf("%d deliveries overdue by more than %s", overdue, d.cfg.BacklogAge)
+	}
+	return nil
+}
+
+// RunOnce claims one batch of due deliveries and sends them concurrently,
+// returning how many were claimed
+func (d *Deliverer) RunOnce(ctx context.Context) (int, error) {
+	repo := d.repo.WithContext(ctx)
+	claimed, err := repo.ClaimDue(d.cfg.BatchSize, sendTimeout+time.Minute)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	for _, delivery := range claimed {
+		wg.Add(1)
+		go func(delivery *Delivery) {
+			defer wg.Done()
+			d.deliver(ctx, repo, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+	return len(claimed), nil
+}
+
+// deliver sends one delivery and records the outcome
+func (d *Deliverer) deliver(ctx context.Context, repo *Repository, delivery *Delivery) {
+	logger := slog.With("delivery_id", delivery.ID, "channel", delivery.Channel, "attempt", delivery.Attempts)
+	err := d.send(ctx, repo, delivery)
+	if ctx.Err() != nil {
+		// Shutting down: the lease expires and the attempt is repeated
+		return
+	}
+	if err == nil {
+		if err := repo.RecordSent(delivery.ID); err != nil {
+			logger.Error("Failed to record notification delivery", "error", err)
+			return
+		}
+		metrics.NotificationDeliveriesTotal.Inc(delivery.Channel, DeliverySent)
+		return
+	}
+
+	status, outcome := DeliveryPending, "retry"
+	if delivery.Attempts >= d.cfg.MaxAttempts || stderrors.Is(err, errNoAddress) {
+		status, outcome = DeliveryFailed, DeliveryFailed
+	}
+	if err := repo.RecordFailure(delivery.ID, status, err.Error(), time.Now().Add(RetryDelay(delivery.Attempts))); err != nil {
+		logger.Error("Failed to record notification delivery failure", "error", err)
+		return
+	}
+	metrics.NotificationDeliveriesTotal.Inc(delivery.Channel, outcome)
+	logger.Warn("Notification delivery failed", "error", err, "status", status)
+}
+
+func (d *Deliverer) send(ctx context.Context, repo *Repository, delivery *Delivery) error {
+	channel, ok := d.channels[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("%w: channel %q is not configured", errNoAddress, delivery.Channel)
+	}
+	to, err := repo.GetRecipient(delivery.UserID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	return channel.Send(ctx, to, &Message{Title: delivery.Title, Body: delivery.Message})
+}
+
+// flushDigests merges the batched deliveries of each user and channel into
+// one digest once the oldest has waited DigestWindow
+func (d *Deliverer) flushDigests(ctx context.Context) error {
+	repo := d.repo.WithContext(ctx)
+	groups, err := repo.GetDigestGroups(time.Now().Add(-d.cfg.DigestWindow), d.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		err := database.WithTransaction(ctx, repo.db, func(tx *sql.Tx) error {
+			txRepo := repo.WithTx(tx)
+			batched, err := txRepo.LockBatched(group.UserID, group.Channel)
+			if err != nil || len(batched) == 0 {
+				return err
+			}
+			settings, err := txRepo.GetSettings(group.UserID)
+			if err != nil {
+				return err
+			}
+			quiet := newQuietHours(settings.Timezone, settings.QuietHoursStart, settings.QuietHoursEnd)
+			digest := &Delivery{
+				UserID:        group.UserID,
+				Channel:       group.Channel,
+				Digest:        true,
+				Title:         fmt.Sprintf("You have %d new notifications", len(batched)),
+				Message:       digestMessage(batched),
+				Status:        DeliveryPending,
+				NextAttemptAt: quiet.NextAllowed(time.Now()),
+			}
+			if err := txRepo.CreateDelivery(digest); err != nil {
+				return err
+			}
+			ids := make([]uuid.UUID, len(batched))
+			for i, b := range batched {
+				ids[i] = b.ID
+			}
+			return txRepo.MarkDigested(ids, digest.ID)
+		})
+		if err != nil {
+			return err
+		}
+		metrics.NotificationDigestsTotal.Inc(group.Channel)
+	}
+	return nil
+}
+
+// digestMessage lists the batched notifications, oldest first
+func digestMessage(batched []*Delivery) string {
+	var b strings.Builder
+	for i, d := range batched {
+		if i == maxDigestLines {
+			fmt.Fprintf(&b, "...and %d more.\n", len(batched)-i)
+			break
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", d.Title, d.Message)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
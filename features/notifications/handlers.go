@@ -0,0 +1,402 @@
+package notifications
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return uuid.Nil, errors.ErrUnauthorized
+	}
+	return user.ID, nil
+}
+
+// pathParts splits a /notifications/... path relative to /api/v1
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/notifications"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// notificationID parses the notification ID at the start of the path
+func notificationID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// List handles GET /api/v1/notifications
+// @Summary      List notifications
+// @Description  List the user's community and NGO notifications, unread first and newest first within each
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  query     string  false  "community or ngo; both when omitted"
+// @Param        unread  query     bool    false  "Only unread notifications"
+// @Param        page    query     int     false  "Page number, from 1"
+// @Param        limit   query     int     false  "Page size (default 20, at most 100)"
+// @Success      200     {object}  NotificationList
+// @Failure      400     {object}  errors.Problem
+// @Failure      401     {object}  errors.Problem
+// @Router       /notifications [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	query := r.URL.Query()
+	filter := ListFilter{Source: query.Get("source")}
+	filter.UnreadOnly, _ = strconv.ParseBool(query.Get("unread"))
+	if pageStr := query.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil {
+			filter.Page = p
+		}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	list, err := h.service.WithContext(r.Context()).List(userID, filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve notifications")
+	}
+	utils.OKResponse(w, "Notifications retrieved successfully", list)
+	return nil
+}
+
+// UnreadCount handles GET /api/v1/notifications/unread-count
+// @Summary      Count unread notifications
+// @Description  Count the user's unread notifications, in total and per source
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  UnreadCount
+// @Failure      401  {object}  errors.Problem
+// @Router       /notifications/unread-count [get]
+func (h *Handler) UnreadCount(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	count, err := h.service.WithContext(r.Context()).UnreadCount(userID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to count unread notifications")
+	}
+	utils.OKResponse(w, "Unread notifications counted successfully", count)
+	return nil
+}
+
+// MarkRead handles POST /api/v1/notifications/:id/read
+// @Summary      Mark notification read
+// @Description  Mark one of the user's notifications read
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Notification ID"
+// @Success      200  {object}  Notification
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /notifications/{id}/read [post]
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := notificationID(r)
+	if err != nil {
+		return err
+	}
+	notification, err := h.service.WithContext(r.Context()).MarkRead(userID, id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to mark notification read")
+	}
+	utils.OKResponse(w, "Notification marked read successfully", notification)
+	return nil
+}
+
+// MarkAllRead handles POST /api/v1/notifications/read-all
+// @Summary      Mark all notifications read
+// @Description  Mark all of the user's unread notifications read, optionally of one source only
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        source  query     string  false  "community or ngo; both when omitted"
+// @Success      200     {object}  map[string]int64
+// @Failure      400     {object}  errors.Problem
+// @Failure      401     {object}  errors.Problem
+// @Router       /notifications/read-all [post]
+func (h *Handler) MarkAllRead(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	count, err := h.service.WithContext(r.Context()).MarkAllRead(userID, r.URL.Query().Get("source"))
+	if err != nil {
+		return errors.Wrap(err, "Failed to mark notifications read")
+	}
+	utils.OKResponse(w, "Notifications marked read successfully", map[string]int64{"updated": count})
+	return nil
+}
+
+// Delete handles DELETE /api/v1/notifications/:id
+// @Summary      Delete notification
+// @Description  Delete one of the user's notifications
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Notification ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /notifications/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := notificationID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(userID, id); err != nil {
+		return errors.Wrap(err, "Failed to delete notification")
+	}
+	utils.OKResponse(w, "Notification deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// GetSettings handles GET /api/v1/notifications/settings
+// @Summary      Get notification settings
+// @Description  Get the user's time zone, quiet hours, phone number and the channels each notification type is sent on besides in-app
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  Settings
+// @Failure      401  {object}  errors.Problem
+// @Router       /notifications/settings [get]
+func (h *Handler) GetSettings(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	settings, err := h.service.WithContext(r.Context()).GetSettings(userID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve notification settings")
+	}
+	utils.OKResponse(w, "Notification settings retrieved successfully", settings)
+	return nil
+}
+
+// UpdateSettings handles PUT /api/v1/notifications/settings
+// @Summary      Update notification settings
+// @Description  Change the fields that are set. channels replaces the channels of the listed source and type pairs; an empty list makes a type in-app only. Quiet hours are HH:MM in the user's time zone and wrap past midnight when the start is later than the end; empty strings turn them off. Only critical NGO notifications are sent during quiet hours.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        settings  body      UpdateSettingsRequest  true  "Settings to change"
+// @Success      200       {object}  Settings
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Router       /notifications/settings [put]
+func (h *Handler) UpdateSettings(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req UpdateSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	settings, err := h.service.WithContext(r.Context()).UpdateSettings(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update notification settings")
+	}
+	utils.OKResponse(w, "Notification settings updated successfully", settings)
+	return nil
+}
+
+// GetPushTokens handles GET /api/v1/notifications/push-tokens
+// @Summary      List push devices
+// @Description  List the devices registered for the user's push notifications
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   PushToken
+// @Failure      401  {object}  errors.Problem
+// @Router       /notifications/push-tokens [get]
+func (h *Handler) GetPushTokens(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	tokens, err := h.service.WithContext(r.Context()).GetPushTokens(userID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve push devices")
+	}
+	utils.OKResponse(w, "Push devices retrieved successfully", tokens)
+	return nil
+}
+
+// RegisterPushToken handles POST /api/v1/notifications/push-tokens
+// @Summary      Register push device
+// @Description  Register a device token for push notifications. A token registered by another account moves to this one.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        device  body      RegisterPushTokenRequest  true  "Device"
+// @Success      201     {object}  PushToken
+// @Failure      400     {object}  errors.Problem
+// @Failure      401     {object}  errors.Problem
+// @Router       /notifications/push-tokens [post]
+func (h *Handler) RegisterPushToken(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req RegisterPushTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	token, err := h.service.WithContext(r.Context()).RegisterPushToken(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to register push device")
+	}
+	utils.CreatedResponse(w, "Push device registered successfully", token)
+	return nil
+}
+
+// DeletePushToken handles DELETE /api/v1/notifications/push-tokens/:token
+// @Summary      Remove push device
+// @Description  Stop sending push notifications to a device
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        token  path      string  true  "Device token, URL-encoded"
+// @Success      200    {object}  map[string]string
+// @Failure      401    {object}  errors.Problem
+// @Failure      404    {object}  errors.Problem
+// @Router       /notifications/push-tokens/{token} [delete]
+func (h *Handler) DeletePushToken(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	parts := pathParts(r)
+	if len(parts) != 2 {
+		return errors.ErrInvalidPath
+	}
+	token, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return errors.ErrInvalidPath
+	}
+	if err := h.service.WithContext(r.Context()).DeletePushToken(userID, token); err != nil {
+		return errors.Wrap(err, "Failed to remove push device")
+	}
+	utils.OKResponse(w, "Push device removed successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// GetDeliveries handles GET /api/v1/notifications/deliveries
+// @Summary      List notification deliveries
+// @Description  List the user's latest email, SMS and push deliveries with their status, attempts and last error, newest first. Batched deliveries wait to be merged into a digest.
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        channel  query     string  false  "email, sms or push"
+// @Param        status   query     string  false  "pending, sending, sent, failed, batched or digested"
+// @Param        limit    query     int     false  "Maximum number of deliveries (default 50, at most 200)"
+// @Success      200      {array}   Delivery
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Router       /notifications/deliveries [get]
+func (h *Handler) GetDeliveries(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	filter := DeliveryFilter{Channel: r.URL.Query().Get("channel"), Status: r.URL.Query().Get("status")}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	deliveries, err := h.service.WithContext(r.Context()).GetDeliveries(userID, filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve notification deliveries")
+	}
+	utils.OKResponse(w, "Notification deliveries retrieved successfully", deliveries)
+	return nil
+}
@@ -0,0 +1,180 @@
+package notifications
+
+import (
+	"foodlink_backend/events"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification sources: the table a notification is stored in
+const (
+	SourceCommunity = events.NotificationsCommunity
+	SourceNGO       = events.NotificationsNGO
+)
+
+// Preferences that can turn a notification off. Community members set
+// notify_on_claim and notify_on_messages in their community profile;
+// restaurants set notify_on_pickup and notify_on_expiry in their
+// preferences. A missing profile or preferences row counts as opted in.
+const (
+	PreferenceClaim    = "claim"
+	PreferenceMessages = "messages"
+	PreferencePickup   = "pickup"
+	PreferenceExpiry   = "expiry"
+)
+
+// NGO notification severities. Critical notifications skip quiet hours and
+// digests.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Types lists the notification types of each source
+var Types = map[string][]string{
+	SourceCommunity: {"claim", "volunteer", "announcement", "surplus", "reminder"},
+	SourceNGO:       {"urgent-offer", "pickup", "volunteer", "feedback", "message"},
+}
+
+// Delivery channels besides the in-app list
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// Delivery statuses in notification_deliveries. Batched deliveries wait to
+// be merged into a digest and become digested when they are.
+const (
+	DeliveryPending  = "pending"
+	DeliverySending  = "sending"
+	DeliverySent     = "sent"
+	DeliveryFailed   = "failed"
+	DeliveryBatched  = "batched"
+	DeliveryDigested = "digested"
+)
+
+// Notification is a community or NGO notification
+type Notification struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	UserID          uuid.UUID  `json:"-" db:"user_id"`
+	Source          string     `json:"source" db:"source"`
+	Type            string     `json:"type" db:"type"`
+	Title           string     `json:"title" db:"title"`
+	Message         string     `json:"message" db:"message"`
+	RelatedEntityID *uuid.UUID `json:"related_entity_id,omitempty" db:"related_entity_id"`
+	Severity        *string    `json:"severity,omitempty" db:"severity"`
+	Read            bool       `json:"read" db:"read"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewNotification is a notification another feature sends. Community
+// notifications take one of the types claim, volunteer, announcement,
+// surplus or reminder; NGO notifications one of urgent-offer, pickup,
+// volunteer, feedback or message.
+type NewNotification struct {
+	UserID uuid.UUID
+	Source string
+	Type   string
+	Title  string
+	// Message is the body, stored as description for NGO notifications
+	Message string
+	// RelatedEntityID and Severity are kept for NGO notifications only
+	RelatedEntityID *uuid.UUID
+	Severity        string
+	// Preference names the setting that can turn this notification off
+	Preference string
+}
+
+// ListFilter selects a page of notifications
+type ListFilter struct {
+	Source     string
+	UnreadOnly bool
+	Page       int
+	Limit      int
+}
+
+// NotificationList is a page of notifications, unread first
+type NotificationList struct {
+	Notifications []*Notification `json:"notifications"`
+	Page          int             `json:"page"`
+	Limit         int             `json:"limit"`
+	Total         int             `json:"total"`
+}
+
+// UnreadCount is the number of unread notifications
+type UnreadCount struct {
+	Total     int `json:"total"`
+	Community int `json:"community"`
+	NGO       int `json:"ngo"`
+}
+
+// ChannelPreference is where notifications of one source and type are sent
+// besides the in-app list. Types without a preference are in-app only.
+type ChannelPreference struct {
+	Source   string   `json:"source" validate:"required,oneof=community ngo"`
+	Type     string   `json:"type" validate:"required,max=50"`
+	Channels []string `json:"channels" validate:"dive,oneof=email sms push"`
+}
+
+// Settings are a user's delivery settings. Quiet hours are "HH:MM" in the
+// user's time zone; during them only critical notifications are sent, the
+// others wait until the quiet hours end.
+type Settings struct {
+	Timezone        string               `json:"timezone"`
+	QuietHoursStart *string              `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *string              `json:"quiet_hours_end,omitempty"`
+	Phone           *string              `json:"phone,omitempty"`
+	Channels        []*ChannelPreference `json:"channels"`
+}
+
+// UpdateSettingsRequest changes the fields that are set. Channels replaces
+// the preferences of the source and type pairs listed; an empty channel
+// list makes a type in-app only again. Empty quiet hours turn them off.
+type UpdateSettingsRequest struct {
+	Timezone        *string              `json:"timezone,omitempty" validate:"omitempty,max=64"`
+	QuietHoursStart *string              `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd   *string              `json:"quiet_hours_end,omitempty"`
+	Phone           *string              `json:"phone,omitempty" validate:"omitempty,e164"`
+	Channels        []*ChannelPreference `json:"channels,omitempty" validate:"dive"`
+}
+
+// PushToken is a device registered for push notifications
+type PushToken struct {
+	Token     string    `json:"token" db:"token"`
+	Platform  string    `json:"platform" db:"platform"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RegisterPushTokenRequest registers a device for push notifications
+type RegisterPushTokenRequest struct {
+	Token    string `json:"token" validate:"required,max=512"`
+	Platform string `json:"platform" validate:"required,oneof=ios android web"`
+}
+
+// Delivery is one notification, or one digest, sent on one channel
+type Delivery struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	UserID         uuid.UUID  `json:"-" db:"user_id"`
+	NotificationID *uuid.UUID `json:"notification_id,omitempty" db:"notification_id"`
+	Channel        string     `json:"channel" db:"channel"`
+	Digest         bool       `json:"digest" db:"digest"`
+	Title          string     `json:"title" db:"title"`
+	Message        string     `json:"message" db:"message"`
+	Status         string     `json:"status" db:"status"`
+	Attempts       int        `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError      *string    `json:"last_error,omitempty" db:"last_error"`
+	DigestID       *uuid.UUID `json:"digest_id,omitempty" db:"digest_id"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	SentAt         *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+}
+
+// DeliveryFilter selects deliveries of the user
+type DeliveryFilter struct {
+	Channel string
+	Status  string
+	Limit   int
+}
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // Time zones for hosts without a zoneinfo database
+)
+
+// parseClock parses "HH:MM" into minutes after midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("must be HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// loadLocation returns the time zone, or UTC when it is unknown
+func loadLocation(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// QuietHours is a daily period in a time zone during which notifications
+// wait. Start after End spans midnight; Start equal to End is never quiet.
+type QuietHours struct {
+	Start    int
+	End      int
+	Location *time.Location
+}
+
+// newQuietHours returns the quiet hours of the settings, or nil when they
+// are not set
+func newQuietHours(timezone string, start, end *string) *QuietHours {
+	if start == nil || end == nil {
+		return nil
+	}
+	s, err := parseClock(*start)
+	if err != nil {
+		return nil
+	}
+	e, err := parseClock(*end)
+	if err != nil || s == e {
+		return nil
+	}
+	return &QuietHours{Start: s, End: e, Location: loadLocation(timezone)}
+}
+
+// NextAllowed returns t when it is outside the quiet hours, and otherwise
+// when they end
+func (q *QuietHours) NextAllowed(t time.Time) time.Time {
+	if q == nil {
+		return t
+	}
+	local := t.In(q.Location)
+	minute := local.Hour()*60 + local.Minute()
+	var days int
+	switch {
+	case q.Start < q.End && minute >= q.Start && minute < q.End:
+		days = 0
+	case q.Start > q.End && minute >= q.Start:
+		days = 1
+	case q.Start > q.End && minute < q.End:
+		days = 0
+	default:
+		return t
+	}
+	y, m, d := local.Date()
+	return time.Date(y, m, d+days, q.End/60, q.End%60, 0, 0, q.Location)
+}
@@ -0,0 +1,601 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+// userNotifications is both tables of the user in $1 with a common shape
+const userNotifications = `(
+	SELECT id, user_id, 'community' AS source, type, title, message, NULL::uuid AS related_entity_id, NULL::varchar AS severity, COALESCE(read, FALSE) AS read, created_at
+	FROM community_notifications WHERE user_id = $1
+	UNION ALL
+	SELECT id, ngo_user_id, 'ngo', type, title, description, related_entity_id, severity, COALESCE(read, FALSE), created_at
+	FROM ngo_notifications WHERE ngo_user_id = $1
+) n`
+
+// listFilter matches the source in $2 (empty for both) and, when $3 is
+// true, unread notifications only
+const listFilter = `($2::text = '' OR source = $2::text) AND (NOT $3::boolean OR NOT read)`
+
+const notificationColumns = `id, user_id, source, type, title, message, related_entity_id, severity, read, created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanNotification(row rowScanner) (*Notification, error) {
+	n := &Notification{}
+	err := row.Scan(&n.ID, &n.UserID, &n.Source, &n.Type, &n.Title, &n.Message, &n.RelatedEntityID, &n.Severity, &n.Read, &n.CreatedAt)
+	return n, err
+}
+
+// List returns a page of the user's notifications, unread first and newest
+// first within each, with the total matching the filter
+func (r *Repository) List(userID uuid.UUID, filter ListFilter) ([]*Notification, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	var total int
+	err := r.conn().QueryRow(`SELECT COUNT(*) FROM `+userNotifications+` WHERE `+listFilter, userID, filter.Source, filter.UnreadOnly).Scan(&total)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	query := `SELECT ` + notificationColumns + ` FROM ` + userNotifications + ` WHERE ` + listFilter + ` ORDER BY read, created_at DESC, id LIMIT $4 OFFSET $5`
+	rows, err := r.conn().Query(query, userID, filter.Source, filter.UnreadOnly, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	list := []*Notification{}
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		list = append(list, n)
+	}
+	return list, total, nil
+}
+
+// GetByID returns the user's notification from either table
+func (r *Repository) GetByID(userID, id uuid.UUID) (*Notification, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	n, err := scanNotification(r.conn().QueryRow(`SELECT `+notificationColumns+` FROM `+userNotifications+` WHERE id = $2`, userID, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return n, nil
+}
+
+// UnreadCount counts the user's unread notifications per source
+func (r *Repository) UnreadCount(userID uuid.UUID) (*UnreadCount, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	count := &UnreadCount{}
+	query := `SELECT COUNT(*) FILTER (WHERE source = 'community'), COUNT(*) FILTER (WHERE source = 'ngo') FROM ` + userNotifications + ` WHERE NOT read`
+	if err := r.conn().QueryRow(query, userID).Scan(&count.Community, &count.NGO); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	count.Total = count.Community + count.NGO
+	return count, nil
+}
+
+// MarkRead marks the user's notification read in whichever table holds it
+func (r *Repository) MarkRead(userID, id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	for _, query := range []string{
+		`UPDATE community_notifications SET read = TRUE WHERE id = $1 AND user_id = $2`,
+		`UPDATE ngo_notifications SET read = TRUE WHERE id = $1 AND ngo_user_id = $2`,
+	} {
+		result, err := r.conn().Exec(query, id, userID)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrDatabase)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+// MarkAllRead marks the user's unread notifications of source read, or of
+// both sources when source is empty, and returns how many changed
+func (r *Repository) MarkAllRead(userID uuid.UUID, source string) (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var total int64
+	if source == "" || source == SourceCommunity {
+		result, err := r.conn().Exec(`UPDATE community_notifications SET read = TRUE WHERE user_id = $1 AND read IS NOT TRUE`, userID)
+		if err != nil {
+			return 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		n, _ := result.RowsAffected()
+		total += n
+	}
+	if source == "" || source == SourceNGO {
+		result, err := r.conn().Exec(`UPDATE ngo_notifications SET read = TRUE WHERE ngo_user_id = $1 AND read IS NOT TRUE`, userID)
+		if err != nil {
+			return 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		n, _ := result.RowsAffected()
+		total += n
+	}
+	return total, nil
+}
+
+// Delete deletes the user's notification from whichever table holds it
+func (r *Repository) Delete(userID, id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	for _, query := range []string{
+		`DELETE FROM community_notifications WHERE id = $1 AND user_id = $2`,
+		`DELETE FROM ngo_notifications WHERE id = $1 AND ngo_user_id = $2`,
+	} {
+		result, err := r.conn().Exec(query, id, userID)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrDatabase)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
+
+// preferenceQueries look up whether a preference is on for the user in $1.
+// No row means the user never changed their settings, which default to on.
+var preferenceQueries = map[string]string{
+	PreferenceClaim:    `SELECT COALESCE(notifications_enabled, TRUE) AND COALESCE(notify_on_claim, TRUE) FROM community_profiles WHERE user_id = $1`,
+	PreferenceMessages: `SELECT COALESCE(notifications_enabled, TRUE) AND COALESCE(notify_on_messages, TRUE) FROM community_profiles WHERE user_id = $1`,
+	PreferencePickup:   `SELECT COALESCE(notifications_enabled, TRUE) AND COALESCE(notify_on_pickup, TRUE) FROM restaurant_preferences WHERE user_id = $1`,
+	PreferenceExpiry:   `SELECT COALESCE(notifications_enabled, TRUE) AND COALESCE(notify_on_expiry, TRUE) FROM restaurant_preferences WHERE user_id = $1`,
+}
+
+// IsEnabled reports whether the user wants notifications governed by the
+// preference
+func (r *Repository) IsEnabled(userID uuid.UUID, preference string) (bool, error) {
+	query, ok := preferenceQueries[preference]
+	if !ok {
+		return true, nil
+	}
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	var enabled bool
+	err := r.conn().QueryRow(query, userID).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return enabled, nil
+}
+
+// Create stores the notification and publishes NotificationCreated. It must
+// run in a transaction.
+func (r *Repository) Create(n *NewNotification) (*Notification, error) {
+	if r.db == nil || r.tx == nil {
+		return nil, errors.ErrDatabase
+	}
+	created := &Notification{
+		UserID:  n.UserID,
+		Source:  n.Source,
+		Type:    n.Type,
+		Title:   n.Title,
+		Message: n.Message,
+	}
+	var err error
+	if n.Source == SourceNGO {
+		severity := n.Severity
+		if severity == "" {
+			severity = SeverityInfo
+		}
+		created.RelatedEntityID = n.RelatedEntityID
+		created.Severity = &severity
+		query := `INSERT INTO ngo_notifications (ngo_user_id, type, title, description, related_entity_id, severity) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+		err = r.conn().QueryRow(query, n.UserID, n.Type, n.Title, n.Message, n.RelatedEntityID, severity).Scan(&created.ID, &created.CreatedAt)
+	} else {
+		query := `INSERT INTO community_notifications (user_id, title, message, type) VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+		err = r.conn().QueryRow(query, n.UserID, n.Title, n.Message, n.Type).Scan(&created.ID, &created.CreatedAt)
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	err = events.Publish(r.ctx, r.tx, events.NotificationCreated{
+		NotificationID: created.ID,
+		UserID:         created.UserID,
+		Source:         created.Source,
+		Type:           created.Type,
+		Title:          created.Title,
+		Message:        created.Message,
+		Severity:       stringValue(created.Severity),
+		CreatedAt:      created.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// GetSettings returns the user's delivery settings, with defaults when the
+// user never saved any
+func (r *Repository) GetSettings(userID uuid.UUID) (*Settings, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	settings := &Settings{Timezone: "UTC", Channels: []*ChannelPreference{}}
+	err := r.conn().QueryRow(`SELECT timezone, quiet_hours_start, quiet_hours_end, phone FROM notification_settings WHERE user_id = $1`, userID).
+		Scan(&settings.Timezone, &settings.QuietHoursStart, &settings.QuietHoursEnd, &settings.Phone)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	rows, err := r.conn().Query(`SELECT source, type, channels FROM notification_channel_preferences WHERE user_id = $1 ORDER BY source, type`, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		pref := &ChannelPreference{}
+		if err := rows.Scan(&pref.Source, &pref.Type, pq.Array(&pref.Channels)); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		settings.Channels = append(settings.Channels, pref)
+	}
+	return settings, nil
+}
+
+// SaveSettings stores the time zone, quiet hours and phone number
+func (r *Repository) SaveSettings(userID uuid.UUID, settings *Settings) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO notification_settings (user_id, timezone, quiet_hours_start, quiet_hours_end, phone) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET timezone = EXCLUDED.timezone, quiet_hours_start = EXCLUDED.quiet_hours_start, quiet_hours_end = EXCLUDED.quiet_hours_end, phone = EXCLUDED.phone, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.conn().Exec(query, userID, settings.Timezone, settings.QuietHoursStart, settings.QuietHoursEnd, settings.Phone); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// SetChannels replaces the channels of one source and type; no channels
+// removes the preference
+func (r *Repository) SetChannels(userID uuid.UUID, pref *ChannelPreference) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	var err error
+	if len(pref.Channels) == 0 {
+		_, err = r.conn().Exec(`DELETE FROM notification_channel_preferences WHERE user_id = $1 AND source = $2 AND type = $3`, userID, pref.Source, pref.Type)
+	} else {
+		_, err = r.conn().Exec(`INSERT INTO notification_channel_preferences (user_id, source, type, channels) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, source, type) DO UPDATE SET channels = EXCLUDED.channels, updated_at = CURRENT_TIMESTAMP`, userID, pref.Source, pref.Type, pq.Array(pref.Channels))
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// GetChannels returns the channels the user receives a source and type on
+func (r *Repository) GetChannels(userID uuid.UUID, source, notificationType string) ([]string, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	var channels []string
+	err := r.conn().QueryRow(`SELECT channels FROM notification_channel_preferences WHERE user_id = $1 AND source = $2 AND type = $3`, userID, source, notificationType).
+		Scan(pq.Array(&channels))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return channels, nil
+}
+
+func (r *Repository) GetPushTokens(userID uuid.UUID) ([]*PushToken, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	rows, err := r.conn().Query(`SELECT token, platform, created_at FROM notification_push_tokens WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	tokens := []*PushToken{}
+	for rows.Next() {
+		t := &PushToken{}
+		if err := rows.Scan(&t.Token, &t.Platform, &t.CreatedAt); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// AddPushToken registers a device for the user. A token registered by
+// another user moves to this one, as the device changed hands.
+func (r *Repository) AddPushToken(userID uuid.UUID, req *RegisterPushTokenRequest) (*PushToken, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	t := &PushToken{}
+	query := `INSERT INTO notification_push_tokens (token, user_id, platform) VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform
+		RETURNING token, platform, created_at`
+	if err := r.conn().QueryRow(query, req.Token, userID, req.Platform).Scan(&t.Token, &t.Platform, &t.CreatedAt); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return t, nil
+}
+
+func (r *Repository) DeletePushToken(userID uuid.UUID, token string) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM notification_push_tokens WHERE token = $1 AND user_id = $2`, token, userID)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetRecipient returns the user's addresses on every channel
+func (r *Repository) GetRecipient(userID uuid.UUID) (*Recipient, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	to := &Recipient{UserID: userID}
+	var phone sql.NullString
+	query := `SELECT u.name, u.email, s.phone FROM users u LEFT JOIN notification_settings s ON s.user_id = u.id WHERE u.id = $1`
+	err := r.conn().QueryRow(query, userID).Scan(&to.Name, &to.Email, &phone)
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	to.Phone = phone.String
+	tokens, err := r.GetPushTokens(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		to.PushTokens = append(to.PushTokens, t.Token)
+	}
+	return to, nil
+}
+
+// CountSince counts the user's deliveries on the channel created since the
+// given time, digests included and batched ones not
+func (r *Repository) CountSince(userID uuid.UUID, channel string, since time.Time) (int, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var count int
+	query := `SELECT COUNT(*) FROM notification_deliveries WHERE user_id = $1 AND channel = $2 AND created_at >= $3 AND status NOT IN ('batched', 'digested')`
+	if err := r.conn().QueryRow(query, userID, channel, since).Scan(&count); err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return count, nil
+}
+
+// CreateDelivery queues a delivery. A notification is queued once per
+// channel.
+func (r *Repository) CreateDelivery(d *Delivery) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO notification_deliveries (user_id, notification_id, channel, digest, title, message, status, next_attempt_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (notification_id, channel) DO NOTHING RETURNING id, created_at`
+	err := r.conn().QueryRow(query, d.UserID, d.NotificationID, d.Channel, d.Digest, d.Title, d.Message, d.Status, d.NextAttemptAt).Scan(&d.ID, &d.CreatedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+const deliveryColumns = `id, user_id, notification_id, channel, digest, title, message, status, attempts, next_attempt_at, last_error, digest_id, created_at, sent_at`
+
+func scanDelivery(row rowScanner) (*Delivery, error) {
+	d := &Delivery{}
+	err := row.Scan(&d.ID, &d.UserID, &d.NotificationID, &d.Channel, &d.Digest, &d.Title, &d.Message, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError, &d.DigestID, &d.CreatedAt, &d.SentAt)
+	return d, err
+}
+
+func (r *Repository) queryDeliveries(query string, args ...interface{}) ([]*Delivery, error) {
+	rows, err := r.conn().Query(query, args...)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+// GetDeliveries returns the user's latest deliveries, newest first
+func (r *Repository) GetDeliveries(userID uuid.UUID, filter DeliveryFilter) ([]*Delivery, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + deliveryColumns + ` FROM notification_deliveries WHERE user_id = $1 AND ($2::text = '' OR channel = $2::text) AND ($3::text = '' OR status = $3::text) ORDER BY created_at DESC LIMIT $4`
+	return r.queryDeliveries(query, userID, filter.Channel, filter.Status, filter.Limit)
+}
+
+// ClaimDue marks up to limit due deliveries as sending and returns them.
+// Deliveries still sending after the lease, because an instance stopped
+// mid-send, are claimed again.
+func (r *Repository) ClaimDue(limit int, lease time.Duration) ([]*Delivery, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `UPDATE notification_deliveries SET status = 'sending', attempts = attempts + 1, locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE (status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP) OR (status = 'sending' AND locked_until < CURRENT_TIMESTAMP)
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	return r.queryDeliveries(query, limit, lease.Seconds())
+}
+
+// CountOverdue counts pending deliveries that have been due for longer than
+// age
+func (r *Repository) CountOverdue(age time.Duration) (int, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var count int
+	err := r.conn().QueryRow(`SELECT COUNT(*) FROM notification_deliveries
+		WHERE status = 'pending' AND next_attempt_at < CURRENT_TIMESTAMP - make_interval(secs => $1)`, age.Seconds()).Scan(&count)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return count, nil
+}
+
+// RecordSent marks the delivery sent
+func (r *Repository) RecordSent(id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	_, err := r.conn().Exec(`UPDATE notification_deliveries SET status = 'sent', sent_at = CURRENT_TIMESTAMP, locked_until = NULL, last_error = NULL WHERE id = $1`, id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// RecordFailure stores a failed attempt. status is pending to retry at
+// nextAttempt, or failed.
+func (r *Repository) RecordFailure(id uuid.UUID, status, lastError string, nextAttempt time.Time) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE notification_deliveries SET status = $2, last_error = $3, next_attempt_at = $4, locked_until = NULL WHERE id = $1`
+	if _, err := r.conn().Exec(query, id, status, lastError, nextAttempt); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// digestGroup is a user's batched deliveries on one channel
+type digestGroup struct {
+	UserID  uuid.UUID
+	Channel string
+}
+
+// GetDigestGroups returns up to limit users and channels whose oldest
+// batched delivery was created before the given time
+func (r *Repository) GetDigestGroups(before time.Time, limit int) ([]digestGroup, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT user_id, channel FROM notification_deliveries WHERE status = 'batched' GROUP BY user_id, channel HAVING MIN(created_at) < $1 LIMIT $2`
+	rows, err := r.conn().Query(query, before, limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var groups []digestGroup
+	for rows.Next() {
+		var g digestGroup
+		if err := rows.Scan(&g.UserID, &g.Channel); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// LockBatched locks the user's batched deliveries on the channel, oldest
+// first, skipping those another instance is merging
+func (r *Repository) LockBatched(userID uuid.UUID, channel string) ([]*Delivery, error) {
+	if r.db == nil || r.tx == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + deliveryColumns + ` FROM notification_deliveries WHERE user_id = $1 AND channel = $2 AND status = 'batched' ORDER BY created_at FOR UPDATE SKIP LOCKED`
+	return r.queryDeliveries(query, userID, channel)
+}
+
+// MarkDigested links batched deliveries to the digest that replaced them
+func (r *Repository) MarkDigested(ids []uuid.UUID, digestID uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	_, err := r.conn().Exec(`UPDATE notification_deliveries SET status = 'digested', digest_id = $2 WHERE id = ANY($1)`, pq.Array(ids), digestID)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// DeleteFinishedBefore deletes sent, failed and digested deliveries created
+// before the given time
+func (r *Repository) DeleteFinishedBefore(before time.Time) (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM notification_deliveries WHERE status IN ('sent', 'failed', 'digested') AND created_at < $1`, before)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
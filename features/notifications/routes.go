@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the notification routes, mounted under /api/v1
+func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 1 && parts[0] == "unread-count" && r.Method == http.MethodGet:
+			return handler.UnreadCount(w, r)
+		case len(parts) == 1 && parts[0] == "read-all" && r.Method == http.MethodPost:
+			return handler.MarkAllRead(w, r)
+		case len(parts) == 1 && parts[0] == "settings" && r.Method == http.MethodGet:
+			return handler.GetSettings(w, r)
+		case len(parts) == 1 && parts[0] == "settings" && r.Method == http.MethodPut:
+			return handler.UpdateSettings(w, r)
+		case len(parts) == 1 && parts[0] == "push-tokens" && r.Method == http.MethodGet:
+			return handler.GetPushTokens(w, r)
+		case len(parts) == 1 && parts[0] == "push-tokens" && r.Method == http.MethodPost:
+			return handler.RegisterPushToken(w, r)
+		case len(parts) == 2 && parts[0] == "push-tokens" && r.Method == http.MethodDelete:
+			return handler.DeletePushToken(w, r)
+		case len(parts) == 1 && parts[0] == "deliveries" && r.Method == http.MethodGet:
+			return handler.GetDeliveries(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		case len(parts) == 2 && parts[1] == "read" && r.Method == http.MethodPost:
+			return handler.MarkRead(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/notifications", routes)
+	mux.Handle("/notifications/", routes)
+	return authMiddleware(mux)
+}
@@ -0,0 +1,249 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default and maximum page size of List
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Default and maximum number of deliveries returned by GetDeliveries
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// Service reads a user's notifications and creates them for other features
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+func validateSource(source string) error {
+	switch source {
+	case "", SourceCommunity, SourceNGO:
+		return nil
+	default:
+		return errors.NewAppError(errors.ErrInvalidInput.Code, "Source must be community or ngo")
+	}
+}
+
+// List returns a page of the user's notifications, unread first
+func (s *Service) List(userID uuid.UUID, filter ListFilter) (*NotificationList, error) {
+	if err := validateSource(filter.Source); err != nil {
+		return nil, err
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	list, total, err := s.repo.List(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationList{Notifications: list, Page: filter.Page, Limit: filter.Limit, Total: total}, nil
+}
+
+func (s *Service) UnreadCount(userID uuid.UUID) (*UnreadCount, error) {
+	return s.repo.UnreadCount(userID)
+}
+
+// MarkRead marks the notification read and returns it
+func (s *Service) MarkRead(userID, id uuid.UUID) (*Notification, error) {
+	if err := s.repo.MarkRead(userID, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(userID, id)
+}
+
+// MarkAllRead marks every unread notification of the source read, or of
+// both sources when source is empty, and returns how many changed
+func (s *Service) MarkAllRead(userID uuid.UUID, source string) (int64, error) {
+	if err := validateSource(source); err != nil {
+		return 0, err
+	}
+	return s.repo.MarkAllRead(userID, source)
+}
+
+func (s *Service) Delete(userID, id uuid.UUID) error {
+	return s.repo.Delete(userID, id)
+}
+
+// Notify creates a notification unless the recipient turned its preference
+// off, in which case it returns nil. It runs in tx when given, so the
+// notification commits with the change it reports, and in its own
+// transaction otherwise.
+func (s *Service) Notify(tx *sql.Tx, n *NewNotification) (*Notification, error) {
+	if n.Source != SourceCommunity && n.Source != SourceNGO {
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Source must be community or ngo")
+	}
+	if tx == nil {
+		var created *Notification
+		err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+			var err error
+			created, err = s.Notify(tx, n)
+			return err
+		})
+		return created, err
+	}
+	repo := s.repo.WithTx(tx)
+	enabled, err := repo.IsEnabled(n.UserID, n.Preference)
+	if err != nil || !enabled {
+		return nil, err
+	}
+	return repo.Create(n)
+}
+
+func (s *Service) GetSettings(userID uuid.UUID) (*Settings, error) {
+	return s.repo.GetSettings(userID)
+}
+
+// UpdateSettings changes the delivery settings that are set in req
+func (s *Service) UpdateSettings(userID uuid.UUID, req *UpdateSettingsRequest) (*Settings, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	settings, err := s.repo.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "timezone must be an IANA time zone such as Europe/Berlin")
+		}
+		settings.Timezone = *req.Timezone
+	}
+	if req.QuietHoursStart != nil {
+		settings.QuietHoursStart = emptyToNil(*req.QuietHoursStart)
+	}
+	if req.QuietHoursEnd != nil {
+		settings.QuietHoursEnd = emptyToNil(*req.QuietHoursEnd)
+	}
+	if (settings.QuietHoursStart == nil) != (settings.QuietHoursEnd == nil) {
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "quiet_hours_start and quiet_hours_end must be set together")
+	}
+	for field, value := range map[string]*string{"quiet_hours_start": settings.QuietHoursStart, "quiet_hours_end": settings.QuietHoursEnd} {
+		if value == nil {
+			continue
+		}
+		if _, err := parseClock(*value); err != nil {
+			return nil, errors.NewAppError(errors.ErrInvalidInput.Code, field+" "+err.Error())
+		}
+	}
+	if req.Phone != nil {
+		settings.Phone = emptyToNil(*req.Phone)
+	}
+	for _, pref := range req.Channels {
+		if !isType(pref.Source, pref.Type) {
+			return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Unknown notification type "+pref.Source+":"+pref.Type)
+		}
+		pref.Channels = uniqueStrings(pref.Channels)
+	}
+
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.SaveSettings(userID, settings); err != nil {
+			return err
+		}
+		for _, pref := range req.Channels {
+			if err := repo.SetChannels(userID, pref); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetSettings(userID)
+}
+
+func (s *Service) GetPushTokens(userID uuid.UUID) ([]*PushToken, error) {
+	return s.repo.GetPushTokens(userID)
+}
+
+// RegisterPushToken registers a device for push notifications
+func (s *Service) RegisterPushToken(userID uuid.UUID, req *RegisterPushTokenRequest) (*PushToken, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	return s.repo.AddPushToken(userID, req)
+}
+
+func (s *Service) DeletePushToken(userID uuid.UUID, token string) error {
+	return s.repo.DeletePushToken(userID, token)
+}
+
+// GetDeliveries returns the user's latest email, SMS and push deliveries
+func (s *Service) GetDeliveries(userID uuid.UUID, filter DeliveryFilter) ([]*Delivery, error) {
+	switch filter.Channel {
+	case "", ChannelEmail, ChannelSMS, ChannelPush:
+	default:
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Channel must be one of email, sms or push")
+	}
+	switch filter.Status {
+	case "", DeliveryPending, DeliverySending, DeliverySent, DeliveryFailed, DeliveryBatched, DeliveryDigested:
+	default:
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Status must be one of pending, sending, sent, failed, batched or digested")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	if filter.Limit > maxDeliveryLimit {
+		filter.Limit = maxDeliveryLimit
+	}
+	return s.repo.GetDeliveries(userID, filter)
+}
+
+// isType reports whether the source has the notification type
+func isType(source, notificationType string) bool {
+	for _, t := range Types[source] {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+func emptyToNil(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,20 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/config"
+	"foodlink_backend/events"
+)
+
+// RegisterSubscribers queues new notifications for email, SMS and push
+func RegisterSubscribers(bus *events.Bus, cfg *config.Config) {
+	settings := cfg.Notifications
+	bus.Subscribe(events.TypeNotificationCreated, "notifications.enqueue_deliveries", func(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+		var created events.NotificationCreated
+		if err := event.Decode(&created); err != nil {
+			return err
+		}
+		return enqueueDeliveries(ctx, tx, settings, &created)
+	})
+}
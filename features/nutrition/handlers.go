@@ -39,17 +39,15 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Param        start_date  query     string  false  "Start date (YYYY-MM-DD)"
 // @Param        end_date    query     string  false  "End date (YYYY-MM-DD)"
 // @Success      200         {array}   NutritionData
-// @Failure      401         {object}  errors.AppError
+// @Failure      401         {object}  errors.Problem
 // @Router       /nutrition [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	startDate := time.Now().AddDate(0, 0, -30)
 	endDate := time.Now()
@@ -63,16 +61,12 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 			endDate = t
 		}
 	}
-	data, err := h.service.GetByUserIDAndDateRange(userID, startDate, endDate)
+	data, err := h.service.WithContext(r.Context()).GetByUserIDAndDateRange(userID, startDate, endDate)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve nutrition data", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve nutrition data")
 	}
 	utils.OKResponse(w, "Nutrition data retrieved successfully", data)
+	return nil
 }
 
 // GetToday handles GET /api/v1/nutrition/today
@@ -83,29 +77,23 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  NutritionData
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /nutrition/today [get]
-func (h *Handler) GetToday(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetToday(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	data, err := h.service.GetToday(userID)
+	data, err := h.service.WithContext(r.Context()).GetToday(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve today's nutrition", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve today's nutrition")
 	}
 	utils.OKResponse(w, "Today's nutrition retrieved successfully", data)
+	return nil
 }
 
 // GetByID handles GET /api/v1/nutrition/:id
@@ -117,30 +105,24 @@ func (h *Handler) GetToday(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Nutrition Data ID"
 // @Success      200  {object}  NutritionData
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /nutrition/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/nutrition/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	data, err := h.service.GetByID(id)
+	data, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve nutrition data", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve nutrition data")
 	}
 	utils.OKResponse(w, "Nutrition data retrieved successfully", data)
+	return nil
 }
 
 // Create handles POST /api/v1/nutrition
@@ -152,34 +134,27 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateNutritionDataRequest  true  "Nutrition data"
 // @Success      201      {object}  NutritionData
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /nutrition [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateNutritionDataRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	data, err := h.service.Create(userID, &req)
+	data, err := h.service.WithContext(r.Context()).Create(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create nutrition data", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create nutrition data")
 	}
 	utils.CreatedResponse(w, "Nutrition data created successfully", data)
+	return nil
 }
 
 // Update handles PUT /api/v1/nutrition/:id
@@ -192,42 +167,34 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                    true  "Nutrition Data ID"
 // @Param        request  body      UpdateNutritionDataRequest true  "Nutrition data"
 // @Success      200      {object}  NutritionData
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /nutrition/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/nutrition/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateNutritionDataRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	data, err := h.service.Update(id, userID, &req)
+	data, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update nutrition data", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update nutrition data")
 	}
 	utils.OKResponse(w, "Nutrition data updated successfully", data)
+	return nil
 }
 
 // GetStats handles GET /api/v1/nutrition/stats
@@ -239,17 +206,15 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        days  query     int  false  "Number of days (default: 30)"
 // @Success      200   {object}  NutritionStats
-// @Failure      401   {object}  errors.AppError
+// @Failure      401   {object}  errors.Problem
 // @Router       /nutrition/stats [get]
-func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	days := 30
 	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
@@ -257,14 +222,10 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 			days = d
 		}
 	}
-	stats, err := h.service.GetStats(userID, days)
+	stats, err := h.service.WithContext(r.Context()).GetStats(userID, days)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve stats", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve stats")
 	}
 	utils.OKResponse(w, "Stats retrieved successfully", stats)
+	return nil
 }
@@ -1,6 +1,7 @@
 package nutrition
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 	"time"
@@ -16,6 +17,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetByUserIDAndDateRange(userID uuid.UUID, startDate, endDate time.Time) ([]*NutritionData, error) {
 	return s.repo.GetByUserIDAndDateRange(userID, startDate, endDate)
 }
@@ -31,7 +36,7 @@ func (s *Service) GetByID(id uuid.UUID) (*NutritionData, error) {
 
 func (s *Service) Create(userID uuid.UUID, req *CreateNutritionDataRequest) (*NutritionData, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	date := req.Date
 	if date.IsZero() {
@@ -71,7 +76,7 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateNutritionDat
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.Calories != nil {
 		d.Calories = *req.Calories
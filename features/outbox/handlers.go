@@ -0,0 +1,99 @@
+package outbox
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// pathParts splits a /events/... path relative to /api/v1/admin
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/events"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// List handles GET /api/v1/admin/events
+// @Summary      List event deliveries
+// @Description  List outbox entries, one per event and subscriber, most recent first. Use status=dead for the dead-letter queue (admin only).
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "Delivery status"  Enums(pending, processing, delivered, dead)
+// @Param        type    query     string  false  "Event type, e.g. PickupDelivered"
+// @Param        limit   query     int     false  "Number of entries (default: 50, max: 200)"
+// @Success      200     {array}   Entry
+// @Failure      400     {object}  errors.Problem
+// @Failure      401     {object}  errors.Problem
+// @Failure      403     {object}  errors.Problem
+// @Router       /admin/events [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	filter := ListFilter{
+		Status:    r.URL.Query().Get("status"),
+		EventType: r.URL.Query().Get("type"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	entries, err := h.service.WithContext(r.Context()).List(filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve events")
+	}
+	utils.OKResponse(w, "Events retrieved successfully", entries)
+	return nil
+}
+
+// Retry handles POST /api/v1/admin/events/:id/retry
+// @Summary      Retry dead-lettered event
+// @Description  Requeue a dead-lettered delivery with a fresh set of attempts (admin only)
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Outbox entry ID"
+// @Success      200  {object}  Entry
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Failure      409  {object}  errors.Problem
+// @Router       /admin/events/{id}/retry [post]
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	parts := pathParts(r)
+	if len(parts) != 2 || parts[1] != "retry" {
+		return errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	entry, err := h.service.WithContext(r.Context()).Retry(id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retry event")
+	}
+	utils.OKResponse(w, "Event requeued successfully", entry)
+	return nil
+}
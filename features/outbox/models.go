@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry is one event delivery to one subscriber
+type Entry struct {
+	ID            uuid.UUID       `json:"id"`
+	EventID       uuid.UUID       `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	Subscriber    string          `json:"subscriber"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// ListFilter narrows the entries returned by List
+type ListFilter struct {
+	Status    string
+	EventType string
+	Limit     int
+}
@@ -0,0 +1,96 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
+const entryColumns = `id, event_id, event_type, subscriber, payload, status, attempts, next_attempt_at, last_error, occurred_at, delivered_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEntry(row rowScanner) (*Entry, error) {
+	entry := &Entry{}
+	err := row.Scan(&entry.ID, &entry.EventID, &entry.EventType, &entry.Subscriber, &entry.Payload, &entry.Status, &entry.Attempts, &entry.NextAttemptAt, &entry.LastError, &entry.OccurredAt, &entry.DeliveredAt)
+	return entry, err
+}
+
+// List returns entries matching filter, most recent first
+func (r *Repository) List(filter ListFilter) ([]*Entry, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + entryColumns + ` FROM event_outbox
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR event_type = $2)
+		ORDER BY occurred_at DESC LIMIT $3`
+	rows, err := r.conn().Query(query, filter.Status, filter.EventType, filter.Limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	entries := []*Entry{}
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*Entry, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	entry, err := scanEntry(r.conn().QueryRow(`SELECT `+entryColumns+` FROM event_outbox WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return entry, nil
+}
+
+// Requeue makes a dead entry due again with a fresh set of attempts. It
+// returns ErrNotFound when the entry doesn't exist or isn't dead.
+func (r *Repository) Requeue(id uuid.UUID) (*Entry, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `UPDATE event_outbox SET status = 'pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, locked_until = NULL
+		WHERE id = $1 AND status = 'dead'
+		RETURNING ` + entryColumns
+	entry, err := scanEntry(r.conn().QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return entry, nil
+}
@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupAdminRoutes sets up the event delivery routes, mounted under /api/v1/admin
+func SetupAdminRoutes(handler *Handler, authMiddleware, requireAdmin func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 2 && parts[1] == "retry" && r.Method == http.MethodPost:
+			return handler.Retry(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/events", routes)
+	mux.Handle("/events/", routes)
+	return middleware.Chain(authMiddleware, requireAdmin)(mux)
+}
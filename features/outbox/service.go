@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"context"
+	"foodlink_backend/errors"
+	"foodlink_backend/events"
+
+	"github.com/google/uuid"
+)
+
+// Default and maximum number of entries returned by List
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// Service lets admins inspect event deliveries and retry dead ones
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+// List returns entries matching filter. An empty status matches every status.
+func (s *Service) List(filter ListFilter) ([]*Entry, error) {
+	switch filter.Status {
+	case "", events.StatusPending, events.StatusProcessing, events.StatusDelivered, events.StatusDead:
+	default:
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Status must be one of pending, processing, delivered or dead")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	return s.repo.List(filter)
+}
+
+// Retry requeues a dead-lettered entry. Entries in any other status are
+// rejected with a conflict.
+func (s *Service) Retry(id uuid.UUID) (*Entry, error) {
+	entry, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != events.StatusDead {
+		return nil, errors.NewAppError(errors.ErrConflict.Code, "Only dead-lettered events can be retried")
+	}
+	return s.repo.Requeue(id)
+}
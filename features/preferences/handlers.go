@@ -34,34 +34,28 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  FamilyPreferences
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /preferences [get]
-func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	// Use user's household_id or user_id as household_id
 	householdID := userID
 	if user, ok := r.Context().Value("user").(*auth.User); ok && user.HouseholdID != nil {
 		householdID = *user.HouseholdID
 	}
-	prefs, err := h.service.GetByHouseholdID(householdID)
+	prefs, err := h.service.WithContext(r.Context()).GetByHouseholdID(householdID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve preferences", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve preferences")
 	}
 	utils.OKResponse(w, "Preferences retrieved successfully", prefs)
+	return nil
 }
 
 // CreateOrUpdate handles POST/PUT /api/v1/preferences
@@ -74,24 +68,21 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 // @Param        request  body      CreatePreferencesRequest  true  "Preferences data"
 // @Success      200      {object}  FamilyPreferences
 // @Success      201      {object}  FamilyPreferences
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /preferences [post]
 // @Router       /preferences [put]
-func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost && r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreatePreferencesRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 	// Set household_id from user if not provided
 	if req.HouseholdID == uuid.Nil {
@@ -101,18 +92,14 @@ func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) {
 			req.HouseholdID = userID
 		}
 	}
-	prefs, err := h.service.CreateOrUpdate(&req)
+	prefs, err := h.service.WithContext(r.Context()).CreateOrUpdate(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to save preferences", err.Error())
-		return
+		return errors.Wrap(err, "Failed to save preferences")
 	}
 	if r.Method == http.MethodPost {
 		utils.CreatedResponse(w, "Preferences created successfully", prefs)
 	} else {
 		utils.OKResponse(w, "Preferences updated successfully", prefs)
 	}
+	return nil
 }
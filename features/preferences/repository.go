@@ -1,6 +1,7 @@
 package preferences
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
@@ -12,13 +13,22 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 func (r *Repository) GetByHouseholdID(householdID uuid.UUID) (*FamilyPreferences, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
@@ -37,7 +47,7 @@ func (r *Repository) GetByHouseholdID(householdID uuid.UUID) (*FamilyPreferences
 		WHERE household_id = $1
 	`
 
-	err := r.db.QueryRow(query, householdID).Scan(
+	err := r.conn().QueryRow(query, householdID).Scan(
 		&prefs.ID, &prefs.HouseholdID, &prefs.HouseholdSize,
 		&ageGroupsJSON, &prefs.CookingFrequency, &eatingScheduleJSON,
 		&prefs.DietaryType, pq.Array(&prefs.DietaryRestrictions), pq.Array(&prefs.Allergies),
@@ -125,7 +135,7 @@ func (r *Repository) Create(prefs *FamilyPreferences) error {
 	now := time.Now()
 	var ageGroupsJSONOut, eatingScheduleJSONOut, budgetRangeJSONOut, macroGoalJSONOut []byte
 
-	err := r.db.QueryRow(query,
+	err := r.conn().QueryRow(query,
 		prefs.ID, prefs.HouseholdID, prefs.HouseholdSize,
 		ageGroupsJSON, prefs.CookingFrequency, eatingScheduleJSON,
 		prefs.DietaryType, pq.Array(prefs.DietaryRestrictions), pq.Array(prefs.Allergies),
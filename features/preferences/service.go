@@ -1,6 +1,7 @@
 package preferences
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,13 +16,17 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetByHouseholdID(householdID uuid.UUID) (*FamilyPreferences, error) {
 	return s.repo.GetByHouseholdID(householdID)
 }
 
 func (s *Service) CreateOrUpdate(req *CreatePreferencesRequest) (*FamilyPreferences, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 
 	prefs := &FamilyPreferences{
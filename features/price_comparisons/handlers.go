@@ -26,21 +26,16 @@ func NewHandler(service *Service) *Handler {
 // @Produce      json
 // @Success      200  {array}   PriceComparison
 // @Router       /price-comparisons [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
-	comparisons, err := h.service.GetAll()
+	comparisons, err := h.service.WithContext(r.Context()).GetAll()
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve price comparisons", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve price comparisons")
 	}
 	utils.OKResponse(w, "Price comparisons retrieved successfully", comparisons)
+	return nil
 }
 
 // GetByID handles GET /api/v1/price-comparisons/:id
@@ -51,29 +46,23 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      string  true  "Price Comparison ID"
 // @Success      200  {object}  PriceComparison
-// @Failure      404  {object}  errors.AppError
+// @Failure      404  {object}  errors.Problem
 // @Router       /price-comparisons/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/price-comparisons/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	comparison, err := h.service.GetByID(id)
+	comparison, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve price comparison", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve price comparison")
 	}
 	utils.OKResponse(w, "Price comparison retrieved successfully", comparison)
+	return nil
 }
 
 // Create handles POST /api/v1/price-comparisons
@@ -84,28 +73,22 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        request  body      CreatePriceComparisonRequest  true  "Price comparison data"
 // @Success      201      {object}  PriceComparison
-// @Failure      400      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
 // @Router       /price-comparisons [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	var req CreatePriceComparisonRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	comparison, err := h.service.Create(&req)
+	comparison, err := h.service.WithContext(r.Context()).Create(&req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create price comparison", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create price comparison")
 	}
 	utils.CreatedResponse(w, "Price comparison created successfully", comparison)
+	return nil
 }
 
 // Update handles PUT /api/v1/price-comparisons/:id
@@ -117,33 +100,26 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                      true  "Price Comparison ID"
 // @Param        request  body      UpdatePriceComparisonRequest true  "Price comparison data"
 // @Success      200      {object}  PriceComparison
-// @Failure      400      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /price-comparisons/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/price-comparisons/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdatePriceComparisonRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	comparison, err := h.service.Update(id, &req)
+	comparison, err := h.service.WithContext(r.Context()).Update(id, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update price comparison", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update price comparison")
 	}
 	utils.OKResponse(w, "Price comparison updated successfully", comparison)
+	return nil
 }
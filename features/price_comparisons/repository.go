@@ -1,6 +1,7 @@
 package price_comparisons
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
@@ -11,19 +12,28 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 func (r *Repository) GetAll() ([]*PriceComparison, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, item_name, category, stores, best_price, updated_at FROM price_comparisons ORDER BY updated_at DESC`
-	rows, err := r.db.Query(query)
+	rows, err := r.conn().Query(query)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -53,7 +63,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*PriceComparison, error) {
 	c := &PriceComparison{}
 	var storesJSON, bestPriceJSON []byte
 	query := `SELECT id, item_name, category, stores, best_price, updated_at FROM price_comparisons WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&c.ID, &c.ItemName, &c.Category, &storesJSON, &bestPriceJSON, &c.UpdatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&c.ID, &c.ItemName, &c.Category, &storesJSON, &bestPriceJSON, &c.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -78,7 +88,7 @@ func (r *Repository) Create(c *PriceComparison) error {
 	query := `INSERT INTO price_comparisons (id, item_name, category, stores, best_price, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, item_name, category, stores, best_price, updated_at`
 	now := time.Now()
 	var storesJSONOut, bestPriceJSONOut []byte
-	err := r.db.QueryRow(query, c.ID, c.ItemName, c.Category, storesJSON, bestPriceJSON, now).Scan(&c.ID, &c.ItemName, &c.Category, &storesJSONOut, &bestPriceJSONOut, &c.UpdatedAt)
+	err := r.conn().QueryRow(query, c.ID, c.ItemName, c.Category, storesJSON, bestPriceJSON, now).Scan(&c.ID, &c.ItemName, &c.Category, &storesJSONOut, &bestPriceJSONOut, &c.UpdatedAt)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -99,7 +109,7 @@ func (r *Repository) Update(c *PriceComparison) error {
 	bestPriceJSON, _ := json.Marshal(c.BestPrice)
 	query := `UPDATE price_comparisons SET item_name=$1, category=$2, stores=$3, best_price=$4, updated_at=$5 WHERE id=$6 RETURNING id, item_name, category, stores, best_price, updated_at`
 	var storesJSONOut, bestPriceJSONOut []byte
-	err := r.db.QueryRow(query, c.ItemName, c.Category, storesJSON, bestPriceJSON, time.Now(), c.ID).Scan(&c.ID, &c.ItemName, &c.Category, &storesJSONOut, &bestPriceJSONOut, &c.UpdatedAt)
+	err := r.conn().QueryRow(query, c.ItemName, c.Category, storesJSON, bestPriceJSON, time.Now(), c.ID).Scan(&c.ID, &c.ItemName, &c.Category, &storesJSONOut, &bestPriceJSONOut, &c.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.ErrNotFound
@@ -1,6 +1,7 @@
 package price_comparisons
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,6 +16,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAll() ([]*PriceComparison, error) {
 	return s.repo.GetAll()
 }
@@ -25,7 +30,7 @@ func (s *Service) GetByID(id uuid.UUID) (*PriceComparison, error) {
 
 func (s *Service) Create(req *CreatePriceComparisonRequest) (*PriceComparison, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	storesJSON := make([]interface{}, len(req.Stores))
 	for i, store := range req.Stores {
@@ -50,7 +55,7 @@ func (s *Service) Update(id uuid.UUID, req *UpdatePriceComparisonRequest) (*Pric
 		return nil, err
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.ItemName != "" {
 		c.ItemName = req.ItemName
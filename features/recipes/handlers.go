@@ -0,0 +1,268 @@
+package recipes
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxCatalogSize is the largest catalog file imported at once
+const maxCatalogSize = 10 << 20
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// getMember returns the signed-in user and their household, the user's own
+// ID when they have none, as family preferences do
+func (h *Handler) getMember(r *http.Request) (Member, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return Member{}, errors.ErrAuthRequired
+	}
+	member := Member{UserID: user.ID, HouseholdID: user.ID}
+	if user.HouseholdID != nil {
+		member.HouseholdID = *user.HouseholdID
+	}
+	return member, nil
+}
+
+// pathParts splits a /recipes/... path relative to /api/v1 or /api/v1/admin
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/recipes"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// recipeID parses the recipe ID at the start of the path
+func recipeID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// List handles GET /api/v1/recipes
+// @Summary      List recipes
+// @Description  List the recipe catalog by name. Recipes without a meal type match any meal_type.
+// @Tags         recipes
+// @Accept       json
+// @Produce      json
+// @Param        cuisine    query     string  false  "Filter by cuisine"
+// @Param        meal_type  query     string  false  "breakfast, lunch, dinner or snack"
+// @Param        tag        query     string  false  "Filter by tag, such as vegan"
+// @Param        page       query     int     false  "Page number, from 1"
+// @Param        limit      query     int     false  "Page size (default 20, at most 100)"
+// @Success      200        {object}  RecipeList
+// @Router       /recipes [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	query := r.URL.Query()
+	filter := ListFilter{Cuisine: query.Get("cuisine"), MealType: query.Get("meal_type"), Tag: query.Get("tag")}
+	if pageStr := query.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil {
+			filter.Page = p
+		}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	list, err := h.service.WithContext(r.Context()).List(filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve recipes")
+	}
+	utils.OKResponse(w, "Recipes retrieved successfully", list)
+	return nil
+}
+
+// Get handles GET /api/v1/recipes/:id
+// @Summary      Get recipe
+// @Description  Get a recipe of the catalog with its ingredients
+// @Tags         recipes
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Recipe ID"
+// @Success      200  {object}  Recipe
+// @Failure      400  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /recipes/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := recipeID(r)
+	if err != nil {
+		return err
+	}
+	recipe, err := h.service.WithContext(r.Context()).Get(id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve recipe")
+	}
+	utils.OKResponse(w, "Recipe retrieved successfully", recipe)
+	return nil
+}
+
+// Suggestions handles GET /api/v1/recipes/suggestions
+// @Summary      Suggest recipes for expiring items
+// @Description  Rank the recipes that use the user's inventory items expiring within days, those using the most and soonest-expiring items first. Recipes conflicting with the household's allergies are left out. Preferred cuisines, the dietary type and the meal prep preference of the family preferences raise the score; recipes conflicting with the dietary type rank far lower and carry warnings.
+// @Tags         recipes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        days       query     int     false  "Items expiring within this many days (default 3, at most 14)"
+// @Param        meal_type  query     string  false  "breakfast, lunch, dinner or snack"
+// @Param        limit      query     int     false  "Number of suggestions (default 10, at most 50)"
+// @Success      200        {array}   Suggestion
+// @Failure      401        {object}  errors.Problem
+// @Router       /recipes/suggestions [get]
+func (h *Handler) Suggestions(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	query := r.URL.Query()
+	filter := SuggestionFilter{MealType: query.Get("meal_type")}
+	filter.Days, _ = strconv.Atoi(query.Get("days"))
+	filter.Limit, _ = strconv.Atoi(query.Get("limit"))
+	suggestions, err := h.service.WithContext(r.Context()).Suggestions(member, filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve recipe suggestions")
+	}
+	utils.OKResponse(w, "Recipe suggestions retrieved successfully", suggestions)
+	return nil
+}
+
+// Plan handles POST /api/v1/recipes/:id/plan
+// @Summary      Add recipe to meal plans
+// @Description  Add a recipe to the household's meal plans on a date, its ingredients scaled to servings. Without meal_type and servings, the recipe's are used, or dinner. The plan carries warnings as in meal plans.
+// @Tags         recipes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string       true  "Recipe ID"
+// @Param        request  body      PlanRequest  true  "Date, meal type and servings"
+// @Success      201      {object}  meal_plans.MealPlan
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Router       /recipes/{id}/plan [post]
+func (h *Handler) Plan(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := recipeID(r)
+	if err != nil {
+		return err
+	}
+	var req PlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	plan, err := h.service.WithContext(r.Context()).Plan(member, id, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add recipe to meal plans")
+	}
+	utils.CreatedResponse(w, "Recipe added to meal plans successfully", plan)
+	return nil
+}
+
+// Import handles POST /api/v1/admin/recipes/import
+// @Summary      Import recipes
+// @Description  Import a catalog file (admin only): a JSON array of recipes, or with Content-Type text/csv, one row per ingredient with a header naming recipe, ingredient and any of description, cuisine, meal_type, tags (separated by semicolons), prep_minutes, servings, instructions, quantity, unit, food_item and optional. Recipes are matched by name and replaced; ingredients are linked to the food item of their name or food_item. All recipes are imported or none.
+// @Tags         recipes
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      []RecipeInput  true  "Catalog"
+// @Success      200      {object}  ImportResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      415      {object}  errors.Problem
+// @Router       /admin/recipes/import [post]
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	format := FormatJSON
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		switch {
+		case err != nil:
+			return errors.ErrUnsupportedMediaType
+		case mediaType == "text/csv":
+			format = FormatCSV
+		case mediaType != "application/json":
+			return errors.ErrUnsupportedMediaType
+		}
+	}
+	inputs, err := ParseCatalog(http.MaxBytesReader(w, r.Body, maxCatalogSize), format)
+	if err != nil {
+		return errors.NewAppError(errors.ErrBadRequest.Code, err.Error())
+	}
+	result, err := h.service.WithContext(r.Context()).Import(inputs)
+	if err != nil {
+		return errors.Wrap(err, "Failed to import recipes")
+	}
+	utils.OKResponse(w, "Recipes imported successfully", result)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/admin/recipes/:id
+// @Summary      Delete recipe
+// @Description  Remove a recipe from the catalog (admin only). Meal plans made from it are kept.
+// @Tags         recipes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Recipe ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /admin/recipes/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := recipeID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(id); err != nil {
+		return errors.Wrap(err, "Failed to delete recipe")
+	}
+	utils.OKResponse(w, "Recipe deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
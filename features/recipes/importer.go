@@ -0,0 +1,140 @@
+package recipes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Catalog file formats
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// csvColumns are the columns of a CSV catalog, one row per ingredient.
+// Recipe columns are read from the first row of a recipe that has them;
+// tags are separated by semicolons.
+var csvColumns = []string{"recipe", "description", "cuisine", "meal_type", "tags", "prep_minutes", "servings", "instructions", "ingredient", "quantity", "unit", "food_item", "optional"}
+
+// ParseCatalog reads a catalog file: a JSON array of recipes, or a CSV file
+// with a header row naming csvColumns, in any order; only recipe and
+// ingredient are required
+func ParseCatalog(r io.Reader, format string) ([]*RecipeInput, error) {
+	switch format {
+	case FormatJSON:
+		var inputs []*RecipeInput
+		if err := json.NewDecoder(r).Decode(&inputs); err != nil {
+			return nil, fmt.Errorf("invalid JSON catalog: %w", err)
+		}
+		return inputs, nil
+	case FormatCSV:
+		return parseCSV(r)
+	}
+	return nil, fmt.Errorf("unsupported catalog format %q", format)
+}
+
+func parseCSV(r io.Reader) ([]*RecipeInput, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV catalog: %w", err)
+	}
+	index := make(map[string]int)
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"recipe", "ingredient"} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("invalid CSV catalog: missing %s column", required)
+		}
+	}
+
+	var inputs []*RecipeInput
+	byName := make(map[string]*RecipeInput)
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV catalog: %w", err)
+		}
+		field := func(column string) string {
+			if i, ok := index[column]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		name := field("recipe")
+		if name == "" {
+			return nil, fmt.Errorf("line %d: recipe is required", line)
+		}
+		input, ok := byName[strings.ToLower(name)]
+		if !ok {
+			input = &RecipeInput{Name: name}
+			byName[strings.ToLower(name)] = input
+			inputs = append(inputs, input)
+		}
+		if err := fillRecipe(input, field); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		ing := IngredientInput{Name: field("ingredient"), Unit: field("unit"), FoodItem: field("food_item")}
+		if value := field("quantity"); value != "" {
+			quantity, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quantity %q", line, value)
+			}
+			ing.Quantity = &quantity
+		}
+		if value := field("optional"); value != "" {
+			if ing.Optional, err = strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("line %d: invalid optional %q", line, value)
+			}
+		}
+		input.Ingredients = append(input.Ingredients, ing)
+	}
+	return inputs, nil
+}
+
+// fillRecipe sets the recipe fields the input does not have yet
+func fillRecipe(input *RecipeInput, field func(string) string) error {
+	if input.Description == "" {
+		input.Description = field("description")
+	}
+	if input.Cuisine == "" {
+		input.Cuisine = field("cuisine")
+	}
+	if input.MealType == "" {
+		input.MealType = field("meal_type")
+	}
+	if input.Instructions == "" {
+		input.Instructions = field("instructions")
+	}
+	if len(input.Tags) == 0 {
+		for _, tag := range strings.Split(field("tags"), ";") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				input.Tags = append(input.Tags, tag)
+			}
+		}
+	}
+	if value := field("prep_minutes"); input.PrepMinutes == nil && value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid prep_minutes %q", value)
+		}
+		input.PrepMinutes = &minutes
+	}
+	if value := field("servings"); input.Servings == 0 && value != "" {
+		servings, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid servings %q", value)
+		}
+		input.Servings = servings
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package recipes
+
+import (
+	"foodlink_backend/features/meal_plans"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recipe is a recipe of the catalog. Tags name the diets it fits, such as
+// vegan or halal, and traits such as high-protein.
+type Recipe struct {
+	ID           uuid.UUID     `json:"id" db:"id"`
+	Name         string        `json:"name" db:"name"`
+	Description  string        `json:"description,omitempty" db:"description"`
+	Cuisine      string        `json:"cuisine,omitempty" db:"cuisine"`
+	MealType     string        `json:"meal_type,omitempty" db:"meal_type"`
+	Tags         []string      `json:"tags" db:"tags"`
+	PrepMinutes  *int          `json:"prep_minutes,omitempty" db:"prep_minutes"`
+	Servings     int           `json:"servings" db:"servings"`
+	Instructions string        `json:"instructions,omitempty" db:"instructions"`
+	Ingredients  []*Ingredient `json:"ingredients"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Ingredient is an ingredient of a recipe, for the recipe's servings.
+// FoodItemID links it to the reference food item of the same name.
+type Ingredient struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	Quantity   *float64   `json:"quantity,omitempty" db:"quantity"`
+	Unit       string     `json:"unit,omitempty" db:"unit"`
+	FoodItemID *uuid.UUID `json:"food_item_id,omitempty" db:"food_item_id"`
+	Optional   bool       `json:"optional" db:"optional"`
+}
+
+// RecipeInput is a recipe of a catalog file. Recipes are matched by name:
+// importing one again replaces it.
+type RecipeInput struct {
+	Name         string            `json:"name" validate:"required,min=1,max=255"`
+	Description  string            `json:"description,omitempty"`
+	Cuisine      string            `json:"cuisine,omitempty" validate:"omitempty,max=100"`
+	MealType     string            `json:"meal_type,omitempty" validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Tags         []string          `json:"tags,omitempty" validate:"omitempty,dive,min=1,max=50"`
+	PrepMinutes  *int              `json:"prep_minutes,omitempty" validate:"omitempty,min=0"`
+	Servings     int               `json:"servings,omitempty" validate:"omitempty,min=1,max=100"`
+	Instructions string            `json:"instructions,omitempty"`
+	Ingredients  []IngredientInput `json:"ingredients" validate:"required,min=1,dive"`
+}
+
+// IngredientInput is an ingredient of a catalog file. FoodItem names the
+// food item to link it to when it differs from Name.
+type IngredientInput struct {
+	Name     string   `json:"name" validate:"required,min=1,max=255"`
+	Quantity *float64 `json:"quantity,omitempty" validate:"omitempty,gt=0"`
+	Unit     string   `json:"unit,omitempty" validate:"omitempty,max=50"`
+	FoodItem string   `json:"food_item,omitempty" validate:"omitempty,max=255"`
+	Optional bool     `json:"optional,omitempty"`
+}
+
+// ImportResult is what importing a catalog file did. Unlinked lists the
+// ingredients no food item was found for; they are matched by name only.
+type ImportResult struct {
+	Created  int      `json:"created"`
+	Updated  int      `json:"updated"`
+	Unlinked []string `json:"unlinked"`
+}
+
+// ListFilter selects a page of the catalog
+type ListFilter struct {
+	Cuisine  string
+	MealType string
+	Tag      string
+	Page     int
+	Limit    int
+}
+
+// RecipeList is a page of the catalog
+type RecipeList struct {
+	Recipes []*Recipe `json:"recipes"`
+	Page    int       `json:"page"`
+	Limit   int       `json:"limit"`
+	Total   int       `json:"total"`
+}
+
+// SuggestionFilter selects the expiring items to use up and the recipes
+// suggested for them
+type SuggestionFilter struct {
+	// Days is how soon items expire, 3 by default
+	Days     int
+	MealType string
+	Limit    int
+}
+
+// ExpiringUse is an inventory item about to expire that a recipe uses
+type ExpiringUse struct {
+	InventoryItemID uuid.UUID `json:"inventory_item_id"`
+	Name            string    `json:"name"`
+	ExpiryDate      time.Time `json:"expiry_date"`
+	DaysLeft        int       `json:"days_left"`
+}
+
+// Suggestion is a recipe that uses up expiring items. Score ranks
+// suggestions, higher first; Reasons explain it. Missing lists the
+// required ingredients not in stock.
+type Suggestion struct {
+	Recipe   *Recipe              `json:"recipe"`
+	Score    float64              `json:"score"`
+	Uses     []*ExpiringUse       `json:"uses"`
+	Missing  []string             `json:"missing"`
+	Reasons  []string             `json:"reasons"`
+	Warnings []meal_plans.Warning `json:"warnings,omitempty"`
+}
+
+// PlanRequest adds a recipe to the household's meal plans. Without
+// meal_type and servings, the recipe's are used, or dinner.
+type PlanRequest struct {
+	Date     string `json:"date" validate:"required,datetime=2006-01-02"`
+	MealType string `json:"meal_type,omitempty" validate:"omitempty,oneof=breakfast lunch dinner snack"`
+	Servings *int   `json:"servings,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// preferences is what the household's family preferences say about the
+// recipes it likes
+type preferences struct {
+	diet     *meal_plans.Diet
+	cuisines []string
+	mealPrep string
+}
+
+// stockItem is an inventory item of the user that has not expired
+type stockItem struct {
+	name       string
+	foodItemID *uuid.UUID
+}
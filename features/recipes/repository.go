@@ -0,0 +1,278 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/meal_plans"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+const recipeColumns = `id, name, COALESCE(description, ''), COALESCE(cuisine, ''), COALESCE(meal_type, ''), tags, prep_minutes, servings, COALESCE(instructions, ''), created_at, updated_at`
+
+// listFilter selects recipes by $1 cuisine, $2 meal type and $3 tag, each
+// ignored when empty. Recipes without a meal type fit any.
+const listFilter = `($1 = '' OR lower(cuisine) = lower($1))
+	AND ($2 = '' OR meal_type = $2 OR meal_type IS NULL)
+	AND ($3 = '' OR $3 = ANY(tags))`
+
+// scanRecipes scans recipes and loads their ingredients
+func (r *Repository) scanRecipes(rows *sql.Rows) ([]*Recipe, error) {
+	defer rows.Close()
+	recipes := []*Recipe{}
+	for rows.Next() {
+		recipe := &Recipe{Ingredients: []*Ingredient{}}
+		var prepMinutes sql.NullInt64
+		if err := rows.Scan(&recipe.ID, &recipe.Name, &recipe.Description, &recipe.Cuisine, &recipe.MealType, pq.Array(&recipe.Tags), &prepMinutes, &recipe.Servings, &recipe.Instructions, &recipe.CreatedAt, &recipe.UpdatedAt); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		if prepMinutes.Valid {
+			minutes := int(prepMinutes.Int64)
+			recipe.PrepMinutes = &minutes
+		}
+		if recipe.Tags == nil {
+			recipe.Tags = []string{}
+		}
+		recipes = append(recipes, recipe)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	rows.Close()
+	return recipes, r.loadIngredients(recipes)
+}
+
+func (r *Repository) loadIngredients(recipes []*Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(recipes))
+	byID := make(map[uuid.UUID]*Recipe, len(recipes))
+	for i, recipe := range recipes {
+		ids[i] = recipe.ID
+		byID[recipe.ID] = recipe
+	}
+	query := `SELECT recipe_id, id, name, quantity, COALESCE(unit, ''), food_item_id, optional
+		FROM recipe_ingredients WHERE recipe_id = ANY($1) ORDER BY recipe_id, position`
+	rows, err := r.conn().Query(query, pq.Array(ids))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var recipeID uuid.UUID
+		var quantity sql.NullFloat64
+		ing := &Ingredient{}
+		if err := rows.Scan(&recipeID, &ing.ID, &ing.Name, &quantity, &ing.Unit, &ing.FoodItemID, &ing.Optional); err != nil {
+			return errors.WrapError(err, errors.ErrDatabase)
+		}
+		if quantity.Valid {
+			ing.Quantity = &quantity.Float64
+		}
+		if recipe, ok := byID[recipeID]; ok {
+			recipe.Ingredients = append(recipe.Ingredients, ing)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// List returns a page of the catalog by name, with the number of recipes
+// matching the filter
+func (r *Repository) List(filter ListFilter) ([]*Recipe, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	var total int
+	if err := r.conn().QueryRow(`SELECT COUNT(*) FROM recipes WHERE `+listFilter, filter.Cuisine, filter.MealType, filter.Tag).Scan(&total); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	query := `SELECT ` + recipeColumns + ` FROM recipes WHERE ` + listFilter + ` ORDER BY name LIMIT $4 OFFSET $5`
+	rows, err := r.conn().Query(query, filter.Cuisine, filter.MealType, filter.Tag, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	recipes, err := r.scanRecipes(rows)
+	return recipes, total, err
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*Recipe, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	rows, err := r.conn().Query(`SELECT `+recipeColumns+` FROM recipes WHERE id = $1`, id)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	recipes, err := r.scanRecipes(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, errors.ErrNotFound
+	}
+	return recipes[0], nil
+}
+
+// GetUsing returns the recipes of the meal type, or of any without one,
+// with an ingredient linked to one of the food items or named like one of
+// the lowercased names
+func (r *Repository) GetUsing(foodItemIDs []uuid.UUID, names []string, mealType string) ([]*Recipe, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + recipeColumns + ` FROM recipes
+		WHERE ($3 = '' OR meal_type = $3 OR meal_type IS NULL)
+			AND id IN (SELECT recipe_id FROM recipe_ingredients WHERE food_item_id = ANY($1) OR lower(name) = ANY($2))
+		ORDER BY name`
+	rows, err := r.conn().Query(query, pq.Array(foodItemIDs), pq.Array(names), mealType)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return r.scanRecipes(rows)
+}
+
+// Upsert saves the recipe by name, replacing its ingredients, and reports
+// whether it was created. Ingredients are linked to the food item named
+// like any of foodItemNames[i], lowercased; the IDs linked are set on the
+// recipe's ingredients.
+func (r *Repository) Upsert(recipe *Recipe, foodItemNames [][]string) (bool, error) {
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	query := `INSERT INTO recipes (name, description, cuisine, meal_type, tags, prep_minutes, servings, instructions)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, NULLIF($8, ''))
+		ON CONFLICT (name) DO UPDATE SET
+			description = EXCLUDED.description, cuisine = EXCLUDED.cuisine, meal_type = EXCLUDED.meal_type, tags = EXCLUDED.tags,
+			prep_minutes = EXCLUDED.prep_minutes, servings = EXCLUDED.servings, instructions = EXCLUDED.instructions
+		RETURNING id, (xmax = 0)`
+	var created bool
+	err := r.conn().QueryRow(query, recipe.Name, recipe.Description, recipe.Cuisine, recipe.MealType, pq.Array(recipe.Tags), recipe.PrepMinutes, recipe.Servings, recipe.Instructions).Scan(&recipe.ID, &created)
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	if _, err := r.conn().Exec(`DELETE FROM recipe_ingredients WHERE recipe_id = $1`, recipe.ID); err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	insert := `INSERT INTO recipe_ingredients (recipe_id, position, name, quantity, unit, food_item_id, optional)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), (SELECT id FROM food_items WHERE lower(name) = ANY($6) ORDER BY name LIMIT 1), $7)
+		RETURNING id, food_item_id`
+	for i, ing := range recipe.Ingredients {
+		if err := r.conn().QueryRow(insert, recipe.ID, i, ing.Name, ing.Quantity, ing.Unit, pq.Array(foodItemNames[i]), ing.Optional).Scan(&ing.ID, &ing.FoodItemID); err != nil {
+			return false, errors.WrapError(err, errors.ErrDatabase)
+		}
+	}
+	return created, nil
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM recipes WHERE id = $1`, id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetPreferences returns what the household's family preferences say
+// about recipes, empty preferences when it has none
+func (r *Repository) GetPreferences(householdID uuid.UUID) (*preferences, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	prefs := &preferences{}
+	diet := &meal_plans.Diet{}
+	query := `SELECT household_size, COALESCE(dietary_type, ''), COALESCE(allergies, '{}'), COALESCE(preferred_cuisines, '{}'), COALESCE(meal_prep_preference, '')
+		FROM family_preferences WHERE household_id = $1`
+	err := r.conn().QueryRow(query, householdID).Scan(&diet.HouseholdSize, &diet.DietaryType, pq.Array(&diet.Allergies), pq.Array(&prefs.cuisines), &prefs.mealPrep)
+	if err == sql.ErrNoRows {
+		return prefs, nil
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	prefs.diet = diet
+	return prefs, nil
+}
+
+// GetStock returns the user's inventory items that have not expired
+func (r *Repository) GetStock(userID uuid.UUID) ([]stockItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	rows, err := r.conn().Query(`SELECT name, food_item_id FROM inventory_items WHERE user_id = $1 AND (expiry_date IS NULL OR expiry_date >= CURRENT_TIMESTAMP)`, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var stock []stockItem
+	for rows.Next() {
+		var item stockItem
+		if err := rows.Scan(&item.name, &item.foodItemID); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		stock = append(stock, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return stock, nil
+}
+
+// GetPlannedNames returns the lowercased names of the household's meals
+// planned since the given date
+func (r *Repository) GetPlannedNames(householdID uuid.UUID, since time.Time) (map[string]bool, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	rows, err := r.conn().Query(`SELECT DISTINCT lower(name) FROM meal_plans WHERE household_id = $1 AND date >= $2::date`, householdID, since.Format(meal_plans.DateLayout))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		names[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return names, nil
+}
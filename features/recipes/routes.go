@@ -0,0 +1,67 @@
+package recipes
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the public catalog routes, mounted under /api/v1.
+// Suggestions and planning need a signed-in user.
+func SetupRoutes(handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	member := authMiddleware(middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 1 && parts[0] == "suggestions" && r.Method == http.MethodGet:
+			return handler.Suggestions(w, r)
+		case len(parts) == 2 && parts[1] == "plan" && r.Method == http.MethodPost:
+			return handler.Plan(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	}))
+	public := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.Get(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	routes := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := pathParts(r)
+		if (len(parts) == 1 && parts[0] == "suggestions") || (len(parts) == 2 && parts[1] == "plan") {
+			member.ServeHTTP(w, r)
+			return
+		}
+		public.ServeHTTP(w, r)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/recipes", routes)
+	mux.Handle("/recipes/", routes)
+	return mux
+}
+
+// SetupAdminRoutes sets up catalog management routes, mounted under
+// /api/v1/admin
+func SetupAdminRoutes(handler *Handler, authMiddleware, requireAdmin func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 1 && parts[0] == "import" && r.Method == http.MethodPost:
+			return handler.Import(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/recipes", routes)
+	mux.Handle("/recipes/", routes)
+	return middleware.Chain(authMiddleware, requireAdmin)(mux)
+}
@@ -0,0 +1,399 @@
+package recipes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/inventory"
+	"foodlink_backend/features/meal_plans"
+	"foodlink_backend/utils"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit       = 20
+	maxListLimit           = 100
+	defaultSuggestionDays  = 3
+	maxSuggestionDays      = 14
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 50
+	defaultServings        = 2
+	// quickMinutes is the longest prep time of a quick recipe
+	quickMinutes = 30
+	// recentDays is how far back a diverse household's meals are not
+	// suggested again
+	recentDays = 14
+)
+
+// Member is a signed-in user and their household
+type Member struct {
+	UserID      uuid.UUID
+	HouseholdID uuid.UUID
+}
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+func (s *Service) List(filter ListFilter) (*RecipeList, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	filter.Tag = strings.ToLower(filter.Tag)
+	recipes, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &RecipeList{Recipes: recipes, Page: filter.Page, Limit: filter.Limit, Total: total}, nil
+}
+
+func (s *Service) Get(id uuid.UUID) (*Recipe, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *Service) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+// Import saves the recipes of a catalog file, all or none. Recipes already
+// in the catalog, by name, are replaced. Tags are stored lowercased.
+func (s *Service) Import(inputs []*RecipeInput) (*ImportResult, error) {
+	if len(inputs) == 0 {
+		return nil, errors.NewAppError(errors.ErrValidationFailed.Code, "Validation failed: the catalog has no recipes")
+	}
+	for i, input := range inputs {
+		if validationErrors := utils.ValidateStruct(input); len(validationErrors) > 0 {
+			message := fmt.Sprintf("Validation failed: recipe %d (%s): %s", i+1, input.Name, validationErrors[0])
+			return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, message, utils.ValidationErrors(validationErrors))
+		}
+	}
+	result := &ImportResult{Unlinked: []string{}}
+	err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		repo := s.repo.WithTx(tx)
+		for _, input := range inputs {
+			recipe, foodItemNames := newRecipe(input)
+			created, err := repo.Upsert(recipe, foodItemNames)
+			if err != nil {
+				return err
+			}
+			if created {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+			for _, ing := range recipe.Ingredients {
+				if ing.FoodItemID == nil {
+					result.Unlinked = append(result.Unlinked, recipe.Name+": "+ing.Name)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// newRecipe makes the recipe of an input, with the names of the food item
+// each ingredient is linked to
+func newRecipe(input *RecipeInput) (*Recipe, [][]string) {
+	recipe := &Recipe{
+		Name:         strings.TrimSpace(input.Name),
+		Description:  input.Description,
+		Cuisine:      input.Cuisine,
+		MealType:     input.MealType,
+		Tags:         []string{},
+		PrepMinutes:  input.PrepMinutes,
+		Servings:     input.Servings,
+		Instructions: input.Instructions,
+	}
+	if recipe.Servings == 0 {
+		recipe.Servings = defaultServings
+	}
+	for _, tag := range input.Tags {
+		recipe.Tags = append(recipe.Tags, strings.ToLower(strings.TrimSpace(tag)))
+	}
+	foodItemNames := make([][]string, len(input.Ingredients))
+	for i, in := range input.Ingredients {
+		recipe.Ingredients = append(recipe.Ingredients, &Ingredient{Name: strings.TrimSpace(in.Name), Quantity: in.Quantity, Unit: in.Unit, Optional: in.Optional})
+		foodItem := in.FoodItem
+		if foodItem == "" {
+			foodItem = in.Name
+		}
+		foodItemNames[i] = utils.NameVariants(utils.NameKey(foodItem))
+	}
+	return recipe, foodItemNames
+}
+
+// Suggestions ranks the recipes that use the user's items expiring within
+// filter.Days. Each item expiring counts more the sooner it expires.
+// Recipes conflicting with the household's allergies are left out; the
+// household's cuisines, dietary type and meal prep preference weigh the
+// rest, and recipes conflicting with its diet rank far lower, with
+// warnings.
+func (s *Service) Suggestions(member Member, filter SuggestionFilter) ([]*Suggestion, error) {
+	if filter.Days <= 0 {
+		filter.Days = defaultSuggestionDays
+	}
+	if filter.Days > maxSuggestionDays {
+		filter.Days = maxSuggestionDays
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSuggestionLimit
+	}
+	if filter.Limit > maxSuggestionLimit {
+		filter.Limit = maxSuggestionLimit
+	}
+	expiring, err := inventory.NewService().WithContext(s.repo.ctx).GetExpiring(member.UserID, filter.Days)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := []*Suggestion{}
+	if len(expiring) == 0 {
+		return suggestions, nil
+	}
+	var foodItemIDs []uuid.UUID
+	var names []string
+	for _, item := range expiring {
+		if item.FoodItemID != nil {
+			foodItemIDs = append(foodItemIDs, *item.FoodItemID)
+		}
+		names = append(names, utils.NameVariants(utils.NameKey(item.Name))...)
+	}
+	recipes, err := s.repo.GetUsing(foodItemIDs, names, filter.MealType)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := s.repo.GetPreferences(member.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := s.repo.GetStock(member.UserID)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := s.repo.GetPlannedNames(member.HouseholdID, time.Now().UTC().AddDate(0, 0, -recentDays))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, recipe := range recipes {
+		if suggestion := rank(recipe, expiring, stock, prefs, recent, now); suggestion != nil {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return len(suggestions[i].Uses) > len(suggestions[j].Uses)
+	})
+	if len(suggestions) > filter.Limit {
+		suggestions = suggestions[:filter.Limit]
+	}
+	return suggestions, nil
+}
+
+// rank scores the recipe for the expiring items it uses, nil when it uses
+// none or conflicts with an allergy
+func rank(recipe *Recipe, expiring []*inventory.InventoryItem, stock []stockItem, prefs *preferences, recent map[string]bool, now time.Time) *Suggestion {
+	warnings := meal_plans.Conflicts(&meal_plans.MealPlan{Name: recipe.Name, Ingredients: ingredientNames(recipe)}, prefs.diet)
+	for _, warning := range warnings {
+		if warning.Type == meal_plans.WarningAllergy {
+			return nil
+		}
+	}
+
+	suggestion := &Suggestion{Recipe: recipe, Uses: []*ExpiringUse{}, Missing: []string{}, Reasons: []string{}, Warnings: warnings}
+	score := 0.0
+	for _, item := range expiring {
+		if item.ExpiryDate == nil || !uses(recipe, item.Name, item.FoodItemID) {
+			continue
+		}
+		daysLeft := int(math.Max(0, math.Floor(item.ExpiryDate.Sub(now).Hours()/24)))
+		suggestion.Uses = append(suggestion.Uses, &ExpiringUse{InventoryItemID: item.ID, Name: item.Name, ExpiryDate: *item.ExpiryDate, DaysLeft: daysLeft})
+		score += 1 + 1/float64(1+daysLeft)
+	}
+	if len(suggestion.Uses) == 0 {
+		return nil
+	}
+	suggestion.Reasons = append(suggestion.Reasons, fmt.Sprintf("Uses %d item(s) expiring soon", len(suggestion.Uses)))
+
+	required, inStock := 0, 0
+	for _, ing := range recipe.Ingredients {
+		if ing.Optional {
+			continue
+		}
+		required++
+		if inStockOf(stock, ing) {
+			inStock++
+		} else {
+			suggestion.Missing = append(suggestion.Missing, ing.Name)
+		}
+	}
+
+	if prefs.diet != nil && prefs.diet.DietaryType != "" && contains(recipe.Tags, prefs.diet.DietaryType) {
+		score += 0.5
+		suggestion.Reasons = append(suggestion.Reasons, "Fits the household's "+prefs.diet.DietaryType+" diet")
+	}
+	switch prefs.mealPrep {
+	case "quick":
+		if recipe.PrepMinutes != nil && *recipe.PrepMinutes <= quickMinutes {
+			score += 0.5
+			suggestion.Reasons = append(suggestion.Reasons, "Quick to prepare")
+		}
+	case "diverse":
+		if !recent[strings.ToLower(recipe.Name)] {
+			score += 0.5
+			suggestion.Reasons = append(suggestion.Reasons, "Not planned in the last two weeks")
+		}
+	case "budget":
+		if required > 0 && inStock > 0 {
+			score += 0.5 * float64(inStock) / float64(required)
+			suggestion.Reasons = append(suggestion.Reasons, fmt.Sprintf("%d of %d ingredients in stock", inStock, required))
+		}
+	case "high-protein":
+		if contains(recipe.Tags, "high-protein") {
+			score += 0.5
+			suggestion.Reasons = append(suggestion.Reasons, "High in protein")
+		}
+	}
+	for _, cuisine := range prefs.cuisines {
+		if recipe.Cuisine != "" && strings.EqualFold(cuisine, recipe.Cuisine) {
+			score *= 1.25
+			suggestion.Reasons = append(suggestion.Reasons, "A preferred cuisine")
+			break
+		}
+	}
+	if len(warnings) > 0 {
+		score *= 0.25
+		suggestion.Reasons = append(suggestion.Reasons, "Conflicts with the household's "+prefs.diet.DietaryType+" diet")
+	}
+	suggestion.Score = math.Round(score*100) / 100
+	return suggestion
+}
+
+// Plan adds the recipe to the household's meal plans and returns the plan,
+// with the warnings of meal plans
+func (s *Service) Plan(member Member, id uuid.UUID, req *PlanRequest) (*meal_plans.MealPlan, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	recipe, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	plan := &meal_plans.CreateMealPlanRequest{
+		Date:        req.Date,
+		MealType:    req.MealType,
+		Name:        recipe.Name,
+		Description: truncate(recipe.Description, 2000),
+		Ingredients: []string{},
+		Servings:    req.Servings,
+	}
+	if plan.MealType == "" {
+		plan.MealType = recipe.MealType
+	}
+	if plan.MealType == "" {
+		plan.MealType = "dinner"
+	}
+	if plan.Servings == nil {
+		plan.Servings = &recipe.Servings
+	}
+	// Quantities are for the recipe's servings; meal plans scale them
+	// from the plan's servings
+	factor := float64(*plan.Servings) / float64(recipe.Servings)
+	for _, ing := range recipe.Ingredients {
+		plan.Ingredients = append(plan.Ingredients, formatIngredient(ing, factor))
+	}
+	return meal_plans.NewService().WithContext(s.repo.ctx).Create(meal_plans.Member(member), plan)
+}
+
+// formatIngredient writes the ingredient as meal plans do, such as
+// "200 g rice", its quantity multiplied by factor
+func formatIngredient(ing *Ingredient, factor float64) string {
+	parts := []string{}
+	if ing.Quantity != nil {
+		parts = append(parts, strconv.FormatFloat(math.Round(*ing.Quantity*factor*100)/100, 'f', -1, 64))
+	}
+	if ing.Unit != "" {
+		parts = append(parts, ing.Unit)
+	}
+	parts = append(parts, ing.Name)
+	s := strings.Join(parts, " ")
+	if ing.Optional {
+		s += " (optional)"
+	}
+	return s
+}
+
+func ingredientNames(recipe *Recipe) []string {
+	names := make([]string, len(recipe.Ingredients))
+	for i, ing := range recipe.Ingredients {
+		names[i] = ing.Name
+	}
+	return names
+}
+
+// uses reports whether the recipe has an ingredient that is the item, by
+// food item or by name
+func uses(recipe *Recipe, name string, foodItemID *uuid.UUID) bool {
+	key := utils.NameKey(name)
+	for _, ing := range recipe.Ingredients {
+		if (foodItemID != nil && ing.FoodItemID != nil && *foodItemID == *ing.FoodItemID) || utils.NameKey(ing.Name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func inStockOf(stock []stockItem, ing *Ingredient) bool {
+	key := utils.NameKey(ing.Name)
+	for _, item := range stock {
+		if (item.foodItemID != nil && ing.FoodItemID != nil && *item.foodItemID == *ing.FoodItemID) || utils.NameKey(item.name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,256 @@
+package resources
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// pathParts splits a /resources/... path relative to /api/v1 or
+// /api/v1/admin
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/resources"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// resourceID parses the resource ID at the start of the path
+func resourceID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// List handles GET /api/v1/resources
+// @Summary      List resources
+// @Description  List the resource library with category and tag facets. With q, resources are searched by title, tags, category and description, best matches first; q supports "quoted phrases", OR and -excluded words.
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Param        q         query     string  false  "Full-text search query"
+// @Param        category  query     string  false  "Filter by category"
+// @Param        tag       query     string  false  "Filter by tag"
+// @Param        page      query     int     false  "Page number, from 1"
+// @Param        limit     query     int     false  "Page size (default 20, at most 100)"
+// @Success      200       {object}  ResourceList
+// @Router       /resources [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	query := r.URL.Query()
+	filter := ListFilter{Query: query.Get("q"), Category: query.Get("category"), Tag: query.Get("tag")}
+	if pageStr := query.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil {
+			filter.Page = p
+		}
+	}
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	list, err := h.service.WithContext(r.Context()).List(filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve resources")
+	}
+	utils.OKResponse(w, "Resources retrieved successfully", list)
+	return nil
+}
+
+// Get handles GET /api/v1/resources/:id
+// @Summary      Get resource
+// @Description  Get a resource of the library
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Resource ID"
+// @Success      200  {object}  Resource
+// @Failure      400  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /resources/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	res, err := h.service.WithContext(r.Context()).Get(id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve resource")
+	}
+	utils.OKResponse(w, "Resource retrieved successfully", res)
+	return nil
+}
+
+// Related handles GET /api/v1/resources/:id/related
+// @Summary      Get related resources
+// @Description  Get resources sharing tags with a resource, those sharing the most first, then those of the same category
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true   "Resource ID"
+// @Param        limit  query     int     false  "Number of resources (default 5, at most 20)"
+// @Success      200    {array}   Resource
+// @Failure      400    {object}  errors.Problem
+// @Failure      404    {object}  errors.Problem
+// @Router       /resources/{id}/related [get]
+func (h *Handler) Related(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	related, err := h.service.WithContext(r.Context()).Related(id, limit)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve related resources")
+	}
+	utils.OKResponse(w, "Related resources retrieved successfully", related)
+	return nil
+}
+
+// Recommendations handles GET /api/v1/resources/recommendations
+// @Summary      Get recommended resources
+// @Description  Get resources for the food categories the user has in stock and wasted at least twice in the last 90 days, by their consumption logs. Resources are matched by a tag naming the category, storage tips first.
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   Recommendation
+// @Failure      401  {object}  errors.Problem
+// @Router       /resources/recommendations [get]
+func (h *Handler) Recommendations(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return errors.ErrAuthRequired
+	}
+	recommendations, err := h.service.WithContext(r.Context()).Recommendations(user.ID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve recommendations")
+	}
+	utils.OKResponse(w, "Recommendations retrieved successfully", recommendations)
+	return nil
+}
+
+// Create handles POST /api/v1/admin/resources
+// @Summary      Create resource
+// @Description  Add a resource to the library (admin only). Category and tags are stored lowercased.
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateResourceRequest  true  "Resource"
+// @Success      201      {object}  Resource
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Router       /admin/resources [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	var req CreateResourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	res, err := h.service.WithContext(r.Context()).Create(&req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create resource")
+	}
+	utils.CreatedResponse(w, "Resource created successfully", res)
+	return nil
+}
+
+// Update handles PUT /api/v1/admin/resources/:id
+// @Summary      Update resource
+// @Description  Update the given fields of a resource (admin only). Tags, when given, replace the resource's tags.
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                 true  "Resource ID"
+// @Param        request  body      UpdateResourceRequest  true  "Fields to update"
+// @Success      200      {object}  Resource
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Router       /admin/resources/{id} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	var req UpdateResourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	res, err := h.service.WithContext(r.Context()).Update(id, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update resource")
+	}
+	utils.OKResponse(w, "Resource updated successfully", res)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/admin/resources/:id
+// @Summary      Delete resource
+// @Description  Remove a resource from the library (admin only)
+// @Tags         resources
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Resource ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /admin/resources/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(id); err != nil {
+		return errors.Wrap(err, "Failed to delete resource")
+	}
+	utils.OKResponse(w, "Resource deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"database/sql"
+	"fmt"
+	"foodlink_backend/database"
+	"foodlink_backend/database/migrations"
+)
+
+// RegisterMigrations registers the migrations of resources
+func RegisterMigrations() {
+	migrations.RegisterMigration(migrations.Migration{
+		Version: 2,
+		Name:    "resources_search",
+		Up:      addSearchVector,
+	})
+}
+
+// addSearchVector adds the full-text search column and its index to
+// resources created before search. The function is the one of schema.sql,
+// which runs after migrations.
+func addSearchVector(db *sql.DB) error {
+	return database.WithTransaction(nil, db, func(tx *sql.Tx) error {
+		exists, err := migrations.TableExists(tx, "resources")
+		if err != nil || !exists {
+			return err
+		}
+		statements := []string{
+			`CREATE OR REPLACE FUNCTION resource_search_vector(title TEXT, description TEXT, category TEXT, tags TEXT[])
+			RETURNS tsvector AS $$
+				SELECT setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+					setweight(to_tsvector('english', COALESCE(array_to_string(tags, ' '), '') || ' ' || COALESCE(category, '')), 'B') ||
+					setweight(to_tsvector('english', COALESCE(description, '')), 'C')
+			$$ LANGUAGE sql IMMUTABLE`,
+			`UPDATE resources SET tags = '{}' WHERE tags IS NULL`,
+			`ALTER TABLE resources ALTER COLUMN tags SET DEFAULT '{}', ALTER COLUMN tags SET NOT NULL`,
+			`ALTER TABLE resources ADD COLUMN IF NOT EXISTS search_vector tsvector
+				GENERATED ALWAYS AS (resource_search_vector(title, description, category, tags)) STORED`,
+			`CREATE INDEX IF NOT EXISTS idx_resources_search ON resources USING GIN(search_vector)`,
+		}
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement); err != nil {
+				return fmt.Errorf("failed to add resources search: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource is an educational article, video or guide in the library.
+// Categories and tags are stored lowercased.
+type Resource struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description,omitempty" db:"description"`
+	Category    string    `json:"category" db:"category"`
+	URL         string    `json:"url,omitempty" db:"url"`
+	Tags        []string  `json:"tags" db:"tags"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CreateResourceRequest struct {
+	Title       string   `json:"title" validate:"required,min=1,max=255"`
+	Description string   `json:"description,omitempty"`
+	Category    string   `json:"category" validate:"required,min=1,max=100"`
+	URL         string   `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Tags        []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// UpdateResourceRequest changes the fields that are set. Tags, when set,
+// replace the resource's tags; an empty description clears it.
+type UpdateResourceRequest struct {
+	Title       string   `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Description *string  `json:"description,omitempty"`
+	Category    string   `json:"category,omitempty" validate:"omitempty,min=1,max=100"`
+	URL         string   `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Tags        []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// ListFilter selects a page of resources. Query is a web search style
+// full-text query: words, "quoted phrases", OR and -excluded words.
+type ListFilter struct {
+	Query    string
+	Category string
+	Tag      string
+	Page     int
+	Limit    int
+}
+
+// Facet is a category or tag with the number of matching resources
+type Facet struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Facets count the matching resources by category and by tag. Each is
+// counted with the other filters applied, so a selected category still
+// lists its siblings.
+type Facets struct {
+	Categories []Facet `json:"categories"`
+	Tags       []Facet `json:"tags"`
+}
+
+// ResourceList is a page of resources, best matches first when searching
+// and newest first otherwise
+type ResourceList struct {
+	Resources []*Resource `json:"resources"`
+	Facets    Facets      `json:"facets"`
+	Page      int         `json:"page"`
+	Limit     int         `json:"limit"`
+	Total     int         `json:"total"`
+}
+
+// Recommendation is a resource suggested for the user, with why
+type Recommendation struct {
+	Resource *Resource `json:"resource"`
+	// Category is the food category the resource was picked for
+	Category    string `json:"category"`
+	WastedCount int    `json:"wasted_count"`
+	Reason      string `json:"reason"`
+}
@@ -0,0 +1,261 @@
+package resources
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
+const resourceColumns = `id, title, COALESCE(description, ''), category, COALESCE(url, ''), tags, created_at, updated_at`
+
+// listFilter matches the full-text query in $1 (empty for all), the category
+// in $2 and the tag in $3 (empty for any)
+const listFilter = `($1::text = '' OR search_vector @@ websearch_to_tsquery('english', $1::text))
+	AND ($2::text = '' OR category = $2::text)
+	AND ($3::text = '' OR $3::text = ANY(tags))`
+
+// listOrder ranks matches of the query in $1, newest first without one
+const listOrder = `CASE WHEN $1::text = '' THEN 0 ELSE ts_rank_cd(search_vector, websearch_to_tsquery('english', $1::text)) END DESC, created_at DESC, id`
+
+// maxFacets is the most values returned per facet
+const maxFacets = 50
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanResource(row rowScanner) (*Resource, error) {
+	res := &Resource{}
+	err := row.Scan(&res.ID, &res.Title, &res.Description, &res.Category, &res.URL, pq.Array(&res.Tags), &res.CreatedAt, &res.UpdatedAt)
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+	return res, err
+}
+
+func scanResources(rows *sql.Rows) ([]*Resource, error) {
+	defer rows.Close()
+	list := []*Resource{}
+	for rows.Next() {
+		res, err := scanResource(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		list = append(list, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return list, nil
+}
+
+// List returns a page of resources matching the filter with the total
+func (r *Repository) List(filter ListFilter) ([]*Resource, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	var total int
+	err := r.conn().QueryRow(`SELECT COUNT(*) FROM resources WHERE `+listFilter, filter.Query, filter.Category, filter.Tag).Scan(&total)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	query := `SELECT ` + resourceColumns + ` FROM resources WHERE ` + listFilter + ` ORDER BY ` + listOrder + ` LIMIT $4 OFFSET $5`
+	rows, err := r.conn().Query(query, filter.Query, filter.Category, filter.Tag, filter.Limit, (filter.Page-1)*filter.Limit)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	list, err := scanResources(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+// Facets counts the resources matching the filter by category, ignoring the
+// filter's category, and by tag, ignoring its tag
+func (r *Repository) Facets(filter ListFilter) (*Facets, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	categories, err := r.facet(`SELECT category, COUNT(*) FROM resources WHERE `+listFilter+` GROUP BY category ORDER BY COUNT(*) DESC, category LIMIT $4`,
+		filter.Query, "", filter.Tag)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.facet(`SELECT tag, COUNT(*) FROM resources, unnest(tags) AS tag WHERE `+listFilter+` GROUP BY tag ORDER BY COUNT(*) DESC, tag LIMIT $4`,
+		filter.Query, filter.Category, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Facets{Categories: categories, Tags: tags}, nil
+}
+
+func (r *Repository) facet(query, search, category, tag string) ([]Facet, error) {
+	rows, err := r.conn().Query(query, search, category, tag, maxFacets)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	facets := []Facet{}
+	for rows.Next() {
+		var f Facet
+		if err := rows.Scan(&f.Value, &f.Count); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		facets = append(facets, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return facets, nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*Resource, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	res, err := scanResource(r.conn().QueryRow(`SELECT `+resourceColumns+` FROM resources WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return res, nil
+}
+
+// Related returns resources sharing tags with the resource, those sharing
+// the most first, then those of the same category
+func (r *Repository) Related(id uuid.UUID, limit int) ([]*Resource, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + resourceColumns + ` FROM resources r
+		CROSS JOIN LATERAL (SELECT category AS source_category, tags AS source_tags FROM resources WHERE id = $1) s
+		WHERE r.id <> $1 AND r.tags && s.source_tags
+		ORDER BY cardinality(ARRAY(SELECT unnest(r.tags) INTERSECT SELECT unnest(s.source_tags))) DESC,
+			r.category = s.source_category DESC, r.created_at DESC, r.id
+		LIMIT $2`
+	rows, err := r.conn().Query(query, id, limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return scanResources(rows)
+}
+
+// wastedCategory is a food category the user often wastes and has in stock
+type wastedCategory struct {
+	Category string
+	Wasted   int
+}
+
+// WastedCategories returns the categories of the user's inventory with at
+// least minWasted wasted consumption logs since the given time, most wasted
+// first. Categories are compared lowercased.
+func (r *Repository) WastedCategories(userID uuid.UUID, since time.Time, minWasted, limit int) ([]wastedCategory, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT w.category, w.wasted FROM (
+			SELECT lower(category) AS category, COUNT(*) AS wasted FROM consumption_logs
+			WHERE user_id = $1 AND was_wasted AND category IS NOT NULL AND consumed_at >= $2
+			GROUP BY lower(category) HAVING COUNT(*) >= $3
+		) w
+		WHERE EXISTS (SELECT 1 FROM inventory_items i WHERE i.user_id = $1 AND lower(i.category) = w.category)
+		ORDER BY w.wasted DESC, w.category
+		LIMIT $4`
+	rows, err := r.conn().Query(query, userID, since, minWasted, limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var categories []wastedCategory
+	for rows.Next() {
+		var c wastedCategory
+		if err := rows.Scan(&c.Category, &c.Wasted); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		categories = append(categories, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return categories, nil
+}
+
+// TaggedWith returns resources tagged with the tag, those of the preferred
+// category first, then the newest
+func (r *Repository) TaggedWith(tag, preferredCategory string, limit int) ([]*Resource, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + resourceColumns + ` FROM resources WHERE $1 = ANY(tags) ORDER BY category = $2 DESC, created_at DESC, id LIMIT $3`
+	rows, err := r.conn().Query(query, tag, preferredCategory, limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return scanResources(rows)
+}
+
+func (r *Repository) Create(res *Resource) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO resources (id, title, description, category, url, tags) VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), $6) RETURNING created_at, updated_at`
+	err := r.conn().QueryRow(query, res.ID, res.Title, res.Description, res.Category, res.URL, pq.Array(res.Tags)).Scan(&res.CreatedAt, &res.UpdatedAt)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+func (r *Repository) Update(res *Resource) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE resources SET title = $1, description = NULLIF($2, ''), category = $3, url = NULLIF($4, ''), tags = $5, updated_at = CURRENT_TIMESTAMP WHERE id = $6 RETURNING updated_at`
+	err := r.conn().QueryRow(query, res.Title, res.Description, res.Category, res.URL, pq.Array(res.Tags), res.ID).Scan(&res.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return errors.ErrNotFound
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM resources WHERE id = $1`, id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
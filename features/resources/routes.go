@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the public library routes, mounted under /api/v1.
+// Only recommendations need a signed-in user.
+func SetupRoutes(handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	recommendations := authMiddleware(middleware.Handle(handler.Recommendations))
+	public := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.Get(w, r)
+		case len(parts) == 2 && parts[1] == "related" && r.Method == http.MethodGet:
+			return handler.Related(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	routes := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if parts := pathParts(r); len(parts) == 1 && parts[0] == "recommendations" {
+			recommendations.ServeHTTP(w, r)
+			return
+		}
+		public.ServeHTTP(w, r)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/resources", routes)
+	mux.Handle("/resources/", routes)
+	return mux
+}
+
+// SetupAdminRoutes sets up library management routes, mounted under
+// /api/v1/admin
+func SetupAdminRoutes(handler *Handler, authMiddleware, requireAdmin func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodPost:
+			return handler.Create(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			return handler.Update(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/resources", routes)
+	mux.Handle("/resources/", routes)
+	return middleware.Chain(authMiddleware, requireAdmin)(mux)
+}
@@ -0,0 +1,191 @@
+package resources
+
+import (
+	"context"
+	"foodlink_backend/errors"
+	"foodlink_backend/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit    = 20
+	maxListLimit        = 100
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 20
+)
+
+// Recommendations look back over recommendationDays of consumption logs for
+// categories wasted at least minWastedLogs times, suggesting up to
+// resourcesPerCategory resources for each of maxWastedCategories.
+const (
+	recommendationDays   = 90
+	minWastedLogs        = 2
+	maxWastedCategories  = 5
+	resourcesPerCategory = 3
+	// storageCategory is the category of storage tips, preferred when
+	// recommending resources for wasted food
+	storageCategory = "storage"
+)
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+// List returns a page of resources matching the filter, with category and
+// tag facets
+func (s *Service) List(filter ListFilter) (*ResourceList, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.Category = normalize(filter.Category)
+	filter.Tag = normalize(filter.Tag)
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	list, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	facets, err := s.repo.Facets(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &ResourceList{Resources: list, Facets: *facets, Page: filter.Page, Limit: filter.Limit, Total: total}, nil
+}
+
+func (s *Service) Get(id uuid.UUID) (*Resource, error) {
+	return s.repo.GetByID(id)
+}
+
+// Related returns resources sharing tags with the resource
+func (s *Service) Related(id uuid.UUID, limit int) ([]*Resource, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+	return s.repo.Related(id, limit)
+}
+
+// Recommendations suggests resources tagged with the food categories the
+// user has in stock and often wastes, storage tips first. A resource is
+// suggested once, for the most wasted category.
+func (s *Service) Recommendations(userID uuid.UUID) ([]*Recommendation, error) {
+	categories, err := s.repo.WastedCategories(userID, time.Now().AddDate(0, 0, -recommendationDays), minWastedLogs, maxWastedCategories)
+	if err != nil {
+		return nil, err
+	}
+	recommendations := []*Recommendation{}
+	seen := make(map[uuid.UUID]bool)
+	for _, c := range categories {
+		list, err := s.repo.TaggedWith(c.Category, storageCategory, resourcesPerCategory)
+		if err != nil {
+			return nil, err
+		}
+		for _, res := range list {
+			if seen[res.ID] {
+				continue
+			}
+			seen[res.ID] = true
+			recommendations = append(recommendations, &Recommendation{
+				Resource:    res,
+				Category:    c.Category,
+				WastedCount: c.Wasted,
+				Reason:      "You have " + c.Category + " in stock and wasted it " + strconv.Itoa(c.Wasted) + " times in the last " + strconv.Itoa(recommendationDays) + " days",
+			})
+		}
+	}
+	return recommendations, nil
+}
+
+func (s *Service) Create(req *CreateResourceRequest) (*Resource, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	res := &Resource{
+		ID:          uuid.New(),
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Category:    normalize(req.Category),
+		URL:         req.URL,
+		Tags:        normalizeTags(req.Tags),
+	}
+	if err := s.repo.Create(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Service) Update(id uuid.UUID, req *UpdateResourceRequest) (*Resource, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	res, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Title != "" {
+		res.Title = strings.TrimSpace(req.Title)
+	}
+	if req.Description != nil {
+		res.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Category != "" {
+		res.Category = normalize(req.Category)
+	}
+	if req.URL != "" {
+		res.URL = req.URL
+	}
+	if req.Tags != nil {
+		res.Tags = normalizeTags(req.Tags)
+	}
+	if err := s.repo.Update(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *Service) Delete(id uuid.UUID) error {
+	return s.repo.Delete(id)
+}
+
+// normalize lowercases a category or tag so that facets and recommendations
+// match regardless of how it was typed
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// normalizeTags normalizes tags, dropping empty and repeated ones
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = normalize(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
@@ -1,6 +1,7 @@
 package donations
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
@@ -11,19 +12,33 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*DonationLog, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, user_id, date, recipient_type, recipient_name, items, quantity, unit, meals_provided, co2_saved_kg, notes, created_at FROM restaurant_donation_logs WHERE user_id = $1 ORDER BY date DESC, created_at DESC`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -44,7 +59,7 @@ func (r *Repository) Create(log *DonationLog) error {
 		return errors.ErrDatabase
 	}
 	query := `INSERT INTO restaurant_donation_logs (id, user_id, date, recipient_type, recipient_name, items, quantity, unit, meals_provided, co2_saved_kg, notes, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, user_id, date, recipient_type, recipient_name, items, quantity, unit, meals_provided, co2_saved_kg, notes, created_at`
-	return r.db.QueryRow(query, log.ID, log.UserID, log.Date, log.RecipientType, log.RecipientName, log.Items, log.Quantity, log.Unit, log.MealsProvided, log.CO2SavedKg, log.Notes, time.Now()).Scan(&log.ID, &log.UserID, &log.Date, &log.RecipientType, &log.RecipientName, &log.Items, &log.Quantity, &log.Unit, &log.MealsProvided, &log.CO2SavedKg, &log.Notes, &log.CreatedAt)
+	return r.conn().QueryRow(query, log.ID, log.UserID, log.Date, log.RecipientType, log.RecipientName, log.Items, log.Quantity, log.Unit, log.MealsProvided, log.CO2SavedKg, log.Notes, time.Now()).Scan(&log.ID, &log.UserID, &log.Date, &log.RecipientType, &log.RecipientName, &log.Items, &log.Quantity, &log.Unit, &log.MealsProvided, &log.CO2SavedKg, &log.Notes, &log.CreatedAt)
 }
 
 func (r *Repository) GetImpactByUserID(userID uuid.UUID) (*ImpactMetrics, error) {
@@ -54,7 +69,7 @@ func (r *Repository) GetImpactByUserID(userID uuid.UUID) (*ImpactMetrics, error)
 	metrics := &ImpactMetrics{}
 	var weeklyTrendJSON, monthlyTrendJSON, categoryBreakdownJSON []byte
 	query := `SELECT id, user_id, waste_prevented_kg, surplus_donation_rate, water_saved_liters, co2_prevented_kg, sustainability_score, weekly_trend, monthly_trend, category_breakdown, updated_at FROM restaurant_impact_metrics WHERE user_id = $1`
-	err := r.db.QueryRow(query, userID).Scan(&metrics.ID, &metrics.UserID, &metrics.WastePreventedKg, &metrics.SurplusDonationRate, &metrics.WaterSavedLiters, &metrics.CO2PreventedKg, &metrics.SustainabilityScore, &weeklyTrendJSON, &monthlyTrendJSON, &categoryBreakdownJSON, &metrics.UpdatedAt)
+	err := r.conn().QueryRow(query, userID).Scan(&metrics.ID, &metrics.UserID, &metrics.WastePreventedKg, &metrics.SurplusDonationRate, &metrics.WaterSavedLiters, &metrics.CO2PreventedKg, &metrics.SustainabilityScore, &weeklyTrendJSON, &monthlyTrendJSON, &categoryBreakdownJSON, &metrics.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -72,3 +87,20 @@ func (r *Repository) GetImpactByUserID(userID uuid.UUID) (*ImpactMetrics, error)
 	}
 	return metrics, nil
 }
+
+// AddImpact adds prevented waste and CO2 to the restaurant's impact metrics,
+// creating them on the first donation
+func (r *Repository) AddImpact(userID uuid.UUID, wastePreventedKg, co2PreventedKg float64) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO restaurant_impact_metrics (user_id, waste_prevented_kg, co2_prevented_kg, updated_at) VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE SET
+			waste_prevented_kg = restaurant_impact_metrics.waste_prevented_kg + EXCLUDED.waste_prevented_kg,
+			co2_prevented_kg = restaurant_impact_metrics.co2_prevented_kg + EXCLUDED.co2_prevented_kg,
+			updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.conn().Exec(query, userID, wastePreventedKg, co2PreventedKg); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
@@ -1,8 +1,14 @@
 package donations
 
 import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
 	"foodlink_backend/errors"
+	"foodlink_backend/events"
+	"foodlink_backend/metrics"
 	"foodlink_backend/utils"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -15,13 +21,17 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*DonationLog, error) {
 	return s.repo.GetAllByUserID(userID)
 }
 
 func (s *Service) Create(userID uuid.UUID, req *CreateDonationLogRequest) (*DonationLog, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	log := &DonationLog{
 		ID:            uuid.New(),
@@ -36,8 +46,27 @@ func (s *Service) Create(userID uuid.UUID, req *CreateDonationLogRequest) (*Dona
 		CO2SavedKg:    req.CO2SavedKg,
 		Notes:         req.Notes,
 	}
-	if err := s.repo.Create(log); err != nil {
-		return nil, err
+	// Impact metrics are updated through the DonationLogged event
+	err := database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		if err := s.repo.WithTx(tx).Create(log); err != nil {
+			return err
+		}
+		return events.Publish(s.repo.ctx, tx, events.DonationLogged{
+			DonationID:    log.ID,
+			RestaurantID:  log.UserID,
+			RecipientType: log.RecipientType,
+			RecipientName: log.RecipientName,
+			Quantity:      log.Quantity,
+			Unit:          log.Unit,
+			MealsProvided: log.MealsProvided,
+			CO2SavedKg:    log.CO2SavedKg,
+		})
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to log donation")
+	}
+	if strings.EqualFold(log.Unit, "kg") {
+		metrics.DonatedKg.Add(log.Quantity, "restaurant_donation")
 	}
 	return log, nil
 }
@@ -0,0 +1,27 @@
+package donations
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/events"
+	"strings"
+)
+
+// RegisterSubscribers subscribes the donations feature to domain events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypeDonationLogged, "restaurant_impact.add_donation", addDonationImpact)
+}
+
+// addDonationImpact adds a logged donation to the restaurant's impact
+// metrics. Only quantities in kg count as prevented waste.
+func addDonationImpact(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var donation events.DonationLogged
+	if err := event.Decode(&donation); err != nil {
+		return err
+	}
+	var wasteKg float64
+	if strings.EqualFold(donation.Unit, "kg") {
+		wasteKg = donation.Quantity
+	}
+	return NewRepository().WithContext(ctx).WithTx(tx).AddImpact(donation.RestaurantID, wasteKg, donation.CO2SavedKg)
+}
@@ -36,28 +36,22 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   RestaurantInventoryItem
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /restaurant/inventory [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	items, err := h.service.GetAllByUserID(userID)
+	items, err := h.service.WithContext(r.Context()).GetAllByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve inventory", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve inventory")
 	}
 	utils.OKResponse(w, "Inventory retrieved successfully", items)
+	return nil
 }
 
 // GetByID handles GET /api/v1/restaurant/inventory/:id
@@ -69,30 +63,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Inventory Item ID"
 // @Success      200  {object}  RestaurantInventoryItem
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /restaurant/inventory/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/inventory/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	item, err := h.service.GetByID(id)
+	item, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve inventory item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve inventory item")
 	}
 	utils.OKResponse(w, "Inventory item retrieved successfully", item)
+	return nil
 }
 
 // Create handles POST /api/v1/restaurant/inventory
@@ -104,34 +92,27 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateRestaurantInventoryItemRequest  true  "Inventory item data"
 // @Success      201      {object}  RestaurantInventoryItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /restaurant/inventory [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateRestaurantInventoryItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	item, err := h.service.Create(userID, &req)
+	item, err := h.service.WithContext(r.Context()).Create(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create inventory item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create inventory item")
 	}
 	utils.CreatedResponse(w, "Inventory item created successfully", item)
+	return nil
 }
 
 // Update handles PUT /api/v1/restaurant/inventory/:id
@@ -144,42 +125,34 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                            true  "Inventory Item ID"
 // @Param        request  body      UpdateRestaurantInventoryItemRequest true  "Inventory item data"
 // @Success      200      {object}  RestaurantInventoryItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /restaurant/inventory/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/inventory/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateRestaurantInventoryItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	item, err := h.service.Update(id, userID, &req)
+	item, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update inventory item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update inventory item")
 	}
 	utils.OKResponse(w, "Inventory item updated successfully", item)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/restaurant/inventory/:id
@@ -191,35 +164,28 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Inventory Item ID"
 // @Success      200  {object}  map[string]string
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /restaurant/inventory/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/inventory/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	if err := h.service.Delete(id, userID); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete inventory item", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete inventory item")
 	}
 	utils.OKResponse(w, "Inventory item deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
 }
 
 // GetExpiring handles GET /api/v1/restaurant/inventory/expiring
@@ -231,17 +197,15 @@ func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        days  query     int  false  "Number of days (default: 7)"
 // @Success      200   {array}   RestaurantInventoryItem
-// @Failure      401   {object}  errors.AppError
+// @Failure      401   {object}  errors.Problem
 // @Router       /restaurant/inventory/expiring [get]
-func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	days := 7
 	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
@@ -249,14 +213,44 @@ func (h *Handler) GetExpiring(w http.ResponseWriter, r *http.Request) {
 			days = d
 		}
 	}
-	items, err := h.service.GetExpiring(userID, days)
+	items, err := h.service.WithContext(r.Context()).GetExpiring(userID, days)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve expiring items", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve expiring items")
 	}
 	utils.OKResponse(w, "Expiring items retrieved successfully", items)
+	return nil
+}
+
+// Batch handles POST /api/v1/restaurant/inventory/batch
+// @Summary      Batch create, update and delete inventory items
+// @Description  Apply up to 500 create, update and delete operations to the restaurant's inventory. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.
+// @Tags         restaurant-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      utils.BatchRequest  true  "Batch operations"
+// @Success      200      {object}  utils.BatchResult
+// @Success      207      {object}  utils.BatchResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      422      {object}  utils.BatchResult
+// @Router       /restaurant/inventory/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req utils.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Batch(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to process batch")
+	}
+	utils.BatchResponse(w, result)
+	return nil
 }
@@ -0,0 +1,61 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/events"
+	"foodlink_backend/jobs"
+	"log/slog"
+	"time"
+)
+
+// expiringWithin is how close to its expiry date an item is flagged expiring
+const expiringWithin = 48 * time.Hour
+
+// expiredLookback is how far back the first run looks for expired items
+const expiredLookback = 24 * time.Hour
+
+// RegisterJobs registers the restaurant inventory background jobs
+func RegisterJobs(scheduler *jobs.Scheduler) {
+	scheduler.Schedule("restaurant_inventory.mark_expiring", "0 * * * *", markExpiring)
+	scheduler.Schedule("restaurant_inventory.publish_expired", "*/15 * * * *", publishExpired)
+}
+
+// markExpiring sets the expiring status on items close to their expiry date
+func markExpiring(ctx context.Context, job *jobs.Job) error {
+	count, err := NewRepository().WithContext(ctx).MarkExpiring(time.Now().Add(expiringWithin))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		slog.Info("Marked restaurant inventory items expiring", "count", count)
+	}
+	return nil
+}
+
+// publishExpired publishes InventoryExpired for items that expired since the
+// previous run
+func publishExpired(ctx context.Context, job *jobs.Job) error {
+	from, to := job.Window(expiredLookback)
+	repo := NewRepository().WithContext(ctx)
+	return database.WithTransaction(ctx, repo.db, func(tx *sql.Tx) error {
+		items, err := repo.WithTx(tx).GetExpiredBetween(from, to)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			err := events.Publish(ctx, tx, events.InventoryExpired{
+				ItemID:    item.ID,
+				OwnerID:   item.UserID,
+				Source:    events.InventoryRestaurant,
+				Name:      item.Name,
+				ExpiredAt: item.ExpiryDate,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -11,19 +12,33 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*RestaurantInventoryItem, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
-	query := `SELECT id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_image, created_at, updated_at FROM restaurant_inventory_items WHERE user_id = $1 ORDER BY expiry_date ASC, created_at DESC`
-	rows, err := r.db.Query(query, userID)
+	query := `SELECT id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_upload_id, created_at, updated_at FROM restaurant_inventory_items WHERE user_id = $1 ORDER BY expiry_date ASC, created_at DESC`
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -31,7 +46,7 @@ func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*RestaurantInventoryIte
 	var items []*RestaurantInventoryItem
 	for rows.Next() {
 		item := &RestaurantInventoryItem{}
-		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceImage, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceUploadID, &item.CreatedAt, &item.UpdatedAt); err != nil {
 			return nil, errors.WrapError(err, errors.ErrDatabase)
 		}
 		items = append(items, item)
@@ -44,8 +59,8 @@ func (r *Repository) GetByID(id uuid.UUID) (*RestaurantInventoryItem, error) {
 		return nil, errors.ErrDatabase
 	}
 	item := &RestaurantInventoryItem{}
-	query := `SELECT id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_image, created_at, updated_at FROM restaurant_inventory_items WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceImage, &item.CreatedAt, &item.UpdatedAt)
+	query := `SELECT id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_upload_id, created_at, updated_at FROM restaurant_inventory_items WHERE id = $1`
+	err := r.conn().QueryRow(query, id).Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceUploadID, &item.CreatedAt, &item.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -59,24 +74,24 @@ func (r *Repository) Create(item *RestaurantInventoryItem) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	query := `INSERT INTO restaurant_inventory_items (id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_image, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_image, created_at, updated_at`
+	query := `INSERT INTO restaurant_inventory_items (id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_upload_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_upload_id, created_at, updated_at`
 	now := time.Now()
-	return r.db.QueryRow(query, item.ID, item.UserID, item.Name, item.Quantity, item.Unit, item.Category, item.ExpiryDate, item.StorageType, item.BatchCode, pq.Array(item.AlertTags), item.Status, item.InvoiceImage, now, now).Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceImage, &item.CreatedAt, &item.UpdatedAt)
+	return r.conn().QueryRow(query, item.ID, item.UserID, item.Name, item.Quantity, item.Unit, item.Category, item.ExpiryDate, item.StorageType, item.BatchCode, pq.Array(item.AlertTags), item.Status, item.InvoiceUploadID, now, now).Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceUploadID, &item.CreatedAt, &item.UpdatedAt)
 }
 
 func (r *Repository) Update(item *RestaurantInventoryItem) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	query := `UPDATE restaurant_inventory_items SET name=$1, quantity=$2, unit=$3, category=$4, expiry_date=$5, storage_type=$6, batch_code=$7, alert_tags=$8, status=$9, invoice_image=$10, updated_at=$11 WHERE id=$12 RETURNING id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_image, created_at, updated_at`
-	return r.db.QueryRow(query, item.Name, item.Quantity, item.Unit, item.Category, item.ExpiryDate, item.StorageType, item.BatchCode, pq.Array(item.AlertTags), item.Status, item.InvoiceImage, time.Now(), item.ID).Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceImage, &item.CreatedAt, &item.UpdatedAt)
+	query := `UPDATE restaurant_inventory_items SET name=$1, quantity=$2, unit=$3, category=$4, expiry_date=$5, storage_type=$6, batch_code=$7, alert_tags=$8, status=$9, invoice_upload_id=$10, updated_at=$11 WHERE id=$12 RETURNING id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_upload_id, created_at, updated_at`
+	return r.conn().QueryRow(query, item.Name, item.Quantity, item.Unit, item.Category, item.ExpiryDate, item.StorageType, item.BatchCode, pq.Array(item.AlertTags), item.Status, item.InvoiceUploadID, time.Now(), item.ID).Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceUploadID, &item.CreatedAt, &item.UpdatedAt)
 }
 
 func (r *Repository) Delete(id uuid.UUID) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	result, err := r.db.Exec(`DELETE FROM restaurant_inventory_items WHERE id = $1`, id)
+	result, err := r.conn().Exec(`DELETE FROM restaurant_inventory_items WHERE id = $1`, id)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -92,8 +107,8 @@ func (r *Repository) GetExpiring(userID uuid.UUID, days int) ([]*RestaurantInven
 		return nil, errors.ErrDatabase
 	}
 	cutoffDate := time.Now().AddDate(0, 0, days)
-	query := `SELECT id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_image, created_at, updated_at FROM restaurant_inventory_items WHERE user_id = $1 AND expiry_date <= $2 AND expiry_date >= CURRENT_TIMESTAMP ORDER BY expiry_date ASC`
-	rows, err := r.db.Query(query, userID, cutoffDate)
+	query := `SELECT id, user_id, name, quantity, unit, category, expiry_date, storage_type, batch_code, alert_tags, status, invoice_upload_id, created_at, updated_at FROM restaurant_inventory_items WHERE user_id = $1 AND expiry_date <= $2 AND expiry_date >= CURRENT_TIMESTAMP ORDER BY expiry_date ASC`
+	rows, err := r.conn().Query(query, userID, cutoffDate)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -101,10 +116,47 @@ func (r *Repository) GetExpiring(userID uuid.UUID, days int) ([]*RestaurantInven
 	var items []*RestaurantInventoryItem
 	for rows.Next() {
 		item := &RestaurantInventoryItem{}
-		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceImage, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.ExpiryDate, &item.StorageType, &item.BatchCode, pq.Array(&item.AlertTags), &item.Status, &item.InvoiceUploadID, &item.CreatedAt, &item.UpdatedAt); err != nil {
 			return nil, errors.WrapError(err, errors.ErrDatabase)
 		}
 		items = append(items, item)
 	}
 	return items, nil
 }
+
+// GetExpiredBetween returns the items whose expiry date falls in (from, to],
+// with only their ID, owner, name and expiry date set
+func (r *Repository) GetExpiredBetween(from, to time.Time) ([]*RestaurantInventoryItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT id, user_id, name, expiry_date FROM restaurant_inventory_items WHERE expiry_date > $1 AND expiry_date <= $2 ORDER BY expiry_date`
+	rows, err := r.conn().Query(query, from, to)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var items []*RestaurantInventoryItem
+	for rows.Next() {
+		item := &RestaurantInventoryItem{}
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.ExpiryDate); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// MarkExpiring flags normal items expiring before cutoff as expiring and
+// returns how many changed
+func (r *Repository) MarkExpiring(cutoff time.Time) (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	query := `UPDATE restaurant_inventory_items SET status = 'expiring', updated_at = CURRENT_TIMESTAMP WHERE status = 'normal' AND expiry_date <= $1`
+	result, err := r.conn().Exec(query, cutoff)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
@@ -1,7 +1,10 @@
 package inventory
 
 import (
+	"context"
+	"database/sql"
 	"foodlink_backend/errors"
+	"foodlink_backend/features/uploads"
 	"foodlink_backend/utils"
 
 	"github.com/google/uuid"
@@ -15,6 +18,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*RestaurantInventoryItem, error) {
 	return s.repo.GetAllByUserID(userID)
 }
@@ -25,21 +32,24 @@ func (s *Service) GetByID(id uuid.UUID) (*RestaurantInventoryItem, error) {
 
 func (s *Service) Create(userID uuid.UUID, req *CreateRestaurantInventoryItemRequest) (*RestaurantInventoryItem, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.InvoiceUploadID); err != nil {
+		return nil, err
 	}
 	item := &RestaurantInventoryItem{
-		ID:          uuid.New(),
-		UserID:      userID,
-		Name:        req.Name,
-		Quantity:    req.Quantity,
-		Unit:        req.Unit,
-		Category:    req.Category,
-		ExpiryDate:  req.ExpiryDate,
-		StorageType: req.StorageType,
-		BatchCode:   req.BatchCode,
-		AlertTags:   req.AlertTags,
-		Status:      "normal",
-		InvoiceImage: req.InvoiceImage,
+		ID:              uuid.New(),
+		UserID:          userID,
+		Name:            req.Name,
+		Quantity:        req.Quantity,
+		Unit:            req.Unit,
+		Category:        req.Category,
+		ExpiryDate:      req.ExpiryDate,
+		StorageType:     req.StorageType,
+		BatchCode:       req.BatchCode,
+		AlertTags:       req.AlertTags,
+		Status:          "normal",
+		InvoiceUploadID: req.InvoiceUploadID,
 	}
 	if err := s.repo.Create(item); err != nil {
 		return nil, err
@@ -56,7 +66,10 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateRestaurantIn
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.InvoiceUploadID); err != nil {
+		return nil, err
 	}
 	if req.Name != "" {
 		item.Name = req.Name
@@ -85,8 +98,8 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateRestaurantIn
 	if req.Status != "" {
 		item.Status = req.Status
 	}
-	if req.InvoiceImage != "" {
-		item.InvoiceImage = req.InvoiceImage
+	if req.InvoiceUploadID != nil {
+		item.InvoiceUploadID = req.InvoiceUploadID
 	}
 	if err := s.repo.Update(item); err != nil {
 		return nil, err
@@ -105,6 +118,28 @@ func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	return s.repo.Delete(id)
 }
 
+func (s *Service) Batch(userID uuid.UUID, req *utils.BatchRequest) (*utils.BatchResult, error) {
+	return utils.RunBatch(s.repo.ctx, s.repo.db, req, func(tx *sql.Tx, op *utils.BatchOperation) (interface{}, error) {
+		txService := &Service{repo: s.repo.WithTx(tx)}
+		switch op.Op {
+		case utils.BatchOpCreate:
+			var createReq CreateRestaurantInventoryItemRequest
+			if err := op.Decode(&createReq); err != nil {
+				return nil, err
+			}
+			return txService.Create(userID, &createReq)
+		case utils.BatchOpUpdate:
+			var updateReq UpdateRestaurantInventoryItemRequest
+			if err := op.Decode(&updateReq); err != nil {
+				return nil, err
+			}
+			return txService.Update(*op.ID, userID, &updateReq)
+		default:
+			return nil, txService.Delete(*op.ID, userID)
+		}
+	})
+}
+
 func (s *Service) GetExpiring(userID uuid.UUID, days int) ([]*RestaurantInventoryItem, error) {
 	if days <= 0 {
 		days = 7
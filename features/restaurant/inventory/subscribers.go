@@ -0,0 +1,37 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/events"
+	"foodlink_backend/features/notifications"
+)
+
+// RegisterSubscribers subscribes the restaurant inventory feature to domain
+// events
+func RegisterSubscribers(bus *events.Bus) {
+	bus.Subscribe(events.TypeInventoryExpired, "restaurant_inventory.notify_expired", notifyExpired)
+}
+
+// notifyExpired tells the restaurant that an inventory item expired, unless
+// it turned expiry notifications off
+func notifyExpired(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	var expired events.InventoryExpired
+	if err := event.Decode(&expired); err != nil {
+		return err
+	}
+	if expired.Source != events.InventoryRestaurant {
+		return nil
+	}
+	message := fmt.Sprintf("%s expired on %s.", expired.Name, expired.ExpiredAt.UTC().Format("Jan 2"))
+	_, err := notifications.NewService().WithContext(ctx).Notify(tx, &notifications.NewNotification{
+		UserID:     expired.OwnerID,
+		Source:     notifications.SourceCommunity,
+		Type:       "reminder",
+		Title:      "Inventory item expired",
+		Message:    message,
+		Preference: notifications.PreferenceExpiry,
+	})
+	return err
+}
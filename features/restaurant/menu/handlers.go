@@ -5,6 +5,7 @@ import (
 	"foodlink_backend/errors"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/utils"
+	"io"
 	"net/http"
 	"strings"
 
@@ -35,28 +36,22 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   RestaurantMenuItem
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /restaurant/menu [get]
-func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	items, err := h.service.GetAllByUserID(userID)
+	items, err := h.service.WithContext(r.Context()).GetAllByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve menu items", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve menu items")
 	}
 	utils.OKResponse(w, "Menu items retrieved successfully", items)
+	return nil
 }
 
 // GetByID handles GET /api/v1/restaurant/menu/:id
@@ -68,30 +63,24 @@ func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Menu Item ID"
 // @Success      200  {object}  RestaurantMenuItem
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /restaurant/menu/{id} [get]
-func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/menu/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	item, err := h.service.GetByID(id)
+	item, err := h.service.WithContext(r.Context()).GetByID(id)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve menu item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve menu item")
 	}
 	utils.OKResponse(w, "Menu item retrieved successfully", item)
+	return nil
 }
 
 // Create handles POST /api/v1/restaurant/menu
@@ -103,34 +92,27 @@ func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateRestaurantMenuItemRequest  true  "Menu item data"
 // @Success      201      {object}  RestaurantMenuItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /restaurant/menu [post]
-func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateRestaurantMenuItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	item, err := h.service.Create(userID, &req)
+	item, err := h.service.WithContext(r.Context()).Create(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create menu item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create menu item")
 	}
 	utils.CreatedResponse(w, "Menu item created successfully", item)
+	return nil
 }
 
 // Update handles PUT /api/v1/restaurant/menu/:id
@@ -143,42 +125,78 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                      true  "Menu Item ID"
 // @Param        request  body      UpdateRestaurantMenuItemRequest true  "Menu item data"
 // @Success      200      {object}  RestaurantMenuItem
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /restaurant/menu/{id} [put]
-func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/menu/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateRestaurantMenuItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	item, err := h.service.Update(id, userID, &req)
+	item, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update menu item", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update menu item")
 	}
 	utils.OKResponse(w, "Menu item updated successfully", item)
+	return nil
+}
+
+// Patch handles PATCH /api/v1/restaurant/menu/:id
+// @Summary      Patch menu item
+// @Description  Partially update a menu item with a JSON Merge Patch (RFC 7396) document. Members set to null are cleared.
+// @Tags         restaurant-menu
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string  true  "Menu Item ID"
+// @Param        request  body      PatchRestaurantMenuItemRequest  true  "Merge patch document"
+// @Success      200      {object}  RestaurantMenuItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Failure      415      {object}  errors.Problem
+// @Router       /restaurant/menu/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/menu/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Patch(id, userID, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update menu item")
+	}
+	utils.OKResponse(w, "Menu item updated successfully", item)
+	return nil
 }
 
 // Delete handles DELETE /api/v1/restaurant/menu/:id
@@ -190,33 +208,60 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        id   path      string  true  "Menu Item ID"
 // @Success      200  {object}  map[string]string
-// @Failure      401  {object}  errors.AppError
-// @Failure      403  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /restaurant/menu/{id} [delete]
-func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodDelete {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/menu/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
-	if err := h.service.Delete(id, userID); err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to delete menu item", err.Error())
-		return
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete menu item")
 	}
 	utils.OKResponse(w, "Menu item deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// Batch handles POST /api/v1/restaurant/menu/batch
+// @Summary      Batch create, update and delete menu items
+// @Description  Apply up to 500 create, update and delete operations to the restaurant's menu. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.
+// @Tags         restaurant-menu
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      utils.BatchRequest  true  "Batch operations"
+// @Success      200      {object}  utils.BatchResult
+// @Success      207      {object}  utils.BatchResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      422      {object}  utils.BatchResult
+// @Router       /restaurant/menu/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req utils.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Batch(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to process batch")
+	}
+	utils.BatchResponse(w, result)
+	return nil
 }
@@ -62,3 +62,15 @@ type UpdateRestaurantMenuItemRequest struct {
 	Margin             *float64               `json:"margin,omitempty"`
 	Suggestions        []string               `json:"suggestions,omitempty"`
 }
+
+// PatchRestaurantMenuItemRequest holds the mutable fields of a menu item.
+// PATCH requests merge a JSON Merge Patch document into it and validate the result.
+type PatchRestaurantMenuItemRequest struct {
+	Name                string                   `json:"name" validate:"required,min=1,max=255"`
+	Category            string                   `json:"category" validate:"required,min=1,max=100"`
+	Ingredients         []map[string]interface{} `json:"ingredients" validate:"required"`
+	PredictedWasteScore string                   `json:"predicted_waste_score,omitempty" validate:"omitempty,oneof=low medium high"`
+	Price               float64                  `json:"price" validate:"required,gt=0"`
+	Margin              float64                  `json:"margin"`
+	Suggestions         []string                 `json:"suggestions,omitempty"`
+}
@@ -1,6 +1,7 @@
 package menu
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"foodlink_backend/database"
@@ -12,19 +13,33 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
 func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*RestaurantMenuItem, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, user_id, name, category, ingredients, predicted_waste_score, price, margin, suggestions, created_at, updated_at FROM restaurant_menu_items WHERE user_id = $1 ORDER BY created_at DESC`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -51,7 +66,7 @@ func (r *Repository) GetByID(id uuid.UUID) (*RestaurantMenuItem, error) {
 	item := &RestaurantMenuItem{}
 	var ingredientsJSON []byte
 	query := `SELECT id, user_id, name, category, ingredients, predicted_waste_score, price, margin, suggestions, created_at, updated_at FROM restaurant_menu_items WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &ingredientsJSON, &item.PredictedWasteScore, &item.Price, &item.Margin, pq.Array(&item.Suggestions), &item.CreatedAt, &item.UpdatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &ingredientsJSON, &item.PredictedWasteScore, &item.Price, &item.Margin, pq.Array(&item.Suggestions), &item.CreatedAt, &item.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -72,7 +87,7 @@ func (r *Repository) Create(item *RestaurantMenuItem) error {
 	query := `INSERT INTO restaurant_menu_items (id, user_id, name, category, ingredients, predicted_waste_score, price, margin, suggestions, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, user_id, name, category, ingredients, predicted_waste_score, price, margin, suggestions, created_at, updated_at`
 	now := time.Now()
 	var ingredientsJSONOut []byte
-	err := r.db.QueryRow(query, item.ID, item.UserID, item.Name, item.Category, ingredientsJSON, item.PredictedWasteScore, item.Price, item.Margin, pq.Array(item.Suggestions), now, now).Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &ingredientsJSONOut, &item.PredictedWasteScore, &item.Price, &item.Margin, pq.Array(&item.Suggestions), &item.CreatedAt, &item.UpdatedAt)
+	err := r.conn().QueryRow(query, item.ID, item.UserID, item.Name, item.Category, ingredientsJSON, item.PredictedWasteScore, item.Price, item.Margin, pq.Array(item.Suggestions), now, now).Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &ingredientsJSONOut, &item.PredictedWasteScore, &item.Price, &item.Margin, pq.Array(&item.Suggestions), &item.CreatedAt, &item.UpdatedAt)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -89,7 +104,7 @@ func (r *Repository) Update(item *RestaurantMenuItem) error {
 	ingredientsJSON, _ := json.Marshal(item.Ingredients)
 	query := `UPDATE restaurant_menu_items SET name=$1, category=$2, ingredients=$3, predicted_waste_score=$4, price=$5, margin=$6, suggestions=$7, updated_at=$8 WHERE id=$9 RETURNING id, user_id, name, category, ingredients, predicted_waste_score, price, margin, suggestions, created_at, updated_at`
 	var ingredientsJSONOut []byte
-	err := r.db.QueryRow(query, item.Name, item.Category, ingredientsJSON, item.PredictedWasteScore, item.Price, item.Margin, pq.Array(item.Suggestions), time.Now(), item.ID).Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &ingredientsJSONOut, &item.PredictedWasteScore, &item.Price, &item.Margin, pq.Array(&item.Suggestions), &item.CreatedAt, &item.UpdatedAt)
+	err := r.conn().QueryRow(query, item.Name, item.Category, ingredientsJSON, item.PredictedWasteScore, item.Price, item.Margin, pq.Array(item.Suggestions), time.Now(), item.ID).Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &ingredientsJSONOut, &item.PredictedWasteScore, &item.Price, &item.Margin, pq.Array(&item.Suggestions), &item.CreatedAt, &item.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return errors.ErrNotFound
@@ -106,7 +121,7 @@ func (r *Repository) Delete(id uuid.UUID) error {
 	if r.db == nil {
 		return errors.ErrDatabase
 	}
-	result, err := r.db.Exec(`DELETE FROM restaurant_menu_items WHERE id = $1`, id)
+	result, err := r.conn().Exec(`DELETE FROM restaurant_menu_items WHERE id = $1`, id)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
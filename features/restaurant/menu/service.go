@@ -1,6 +1,8 @@
 package menu
 
 import (
+	"context"
+	"database/sql"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,6 +17,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*RestaurantMenuItem, error) {
 	return s.repo.GetAllByUserID(userID)
 }
@@ -25,7 +31,7 @@ func (s *Service) GetByID(id uuid.UUID) (*RestaurantMenuItem, error) {
 
 func (s *Service) Create(userID uuid.UUID, req *CreateRestaurantMenuItemRequest) (*RestaurantMenuItem, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	ingredientsJSON := JSONB{"ingredients": req.Ingredients}
 	item := &RestaurantMenuItem{
@@ -54,7 +60,7 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateRestaurantMe
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.Name != "" {
 		item.Name = req.Name
@@ -83,6 +89,57 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateRestaurantMe
 	return item, nil
 }
 
+func (s *Service) Patch(id uuid.UUID, userID uuid.UUID, patch []byte) (*RestaurantMenuItem, error) {
+	item, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	doc := &PatchRestaurantMenuItemRequest{
+		Name:                item.Name,
+		Category:            item.Category,
+		Ingredients:         ingredientsList(item.Ingredients),
+		PredictedWasteScore: item.PredictedWasteScore,
+		Price:               item.Price,
+		Margin:              item.Margin,
+		Suggestions:         item.Suggestions,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	item.Name = doc.Name
+	item.Category = doc.Category
+	item.Ingredients = JSONB{"ingredients": doc.Ingredients}
+	item.PredictedWasteScore = doc.PredictedWasteScore
+	item.Price = doc.Price
+	item.Margin = doc.Margin
+	item.Suggestions = doc.Suggestions
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ingredientsList unwraps the ingredients array stored under the "ingredients" key
+func ingredientsList(ingredients JSONB) []map[string]interface{} {
+	raw, ok := ingredients["ingredients"].([]interface{})
+	if !ok {
+		return nil
+	}
+	list := make([]map[string]interface{}, 0, len(raw))
+	for _, entry := range raw {
+		if ingredient, ok := entry.(map[string]interface{}); ok {
+			list = append(list, ingredient)
+		}
+	}
+	return list
+}
+
 func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	item, err := s.repo.GetByID(id)
 	if err != nil {
@@ -93,3 +150,25 @@ func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
 	}
 	return s.repo.Delete(id)
 }
+
+func (s *Service) Batch(userID uuid.UUID, req *utils.BatchRequest) (*utils.BatchResult, error) {
+	return utils.RunBatch(s.repo.ctx, s.repo.db, req, func(tx *sql.Tx, op *utils.BatchOperation) (interface{}, error) {
+		txService := &Service{repo: s.repo.WithTx(tx)}
+		switch op.Op {
+		case utils.BatchOpCreate:
+			var createReq CreateRestaurantMenuItemRequest
+			if err := op.Decode(&createReq); err != nil {
+				return nil, err
+			}
+			return txService.Create(userID, &createReq)
+		case utils.BatchOpUpdate:
+			var updateReq UpdateRestaurantMenuItemRequest
+			if err := op.Decode(&updateReq); err != nil {
+				return nil, err
+			}
+			return txService.Update(*op.ID, userID, &updateReq)
+		default:
+			return nil, txService.Delete(*op.ID, userID)
+		}
+	})
+}
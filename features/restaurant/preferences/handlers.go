@@ -34,29 +34,23 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  RestaurantPreferences
-// @Failure      401  {object}  errors.AppError
-// @Failure      404  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
 // @Router       /restaurant/preferences [get]
-func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	prefs, err := h.service.GetByUserID(userID)
+	prefs, err := h.service.WithContext(r.Context()).GetByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve preferences", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve preferences")
 	}
 	utils.OKResponse(w, "Preferences retrieved successfully", prefs)
+	return nil
 }
 
 // CreateOrUpdate handles POST /api/v1/restaurant/preferences
@@ -69,32 +63,25 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 // @Param        request  body      CreateRestaurantPreferencesRequest  true  "Preferences data"
 // @Success      200      {object}  RestaurantPreferences
 // @Success      201      {object}  RestaurantPreferences
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /restaurant/preferences [post]
-func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateOrUpdate(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateRestaurantPreferencesRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	prefs, err := h.service.CreateOrUpdate(userID, &req)
+	prefs, err := h.service.WithContext(r.Context()).CreateOrUpdate(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to save preferences", err.Error())
-		return
+		return errors.Wrap(err, "Failed to save preferences")
 	}
 	utils.OKResponse(w, "Preferences saved successfully", prefs)
+	return nil
 }
@@ -35,28 +35,22 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   StaffTask
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /restaurant/tasks [get]
-func (h *Handler) GetAllTasks(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAllTasks(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	tasks, err := h.service.GetAllTasks(userID)
+	tasks, err := h.service.WithContext(r.Context()).GetAllTasks(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve tasks", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve tasks")
 	}
 	utils.OKResponse(w, "Tasks retrieved successfully", tasks)
+	return nil
 }
 
 // CreateTask handles POST /api/v1/restaurant/tasks
@@ -68,34 +62,27 @@ func (h *Handler) GetAllTasks(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateStaffTaskRequest  true  "Task data"
 // @Success      201      {object}  StaffTask
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /restaurant/tasks [post]
-func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateStaffTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	task, err := h.service.CreateTask(userID, &req)
+	task, err := h.service.WithContext(r.Context()).CreateTask(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create task", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create task")
 	}
 	utils.CreatedResponse(w, "Task created successfully", task)
+	return nil
 }
 
 // UpdateTask handles PUT /api/v1/restaurant/tasks/:id
@@ -108,42 +95,34 @@ func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
 // @Param        id       path      string                true  "Task ID"
 // @Param        request  body      UpdateStaffTaskRequest true  "Task data"
 // @Success      200      {object}  StaffTask
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
-// @Failure      403      {object}  errors.AppError
-// @Failure      404      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
 // @Router       /restaurant/tasks/{id} [put]
-func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPut {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/restaurant/tasks/")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.BadRequestResponse(w, "Invalid ID format", nil)
-		return
+		return errors.ErrInvalidID
 	}
 	var req UpdateStaffTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	task, err := h.service.UpdateTask(id, userID, &req)
+	task, err := h.service.WithContext(r.Context()).UpdateTask(id, userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to update task", err.Error())
-		return
+		return errors.Wrap(err, "Failed to update task")
 	}
 	utils.OKResponse(w, "Task updated successfully", task)
+	return nil
 }
 
 // GetAllShifts handles GET /api/v1/restaurant/shifts
@@ -154,28 +133,22 @@ func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {array}   ShiftSchedule
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /restaurant/shifts [get]
-func (h *Handler) GetAllShifts(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetAllShifts(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	shifts, err := h.service.GetAllShifts(userID)
+	shifts, err := h.service.WithContext(r.Context()).GetAllShifts(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve shifts", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve shifts")
 	}
 	utils.OKResponse(w, "Shifts retrieved successfully", shifts)
+	return nil
 }
 
 // CreateShift handles POST /api/v1/restaurant/shifts
@@ -187,32 +160,25 @@ func (h *Handler) GetAllShifts(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      CreateShiftScheduleRequest  true  "Shift data"
 // @Success      201      {object}  ShiftSchedule
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /restaurant/shifts [post]
-func (h *Handler) CreateShift(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) CreateShift(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req CreateShiftScheduleRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
-	shift, err := h.service.CreateShift(userID, &req)
+	shift, err := h.service.WithContext(r.Context()).CreateShift(userID, &req)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to create shift", err.Error())
-		return
+		return errors.Wrap(err, "Failed to create shift")
 	}
 	utils.CreatedResponse(w, "Shift created successfully", shift)
+	return nil
 }
@@ -1,6 +1,7 @@
 package staff
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -10,19 +11,28 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 func (r *Repository) GetAllTasksByUserID(userID uuid.UUID) ([]*StaffTask, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, user_id, title, description, assignee, shift, completed, priority, created_at FROM restaurant_staff_tasks WHERE user_id = $1 ORDER BY created_at DESC`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -44,7 +54,7 @@ func (r *Repository) GetTaskByID(id uuid.UUID) (*StaffTask, error) {
 	}
 	task := &StaffTask{}
 	query := `SELECT id, user_id, title, description, assignee, shift, completed, priority, created_at FROM restaurant_staff_tasks WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Assignee, &task.Shift, &task.Completed, &task.Priority, &task.CreatedAt)
+	err := r.conn().QueryRow(query, id).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Assignee, &task.Shift, &task.Completed, &task.Priority, &task.CreatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -59,7 +69,7 @@ func (r *Repository) CreateTask(task *StaffTask) error {
 		return errors.ErrDatabase
 	}
 	query := `INSERT INTO restaurant_staff_tasks (id, user_id, title, description, assignee, shift, completed, priority, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, user_id, title, description, assignee, shift, completed, priority, created_at`
-	return r.db.QueryRow(query, task.ID, task.UserID, task.Title, task.Description, task.Assignee, task.Shift, task.Completed, task.Priority, time.Now()).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Assignee, &task.Shift, &task.Completed, &task.Priority, &task.CreatedAt)
+	return r.conn().QueryRow(query, task.ID, task.UserID, task.Title, task.Description, task.Assignee, task.Shift, task.Completed, task.Priority, time.Now()).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Assignee, &task.Shift, &task.Completed, &task.Priority, &task.CreatedAt)
 }
 
 func (r *Repository) UpdateTask(task *StaffTask) error {
@@ -67,7 +77,7 @@ func (r *Repository) UpdateTask(task *StaffTask) error {
 		return errors.ErrDatabase
 	}
 	query := `UPDATE restaurant_staff_tasks SET title=$1, description=$2, assignee=$3, shift=$4, completed=$5, priority=$6 WHERE id=$7 RETURNING id, user_id, title, description, assignee, shift, completed, priority, created_at`
-	return r.db.QueryRow(query, task.Title, task.Description, task.Assignee, task.Shift, task.Completed, task.Priority, task.ID).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Assignee, &task.Shift, &task.Completed, &task.Priority, &task.CreatedAt)
+	return r.conn().QueryRow(query, task.Title, task.Description, task.Assignee, task.Shift, task.Completed, task.Priority, task.ID).Scan(&task.ID, &task.UserID, &task.Title, &task.Description, &task.Assignee, &task.Shift, &task.Completed, &task.Priority, &task.CreatedAt)
 }
 
 func (r *Repository) GetAllShiftsByUserID(userID uuid.UUID) ([]*ShiftSchedule, error) {
@@ -75,7 +85,7 @@ func (r *Repository) GetAllShiftsByUserID(userID uuid.UUID) ([]*ShiftSchedule, e
 		return nil, errors.ErrDatabase
 	}
 	query := `SELECT id, user_id, role, staff, time, notes, created_at FROM restaurant_shift_schedule WHERE user_id = $1 ORDER BY created_at DESC`
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.conn().Query(query, userID)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -96,5 +106,5 @@ func (r *Repository) CreateShift(shift *ShiftSchedule) error {
 		return errors.ErrDatabase
 	}
 	query := `INSERT INTO restaurant_shift_schedule (id, user_id, role, staff, time, notes, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, user_id, role, staff, time, notes, created_at`
-	return r.db.QueryRow(query, shift.ID, shift.UserID, shift.Role, shift.Staff, shift.Time, shift.Notes, time.Now()).Scan(&shift.ID, &shift.UserID, &shift.Role, &shift.Staff, &shift.Time, &shift.Notes, &shift.CreatedAt)
+	return r.conn().QueryRow(query, shift.ID, shift.UserID, shift.Role, shift.Staff, shift.Time, shift.Notes, time.Now()).Scan(&shift.ID, &shift.UserID, &shift.Role, &shift.Staff, &shift.Time, &shift.Notes, &shift.CreatedAt)
 }
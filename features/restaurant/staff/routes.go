@@ -1,38 +1,39 @@
 package staff
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 )
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/tasks", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/tasks", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		switch {
 		case r.Method == http.MethodGet:
-			handler.GetAllTasks(w, r)
+			return handler.GetAllTasks(w, r)
 		case r.Method == http.MethodPost:
-			handler.CreateTask(w, r)
+			return handler.CreateTask(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/tasks/", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/tasks/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodPut {
-			handler.UpdateTask(w, r)
+			return handler.UpdateTask(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/shifts", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/shifts", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		switch {
 		case r.Method == http.MethodGet:
-			handler.GetAllShifts(w, r)
+			return handler.GetAllShifts(w, r)
 		case r.Method == http.MethodPost:
-			handler.CreateShift(w, r)
+			return handler.CreateShift(w, r)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
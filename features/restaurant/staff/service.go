@@ -1,6 +1,7 @@
 package staff
 
 import (
+	"context"
 	"foodlink_backend/errors"
 	"foodlink_backend/utils"
 
@@ -15,6 +16,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllTasks(userID uuid.UUID) ([]*StaffTask, error) {
 	return s.repo.GetAllTasksByUserID(userID)
 }
@@ -25,7 +30,7 @@ func (s *Service) GetTaskByID(id uuid.UUID) (*StaffTask, error) {
 
 func (s *Service) CreateTask(userID uuid.UUID, req *CreateStaffTaskRequest) (*StaffTask, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	priority := req.Priority
 	if priority == "" {
@@ -56,7 +61,7 @@ func (s *Service) UpdateTask(id uuid.UUID, userID uuid.UUID, req *UpdateStaffTas
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	if req.Title != "" {
 		task.Title = req.Title
@@ -88,7 +93,7 @@ func (s *Service) GetAllShifts(userID uuid.UUID) ([]*ShiftSchedule, error) {
 
 func (s *Service) CreateShift(userID uuid.UUID, req *CreateShiftScheduleRequest) (*ShiftSchedule, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
 	shift := &ShiftSchedule{
 		ID:     uuid.New(),
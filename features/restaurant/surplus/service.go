@@ -1,7 +1,10 @@
 package surplus
 
 import (
+	"context"
 	"foodlink_backend/errors"
+	"foodlink_backend/features/uploads"
+	"foodlink_backend/metrics"
 	"foodlink_backend/utils"
 
 	"github.com/google/uuid"
@@ -15,6 +18,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*RestaurantSurplusItem, error) {
 	return s.repo.GetAllByUserID(userID)
 }
@@ -25,25 +32,29 @@ func (s *Service) GetByID(id uuid.UUID) (*RestaurantSurplusItem, error) {
 
 func (s *Service) Create(userID uuid.UUID, req *CreateRestaurantSurplusItemRequest) (*RestaurantSurplusItem, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
 	}
 	item := &RestaurantSurplusItem{
-		ID:           uuid.New(),
-		UserID:       userID,
-		Title:        req.Title,
-		Description:  req.Description,
-		Quantity:     req.Quantity,
-		Unit:         req.Unit,
-		Category:     req.Category,
-		StorageType:  req.StorageType,
-		PickupWindow: JSONB(req.PickupWindow),
-		Tags:         req.Tags,
-		Image:        req.Image,
-		Status:       "pending",
+		ID:            uuid.New(),
+		UserID:        userID,
+		Title:         req.Title,
+		Description:   req.Description,
+		Quantity:      req.Quantity,
+		Unit:          req.Unit,
+		Category:      req.Category,
+		StorageType:   req.StorageType,
+		PickupWindow:  JSONB(req.PickupWindow),
+		Tags:          req.Tags,
+		ImageUploadID: req.ImageUploadID,
+		Status:        "pending",
 	}
 	if err := s.repo.Create(item); err != nil {
 		return nil, err
 	}
+	metrics.SurplusPostsCreated.Inc(metrics.SourceRestaurant)
 	return item, nil
 }
 
@@ -56,7 +67,10 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateRestaurantSu
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
 	}
 	if req.Title != "" {
 		item.Title = req.Title
@@ -82,8 +96,8 @@ func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateRestaurantSu
 	if req.Tags != nil {
 		item.Tags = req.Tags
 	}
-	if req.Image != "" {
-		item.Image = req.Image
+	if req.ImageUploadID != nil {
+		item.ImageUploadID = req.ImageUploadID
 	}
 	if req.Status != "" {
 		item.Status = req.Status
@@ -103,12 +117,16 @@ func (s *Service) Assign(id uuid.UUID, userID uuid.UUID, req *AssignSurplusItemR
 		return nil, errors.ErrForbidden
 	}
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
-		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], nil)
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
+	wasAssigned := item.AssignedTo != ""
 	item.AssignedTo = req.AssignedTo
 	item.RecipientName = req.RecipientName
 	if err := s.repo.Update(item); err != nil {
 		return nil, err
 	}
+	if !wasAssigned {
+		metrics.SurplusPostsClaimed.Inc(metrics.SourceRestaurant)
+	}
 	return item, nil
 }
@@ -0,0 +1,252 @@
+package inventory
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return uuid.Nil, errors.ErrUnauthorized
+	}
+	return user.ID, nil
+}
+
+// GetAll handles GET /api/v1/shop/inventory
+// @Summary      List shop inventory
+// @Description  Get all inventory items for the authenticated shop
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   ShopInventoryItem
+// @Failure      401  {object}  errors.Problem
+// @Router       /shop/inventory [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	items, err := h.service.WithContext(r.Context()).GetAllByUserID(userID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve inventory")
+	}
+	utils.OKResponse(w, "Inventory retrieved successfully", items)
+	return nil
+}
+
+// GetByID handles GET /api/v1/shop/inventory/:id
+// @Summary      Get inventory item by ID
+// @Description  Get details of a specific inventory item
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Inventory Item ID"
+// @Success      200  {object}  ShopInventoryItem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /shop/inventory/{id} [get]
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/shop/inventory/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	item, err := h.service.WithContext(r.Context()).GetByID(id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve inventory item")
+	}
+	utils.OKResponse(w, "Inventory item retrieved successfully", item)
+	return nil
+}
+
+// GetByBarcode handles GET /api/v1/shop/inventory/barcode/:barcode
+// @Summary      Get inventory item by barcode
+// @Description  Look up a shop inventory item by its barcode, returning the batch that expires first
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        barcode  path      string  true  "Barcode"
+// @Success      200      {object}  ShopInventoryItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Router       /shop/inventory/barcode/{barcode} [get]
+func (h *Handler) GetByBarcode(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	barcode := strings.TrimPrefix(r.URL.Path, "/api/v1/shop/inventory/barcode/")
+	item, err := h.service.WithContext(r.Context()).GetByBarcode(userID, barcode)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve inventory item")
+	}
+	utils.OKResponse(w, "Inventory item retrieved successfully", item)
+	return nil
+}
+
+// Create handles POST /api/v1/shop/inventory
+// @Summary      Add inventory item
+// @Description  Add a new item to shop inventory
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateShopInventoryItemRequest  true  "Inventory item data"
+// @Success      201      {object}  ShopInventoryItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Router       /shop/inventory [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req CreateShopInventoryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Create(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create inventory item")
+	}
+	utils.CreatedResponse(w, "Inventory item created successfully", item)
+	return nil
+}
+
+// Update handles PUT /api/v1/shop/inventory/:id
+// @Summary      Update inventory item
+// @Description  Update an existing inventory item
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                            true  "Inventory Item ID"
+// @Param        request  body      UpdateShopInventoryItemRequest  true  "Inventory item data"
+// @Success      200      {object}  ShopInventoryItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      403      {object}  errors.Problem
+// @Failure      404      {object}  errors.Problem
+// @Router       /shop/inventory/{id} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/shop/inventory/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	var req UpdateShopInventoryItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Update(id, userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update inventory item")
+	}
+	utils.OKResponse(w, "Inventory item updated successfully", item)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/shop/inventory/:id
+// @Summary      Delete inventory item
+// @Description  Delete an inventory item
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Inventory Item ID"
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /shop/inventory/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	idStr := strings.TrimPrefix(r.URL.Path, "/api/v1/shop/inventory/")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return errors.ErrInvalidID
+	}
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete inventory item")
+	}
+	utils.OKResponse(w, "Inventory item deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// Batch handles POST /api/v1/shop/inventory/batch
+// @Summary      Batch create, update and delete inventory items
+// @Description  Apply up to 500 create, update and delete operations to the shop's inventory. In transactional mode (default) the batch is rolled back if any operation fails; in best_effort mode successful operations are committed. Results are reported per operation, indexed by position.
+// @Tags         shop-inventory
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      utils.BatchRequest  true  "Batch operations"
+// @Success      200      {object}  utils.BatchResult
+// @Success      207      {object}  utils.BatchResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      422      {object}  utils.BatchResult
+// @Router       /shop/inventory/batch [post]
+func (h *Handler) Batch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req utils.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Batch(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to process batch")
+	}
+	utils.BatchResponse(w, result)
+	return nil
+}
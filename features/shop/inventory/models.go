@@ -0,0 +1,58 @@
+package inventory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShopInventoryItem struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
+	Name            string     `json:"name" db:"name"`
+	Category        string     `json:"category" db:"category"`
+	Barcode         string     `json:"barcode" db:"barcode"`
+	StockQuantity   float64    `json:"stock_quantity" db:"stock_quantity"`
+	Unit            string     `json:"unit" db:"unit"`
+	Price           float64    `json:"price" db:"price"`
+	Cost            float64    `json:"cost" db:"cost"`
+	ExpiryDate      time.Time  `json:"expiry_date" db:"expiry_date"`
+	StorageType     string     `json:"storage_type" db:"storage_type"`
+	ShelfLocation   string     `json:"shelf_location,omitempty" db:"shelf_location"`
+	ImageUploadID   *uuid.UUID `json:"image_upload_id,omitempty" db:"image_upload_id"`
+	MarkdownStatus  string     `json:"markdown_status" db:"markdown_status"`
+	SurplusEligible bool       `json:"surplus_eligible" db:"surplus_eligible"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+type CreateShopInventoryItemRequest struct {
+	Name            string     `json:"name" validate:"required,min=1,max=255"`
+	Category        string     `json:"category" validate:"required,min=1,max=100"`
+	Barcode         string     `json:"barcode" validate:"required,min=1,max=255"`
+	StockQuantity   float64    `json:"stock_quantity" validate:"gte=0"`
+	Unit            string     `json:"unit" validate:"required,min=1,max=50"`
+	Price           float64    `json:"price" validate:"required,gt=0"`
+	Cost            float64    `json:"cost" validate:"gte=0"`
+	ExpiryDate      time.Time  `json:"expiry_date" validate:"required"`
+	StorageType     string     `json:"storage_type" validate:"required,oneof=frozen chilled ambient"`
+	ShelfLocation   string     `json:"shelf_location,omitempty" validate:"omitempty,max=100"`
+	ImageUploadID   *uuid.UUID `json:"image_upload_id,omitempty"`
+	SurplusEligible bool       `json:"surplus_eligible"`
+}
+
+type UpdateShopInventoryItemRequest struct {
+	Name            string     `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Category        string     `json:"category,omitempty" validate:"omitempty,min=1,max=100"`
+	Barcode         string     `json:"barcode,omitempty" validate:"omitempty,min=1,max=255"`
+	StockQuantity   *float64   `json:"stock_quantity,omitempty" validate:"omitempty,gte=0"`
+	Unit            string     `json:"unit,omitempty" validate:"omitempty,min=1,max=50"`
+	Price           *float64   `json:"price,omitempty" validate:"omitempty,gt=0"`
+	Cost            *float64   `json:"cost,omitempty" validate:"omitempty,gte=0"`
+	ExpiryDate      *time.Time `json:"expiry_date,omitempty"`
+	StorageType     string     `json:"storage_type,omitempty" validate:"omitempty,oneof=frozen chilled ambient"`
+	ShelfLocation   string     `json:"shelf_location,omitempty" validate:"omitempty,max=100"`
+	ImageUploadID   *uuid.UUID `json:"image_upload_id,omitempty"`
+	MarkdownStatus  string     `json:"markdown_status,omitempty" validate:"omitempty,oneof=none scheduled active"`
+	SurplusEligible *bool      `json:"surplus_eligible,omitempty"`
+}
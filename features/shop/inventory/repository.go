@@ -0,0 +1,164 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const shopInventoryColumns = `id, user_id, name, category, barcode, stock_quantity, unit, price, cost, expiry_date, storage_type, COALESCE(shelf_location, ''), image_upload_id, markdown_status, surplus_eligible, created_at, updated_at`
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanItem(row scanner) (*ShopInventoryItem, error) {
+	item := &ShopInventoryItem{}
+	err := row.Scan(&item.ID, &item.UserID, &item.Name, &item.Category, &item.Barcode, &item.StockQuantity, &item.Unit, &item.Price, &item.Cost, &item.ExpiryDate, &item.StorageType, &item.ShelfLocation, &item.ImageUploadID, &item.MarkdownStatus, &item.SurplusEligible, &item.CreatedAt, &item.UpdatedAt)
+	return item, err
+}
+
+func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*ShopInventoryItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + shopInventoryColumns + ` FROM shop_inventory_items WHERE user_id = $1 ORDER BY expiry_date ASC, created_at DESC`
+	rows, err := r.conn().Query(query, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var items []*ShopInventoryItem
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*ShopInventoryItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + shopInventoryColumns + ` FROM shop_inventory_items WHERE id = $1`
+	item, err := scanItem(r.conn().QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return item, nil
+}
+
+func (r *Repository) GetByBarcode(userID uuid.UUID, barcode string) (*ShopInventoryItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + shopInventoryColumns + ` FROM shop_inventory_items WHERE user_id = $1 AND barcode = $2 ORDER BY expiry_date ASC LIMIT 1`
+	item, err := scanItem(r.conn().QueryRow(query, userID, barcode))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return item, nil
+}
+
+func (r *Repository) Create(item *ShopInventoryItem) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO shop_inventory_items (id, user_id, name, category, barcode, stock_quantity, unit, price, cost, expiry_date, storage_type, shelf_location, image_upload_id, markdown_status, surplus_eligible, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING ` + shopInventoryColumns
+	now := time.Now()
+	created, err := scanItem(r.conn().QueryRow(query, item.ID, item.UserID, item.Name, item.Category, item.Barcode, item.StockQuantity, item.Unit, item.Price, item.Cost, item.ExpiryDate, item.StorageType, item.ShelfLocation, item.ImageUploadID, item.MarkdownStatus, item.SurplusEligible, now, now))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*item = *created
+	return nil
+}
+
+func (r *Repository) Update(item *ShopInventoryItem) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE shop_inventory_items SET name=$1, category=$2, barcode=$3, stock_quantity=$4, unit=$5, price=$6, cost=$7, expiry_date=$8, storage_type=$9, shelf_location=$10, image_upload_id=$11, markdown_status=$12, surplus_eligible=$13, updated_at=$14 WHERE id=$15 RETURNING ` + shopInventoryColumns
+	updated, err := scanItem(r.conn().QueryRow(query, item.Name, item.Category, item.Barcode, item.StockQuantity, item.Unit, item.Price, item.Cost, item.ExpiryDate, item.StorageType, item.ShelfLocation, item.ImageUploadID, item.MarkdownStatus, item.SurplusEligible, time.Now(), item.ID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*item = *updated
+	return nil
+}
+
+func (r *Repository) Delete(id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM shop_inventory_items WHERE id = $1`, id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetExpiredBetween returns the items whose expiry date falls in (from, to],
+// with only their ID, owner, name and expiry date set
+func (r *Repository) GetExpiredBetween(from, to time.Time) ([]*ShopInventoryItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT id, user_id, name, expiry_date FROM shop_inventory_items WHERE expiry_date > $1 AND expiry_date <= $2 ORDER BY expiry_date`
+	rows, err := r.conn().Query(query, from, to)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var items []*ShopInventoryItem
+	for rows.Next() {
+		item := &ShopInventoryItem{}
+		if err := rows.Scan(&item.ID, &item.UserID, &item.Name, &item.ExpiryDate); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,36 @@
+package inventory
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+	"strings"
+)
+
+// SetupRoutes sets up shop inventory routes. requireModule runs after
+// authentication and hides the routes while the shop module is off.
+func SetupRoutes(service *Service, handler *Handler, authMiddleware, requireModule func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		path := strings.TrimPrefix(r.URL.Path, "/api/v1/shop/inventory/")
+		switch {
+		case path == "" && r.Method == http.MethodGet:
+			return handler.GetAll(w, r)
+		case strings.HasPrefix(path, "barcode/") && r.Method == http.MethodGet:
+			return handler.GetByBarcode(w, r)
+		case path == "batch" && r.Method == http.MethodPost:
+			return handler.Batch(w, r)
+		case len(path) == 36 && r.Method == http.MethodGet:
+			return handler.GetByID(w, r)
+		case len(path) == 36 && r.Method == http.MethodPut:
+			return handler.Update(w, r)
+		case len(path) == 36 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		case r.Method == http.MethodPost:
+			return handler.Create(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	}))
+	return middleware.Chain(authMiddleware, requireModule)(mux)
+}
@@ -0,0 +1,162 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/uploads"
+	"foodlink_backend/utils"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+func (s *Service) GetAllByUserID(userID uuid.UUID) ([]*ShopInventoryItem, error) {
+	return s.repo.GetAllByUserID(userID)
+}
+
+func (s *Service) GetByID(id uuid.UUID) (*ShopInventoryItem, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *Service) GetByBarcode(userID uuid.UUID, barcode string) (*ShopInventoryItem, error) {
+	barcode = strings.TrimSpace(barcode)
+	if barcode == "" {
+		return nil, errors.NewAppError(errors.ErrBadRequest.Code, "Barcode is required")
+	}
+	return s.repo.GetByBarcode(userID, barcode)
+}
+
+func (s *Service) Create(userID uuid.UUID, req *CreateShopInventoryItemRequest) (*ShopInventoryItem, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
+	}
+	item := &ShopInventoryItem{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Name:            req.Name,
+		Category:        req.Category,
+		Barcode:         req.Barcode,
+		StockQuantity:   req.StockQuantity,
+		Unit:            req.Unit,
+		Price:           req.Price,
+		Cost:            req.Cost,
+		ExpiryDate:      req.ExpiryDate,
+		StorageType:     req.StorageType,
+		ShelfLocation:   req.ShelfLocation,
+		ImageUploadID:   req.ImageUploadID,
+		MarkdownStatus:  "none",
+		SurplusEligible: req.SurplusEligible,
+	}
+	if err := s.repo.Create(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *Service) Update(id uuid.UUID, userID uuid.UUID, req *UpdateShopInventoryItemRequest) (*ShopInventoryItem, error) {
+	item, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := uploads.CheckReference(s.repo.ctx, userID, req.ImageUploadID); err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		item.Name = req.Name
+	}
+	if req.Category != "" {
+		item.Category = req.Category
+	}
+	if req.Barcode != "" {
+		item.Barcode = req.Barcode
+	}
+	if req.StockQuantity != nil {
+		item.StockQuantity = *req.StockQuantity
+	}
+	if req.Unit != "" {
+		item.Unit = req.Unit
+	}
+	if req.Price != nil {
+		item.Price = *req.Price
+	}
+	if req.Cost != nil {
+		item.Cost = *req.Cost
+	}
+	if req.ExpiryDate != nil {
+		item.ExpiryDate = *req.ExpiryDate
+	}
+	if req.StorageType != "" {
+		item.StorageType = req.StorageType
+	}
+	if req.ShelfLocation != "" {
+		item.ShelfLocation = req.ShelfLocation
+	}
+	if req.ImageUploadID != nil {
+		item.ImageUploadID = req.ImageUploadID
+	}
+	if req.MarkdownStatus != "" {
+		item.MarkdownStatus = req.MarkdownStatus
+	}
+	if req.SurplusEligible != nil {
+		item.SurplusEligible = *req.SurplusEligible
+	}
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *Service) Delete(id uuid.UUID, userID uuid.UUID) error {
+	item, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if item.UserID != userID {
+		return errors.ErrForbidden
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *Service) Batch(userID uuid.UUID, req *utils.BatchRequest) (*utils.BatchResult, error) {
+	return utils.RunBatch(s.repo.ctx, s.repo.db, req, func(tx *sql.Tx, op *utils.BatchOperation) (interface{}, error) {
+		txService := &Service{repo: s.repo.WithTx(tx)}
+		switch op.Op {
+		case utils.BatchOpCreate:
+			var createReq CreateShopInventoryItemRequest
+			if err := op.Decode(&createReq); err != nil {
+				return nil, err
+			}
+			return txService.Create(userID, &createReq)
+		case utils.BatchOpUpdate:
+			var updateReq UpdateShopInventoryItemRequest
+			if err := op.Decode(&updateReq); err != nil {
+				return nil, err
+			}
+			return txService.Update(*op.ID, userID, &updateReq)
+		default:
+			return nil, txService.Delete(*op.ID, userID)
+		}
+	})
+}
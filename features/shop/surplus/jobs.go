@@ -0,0 +1,68 @@
+package surplus
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"foodlink_backend/database"
+	"foodlink_backend/features/notifications"
+	"foodlink_backend/jobs"
+	"log/slog"
+	"time"
+)
+
+// reminderLead is how long before pickup the shop is reminded
+const reminderLead = 2 * time.Hour
+
+// RegisterJobs registers the shop surplus background jobs
+func RegisterJobs(scheduler *jobs.Scheduler) {
+	scheduler.Schedule("shop_surplus.expire_items", "*/15 * * * *", expireItems)
+	scheduler.Schedule("shop_surplus.send_reminders", "*/5 * * * *", sendReminders)
+}
+
+// expireItems marks pending items past their expiry window as expired
+func expireItems(ctx context.Context, job *jobs.Job) error {
+	count, err := NewRepository().WithContext(ctx).ExpireDue()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		slog.Info("Expired shop surplus items", "count", count)
+	}
+	return nil
+}
+
+// sendReminders notifies shops of surplus pickups coming up within
+// reminderLead, once per item
+func sendReminders(ctx context.Context, job *jobs.Job) error {
+	repo := NewRepository().WithContext(ctx)
+	notifier := notifications.NewService().WithContext(ctx)
+	return database.WithTransaction(ctx, repo.db, func(tx *sql.Tx) error {
+		txRepo := repo.WithTx(tx)
+		reminders, err := txRepo.LockDueReminders(time.Now().Add(reminderLead))
+		if err != nil {
+			return err
+		}
+		for _, reminder := range reminders {
+			message := fmt.Sprintf("%s is due for pickup at %s UTC.", reminder.SKUName, reminder.PickupTime.UTC().Format("15:04"))
+			if reminder.DestinationName != nil && *reminder.DestinationName != "" {
+				message = fmt.Sprintf("%s is due for pickup by %s at %s UTC.", reminder.SKUName, *reminder.DestinationName, reminder.PickupTime.UTC().Format("15:04"))
+			}
+			_, err := notifier.Notify(tx, &notifications.NewNotification{
+				UserID:     reminder.UserID,
+				Source:     notifications.SourceCommunity,
+				Type:       "reminder",
+				Title:      "Surplus pickup soon",
+				Message:    message,
+				Preference: notifications.PreferencePickup,
+			})
+			if err != nil {
+				return err
+			}
+			if err := txRepo.MarkReminderSent(reminder.ItemID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
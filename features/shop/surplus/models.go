@@ -0,0 +1,16 @@
+package surplus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PickupReminder is a pending shop surplus item whose pickup is coming up
+type PickupReminder struct {
+	ItemID          uuid.UUID
+	UserID          uuid.UUID
+	SKUName         string
+	PickupTime      time.Time
+	DestinationName *string
+}
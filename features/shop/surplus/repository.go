@@ -0,0 +1,86 @@
+package surplus
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+// ExpireDue marks pending items past their expiry window as expired and
+// returns how many changed
+func (r *Repository) ExpireDue() (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	query := `UPDATE shop_surplus_items SET status = 'expired', updated_at = CURRENT_TIMESTAMP WHERE status = 'pending' AND expiry_window_end < CURRENT_TIMESTAMP`
+	result, err := r.conn().Exec(query)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
+
+// LockDueReminders returns pending items with a pickup before cutoff and no
+// reminder sent yet. Run it in a transaction: the rows stay locked until it
+// ends, and rows locked by another transaction are skipped.
+func (r *Repository) LockDueReminders(cutoff time.Time) ([]*PickupReminder, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT id, user_id, sku_name, pickup_time, destination_name FROM shop_surplus_items
+		WHERE status = 'pending' AND reminder_sent = FALSE AND pickup_time > CURRENT_TIMESTAMP AND pickup_time <= $1
+		ORDER BY pickup_time
+		FOR UPDATE SKIP LOCKED`
+	rows, err := r.conn().Query(query, cutoff)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var reminders []*PickupReminder
+	for rows.Next() {
+		reminder := &PickupReminder{}
+		if err := rows.Scan(&reminder.ItemID, &reminder.UserID, &reminder.SKUName, &reminder.PickupTime, &reminder.DestinationName); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+// MarkReminderSent records that the pickup reminder for an item went out
+func (r *Repository) MarkReminderSent(id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE shop_surplus_items SET reminder_sent = TRUE, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	if _, err := r.conn().Exec(query, id); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
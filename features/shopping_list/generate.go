@@ -0,0 +1,272 @@
+package shopping_list
+
+import (
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/meal_plans"
+	"foodlink_backend/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// generateDays is the number of days planned for without dates
+const generateDays = 7
+
+// demand is what the meal plans need of an ingredient in one dimension,
+// in the dimension's base unit
+type demand struct {
+	key       string
+	name      string
+	unit      unit
+	base      float64
+	hasAmount bool
+	neededOn  time.Time
+}
+
+// Generate adds what the household's meal plans need, scaled to the
+// household size, and its inventory lacks. Stock counts for a meal if it
+// has not expired by the meal's date. A pending item of the same name and
+// a compatible unit is merged into: its quantity is raised to what is
+// needed, never lowered, so generating twice adds nothing twice.
+func (s *Service) Generate(member Member, req *GenerateRequest) (*GenerateResult, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := today, today.AddDate(0, 0, generateDays-1)
+	if req.From != "" {
+		from, _ = time.Parse(meal_plans.DateLayout, req.From)
+		if req.To == "" {
+			to = from.AddDate(0, 0, generateDays-1)
+		}
+	}
+	if req.To != "" {
+		to, _ = time.Parse(meal_plans.DateLayout, req.To)
+	}
+
+	plans, err := meal_plans.NewService().WithContext(s.repo.ctx).List(meal_plans.Member(member), meal_plans.ListFilter{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	demands := mealDemands(plans)
+	stock, err := s.repo.GetStock(member.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	purchased := false
+	pending, err := s.repo.GetByHousehold(member.HouseholdID, &purchased)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerateResult{From: from.Format(meal_plans.DateLayout), To: to.Format(meal_plans.DateLayout), Lines: []*GeneratedLine{}}
+	var changes []*ShoppingListItem
+	for _, d := range demands {
+		line := &GeneratedLine{
+			Name:     d.name,
+			Unit:     d.unit.symbol,
+			Needed:   roundUp(d.base / d.unit.factor),
+			Priority: priority(today, d.neededOn),
+			NeededOn: d.neededOn.Format(meal_plans.DateLayout),
+		}
+		inStock := stockOf(stock, d)
+		line.InStock = roundUp(inStock / d.unit.factor)
+		toBuy := d.base - inStock
+		if !d.hasAmount {
+			toBuy = 0
+			if inStock == 0 {
+				toBuy = 1
+			}
+			line.Needed, line.InStock = 0, 0
+		}
+		result.Lines = append(result.Lines, line)
+		if toBuy <= 1e-9 {
+			continue
+		}
+		line.ToBuy = roundUp(toBuy / d.unit.factor)
+		if err := s.price(line, d, toBuy); err != nil {
+			return nil, err
+		}
+		item, err := s.lineItem(member, line, d, toBuy, pending)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			changes = append(changes, item)
+		}
+	}
+
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		repo := s.repo.WithTx(tx)
+		for _, item := range changes {
+			if item.Version == 0 {
+				if err := repo.Create(item); err != nil {
+					return err
+				}
+				continue
+			}
+			saved, err := repo.Update(item)
+			if err != nil {
+				return err
+			}
+			if !saved {
+				return errItemChanged
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.List, err = s.List(member, ListFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mealDemands sums the ingredients of the plans by name and dimension, in
+// the order they are first needed. A plan's ingredients are scaled to the
+// household size when it has servings.
+func mealDemands(plans []*meal_plans.MealPlan) []*demand {
+	var demands []*demand
+	byKey := make(map[string]*demand)
+	for _, plan := range plans {
+		date, _ := time.Parse(meal_plans.DateLayout, plan.Date)
+		ingredients := plan.Ingredients
+		if plan.Scaled != nil {
+			ingredients = plan.Scaled.Ingredients
+		}
+		for _, s := range ingredients {
+			ing := parseIngredient(s)
+			if ing.name == "" {
+				continue
+			}
+			key := utils.NameKey(ing.name)
+			d, ok := byKey[key+"|"+ing.unit.dim]
+			if !ok {
+				d = &demand{key: key, name: ing.name, unit: ing.unit, neededOn: date}
+				byKey[key+"|"+ing.unit.dim] = d
+				demands = append(demands, d)
+			}
+			if ing.hasAmount {
+				d.base += ing.amount * ing.unit.factor
+				d.hasAmount = true
+			}
+		}
+	}
+	return demands
+}
+
+// stockOf returns how much of the demand the stock covers, in base units.
+// For an ingredient without a quantity, any stock of it covers it.
+func stockOf(stock []stockItem, d *demand) float64 {
+	total := 0.0
+	for _, item := range stock {
+		if utils.NameKey(item.name) != d.key || (item.expiryDate != nil && item.expiryDate.Before(d.neededOn)) {
+			continue
+		}
+		u, ok := lookupUnit(item.unit)
+		if !d.hasAmount && item.quantity > 0 {
+			return 1
+		}
+		if ok && u.dim == d.unit.dim {
+			total += item.quantity * u.factor
+		}
+	}
+	return total
+}
+
+// price sets the line's cheapest store and estimated price, for toBuy base
+// units. A store price is for its unit, such as "kg" or "500 g", or for one
+// piece without one; prices in another dimension are left out.
+func (s *Service) price(line *GeneratedLine, d *demand, toBuy float64) error {
+	offers, err := s.repo.GetStoreOffers(utils.NameVariants(d.key))
+	if err != nil {
+		return err
+	}
+	for _, offer := range offers {
+		amount, rest, ok := meal_plans.SplitQuantity(offer.unit)
+		if !ok {
+			amount, rest = 1, offer.unit
+		}
+		u, ok := lookupUnit(strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(rest), "per "), "/"))
+		if !ok || u.dim != d.unit.dim || amount <= 0 {
+			continue
+		}
+		estimate := roundPrice(offer.price * toBuy / (amount * u.factor))
+		if line.EstimatedPrice == nil || estimate < *line.EstimatedPrice {
+			line.Store = offer.store
+			line.EstimatedPrice = &estimate
+		}
+	}
+	return nil
+}
+
+// lineItem returns the item to add for the line, or the pending item to
+// raise to it, nil when a pending item already covers it
+func (s *Service) lineItem(member Member, line *GeneratedLine, d *demand, toBuy float64, pending []*ShoppingListItem) (*ShoppingListItem, error) {
+	for _, item := range pending {
+		u, ok := lookupUnit(item.Unit)
+		if utils.NameKey(item.Name) != d.key || !ok || u.dim != d.unit.dim {
+			continue
+		}
+		line.ItemID = &item.ID
+		line.Merged = true
+		if item.Quantity*u.factor >= toBuy-1e-9 {
+			return nil, nil
+		}
+		item.Quantity = roundUp(toBuy / u.factor)
+		if line.EstimatedPrice != nil {
+			item.EstimatedPrice = line.EstimatedPrice
+			item.Store = line.Store
+		}
+		if priorityRank(line.Priority) < priorityRank(item.Priority) {
+			item.Priority = line.Priority
+		}
+		return item, nil
+	}
+	category, err := s.repo.FindFoodCategory(utils.NameVariants(d.key))
+	if err != nil {
+		return nil, err
+	}
+	item := &ShoppingListItem{
+		ID:             uuid.New(),
+		UserID:         member.UserID,
+		HouseholdID:    member.HouseholdID,
+		Name:           d.name,
+		Quantity:       line.ToBuy,
+		Unit:           d.unit.symbol,
+		Category:       category,
+		Priority:       line.Priority,
+		EstimatedPrice: line.EstimatedPrice,
+		Store:          line.Store,
+	}
+	line.ItemID = &item.ID
+	return item, nil
+}
+
+// priority is high for what is needed within two days, medium within five
+func priority(today, neededOn time.Time) string {
+	switch days := neededOn.Sub(today).Hours() / 24; {
+	case days <= 2:
+		return "high"
+	case days <= 5:
+		return "medium"
+	}
+	return "low"
+}
+
+func priorityRank(priority string) int {
+	switch priority {
+	case "high":
+		return 0
+	case "medium":
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,390 @@
+package shopping_list
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// getMember returns the signed-in user and their household, the user's own
+// ID when they have none, as family preferences do
+func (h *Handler) getMember(r *http.Request) (Member, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return Member{}, errors.ErrAuthRequired
+	}
+	member := Member{UserID: user.ID, HouseholdID: user.ID}
+	if user.HouseholdID != nil {
+		member.HouseholdID = *user.HouseholdID
+	}
+	return member, nil
+}
+
+// pathParts splits a /shopping-list/... path relative to /api/v1
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/shopping-list"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// itemID parses the item ID at the start of the path
+func itemID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// setETag sends the item's version as its entity tag
+func setETag(w http.ResponseWriter, item *ShoppingListItem) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(item.Version)+`"`)
+}
+
+// ifMatch returns the version in the If-Match header, 0 without one or for
+// "*". A tag that is not a version never matches.
+func ifMatch(r *http.Request) (int, error) {
+	tag := strings.TrimSpace(r.Header.Get("If-Match"))
+	if tag == "" || tag == "*" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(tag, "W/"), `"`))
+	if err != nil || version < 1 {
+		return 0, errItemChanged
+	}
+	return version, nil
+}
+
+// List handles GET /api/v1/shopping-list
+// @Summary      Get shopping list
+// @Description  Get the household's shopping list, pending items first and by priority, with the week's running totals against the weekly budget of the family preferences. Prices are estimates for an item's whole quantity.
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        purchased  query     bool    false  "Only purchased (true) or pending (false) items"
+// @Param        group_by   query     string  false  "Group items by category or aisle"
+// @Success      200        {object}  ShoppingList
+// @Failure      400        {object}  errors.Problem
+// @Failure      401        {object}  errors.Problem
+// @Router       /shopping-list [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	query := r.URL.Query()
+	filter := ListFilter{GroupBy: query.Get("group_by")}
+	if purchased, err := strconv.ParseBool(query.Get("purchased")); err == nil {
+		filter.Purchased = &purchased
+	}
+	list, err := h.service.WithContext(r.Context()).List(member, filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve shopping list")
+	}
+	utils.OKResponse(w, "Shopping list retrieved successfully", list)
+	return nil
+}
+
+// Create handles POST /api/v1/shopping-list
+// @Summary      Add shopping list item
+// @Description  Add an item to the household's shopping list
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      CreateShoppingListItemRequest  true  "Item"
+// @Success      201      {object}  ShoppingListItem
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Router       /shopping-list [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	var req CreateShoppingListItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Create(member, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add shopping list item")
+	}
+	setETag(w, item)
+	utils.CreatedResponse(w, "Shopping list item added successfully", item)
+	return nil
+}
+
+// Get handles GET /api/v1/shopping-list/:id
+// @Summary      Get shopping list item
+// @Description  Get an item of the household's shopping list. The ETag header is the item's version.
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Item ID"
+// @Success      200  {object}  ShoppingListItem
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /shopping-list/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := itemID(r)
+	if err != nil {
+		return err
+	}
+	item, err := h.service.WithContext(r.Context()).Get(member, id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve shopping list item")
+	}
+	setETag(w, item)
+	utils.OKResponse(w, "Shopping list item retrieved successfully", item)
+	return nil
+}
+
+// Update handles PUT /api/v1/shopping-list/:id
+// @Summary      Update shopping list item
+// @Description  Update the given fields of an item. Send the ETag or version read as If-Match: if another household member changed the item since, nothing is saved and 412 is returned.
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                         true   "Item ID"
+// @Param        If-Match  header    string                         false  "Version the item was read at"
+// @Param        request   body      UpdateShoppingListItemRequest  true   "Fields to update"
+// @Success      200       {object}  ShoppingListItem
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Failure      404       {object}  errors.Problem
+// @Failure      409       {object}  errors.Problem
+// @Failure      412       {object}  errors.Problem
+// @Router       /shopping-list/{id} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := itemID(r)
+	if err != nil {
+		return err
+	}
+	version, err := ifMatch(r)
+	if err != nil {
+		return err
+	}
+	var req UpdateShoppingListItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Update(member, id, version, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update shopping list item")
+	}
+	setETag(w, item)
+	utils.OKResponse(w, "Shopping list item updated successfully", item)
+	return nil
+}
+
+// Patch handles PATCH /api/v1/shopping-list/:id
+// @Summary      Patch shopping list item
+// @Description  Partially update an item with a JSON Merge Patch (RFC 7396); null clears unit, category, aisle or estimated_price. If-Match is checked like for PUT.
+// @Tags         shopping-list
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string                        true   "Item ID"
+// @Param        If-Match  header    string                        false  "Version the item was read at"
+// @Param        patch     body      PatchShoppingListItemRequest  true   "Merge patch document"
+// @Success      200       {object}  ShoppingListItem
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Failure      404       {object}  errors.Problem
+// @Failure      412       {object}  errors.Problem
+// @Failure      415       {object}  errors.Problem
+// @Router       /shopping-list/{id} [patch]
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPatch {
+		return errors.ErrMethodNotAllowed
+	}
+	if !utils.IsMergePatch(r) {
+		return errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Content-Type must be "+utils.MergePatchContentType)
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := itemID(r)
+	if err != nil {
+		return err
+	}
+	version, err := ifMatch(r)
+	if err != nil {
+		return err
+	}
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	item, err := h.service.WithContext(r.Context()).Patch(member, id, version, patch)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update shopping list item")
+	}
+	setETag(w, item)
+	utils.OKResponse(w, "Shopping list item updated successfully", item)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/shopping-list/:id
+// @Summary      Remove shopping list item
+// @Description  Remove an item from the household's list. If-Match is checked like for PUT.
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string  true   "Item ID"
+// @Param        If-Match  header    string  false  "Version the item was read at"
+// @Success      200       {object}  map[string]string
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Failure      404       {object}  errors.Problem
+// @Failure      412       {object}  errors.Problem
+// @Router       /shopping-list/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := itemID(r)
+	if err != nil {
+		return err
+	}
+	version, err := ifMatch(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(member, id, version); err != nil {
+		return errors.Wrap(err, "Failed to remove shopping list item")
+	}
+	utils.OKResponse(w, "Shopping list item removed successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// Purchase handles PUT /api/v1/shopping-list/:id/purchase
+// @Summary      Mark shopping list item purchased
+// @Description  Mark an item purchased and, unless add_to_inventory is false, add it to the buyer's inventory. Without expiry_date, the expiry is estimated from the typical expiry days of the food item of the same name. An item is bought once: a second purchase returns 409. If-Match is checked like for PUT.
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string           true   "Item ID"
+// @Param        If-Match  header    string           false  "Version the item was read at"
+// @Param        request   body      PurchaseRequest  false  "Inventory options"
+// @Success      200       {object}  PurchaseResult
+// @Failure      400       {object}  errors.Problem
+// @Failure      401       {object}  errors.Problem
+// @Failure      404       {object}  errors.Problem
+// @Failure      409       {object}  errors.Problem
+// @Failure      412       {object}  errors.Problem
+// @Router       /shopping-list/{id}/purchase [put]
+func (h *Handler) Purchase(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	id, err := itemID(r)
+	if err != nil {
+		return err
+	}
+	version, err := ifMatch(r)
+	if err != nil {
+		return err
+	}
+	var req PurchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Purchase(member, id, version, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to mark shopping list item purchased")
+	}
+	setETag(w, result.Item)
+	utils.OKResponse(w, "Shopping list item purchased successfully", result)
+	return nil
+}
+
+// Generate handles POST /api/v1/shopping-list/generate
+// @Summary      Generate shopping list from meal plans
+// @Description  Add what the household's meal plans between from and to (the next seven days by default) need and its inventory lacks. Ingredients such as "200 g rice" are summed by name, with units converted, scaled to the household size, and reduced by the members' inventory that has not expired by the meal's date. Pending items of the same name are raised to what is needed rather than added twice. Each line gets the cheapest store and an estimated price from the price comparisons, and a priority by how soon it is needed.
+// @Tags         shopping-list
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      GenerateRequest  false  "Date range"
+// @Success      200      {object}  GenerateResult
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
+// @Failure      412      {object}  errors.Problem
+// @Router       /shopping-list/generate [post]
+func (h *Handler) Generate(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	member, err := h.getMember(r)
+	if err != nil {
+		return err
+	}
+	var req GenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	result, err := h.service.WithContext(r.Context()).Generate(member, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to generate shopping list")
+	}
+	utils.OKResponse(w, "Shopping list generated successfully", result)
+	return nil
+}
@@ -0,0 +1,44 @@
+package shopping_list
+
+import (
+	"database/sql"
+	"fmt"
+	"foodlink_backend/database/migrations"
+)
+
+// RegisterMigrations registers the migrations of shopping lists
+func RegisterMigrations() {
+	migrations.RegisterMigration(migrations.Migration{
+		Version: 3,
+		Name:    "shopping_list_household_columns",
+		Up:      addHouseholdColumns,
+	})
+	migrations.RegisterMigration(migrations.Migration{
+		Version: 4,
+		Name:    "shopping_list_store",
+		Up:      addStoreColumn,
+	})
+}
+
+// addHouseholdColumns adds the columns of household shopping lists to
+// items created before them. Existing items start at version 1.
+func addHouseholdColumns(db *sql.DB) error {
+	_, err := db.Exec(`ALTER TABLE IF EXISTS shopping_list_items
+		ADD COLUMN IF NOT EXISTS aisle VARCHAR(100),
+		ADD COLUMN IF NOT EXISTS purchased_by UUID REFERENCES users(id) ON DELETE SET NULL,
+		ADD COLUMN IF NOT EXISTS inventory_item_id UUID REFERENCES inventory_items(id) ON DELETE SET NULL,
+		ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1`)
+	if err != nil {
+		return fmt.Errorf("failed to add shopping list columns: %w", err)
+	}
+	return nil
+}
+
+// addStoreColumn adds the store of price comparisons to items created
+// before generated lists
+func addStoreColumn(db *sql.DB) error {
+	if _, err := db.Exec(`ALTER TABLE IF EXISTS shopping_list_items ADD COLUMN IF NOT EXISTS store VARCHAR(255)`); err != nil {
+		return fmt.Errorf("failed to add shopping list store column: %w", err)
+	}
+	return nil
+}
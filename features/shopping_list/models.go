@@ -0,0 +1,167 @@
+package shopping_list
+
+import (
+	"foodlink_backend/features/inventory"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group-by options of the list
+const (
+	GroupByCategory = "category"
+	GroupByAisle    = "aisle"
+)
+
+// ShoppingListItem is an item of a household's shopping list. Version is
+// incremented by every change and sent as the ETag.
+type ShoppingListItem struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
+	HouseholdID     uuid.UUID  `json:"household_id" db:"household_id"`
+	Name            string     `json:"name" db:"name"`
+	Quantity        float64    `json:"quantity" db:"quantity"`
+	Unit            string     `json:"unit,omitempty" db:"unit"`
+	Category        string     `json:"category,omitempty" db:"category"`
+	Aisle           string     `json:"aisle,omitempty" db:"aisle"`
+	Priority        string     `json:"priority" db:"priority"`
+	EstimatedPrice  *float64   `json:"estimated_price,omitempty" db:"estimated_price"`
+	Store           string     `json:"store,omitempty" db:"store"`
+	Purchased       bool       `json:"purchased" db:"purchased"`
+	PurchasedAt     *time.Time `json:"purchased_at,omitempty" db:"purchased_at"`
+	PurchasedBy     *uuid.UUID `json:"purchased_by,omitempty" db:"purchased_by"`
+	InventoryItemID *uuid.UUID `json:"inventory_item_id,omitempty" db:"inventory_item_id"`
+	Version         int        `json:"version" db:"version"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateShoppingListItemRequest adds an item; EstimatedPrice is for the
+// whole quantity
+type CreateShoppingListItemRequest struct {
+	Name           string   `json:"name" validate:"required,min=1,max=255"`
+	Quantity       float64  `json:"quantity" validate:"required,gt=0"`
+	Unit           string   `json:"unit,omitempty" validate:"omitempty,max=50"`
+	Category       string   `json:"category,omitempty" validate:"omitempty,max=100"`
+	Aisle          string   `json:"aisle,omitempty" validate:"omitempty,max=100"`
+	Priority       string   `json:"priority,omitempty" validate:"omitempty,oneof=low medium high"`
+	EstimatedPrice *float64 `json:"estimated_price,omitempty" validate:"omitempty,gte=0"`
+	Store          string   `json:"store,omitempty" validate:"omitempty,max=255"`
+}
+
+type UpdateShoppingListItemRequest struct {
+	Name           string   `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Quantity       *float64 `json:"quantity,omitempty" validate:"omitempty,gt=0"`
+	Unit           string   `json:"unit,omitempty" validate:"omitempty,max=50"`
+	Category       string   `json:"category,omitempty" validate:"omitempty,max=100"`
+	Aisle          string   `json:"aisle,omitempty" validate:"omitempty,max=100"`
+	Priority       string   `json:"priority,omitempty" validate:"omitempty,oneof=low medium high"`
+	EstimatedPrice *float64 `json:"estimated_price,omitempty" validate:"omitempty,gte=0"`
+	Store          string   `json:"store,omitempty" validate:"omitempty,max=255"`
+}
+
+// PatchShoppingListItemRequest is the document a JSON Merge Patch applies
+// to; null clears an optional field
+type PatchShoppingListItemRequest struct {
+	Name           string   `json:"name" validate:"required,min=1,max=255"`
+	Quantity       float64  `json:"quantity" validate:"required,gt=0"`
+	Unit           string   `json:"unit,omitempty" validate:"omitempty,max=50"`
+	Category       string   `json:"category,omitempty" validate:"omitempty,max=100"`
+	Aisle          string   `json:"aisle,omitempty" validate:"omitempty,max=100"`
+	Priority       string   `json:"priority" validate:"required,oneof=low medium high"`
+	EstimatedPrice *float64 `json:"estimated_price,omitempty" validate:"omitempty,gte=0"`
+	Store          string   `json:"store,omitempty" validate:"omitempty,max=255"`
+}
+
+// PurchaseRequest marks an item purchased. Unless AddToInventory is false
+// the item is moved into the buyer's inventory, expiring at ExpiryDate or,
+// without one, after the typical expiry days of the food item of that name.
+type PurchaseRequest struct {
+	AddToInventory *bool      `json:"add_to_inventory,omitempty"`
+	ExpiryDate     *time.Time `json:"expiry_date,omitempty"`
+	Location       string     `json:"location,omitempty" validate:"omitempty,max=100"`
+}
+
+// PurchaseResult is the purchased item and the inventory item made from it
+type PurchaseResult struct {
+	Item          *ShoppingListItem        `json:"item"`
+	InventoryItem *inventory.InventoryItem `json:"inventory_item,omitempty"`
+}
+
+// ListFilter selects the household's items
+type ListFilter struct {
+	// Purchased, when set, keeps only purchased or only pending items
+	Purchased *bool
+	GroupBy   string
+}
+
+// ItemGroup is the items sharing a category or aisle; Key is empty for
+// items without one. EstimatedTotal sums the group's pending items.
+type ItemGroup struct {
+	Key            string              `json:"key"`
+	Items          []*ShoppingListItem `json:"items"`
+	EstimatedTotal float64             `json:"estimated_total"`
+}
+
+// Totals are the household's running totals for the current week, which
+// starts on Monday (UTC)
+type Totals struct {
+	WeekStart time.Time `json:"week_start"`
+	// PendingTotal is the estimated price of the items still to buy
+	PendingTotal float64 `json:"pending_total"`
+	// SpentThisWeek is the estimated price of the items bought this week
+	SpentThisWeek  float64 `json:"spent_this_week"`
+	ProjectedTotal float64 `json:"projected_total"`
+	// WeeklyBudget comes from the household's family preferences
+	WeeklyBudget    *float64 `json:"weekly_budget,omitempty"`
+	RemainingBudget *float64 `json:"remaining_budget,omitempty"`
+	OverBudget      bool     `json:"over_budget"`
+	// UnpricedItems is the number of pending items without an estimated
+	// price, left out of the totals
+	UnpricedItems int `json:"unpriced_items"`
+}
+
+// ShoppingList is the household's list, pending items first and by
+// priority, either flat or grouped, with its running totals
+type ShoppingList struct {
+	Items  []*ShoppingListItem `json:"items,omitempty"`
+	Groups []*ItemGroup        `json:"groups,omitempty"`
+	Totals Totals              `json:"totals"`
+}
+
+// GenerateRequest adds what the household's meal plans from From to To
+// need and its inventory lacks. Dates are YYYY-MM-DD; without them the next
+// seven days, from today, are planned for.
+type GenerateRequest struct {
+	From string `json:"from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	To   string `json:"to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// GeneratedLine is an ingredient the meal plans need, in Unit: what is
+// needed, what the household has in date, and what is left to buy. Lines
+// whose stock covers the need are reported without an item.
+type GeneratedLine struct {
+	Name     string  `json:"name"`
+	Unit     string  `json:"unit,omitempty"`
+	Needed   float64 `json:"needed"`
+	InStock  float64 `json:"in_stock"`
+	ToBuy    float64 `json:"to_buy"`
+	Priority string  `json:"priority"`
+	// NeededOn is the first date a meal needs it
+	NeededOn string `json:"needed_on"`
+	// Store and EstimatedPrice are the cheapest offer of the price
+	// comparisons, for ToBuy
+	Store          string   `json:"store,omitempty"`
+	EstimatedPrice *float64 `json:"estimated_price,omitempty"`
+	// ItemID is the item added, or the pending item merged into
+	ItemID *uuid.UUID `json:"item_id,omitempty"`
+	Merged bool       `json:"merged"`
+}
+
+// GenerateResult is what generating the list did, with the list after it
+type GenerateResult struct {
+	From  string           `json:"from"`
+	To    string           `json:"to"`
+	Lines []*GeneratedLine `json:"lines"`
+	List  *ShoppingList    `json:"list"`
+}
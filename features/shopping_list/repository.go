@@ -0,0 +1,309 @@
+package shopping_list
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+const itemColumns = `id, user_id, household_id, name, quantity, COALESCE(unit, ''), COALESCE(category, ''), COALESCE(aisle, ''), COALESCE(priority, 'medium'), estimated_price, COALESCE(store, ''), COALESCE(purchased, FALSE), purchased_at, purchased_by, inventory_item_id, version, created_at, updated_at`
+
+// itemOrder lists pending items first, then by priority, oldest first
+const itemOrder = `COALESCE(purchased, FALSE), CASE priority WHEN 'high' THEN 0 WHEN 'medium' THEN 1 ELSE 2 END, created_at, id`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanItem(row rowScanner) (*ShoppingListItem, error) {
+	item := &ShoppingListItem{}
+	err := row.Scan(&item.ID, &item.UserID, &item.HouseholdID, &item.Name, &item.Quantity, &item.Unit, &item.Category, &item.Aisle, &item.Priority, &item.EstimatedPrice, &item.Store, &item.Purchased, &item.PurchasedAt, &item.PurchasedBy, &item.InventoryItemID, &item.Version, &item.CreatedAt, &item.UpdatedAt)
+	return item, err
+}
+
+// GetByHousehold returns the household's items, all of them when purchased
+// is nil
+func (r *Repository) GetByHousehold(householdID uuid.UUID, purchased *bool) ([]*ShoppingListItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + itemColumns + ` FROM shopping_list_items WHERE household_id = $1 AND ($2::boolean IS NULL OR COALESCE(purchased, FALSE) = $2::boolean) ORDER BY ` + itemOrder
+	rows, err := r.conn().Query(query, householdID, purchased)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	items := []*ShoppingListItem{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return items, nil
+}
+
+// GetByID returns the item if it is on the household's list
+func (r *Repository) GetByID(householdID, id uuid.UUID) (*ShoppingListItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	item, err := scanItem(r.conn().QueryRow(`SELECT `+itemColumns+` FROM shopping_list_items WHERE id = $1 AND household_id = $2`, id, householdID))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return item, nil
+}
+
+func (r *Repository) Create(item *ShoppingListItem) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO shopping_list_items (id, user_id, household_id, name, quantity, unit, category, aisle, priority, estimated_price, store)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9, $10, NULLIF($11, ''))
+		RETURNING ` + itemColumns
+	created, err := scanItem(r.conn().QueryRow(query, item.ID, item.UserID, item.HouseholdID, item.Name, item.Quantity, item.Unit, item.Category, item.Aisle, item.Priority, item.EstimatedPrice, item.Store))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*item = *created
+	return nil
+}
+
+// Update saves the item if it is still at the version it was read at,
+// incrementing the version. It returns false when the item was changed or
+// deleted meanwhile.
+func (r *Repository) Update(item *ShoppingListItem) (bool, error) {
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	query := `UPDATE shopping_list_items
+		SET name = $1, quantity = $2, unit = NULLIF($3, ''), category = NULLIF($4, ''), aisle = NULLIF($5, ''), priority = $6, estimated_price = $7, store = NULLIF($8, ''),
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $9 AND household_id = $10 AND version = $11
+		RETURNING ` + itemColumns
+	updated, err := scanItem(r.conn().QueryRow(query, item.Name, item.Quantity, item.Unit, item.Category, item.Aisle, item.Priority, item.EstimatedPrice, item.Store, item.ID, item.HouseholdID, item.Version))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	*item = *updated
+	return true, nil
+}
+
+// MarkPurchased marks the item purchased by the user if it is still pending
+// and at the given version, linking the inventory item made from it. It
+// returns false when the item was changed meanwhile.
+func (r *Repository) MarkPurchased(item *ShoppingListItem, userID uuid.UUID, inventoryItemID *uuid.UUID, purchasedAt time.Time) (bool, error) {
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	query := `UPDATE shopping_list_items
+		SET purchased = TRUE, purchased_at = $1, purchased_by = $2, inventory_item_id = $3,
+			version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND household_id = $5 AND version = $6 AND NOT COALESCE(purchased, FALSE)
+		RETURNING ` + itemColumns
+	updated, err := scanItem(r.conn().QueryRow(query, purchasedAt, userID, inventoryItemID, item.ID, item.HouseholdID, item.Version))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	*item = *updated
+	return true, nil
+}
+
+// Delete removes the item if it is on the household's list and, unless
+// version is 0, still at that version. It returns false when nothing was
+// deleted.
+func (r *Repository) Delete(householdID, id uuid.UUID, version int) (bool, error) {
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM shopping_list_items WHERE id = $1 AND household_id = $2 AND ($3 = 0 OR version = $3)`, id, householdID, version)
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// GetWeeklyBudget returns the household's weekly budget, nil when it has
+// none
+func (r *Repository) GetWeeklyBudget(householdID uuid.UUID) (*float64, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	var budget sql.NullFloat64
+	err := r.conn().QueryRow(`SELECT weekly_budget FROM family_preferences WHERE household_id = $1`, householdID).Scan(&budget)
+	if err == sql.ErrNoRows || (err == nil && !budget.Valid) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return &budget.Float64, nil
+}
+
+// GetTotals sums the estimated prices of the household's pending items and
+// of those bought since the given time, and counts pending items without a
+// price
+func (r *Repository) GetTotals(householdID uuid.UUID, since time.Time) (*Totals, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT
+			COALESCE(SUM(estimated_price) FILTER (WHERE NOT COALESCE(purchased, FALSE)), 0),
+			COUNT(*) FILTER (WHERE NOT COALESCE(purchased, FALSE) AND estimated_price IS NULL),
+			COALESCE(SUM(estimated_price) FILTER (WHERE purchased AND purchased_at >= $2), 0)
+		FROM shopping_list_items WHERE household_id = $1`
+	totals := &Totals{WeekStart: since}
+	err := r.conn().QueryRow(query, householdID, since).Scan(&totals.PendingTotal, &totals.UnpricedItems, &totals.SpentThisWeek)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return totals, nil
+}
+
+// FindFoodItem returns the reference food item named like the given name,
+// matched case-insensitively, with its typical expiry days
+func (r *Repository) FindFoodItem(name string) (*uuid.UUID, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	var id uuid.UUID
+	var days int
+	err := r.conn().QueryRow(`SELECT id, typical_expiry_days FROM food_items WHERE lower(name) = lower($1) ORDER BY name LIMIT 1`, name).Scan(&id, &days)
+	if err == sql.ErrNoRows {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return &id, days, nil
+}
+
+// stockItem is an inventory item of a household member
+type stockItem struct {
+	name       string
+	quantity   float64
+	unit       string
+	expiryDate *time.Time
+}
+
+// GetStock returns the inventory items of the household's members that
+// have not expired
+func (r *Repository) GetStock(householdID uuid.UUID) ([]stockItem, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT name, quantity, COALESCE(unit, ''), expiry_date FROM inventory_items
+		WHERE (user_id = $1 OR user_id IN (SELECT id FROM users WHERE household_id = $1))
+			AND (expiry_date IS NULL OR expiry_date >= CURRENT_DATE)`
+	rows, err := r.conn().Query(query, householdID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var stock []stockItem
+	for rows.Next() {
+		var item stockItem
+		if err := rows.Scan(&item.name, &item.quantity, &item.unit, &item.expiryDate); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		stock = append(stock, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return stock, nil
+}
+
+// storeOffer is a store's price in a price comparison, for unit
+type storeOffer struct {
+	store string
+	price float64
+	unit  string
+}
+
+// GetStoreOffers returns the available store prices of the price
+// comparisons for any of the given lowercased item names
+func (r *Repository) GetStoreOffers(names []string) ([]storeOffer, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT COALESCE(s->>'store_name', s->>'storeName', ''), (s->>'price')::numeric, COALESCE(s->>'unit', '')
+		FROM price_comparisons, jsonb_array_elements(CASE jsonb_typeof(stores) WHEN 'array' THEN stores ELSE '[]'::jsonb END) AS s
+		WHERE lower(item_name) = ANY($1) AND jsonb_typeof(s->'price') = 'number' AND COALESCE(s->>'available', 'true') <> 'false'`
+	rows, err := r.conn().Query(query, pq.Array(names))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var offers []storeOffer
+	for rows.Next() {
+		var offer storeOffer
+		if err := rows.Scan(&offer.store, &offer.price, &offer.unit); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		offers = append(offers, offer)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return offers, nil
+}
+
+// FindFoodCategory returns the category of the reference food item named
+// like any of the given lowercased names, empty when there is none
+func (r *Repository) FindFoodCategory(names []string) (string, error) {
+	if r.db == nil {
+		return "", errors.ErrDatabase
+	}
+	var category string
+	err := r.conn().QueryRow(`SELECT category FROM food_items WHERE lower(name) = ANY($1) ORDER BY name LIMIT 1`, pq.Array(names)).Scan(&category)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.WrapError(err, errors.ErrDatabase)
+	}
+	return category, nil
+}
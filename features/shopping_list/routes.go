@@ -0,0 +1,39 @@
+package shopping_list
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the shopping list routes, mounted under /api/v1
+func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 0 && r.Method == http.MethodPost:
+			return handler.Create(w, r)
+		case len(parts) == 1 && parts[0] == "generate" && r.Method == http.MethodPost:
+			return handler.Generate(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.Get(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			return handler.Update(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPatch:
+			return handler.Patch(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		case len(parts) == 2 && parts[1] == "purchase" && r.Method == http.MethodPut:
+			return handler.Purchase(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/shopping-list", routes)
+	mux.Handle("/shopping-list/", routes)
+	return middleware.Chain(authMiddleware)(mux)
+}
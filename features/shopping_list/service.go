@@ -0,0 +1,343 @@
+package shopping_list
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/inventory"
+	"foodlink_backend/utils"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	errItemChanged      = errors.NewAppError(errors.ErrPreconditionFailed.Code, "Item was changed by another household member, reload it and try again")
+	errAlreadyPurchased = errors.NewAppError(errors.ErrConflict.Code, "Item is already purchased")
+	errInvalidGroupBy   = errors.NewAppError(errors.ErrBadRequest.Code, "group_by must be category or aisle")
+)
+
+// Member is a signed-in user acting on their household's list. Users
+// without a household have a list of their own.
+type Member struct {
+	UserID      uuid.UUID
+	HouseholdID uuid.UUID
+}
+
+type Service struct {
+	repo *Repository
+}
+
+func NewService() *Service {
+	return &Service{repo: NewRepository()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
+// List returns the household's items, grouped when asked, with the running
+// totals of the week
+func (s *Service) List(member Member, filter ListFilter) (*ShoppingList, error) {
+	if filter.GroupBy != "" && filter.GroupBy != GroupByCategory && filter.GroupBy != GroupByAisle {
+		return nil, errInvalidGroupBy
+	}
+	items, err := s.repo.GetByHousehold(member.HouseholdID, filter.Purchased)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.Totals(member)
+	if err != nil {
+		return nil, err
+	}
+	list := &ShoppingList{Totals: *totals}
+	if filter.GroupBy == "" {
+		list.Items = items
+	} else {
+		list.Groups = groupItems(items, filter.GroupBy)
+	}
+	return list, nil
+}
+
+// Totals sums the household's pending and bought items against its weekly
+// budget
+func (s *Service) Totals(member Member) (*Totals, error) {
+	totals, err := s.repo.GetTotals(member.HouseholdID, weekStart(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	totals.ProjectedTotal = totals.SpentThisWeek + totals.PendingTotal
+	budget, err := s.repo.GetWeeklyBudget(member.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+	if budget != nil {
+		remaining := *budget - totals.ProjectedTotal
+		totals.WeeklyBudget = budget
+		totals.RemainingBudget = &remaining
+		totals.OverBudget = remaining < 0
+	}
+	return totals, nil
+}
+
+func (s *Service) Get(member Member, id uuid.UUID) (*ShoppingListItem, error) {
+	return s.repo.GetByID(member.HouseholdID, id)
+}
+
+func (s *Service) Create(member Member, req *CreateShoppingListItemRequest) (*ShoppingListItem, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	priority := req.Priority
+	if priority == "" {
+		priority = "medium"
+	}
+	item := &ShoppingListItem{
+		ID:             uuid.New(),
+		UserID:         member.UserID,
+		HouseholdID:    member.HouseholdID,
+		Name:           req.Name,
+		Quantity:       req.Quantity,
+		Unit:           req.Unit,
+		Category:       req.Category,
+		Aisle:          req.Aisle,
+		Priority:       priority,
+		EstimatedPrice: req.EstimatedPrice,
+		Store:          req.Store,
+	}
+	if err := s.repo.Create(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Update changes the given fields. version is the one the client read the
+// item at, from If-Match, or 0 to skip the check; the item is only saved if
+// no one changed it since it was read.
+func (s *Service) Update(member Member, id uuid.UUID, version int, req *UpdateShoppingListItemRequest) (*ShoppingListItem, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	item, err := s.read(member, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		item.Name = req.Name
+	}
+	if req.Quantity != nil {
+		item.Quantity = *req.Quantity
+	}
+	if req.Unit != "" {
+		item.Unit = req.Unit
+	}
+	if req.Category != "" {
+		item.Category = req.Category
+	}
+	if req.Aisle != "" {
+		item.Aisle = req.Aisle
+	}
+	if req.Priority != "" {
+		item.Priority = req.Priority
+	}
+	if req.EstimatedPrice != nil {
+		item.EstimatedPrice = req.EstimatedPrice
+	}
+	if req.Store != "" {
+		item.Store = req.Store
+	}
+	return s.save(member, item)
+}
+
+// Patch applies a JSON Merge Patch, checking the version like Update
+func (s *Service) Patch(member Member, id uuid.UUID, version int, patch []byte) (*ShoppingListItem, error) {
+	item, err := s.read(member, id, version)
+	if err != nil {
+		return nil, err
+	}
+	doc := &PatchShoppingListItemRequest{
+		Name:           item.Name,
+		Quantity:       item.Quantity,
+		Unit:           item.Unit,
+		Category:       item.Category,
+		Aisle:          item.Aisle,
+		Priority:       item.Priority,
+		EstimatedPrice: item.EstimatedPrice,
+		Store:          item.Store,
+	}
+	if err := utils.ApplyMergePatch(doc, patch); err != nil {
+		return nil, errors.NewAppErrorWithErr(errors.ErrInvalidJSON.Code, "Invalid merge patch document", err)
+	}
+	if validationErrors := utils.ValidateStruct(doc); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	item.Name = doc.Name
+	item.Quantity = doc.Quantity
+	item.Unit = doc.Unit
+	item.Category = doc.Category
+	item.Aisle = doc.Aisle
+	item.Priority = doc.Priority
+	item.EstimatedPrice = doc.EstimatedPrice
+	item.Store = doc.Store
+	return s.save(member, item)
+}
+
+// Delete removes the item, checking the version like Update
+func (s *Service) Delete(member Member, id uuid.UUID, version int) error {
+	deleted, err := s.repo.Delete(member.HouseholdID, id, version)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return s.conflict(member, id)
+	}
+	return nil
+}
+
+// Purchase marks the item purchased by the member and, unless asked not to,
+// adds it to their inventory in the same transaction. When two members buy
+// the same item at once, one gets a conflict and nothing is added twice.
+func (s *Service) Purchase(member Member, id uuid.UUID, version int, req *PurchaseRequest) (*PurchaseResult, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	item, err := s.read(member, id, version)
+	if err != nil {
+		return nil, err
+	}
+	if item.Purchased {
+		return nil, errAlreadyPurchased
+	}
+	now := time.Now()
+	result := &PurchaseResult{Item: item}
+	if req.AddToInventory == nil || *req.AddToInventory {
+		result.InventoryItem, err = s.inventoryItem(member, item, req, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = database.WithTransaction(s.repo.ctx, s.repo.db, func(tx *sql.Tx) error {
+		var inventoryItemID *uuid.UUID
+		if result.InventoryItem != nil {
+			if err := inventory.NewRepository().WithContext(s.repo.ctx).WithTx(tx).Create(result.InventoryItem); err != nil {
+				return err
+			}
+			inventoryItemID = &result.InventoryItem.ID
+		}
+		purchased, err := s.repo.WithTx(tx).MarkPurchased(item, member.UserID, inventoryItemID, now)
+		if err != nil {
+			return err
+		}
+		if !purchased {
+			return s.conflict(member, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to purchase item")
+	}
+	return result, nil
+}
+
+// inventoryItem builds the inventory item a purchase adds. Without an
+// expiry date in the request, it is estimated from the typical expiry days
+// of the food item of the same name.
+func (s *Service) inventoryItem(member Member, item *ShoppingListItem, req *PurchaseRequest, purchasedAt time.Time) (*inventory.InventoryItem, error) {
+	foodItemID, expiryDays, err := s.repo.FindFoodItem(item.Name)
+	if err != nil {
+		return nil, err
+	}
+	expiryDate := req.ExpiryDate
+	if expiryDate == nil && foodItemID != nil {
+		estimated := purchasedAt.AddDate(0, 0, expiryDays)
+		expiryDate = &estimated
+	}
+	return &inventory.InventoryItem{
+		ID:         uuid.New(),
+		UserID:     member.UserID,
+		Name:       item.Name,
+		Quantity:   item.Quantity,
+		Unit:       item.Unit,
+		ExpiryDate: expiryDate,
+		Category:   item.Category,
+		Location:   req.Location,
+		FoodItemID: foodItemID,
+	}, nil
+}
+
+// read returns the item, failing if the client read it at another version
+func (s *Service) read(member Member, id uuid.UUID, version int) (*ShoppingListItem, error) {
+	item, err := s.repo.GetByID(member.HouseholdID, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && item.Version != version {
+		return nil, errItemChanged
+	}
+	return item, nil
+}
+
+func (s *Service) save(member Member, item *ShoppingListItem) (*ShoppingListItem, error) {
+	saved, err := s.repo.Update(item)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, s.conflict(member, item.ID)
+	}
+	return item, nil
+}
+
+// conflict explains why a conditional change of the item matched nothing:
+// it was deleted, bought or changed by someone else meanwhile
+func (s *Service) conflict(member Member, id uuid.UUID) error {
+	item, err := s.repo.GetByID(member.HouseholdID, id)
+	if err != nil {
+		return err
+	}
+	if item.Purchased {
+		return errAlreadyPurchased
+	}
+	return errItemChanged
+}
+
+// groupItems groups the items by category or aisle, keeping their order
+// within groups. Groups are sorted by key, items without one last.
+func groupItems(items []*ShoppingListItem, groupBy string) []*ItemGroup {
+	groups := []*ItemGroup{}
+	byKey := make(map[string]*ItemGroup)
+	for _, item := range items {
+		key := item.Category
+		if groupBy == GroupByAisle {
+			key = item.Aisle
+		}
+		group, ok := byKey[key]
+		if !ok {
+			group = &ItemGroup{Key: key, Items: []*ShoppingListItem{}}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, item)
+		if item.EstimatedPrice != nil && !item.Purchased {
+			group.EstimatedTotal += *item.EstimatedPrice
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Key, groups[j].Key
+		if (a == "") != (b == "") {
+			return b == ""
+		}
+		return a < b
+	})
+	return groups
+}
+
+// weekStart returns the start of the week of t: Monday, 00:00 UTC
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
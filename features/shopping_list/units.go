@@ -0,0 +1,119 @@
+package shopping_list
+
+import (
+	"foodlink_backend/features/meal_plans"
+	"math"
+	"strings"
+)
+
+// Unit dimensions; quantities of one dimension convert to each other
+const (
+	dimMass   = "mass"
+	dimVolume = "volume"
+	dimCount  = "count"
+)
+
+// unit is a unit of measure, as a multiple of its dimension's base unit:
+// grams, millilitres or pieces
+type unit struct {
+	symbol string
+	dim    string
+	factor float64
+}
+
+var units = map[string]unit{}
+
+func init() {
+	for _, u := range []struct {
+		unit
+		names []string
+	}{
+		{unit{"g", dimMass, 1}, []string{"g", "gr", "gram", "grams", "gramme", "grammes"}},
+		{unit{"kg", dimMass, 1000}, []string{"kg", "kgs", "kilo", "kilos", "kilogram", "kilograms"}},
+		{unit{"mg", dimMass, 0.001}, []string{"mg", "milligram", "milligrams"}},
+		{unit{"lb", dimMass, 453.592}, []string{"lb", "lbs", "pound", "pounds"}},
+		{unit{"oz", dimMass, 28.3495}, []string{"oz", "ounce", "ounces"}},
+		{unit{"ml", dimVolume, 1}, []string{"ml", "millilitre", "millilitres", "milliliter", "milliliters"}},
+		{unit{"cl", dimVolume, 10}, []string{"cl", "centilitre", "centilitres"}},
+		{unit{"dl", dimVolume, 100}, []string{"dl", "decilitre", "decilitres"}},
+		{unit{"l", dimVolume, 1000}, []string{"l", "litre", "litres", "liter", "liters"}},
+		{unit{"tsp", dimVolume, 4.92892}, []string{"tsp", "teaspoon", "teaspoons"}},
+		{unit{"tbsp", dimVolume, 14.7868}, []string{"tbsp", "tablespoon", "tablespoons"}},
+		{unit{"cup", dimVolume, 240}, []string{"cup", "cups"}},
+		{unit{"", dimCount, 1}, []string{"", "pc", "pcs", "piece", "pieces", "each", "ea", "x", "unit", "units", "item", "items"}},
+		{unit{"dozen", dimCount, 12}, []string{"dozen"}},
+	} {
+		for _, name := range u.names {
+			units[name] = u.unit
+		}
+	}
+}
+
+// lookupUnit returns the unit of the given name, case-insensitively
+func lookupUnit(name string) (unit, bool) {
+	u, ok := units[strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")]
+	return u, ok
+}
+
+// ingredient is an ingredient of a meal plan, such as "200 g rice" or
+// "2 onions, chopped". Ingredients without a quantity, such as "salt", are
+// only needed when there is none in stock.
+type ingredient struct {
+	name      string
+	amount    float64
+	unit      unit
+	hasAmount bool
+}
+
+// parseIngredient splits an ingredient into its quantity, unit and name.
+// Anything after a comma or in parentheses describes it and is dropped; a
+// comma between digits is a decimal comma, as in "1,5 l milk".
+func parseIngredient(s string) ingredient {
+	s = s[:noteIndex(s)]
+	amount, rest, ok := meal_plans.SplitQuantity(s)
+	if !ok {
+		return ingredient{name: strings.TrimSpace(s), amount: 1, unit: units[""]}
+	}
+	fields := strings.Fields(rest)
+	u := units[""]
+	if len(fields) > 1 {
+		if found, ok := lookupUnit(fields[0]); ok {
+			u = found
+			fields = fields[1:]
+		}
+	}
+	if len(fields) > 1 && strings.EqualFold(fields[0], "of") {
+		fields = fields[1:]
+	}
+	return ingredient{name: strings.Join(fields, " "), amount: amount, unit: u, hasAmount: true}
+}
+
+// noteIndex returns where the note of an ingredient starts: its first
+// parenthesis or comma that is not a decimal comma, or its length
+func noteIndex(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			return i
+		case ',':
+			if i == 0 || i+1 == len(s) || !isDigit(s[i-1]) || !isDigit(s[i+1]) {
+				return i
+			}
+		}
+	}
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// roundUp rounds a quantity to buy up to two decimals
+func roundUp(quantity float64) float64 {
+	return math.Ceil(quantity*100-1e-6) / 100
+}
+
+// roundPrice rounds a price to cents
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/metrics"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// retryMillis is the reconnect delay suggested to EventSource clients
+const retryMillis = 5000
+
+type Handler struct {
+	service *Service
+	hub     *Hub
+}
+
+func NewHandler(service *Service, hub *Hub) *Handler {
+	return &Handler{service: service, hub: hub}
+}
+
+func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return uuid.Nil, errors.ErrUnauthorized
+	}
+	return user.ID, nil
+}
+
+// lastEventID reads the resume point from the Last-Event-ID header, or the
+// last_event_id query parameter for clients that cannot set headers
+func lastEventID(r *http.Request) (int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, errors.NewAppError(http.StatusBadRequest, "Invalid Last-Event-ID")
+	}
+	return id, nil
+}
+
+// writeEvent writes one SSE message
+func writeEvent(w http.ResponseWriter, e *Event) error {
+	var data bytes.Buffer
+	if err := json.Compact(&data, e.Data); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data.Bytes()); err != nil {
+		return err
+	}
+	metrics.StreamEventsSentTotal.Inc(e.Type)
+	return nil
+}
+
+// Stream handles GET /api/v1/stream
+// @Summary      Stream real-time updates
+// @Description  Server-Sent Events stream of the user's updates: offer.created and pickup.status_changed for NGOs, surplus.requested and surplus.commented for surplus posters, leftover.claimed for leftover owners, and notification.created for everyone. Each message has an id, an event name and a JSON data line. Reconnect with the Last-Event-ID header (or the last_event_id query parameter) to replay missed events from the last hour; when too many were missed a reset event is sent instead and the client should reload. Browsers' EventSource cannot set headers, so the access token may be passed as the access_token query parameter. A comment line is sent periodically to keep the connection open.
+// @Tags         stream
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        Last-Event-ID  header  string  false  "ID of the last event received"
+// @Param        last_event_id  query   string  false  "ID of the last event received, for clients that cannot set headers"
+// @Param        access_token   query   string  false  "Access token, for clients that cannot set headers"
+// @Success      200  {string}  string  "text/event-stream"
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Router       /stream [get]
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return err
+	}
+	after, err := lastEventID(r)
+	if err != nil {
+		return err
+	}
+
+	ctx := r.Context()
+	service := h.service.WithContext(ctx)
+	cfg := service.Config()
+
+	// Subscribe before replaying so nothing is lost in between; events that
+	// arrive twice are skipped below
+	client := h.hub.Subscribe(userID, cfg.ClientBuffer)
+	defer h.hub.Unsubscribe(client)
+
+	var replay []*Event
+	var resetID int64
+	if after > 0 {
+		replay, resetID, err = service.Replay(userID, after)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The server write timeout would cut the stream off
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(ctx, "Failed to clear the stream write deadline", "error", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// From here on errors only end the stream; the client reconnects
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retryMillis); err != nil {
+		return nil
+	}
+	if resetID > 0 {
+		if err := writeEvent(w, &Event{ID: resetID, Type: EventReset, Data: json.RawMessage(`{}`)}); err != nil {
+			return nil
+		}
+	}
+	sent := make(map[int64]bool, len(replay))
+	for _, e := range replay {
+		if err := writeEvent(w, e); err != nil {
+			return nil
+		}
+		sent[e.ID] = true
+	}
+	if err := rc.Flush(); err != nil {
+		slog.WarnContext(ctx, "Streaming is not supported by the response writer", "error", err)
+		return nil
+	}
+
+	heartbeat := time.NewTicker(cfg.Heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-client.Done():
+			return nil
+		case e := <-client.Events():
+			if sent[e.ID] {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return nil
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package stream
+
+import (
+	"context"
+	"foodlink_backend/metrics"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// catchUpPageSize bounds each query when catching up after the listener
+// reconnects
+const catchUpPageSize = 500
+
+// Default is the hub behind the stream endpoint. The server closes it on
+// shutdown so open streams do not hold up the drain.
+var Default = NewHub()
+
+// Client is one open stream. The hub closes Done on shutdown or when the
+// client falls behind.
+type Client struct {
+	userID uuid.UUID
+	events chan *Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Events returns the client's live events
+func (c *Client) Events() <-chan *Event {
+	return c.events
+}
+
+// Done is closed when the hub has dropped the client
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// Hub fans stream events out to the clients connected to this instance.
+// Events reach every instance through PostgreSQL LISTEN/NOTIFY.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[uuid.UUID]map[*Client]struct{}
+	closed  bool
+	// lastID is the highest event ID seen, where catching up resumes
+	lastID int64
+}
+
+// NewHub creates a hub with no clients
+func NewHub() *Hub {
+	return &Hub{clients: map[uuid.UUID]map[*Client]struct{}{}}
+}
+
+// Subscribe registers a stream for the user. buffer is how many events may
+// queue before the client is dropped.
+func (h *Hub) Subscribe(userID uuid.UUID, buffer int) *Client {
+	c := &Client{userID: userID, events: make(chan *Event, buffer), done: make(chan struct{})}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		c.close()
+		return c
+	}
+	if h.clients[userID] == nil {
+		h.clients[userID] = map[*Client]struct{}{}
+	}
+	h.clients[userID][c] = struct{}{}
+	metrics.StreamClients.Add(1)
+	return c
+}
+
+// Unsubscribe removes the client
+func (h *Hub) Unsubscribe(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(c)
+}
+
+// remove drops the client; h.mu must be held
+func (h *Hub) remove(c *Client) {
+	clients := h.clients[c.userID]
+	if _, ok := clients[c]; !ok {
+		return
+	}
+	delete(clients, c)
+	if len(clients) == 0 {
+		delete(h.clients, c.userID)
+	}
+	c.close()
+	metrics.StreamClients.Add(-1)
+}
+
+// Close disconnects every client and refuses new ones
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for _, clients := range h.clients {
+		for c := range clients {
+			h.remove(c)
+		}
+	}
+}
+
+// hasClients reports whether the user has a stream open on this instance
+func (h *Hub) hasClients(userID uuid.UUID) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients[userID]) > 0
+}
+
+// Broadcast sends the event to the user's clients. A client whose buffer is
+// full is dropped rather than blocking the others; it resumes with
+// Last-Event-ID when it reconnects.
+func (h *Hub) Broadcast(e *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if e.ID > h.lastID {
+		h.lastID = e.ID
+	}
+	for c := range h.clients[e.UserID] {
+		select {
+		case c.events <- e:
+		default:
+			h.remove(c)
+			metrics.StreamClientsDroppedTotal.Inc()
+			slog.Info("Dropped slow stream client", "user_id", c.userID)
+		}
+	}
+}
+
+// seen records an event ID that needed no broadcast
+func (h *Hub) seen(id int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if id > h.lastID {
+		h.lastID = id
+	}
+}
+
+// Start listens for new events in the background until ctx is done
+func (h *Hub) Start(ctx context.Context, databaseURL string) {
+	repo := NewRepository().WithContext(ctx)
+	if id, err := repo.GetLatestID(); err == nil {
+		h.seen(id)
+	} else {
+		slog.Warn("Failed to read the latest stream event", "error", err)
+	}
+
+	listener := pq.NewListener(databaseURL, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("Stream listener connection problem", "error", err)
+		}
+	})
+	go func() {
+		defer listener.Close()
+		if err := listener.Listen(notifyChannel); err != nil {
+			slog.Error("Failed to listen for stream events", "error", err)
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-listener.Notify:
+				if n == nil {
+					// Reconnected: notifications sent meanwhile were lost
+					h.catchUp(repo)
+					continue
+				}
+				h.notify(repo, n.Extra)
+			case <-time.After(90 * time.Second):
+				go listener.Ping()
+			}
+		}
+	}()
+}
+
+// notify handles one "<event id>:<user id>" notification, loading the event
+// only when the user has a stream on this instance
+func (h *Hub) notify(repo *Repository, payload string) {
+	idPart, userPart, ok := strings.Cut(payload, ":")
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	userID, uerr := uuid.Parse(userPart)
+	if !ok || err != nil || uerr != nil {
+		slog.Warn("Ignoring malformed stream notification", "payload", payload)
+		return
+	}
+	if !h.hasClients(userID) {
+		h.seen(id)
+		return
+	}
+	e, err := repo.GetByID(id)
+	if err != nil {
+		slog.Warn("Failed to load stream event", "event_id", id, "error", err)
+		return
+	}
+	h.Broadcast(e)
+}
+
+// catchUp broadcasts the events stored since the last one seen
+func (h *Hub) catchUp(repo *Repository) {
+	for {
+		h.mu.Lock()
+		after := h.lastID
+		h.mu.Unlock()
+		list, err := repo.GetAfter(after, catchUpPageSize)
+		if err != nil {
+			slog.Warn("Failed to catch up on stream events", "error", err)
+			return
+		}
+		for _, e := range list {
+			h.Broadcast(e)
+		}
+		if len(list) < catchUpPageSize {
+			return
+		}
+	}
+}
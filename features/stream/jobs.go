@@ -0,0 +1,29 @@
+package stream
+
+import (
+	"context"
+	"foodlink_backend/config"
+	"foodlink_backend/jobs"
+	"log/slog"
+	"time"
+)
+
+// RegisterJobs registers the stream background jobs
+func RegisterJobs(scheduler *jobs.Scheduler, cfg *config.Config) {
+	window := cfg.Stream.ReplayWindow
+	scheduler.Schedule("stream.delete_old_events", "@hourly", func(ctx context.Context, job *jobs.Job) error {
+		return deleteOldEvents(ctx, window)
+	})
+}
+
+// deleteOldEvents deletes events that can no longer be replayed
+func deleteOldEvents(ctx context.Context, window time.Duration) error {
+	count, err := NewRepository().WithContext(ctx).DeleteBefore(time.Now().Add(-window))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		slog.Info("Deleted old stream events", "count", count)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package stream
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stream event names, sent as the SSE event field
+const (
+	EventOfferCreated        = "offer.created"
+	EventPickupStatusChanged = "pickup.status_changed"
+	EventSurplusRequested    = "surplus.requested"
+	EventSurplusCommented    = "surplus.commented"
+	EventLeftoverClaimed     = "leftover.claimed"
+	EventNotificationCreated = "notification.created"
+
+	// EventReset tells the client that events were missed and cannot be
+	// replayed, so it should reload its data
+	EventReset = "reset"
+)
+
+// Event is one message sent to a user's streams
+type Event struct {
+	ID        int64           `json:"id" db:"id"`
+	UserID    uuid.UUID       `json:"-" db:"user_id"`
+	Type      string          `json:"type" db:"type"`
+	Data      json.RawMessage `json:"data" db:"data"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,147 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// notifyChannel is the PostgreSQL channel that announces new stream events
+// to every instance. The payload is "<event id>:<user id>".
+const notifyChannel = "foodlink_stream"
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+const eventColumns = `id, user_id, type, data, created_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEvent(row rowScanner) (*Event, error) {
+	e := &Event{}
+	err := row.Scan(&e.ID, &e.UserID, &e.Type, &e.Data, &e.CreatedAt)
+	return e, err
+}
+
+func (r *Repository) queryEvents(query string, args ...interface{}) ([]*Event, error) {
+	rows, err := r.conn().Query(query, args...)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var list []*Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		list = append(list, e)
+	}
+	return list, nil
+}
+
+// Create stores an event for the user and announces it to every instance
+// when the transaction commits. It must run in a transaction.
+func (r *Repository) Create(userID uuid.UUID, eventType string, data interface{}) error {
+	if r.db == nil || r.tx == nil {
+		return errors.ErrDatabase
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrInternalServer)
+	}
+	var id int64
+	err = r.conn().QueryRow(`INSERT INTO stream_events (user_id, type, data) VALUES ($1, $2, $3) RETURNING id`, userID, eventType, payload).Scan(&id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	_, err = r.conn().Exec(`SELECT pg_notify($1, $2)`, notifyChannel, strconv.FormatInt(id, 10)+":"+userID.String())
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// GetByID returns one event
+func (r *Repository) GetByID(id int64) (*Event, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	e, err := scanEvent(r.conn().QueryRow(`SELECT `+eventColumns+` FROM stream_events WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return e, nil
+}
+
+// GetAfter returns up to limit events of any user with an ID above afterID,
+// oldest first
+func (r *Repository) GetAfter(afterID int64, limit int) ([]*Event, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	return r.queryEvents(`SELECT `+eventColumns+` FROM stream_events WHERE id > $1 ORDER BY id LIMIT $2`, afterID, limit)
+}
+
+// GetForUser returns up to limit of the user's events with an ID above
+// afterID created since the given time, oldest first
+func (r *Repository) GetForUser(userID uuid.UUID, afterID int64, since time.Time, limit int) ([]*Event, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	return r.queryEvents(`SELECT `+eventColumns+` FROM stream_events WHERE user_id = $1 AND id > $2 AND created_at >= $3 ORDER BY id LIMIT $4`, userID, afterID, since, limit)
+}
+
+// GetLatestID returns the highest event ID, or 0 when there are none
+func (r *Repository) GetLatestID() (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var id int64
+	if err := r.conn().QueryRow(`SELECT COALESCE(MAX(id), 0) FROM stream_events`).Scan(&id); err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return id, nil
+}
+
+// DeleteBefore deletes events created before the given time
+func (r *Repository) DeleteBefore(before time.Time) (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM stream_events WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,25 @@
+package stream
+
+import (
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the stream route, mounted under /api/v1
+func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/stream", middleware.Handle(handler.Stream))
+	return middleware.Chain(tokenFromQuery, authMiddleware)(mux)
+}
+
+// tokenFromQuery accepts the access token as the access_token query
+// parameter, since browsers' EventSource cannot set an Authorization header
+func tokenFromQuery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("access_token"); token != "" && r.Header.Get("Authorization") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,47 @@
+package stream
+
+import (
+	"context"
+	"foodlink_backend/config"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	repo *Repository
+	cfg  config.StreamConfig
+}
+
+func NewService(cfg *config.Config) *Service {
+	return &Service{repo: NewRepository(), cfg: cfg.Stream}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx), cfg: s.cfg}
+}
+
+// Config returns the stream settings
+func (s *Service) Config() config.StreamConfig {
+	return s.cfg
+}
+
+// Replay returns the user's events after lastEventID that are still within
+// the replay window. When more events were missed than can be replayed it
+// returns none and a reset ID instead: the client should reload and resume
+// from that ID.
+func (s *Service) Replay(userID uuid.UUID, lastEventID int64) (list []*Event, resetID int64, err error) {
+	since := time.Now().Add(-s.cfg.ReplayWindow)
+	list, err = s.repo.GetForUser(userID, lastEventID, since, s.cfg.ReplayLimit+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(list) <= s.cfg.ReplayLimit {
+		return list, 0, nil
+	}
+	resetID, err = s.repo.GetLatestID()
+	if err != nil {
+		return nil, 0, err
+	}
+	return nil, resetID, nil
+}
@@ -0,0 +1,73 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"foodlink_backend/events"
+
+	"github.com/google/uuid"
+)
+
+// route names the stream event for a domain event and picks the user it is
+// sent to, or uuid.Nil when nobody should receive it
+type route struct {
+	name      string
+	recipient func(payload json.RawMessage) (uuid.UUID, error)
+}
+
+var routes = map[string]route{
+	events.TypeOfferCreated: {EventOfferCreated, func(payload json.RawMessage) (uuid.UUID, error) {
+		var e events.OfferCreated
+		err := json.Unmarshal(payload, &e)
+		return e.NGOUserID, err
+	}},
+	events.TypePickupStatusChanged: {EventPickupStatusChanged, func(payload json.RawMessage) (uuid.UUID, error) {
+		var e events.PickupStatusChanged
+		err := json.Unmarshal(payload, &e)
+		return e.NGOUserID, err
+	}},
+	events.TypeSurplusRequested: {EventSurplusRequested, func(payload json.RawMessage) (uuid.UUID, error) {
+		var e events.SurplusRequested
+		err := json.Unmarshal(payload, &e)
+		return e.OwnerID, err
+	}},
+	events.TypeSurplusCommented: {EventSurplusCommented, func(payload json.RawMessage) (uuid.UUID, error) {
+		var e events.SurplusCommented
+		if err := json.Unmarshal(payload, &e); err != nil || e.AuthorID == e.OwnerID {
+			return uuid.Nil, err
+		}
+		return e.OwnerID, nil
+	}},
+	events.TypeLeftoverClaimed: {EventLeftoverClaimed, func(payload json.RawMessage) (uuid.UUID, error) {
+		var e events.LeftoverClaimed
+		err := json.Unmarshal(payload, &e)
+		return e.OwnerID, err
+	}},
+	events.TypeNotificationCreated: {EventNotificationCreated, func(payload json.RawMessage) (uuid.UUID, error) {
+		var e events.NotificationCreated
+		err := json.Unmarshal(payload, &e)
+		return e.UserID, err
+	}},
+}
+
+// RegisterSubscribers subscribes the stream to the events it pushes
+func RegisterSubscribers(bus *events.Bus) {
+	for eventType := range routes {
+		bus.Subscribe(eventType, "stream.publish", publish)
+	}
+}
+
+// publish stores the event for its recipient; the hub of every instance
+// picks it up once the transaction commits
+func publish(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	r, ok := routes[event.Type]
+	if !ok {
+		return nil
+	}
+	userID, err := r.recipient(event.Payload)
+	if err != nil || userID == uuid.Nil {
+		return err
+	}
+	return NewRepository().WithContext(ctx).WithTx(tx).Create(userID, r.name, event.Payload)
+}
@@ -0,0 +1,264 @@
+package uploads
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fileField is the multipart form field holding the uploaded file
+const fileField = "file"
+
+var errNoFile = errors.NewAppError(errors.ErrBadRequest.Code, "Expected a multipart/form-data body with a file field")
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return uuid.Nil, errors.ErrUnauthorized
+	}
+	return user.ID, nil
+}
+
+// pathParts splits a /uploads/... path relative to /api/v1
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/uploads"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// uploadID parses the upload ID at the start of the path
+func uploadID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// Upload handles POST /api/v1/uploads
+// @Summary      Upload image
+// @Description  Upload a JPEG, PNG, GIF or WebP image as the "file" field of a multipart form. The type is sniffed from the content, metadata such as EXIF location is stripped and a thumbnail is made except for WebP. Other resources reference the returned ID.
+// @Tags         uploads
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "Image file"
+// @Success      201   {object}  Upload
+// @Failure      400   {object}  errors.Problem
+// @Failure      401   {object}  errors.Problem
+// @Failure      413   {object}  errors.Problem
+// @Failure      415   {object}  errors.Problem
+// @Router       /uploads [post]
+func (h *Handler) Upload(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return errNoFile
+	}
+	// The file is read from the stream without buffering other fields to
+	// disk; one byte past the limit tells it is too large
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return errNoFile
+		}
+		if err != nil {
+			return errors.WrapError(err, errors.ErrInvalidRequestBody)
+		}
+		if part.FormName() != fileField {
+			continue
+		}
+		maxBytes := h.service.cfg.Uploads.MaxFileBytes
+		data, err := io.ReadAll(io.LimitReader(part, maxBytes+1))
+		if err != nil {
+			return err
+		}
+		if int64(len(data)) > maxBytes {
+			return errFileTooLarge
+		}
+		upload, err := h.service.WithContext(r.Context()).Upload(userID, part.FileName(), data)
+		if err != nil {
+			return errors.Wrap(err, "Failed to upload file")
+		}
+		utils.CreatedResponse(w, "File uploaded successfully", upload)
+		return nil
+	}
+}
+
+// List handles GET /api/v1/uploads
+// @Summary      List uploads
+// @Description  List the user's uploads, newest first, with download links
+// @Tags         uploads
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     int  false  "Page number, from 1"
+// @Param        limit  query     int  false  "Page size (default 20, at most 100)"
+// @Success      200    {object}  UploadList
+// @Failure      401    {object}  errors.Problem
+// @Router       /uploads [get]
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var page, limit int
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil {
+			page = p
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			limit = l
+		}
+	}
+	list, err := h.service.WithContext(r.Context()).List(userID, page, limit)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve uploads")
+	}
+	utils.OKResponse(w, "Uploads retrieved successfully", list)
+	return nil
+}
+
+// Get handles GET /api/v1/uploads/:id
+// @Summary      Get upload
+// @Description  Get an upload with download links of the image and its thumbnail, valid for a limited time. Any signed-in user may get an upload referenced by a shared resource.
+// @Tags         uploads
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Upload ID"
+// @Success      200  {object}  Upload
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /uploads/{id} [get]
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	if _, err := h.getUserID(r); err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := uploadID(r)
+	if err != nil {
+		return err
+	}
+	upload, err := h.service.WithContext(r.Context()).Get(id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve upload")
+	}
+	utils.OKResponse(w, "Upload retrieved successfully", upload)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/uploads/:id
+// @Summary      Delete upload
+// @Description  Delete one of the user's uploads and its files. Resources referencing it lose their image.
+// @Tags         uploads
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Upload ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /uploads/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := uploadID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(id, userID); err != nil {
+		return errors.Wrap(err, "Failed to delete upload")
+	}
+	utils.OKResponse(w, "Upload deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// Content handles GET /api/v1/uploads/:id/content
+// @Summary      Download upload
+// @Description  Download an image or its thumbnail from a signed link returned with the upload. The link works without signing in until it expires.
+// @Tags         uploads
+// @Produce      image/jpeg,image/png,image/gif,image/webp
+// @Param        id         path      string  true  "Upload ID"
+// @Param        variant    query     string  true  "original or thumbnail"
+// @Param        expires    query     int     true  "Expiry, in Unix seconds"
+// @Param        signature  query     string  true  "Link signature"
+// @Success      200        {file}    file
+// @Failure      400        {object}  errors.Problem
+// @Failure      403        {object}  errors.Problem
+// @Failure      404        {object}  errors.Problem
+// @Router       /uploads/{id}/content [get]
+func (h *Handler) Content(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return errors.ErrMethodNotAllowed
+	}
+	id, err := uploadID(r)
+	if err != nil {
+		return err
+	}
+	query := r.URL.Query()
+	req := &ContentRequest{Variant: query.Get("variant"), Signature: query.Get("signature")}
+	if req.Expires, err = strconv.ParseInt(query.Get("expires"), 10, 64); err != nil {
+		return errInvalidLink
+	}
+	content, err := h.service.WithContext(r.Context()).Open(id, req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to download upload")
+	}
+	defer content.Close()
+
+	header := w.Header()
+	header.Set("Content-Type", content.ContentType)
+	header.Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": content.FileName}))
+	header.Set("Cache-Control", "private, max-age="+strconv.Itoa(int(time.Until(content.ExpiresAt).Seconds())))
+	header.Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	header.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		io.Copy(w, content)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package uploads
+
+import (
+	"bytes"
+	"encoding/binary"
+	stderrors "errors"
+	"foodlink_backend/errors"
+	"image"
+	_ "image/gif" // GIF decoder
+	"net/http"
+)
+
+// Sniffed MIME types accepted for upload
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeGIF  = "image/gif"
+	TypeWebP = "image/webp"
+)
+
+var allowedTypes = map[string]bool{TypeJPEG: true, TypePNG: true, TypeGIF: true, TypeWebP: true}
+
+var (
+	errUnsupportedType = errors.NewAppError(errors.ErrUnsupportedMediaType.Code, "Only JPEG, PNG, GIF and WebP images can be uploaded")
+	errInvalidImage    = errors.NewAppError(errors.ErrBadRequest.Code, "The file is not a valid image")
+	errTooManyPixels   = errors.NewAppError(errors.ErrRequestTooLarge.Code, "The image has too many pixels")
+	errMalformed       = stderrors.New("malformed image")
+)
+
+// processedImage is an upload made safe to store
+type processedImage struct {
+	Data        []byte
+	ContentType string
+	// Width and Height are the displayed dimensions, after orientation
+	Width  int
+	Height int
+	// Thumbnail is nil for WebP, which the standard library cannot decode
+	Thumbnail     []byte
+	ThumbnailType string
+}
+
+// processImage sniffs the file type, strips metadata that could identify
+// the uploader (EXIF with GPS and camera details, XMP, IPTC, comments) and
+// renders the thumbnail. The EXIF orientation of JPEGs is kept, alone, so
+// photos still display upright. GIFs carry no such metadata and are stored
+// as uploaded.
+func processImage(data []byte, maxPixels, thumbnailSize int) (*processedImage, error) {
+	img := &processedImage{ContentType: http.DetectContentType(data)}
+	if !allowedTypes[img.ContentType] {
+		return nil, errUnsupportedType
+	}
+	orientation := 1
+	var err error
+	switch img.ContentType {
+	case TypeJPEG:
+		img.Data, orientation, err = stripJPEG(data)
+	case TypePNG:
+		img.Data, err = stripPNG(data)
+	case TypeWebP:
+		img.Data, err = stripWebP(data)
+	default:
+		img.Data = data
+	}
+	if err != nil {
+		return nil, errInvalidImage
+	}
+
+	if img.ContentType == TypeWebP {
+		img.Width, img.Height, err = webpSize(img.Data)
+	} else {
+		var cfg image.Config
+		cfg, _, err = image.DecodeConfig(bytes.NewReader(img.Data))
+		img.Width, img.Height = cfg.Width, cfg.Height
+	}
+	if err != nil || img.Width <= 0 || img.Height <= 0 {
+		return nil, errInvalidImage
+	}
+	// Checked before decoding, which allocates for every pixel
+	if int64(img.Width)*int64(img.Height) > int64(maxPixels) {
+		return nil, errTooManyPixels
+	}
+	if orientation >= 5 {
+		img.Width, img.Height = img.Height, img.Width
+	}
+
+	if img.ContentType != TypeWebP {
+		img.Thumbnail, img.ThumbnailType, err = thumbnail(img.Data, img.ContentType, orientation, thumbnailSize)
+		if err != nil {
+			return nil, errInvalidImage
+		}
+	}
+	return img, nil
+}
+
+// JPEG markers
+const (
+	jpegSOI  = 0xD8
+	jpegEOI  = 0xD9
+	jpegSOS  = 0xDA
+	jpegAPP0 = 0xE0
+	jpegAPP1 = 0xE1
+	jpegAPP2 = 0xE2 // ICC color profile
+	jpegAPPE = 0xEE // Adobe color transform
+	jpegCOM  = 0xFE
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// stripJPEG drops application segments other than JFIF, ICC profiles and
+// the Adobe color transform, and comments. It returns the EXIF orientation
+// found, which is written back as the only EXIF tag.
+func stripJPEG(data []byte) ([]byte, int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegSOI {
+		return nil, 0, errMalformed
+	}
+	orientation := 1
+	var kept [][]byte
+	i := 2
+	for {
+		if i+2 > len(data) || data[i] != 0xFF {
+			return nil, 0, errMalformed
+		}
+		marker := data[i+1]
+		if marker == 0xFF { // fill byte
+			i++
+			continue
+		}
+		if marker == jpegSOS || marker == jpegEOI {
+			break
+		}
+		if i+4 > len(data) {
+			return nil, 0, errMalformed
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end < i+4 || end > len(data) {
+			return nil, 0, errMalformed
+		}
+		segment := data[i:end]
+		switch {
+		case marker == jpegAPP1: // EXIF or XMP
+			if payload := segment[4:]; bytes.HasPrefix(payload, exifHeader) {
+				if o := exifOrientation(payload[len(exifHeader):]); o != 0 {
+					orientation = o
+				}
+			}
+		case marker == jpegCOM:
+		case marker > jpegAPP0 && marker <= 0xEF && marker != jpegAPP2 && marker != jpegAPPE: // IPTC, maker data
+		default:
+			kept = append(kept, segment)
+		}
+		i = end
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, jpegSOI)
+	// JFIF must come first; EXIF follows it
+	if len(kept) > 0 && kept[0][1] == jpegAPP0 {
+		out = append(out, kept[0]...)
+		kept = kept[1:]
+	}
+	if orientation > 1 {
+		out = append(out, orientationSegment(orientation)...)
+	}
+	for _, segment := range kept {
+		out = append(out, segment...)
+	}
+	return append(out, data[i:]...), orientation, nil
+}
+
+// exifOrientation reads the orientation tag of IFD0 from a TIFF structure,
+// returning 0 when there is none
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < count; k++ {
+		entry := ifd + 2 + k*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// orientationSegment is an APP1 segment whose EXIF holds only the
+// orientation tag
+func orientationSegment(orientation int) []byte {
+	segment := []byte{0xFF, jpegAPP1, 0, 34}
+	segment = append(segment, exifHeader...)
+	segment = append(segment, 'M', 'M', 0, 42, 0, 0, 0, 8) // big-endian TIFF, IFD0 at 8
+	segment = append(segment, 0, 1)                        // one entry
+	segment = append(segment, 0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, byte(orientation), 0, 0)
+	return append(segment, 0, 0, 0, 0) // no next IFD
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the PNG chunks dropped: text, EXIF and timestamps
+var pngMetadataChunks = map[string]bool{"tEXt": true, "zTXt": true, "iTXt": true, "eXIf": true, "tIME": true}
+
+// stripPNG drops metadata chunks, copying the others unchanged
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	i := len(pngSignature)
+	for {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end < i+12 || end > len(data) {
+			return nil, errMalformed
+		}
+		chunkType := string(data[i+4 : i+8])
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+		if chunkType == "IEND" {
+			return out, nil
+		}
+	}
+}
+
+// webpChunk is a chunk of a WebP RIFF container
+type webpChunk struct {
+	fourCC  string
+	payload []byte
+	raw     []byte // header, payload and padding
+}
+
+// webpChunks splits a WebP file into its chunks
+func webpChunks(data []byte) ([]webpChunk, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errMalformed
+	}
+	var chunks []webpChunk
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, errMalformed
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			return nil, errMalformed
+		}
+		chunks = append(chunks, webpChunk{fourCC: string(data[i : i+4]), payload: data[i+8 : i+8+size], raw: data[i:end]})
+		i = end
+	}
+	return chunks, nil
+}
+
+// VP8X flags of the metadata chunks
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebP drops the EXIF and XMP chunks and their VP8X flags
+func stripWebP(data []byte) ([]byte, error) {
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	for _, chunk := range chunks {
+		switch chunk.fourCC {
+		case "EXIF", "XMP ":
+			continue
+		case "VP8X":
+			start := len(out)
+			out = append(out, chunk.raw...)
+			if len(chunk.payload) > 0 {
+				out[start+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+			continue
+		}
+		out = append(out, chunk.raw...)
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// webpSize reads the canvas size of a WebP file from its first image chunk
+func webpSize(data []byte) (int, int, error) {
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, chunk := range chunks {
+		p := chunk.payload
+		switch chunk.fourCC {
+		case "VP8X":
+			if len(p) < 10 {
+				return 0, 0, errMalformed
+			}
+			return 1 + int(uint32(p[4])|uint32(p[5])<<8|uint32(p[6])<<16), 1 + int(uint32(p[7])|uint32(p[8])<<8|uint32(p[9])<<16), nil
+		case "VP8 ":
+			if len(p) < 10 || p[3] != 0x9D || p[4] != 0x01 || p[5] != 0x2A {
+				return 0, 0, errMalformed
+			}
+			return int(binary.LittleEndian.Uint16(p[6:]) & 0x3FFF), int(binary.LittleEndian.Uint16(p[8:]) & 0x3FFF), nil
+		case "VP8L":
+			if len(p) < 5 || p[0] != 0x2F {
+				return 0, 0, errMalformed
+			}
+			bits := binary.LittleEndian.Uint32(p[1:])
+			return 1 + int(bits&0x3FFF), 1 + int(bits>>14&0x3FFF), nil
+		}
+	}
+	return 0, 0, errMalformed
+}
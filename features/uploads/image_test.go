@@ -0,0 +1,174 @@
+package uploads
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+const (
+	testMaxPixels     = 1 << 20
+	testThumbnailSize = 16
+)
+
+// gpsMarker stands for GPS coordinates in the metadata of test images
+const gpsMarker = "GPS 48.8584N 2.2945E"
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(x * 40), uint8(y * 40), 200, 255})
+		}
+	}
+	return img
+}
+
+// exifSegment is a JPEG APP1 segment whose EXIF has the orientation and,
+// after IFD0, the GPS marker
+func exifSegment(orientation int) []byte {
+	payload := append([]byte{}, exifHeader...)
+	payload = append(payload, 'I', 'I', 42, 0, 8, 0, 0, 0) // little-endian TIFF, IFD0 at 8
+	payload = append(payload, 1, 0)                        // one entry
+	payload = append(payload, 0x12, 0x01, 3, 0, 1, 0, 0, 0, byte(orientation), 0, 0, 0)
+	payload = append(payload, 0, 0, 0, 0)
+	payload = append(payload, gpsMarker...)
+	segment := []byte{0xFF, jpegAPP1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func jpegWithEXIF(t *testing.T, width, height, orientation int) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(width, height), nil); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+	comment := append([]byte{0xFF, jpegCOM, 0, byte(len("Camera: X100") + 2)}, "Camera: X100"...)
+	out := append([]byte{}, encoded[:2]...)
+	out = append(out, exifSegment(orientation)...)
+	out = append(out, comment...)
+	return append(out, encoded[2:]...)
+}
+
+// pngChunk encodes a PNG chunk with its CRC
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, chunkType...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func pngWithText(t *testing.T) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage(3, 2)); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+	iend := len(encoded) - 12
+	out := append([]byte{}, encoded[:iend]...)
+	out = append(out, pngChunk("tEXt", []byte("Location\x00"+gpsMarker))...)
+	out = append(out, pngChunk("eXIf", []byte("MM\x00\x2a"+gpsMarker))...)
+	return append(out, encoded[iend:]...)
+}
+
+func TestProcessImageStripsJPEGMetadata(t *testing.T) {
+	data := jpegWithEXIF(t, 4, 2, 6)
+	if !bytes.Contains(data, []byte(gpsMarker)) {
+		t.Fatal("test image has no GPS marker")
+	}
+
+	img, err := processImage(data, testMaxPixels, testThumbnailSize)
+	if err != nil {
+		t.Fatalf("processImage: %v", err)
+	}
+	if img.ContentType != TypeJPEG {
+		t.Errorf("ContentType = %q, want %q", img.ContentType, TypeJPEG)
+	}
+	if bytes.Contains(img.Data, []byte(gpsMarker)) || bytes.Contains(img.Data, []byte("Camera: X100")) {
+		t.Error("metadata kept in the stored image")
+	}
+	if _, o, err := stripJPEG(img.Data); err != nil || o != 6 {
+		t.Errorf("stored orientation = %d (%v), want 6", o, err)
+	}
+	// Orientation 6 rotates the 4x2 image a quarter turn
+	if img.Width != 2 || img.Height != 4 {
+		t.Errorf("size = %dx%d, want 2x4", img.Width, img.Height)
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(img.Data)); err != nil {
+		t.Errorf("stored image does not decode: %v", err)
+	}
+	if img.Thumbnail == nil || bytes.Contains(img.Thumbnail, []byte(gpsMarker)) {
+		t.Error("missing or unstripped thumbnail")
+	}
+}
+
+func TestProcessImageStripsPNGMetadata(t *testing.T) {
+	img, err := processImage(pngWithText(t), testMaxPixels, testThumbnailSize)
+	if err != nil {
+		t.Fatalf("processImage: %v", err)
+	}
+	if bytes.Contains(img.Data, []byte(gpsMarker)) {
+		t.Error("metadata kept in the stored image")
+	}
+	if img.Width != 3 || img.Height != 2 {
+		t.Errorf("size = %dx%d, want 3x2", img.Width, img.Height)
+	}
+	if _, err := png.Decode(bytes.NewReader(img.Data)); err != nil {
+		t.Errorf("stored image does not decode: %v", err)
+	}
+}
+
+func TestProcessImageSniffsType(t *testing.T) {
+	pngData := pngWithText(t)
+	tests := []struct {
+		name     string
+		data     []byte
+		wantType string
+		wantErr  error
+	}{
+		{"png", pngData, TypePNG, nil},
+		{"jpeg", jpegWithEXIF(t, 2, 2, 1), TypeJPEG, nil},
+		{"html", []byte("<!DOCTYPE html><script>alert(1)</script>"), "", errUnsupportedType},
+		{"svg", []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`), "", errUnsupportedType},
+		{"truncated png", pngData[:40], "", errInvalidImage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := processImage(tt.data, testMaxPixels, testThumbnailSize)
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && img.ContentType != tt.wantType {
+				t.Errorf("ContentType = %q, want %q", img.ContentType, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestProcessImageRejectsTooManyPixels(t *testing.T) {
+	var buf bytes.Buffer
+	png.Encode(&buf, testImage(20, 20))
+	if _, err := processImage(buf.Bytes(), 399, testThumbnailSize); err != errTooManyPixels {
+		t.Errorf("err = %v, want errTooManyPixels", err)
+	}
+}
+
+// TestUploadIgnoresDeclaredType checks that a file named as an image is
+// rejected for its content, before anything is stored
+func TestUploadIgnoresDeclaredType(t *testing.T) {
+	storage := &recordingStorage{}
+	s := NewService(testConfig(), storage)
+	_, err := s.Upload(testUserID, "photo.png", []byte("<html><body>not an image</body></html>"))
+	if err != errUnsupportedType {
+		t.Errorf("err = %v, want errUnsupportedType", err)
+	}
+	if storage.puts != 0 {
+		t.Errorf("%d files stored", storage.puts)
+	}
+}
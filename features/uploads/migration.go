@@ -0,0 +1,273 @@
+package uploads
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/database"
+	"foodlink_backend/database/migrations"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// inlineImageColumn is a column that held base64 images before they were
+// uploaded, and the upload column replacing it
+type inlineImageColumn struct {
+	table, owner, column, uploadColumn string
+}
+
+var inlineImageColumns = []inlineImageColumn{
+	{"community_surplus_posts", "user_id", "image", "image_upload_id"},
+	{"leftover_items", "user_id", "image", "image_upload_id"},
+	{"restaurant_inventory_items", "user_id", "invoice_image", "invoice_upload_id"},
+	{"restaurant_surplus_items", "user_id", "image", "image_upload_id"},
+	{"ngo_feedback_entries", "ngo_user_id", "photo", "photo_upload_id"},
+	{"ngo_impact_stories", "ngo_user_id", "image", "photo_upload_id"},
+	{"shop_inventory_items", "user_id", "image_data", "image_upload_id"},
+}
+
+// RegisterMigrations registers the migrations of uploads. Files are put in
+// the storage of cfg, which is only opened when there are rows to migrate.
+func RegisterMigrations(cfg *config.Config) {
+	migrations.RegisterMigration(migrations.Migration{
+		Version: 1,
+		Name:    "uploads_to_storage",
+		Up: func(db *sql.DB) error {
+			return migrateInlineImages(db, func() (Storage, error) {
+				storage, err := NewStorage(cfg)
+				if err != nil {
+					return nil, fmt.Errorf("invalid storage configuration: %w", err)
+				}
+				return storage, nil
+			}, cfg.Uploads)
+		},
+	})
+}
+
+// imageMigration moves base64 images stored in rows into storage, as
+// uploads. Images the upload endpoint would reject are logged and dropped.
+type imageMigration struct {
+	ctx     context.Context
+	db      *sql.DB
+	tx      *sql.Tx
+	storage Storage
+	cfg     config.UploadsConfig
+	// stored are the uploads put in storage, removed if the migration fails
+	stored []*Upload
+}
+
+// migrateInlineImages runs the migration, opening the storage with
+// newStorage once the tables to migrate are found
+func migrateInlineImages(db *sql.DB, newStorage func() (Storage, error), cfg config.UploadsConfig) error {
+	m := &imageMigration{ctx: context.Background(), db: db, cfg: cfg}
+	err := database.WithTransaction(m.ctx, db, func(tx *sql.Tx) error {
+		m.tx = tx
+		exists, err := migrations.TableExists(tx, "uploads")
+		if err != nil || !exists {
+			// A new database: schema.sql creates the tables as they are now
+			return err
+		}
+		if m.storage, err = newStorage(); err != nil {
+			return err
+		}
+		if err := m.migrateUploads(); err != nil {
+			return err
+		}
+		for _, c := range inlineImageColumns {
+			if err := m.migrateColumn(c); err != nil {
+				return fmt.Errorf("%s.%s: %w", c.table, c.column, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, u := range m.stored {
+			deleteFiles(m.ctx, m.storage, u)
+		}
+	}
+	return err
+}
+
+// migrateUploads adds the file columns to uploads and moves the data of its
+// rows into storage
+func (m *imageMigration) migrateUploads() error {
+	_, err := m.tx.ExecContext(m.ctx, `ALTER TABLE uploads
+		ADD COLUMN IF NOT EXISTS width INTEGER,
+		ADD COLUMN IF NOT EXISTS height INTEGER,
+		ADD COLUMN IF NOT EXISTS checksum VARCHAR(64),
+		ADD COLUMN IF NOT EXISTS storage_key TEXT,
+		ADD COLUMN IF NOT EXISTS thumbnail_key TEXT`)
+	if err != nil {
+		return fmt.Errorf("failed to add upload columns: %w", err)
+	}
+	hasData, err := migrations.ColumnExists(m.tx, "uploads", "data")
+	if err != nil {
+		return err
+	}
+	if hasData {
+		rows, err := m.tx.QueryContext(m.ctx, `SELECT id, user_id, file_name, COALESCE(data, '') FROM uploads WHERE storage_key IS NULL`)
+		if err != nil {
+			return fmt.Errorf("failed to read uploads: %w", err)
+		}
+		var pending []*Upload
+		var inline []string
+		for rows.Next() {
+			u := &Upload{}
+			var data string
+			if err := rows.Scan(&u.ID, &u.UserID, &u.FileName, &data); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to read uploads: %w", err)
+			}
+			pending = append(pending, u)
+			inline = append(inline, data)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read uploads: %w", err)
+		}
+
+		for i, u := range pending {
+			stored, err := m.store(u, inline[i], "uploads", u.ID)
+			if err != nil {
+				return err
+			}
+			if !stored {
+				// An upload without a file is of no use to anything
+				if _, err := m.tx.ExecContext(m.ctx, `DELETE FROM uploads WHERE id = $1`, u.ID); err != nil {
+					return fmt.Errorf("failed to delete upload %s: %w", u.ID, err)
+				}
+				continue
+			}
+			_, err = m.tx.ExecContext(m.ctx, `UPDATE uploads SET file_type = $2, file_size = $3, width = $4, height = $5, checksum = $6, storage_key = $7, thumbnail_key = $8 WHERE id = $1`,
+				u.ID, u.FileType, u.FileSize, u.Width, u.Height, u.Checksum, u.StorageKey, u.ThumbnailKey)
+			if err != nil {
+				return fmt.Errorf("failed to update upload %s: %w", u.ID, err)
+			}
+		}
+	}
+
+	_, err = m.tx.ExecContext(m.ctx, `ALTER TABLE uploads
+		DROP COLUMN IF EXISTS data,
+		DROP COLUMN IF EXISTS associated_type,
+		DROP COLUMN IF EXISTS associated_id,
+		ALTER COLUMN width SET NOT NULL,
+		ALTER COLUMN height SET NOT NULL,
+		ALTER COLUMN checksum SET NOT NULL,
+		ALTER COLUMN storage_key SET NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("failed to drop inline upload columns: %w", err)
+	}
+	return nil
+}
+
+// migrateColumn replaces an inline image column by its upload column,
+// creating an upload owned by the row's owner for each image
+func (m *imageMigration) migrateColumn(c inlineImageColumn) error {
+	exists, err := migrations.ColumnExists(m.tx, c.table, c.column)
+	if err != nil || !exists {
+		return err
+	}
+	// Table and column names are constants of inlineImageColumns
+	_, err = m.tx.ExecContext(m.ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s UUID REFERENCES uploads(id) ON DELETE SET NULL`, c.table, c.uploadColumn))
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", c.uploadColumn, err)
+	}
+
+	rows, err := m.tx.QueryContext(m.ctx, fmt.Sprintf(`SELECT id, %s, %s FROM %s WHERE %s IS NOT NULL AND %s <> ''`, c.owner, c.column, c.table, c.column, c.column))
+	if err != nil {
+		return fmt.Errorf("failed to read images: %w", err)
+	}
+	type inlineImage struct {
+		rowID, owner uuid.UUID
+		data         string
+	}
+	var images []inlineImage
+	for rows.Next() {
+		var image inlineImage
+		if err := rows.Scan(&image.rowID, &image.owner, &image.data); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read images: %w", err)
+		}
+		images = append(images, image)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read images: %w", err)
+	}
+
+	repo := (&Repository{db: m.db}).WithContext(m.ctx).WithTx(m.tx)
+	for _, image := range images {
+		u := &Upload{ID: uuid.New(), UserID: image.owner, FileName: c.column}
+		stored, err := m.store(u, image.data, c.table, image.rowID)
+		if err != nil {
+			return err
+		}
+		if !stored {
+			continue
+		}
+		if err := repo.Create(u); err != nil {
+			return fmt.Errorf("failed to create upload: %w", err)
+		}
+		if _, err := m.tx.ExecContext(m.ctx, fmt.Sprintf(`UPDATE %s SET %s = $1 WHERE id = $2`, c.table, c.uploadColumn), u.ID, image.rowID); err != nil {
+			return fmt.Errorf("failed to link upload: %w", err)
+		}
+	}
+
+	if _, err := m.tx.ExecContext(m.ctx, fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, c.table, c.column)); err != nil {
+		return fmt.Errorf("failed to drop column: %w", err)
+	}
+	slog.Info("Moved inline images to uploads", "table", c.table, "column", c.column, "rows", len(images))
+	return nil
+}
+
+// store puts the inline image in storage for the upload. It reports false,
+// with a warning naming the row, for values that are not images the upload
+// endpoint accepts.
+func (m *imageMigration) store(u *Upload, inline, table string, rowID uuid.UUID) (bool, error) {
+	data, ok := decodeInline(inline)
+	if !ok {
+		slog.Warn("Dropping inline image that is not base64", "table", table, "id", rowID, "value", truncate(inline, 100))
+		return false, nil
+	}
+	img, err := processImage(data, m.cfg.MaxPixels, m.cfg.ThumbnailSize)
+	if err != nil {
+		slog.Warn("Dropping inline image that is not a valid upload", "table", table, "id", rowID, "error", err)
+		return false, nil
+	}
+	u.FileName = cleanFileName(u.FileName)
+	if err := storeImage(m.ctx, m.storage, u, img); err != nil {
+		return false, fmt.Errorf("failed to store image of %s %s: %w", table, rowID, err)
+	}
+	m.stored = append(m.stored, u)
+	return true, nil
+}
+
+// decodeInline decodes a base64 image, bare or as a data URI
+func decodeInline(value string) ([]byte, bool) {
+	// Long base64 values are often wrapped over lines
+	value = strings.Join(strings.Fields(value), "")
+	if rest, ok := strings.CutPrefix(value, "data:"); ok {
+		params, payload, found := strings.Cut(rest, ",")
+		if !found || !strings.HasSuffix(params, ";base64") {
+			return nil, false
+		}
+		value = payload
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := enc.DecodeString(value); err == nil && len(data) > 0 {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
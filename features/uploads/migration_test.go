@@ -0,0 +1,37 @@
+package uploads
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestDecodeInline(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\nimage bytes?")
+	encoded := base64.StdEncoding.EncodeToString(data)
+	tests := []struct {
+		name  string
+		value string
+		ok    bool
+	}{
+		{"bare", encoded, true},
+		{"data uri", "data:image/png;base64," + encoded, true},
+		{"wrapped", encoded[:8] + "\n" + encoded[8:] + "\n", true},
+		{"unpadded", base64.RawStdEncoding.EncodeToString(data), true},
+		{"url safe", base64.URLEncoding.EncodeToString(data), true},
+		{"url", "https://cdn.example.com/photo.jpg", false},
+		{"data uri without base64", "data:image/svg+xml,<svg/>", false},
+		{"empty", "  ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := decodeInline(tt.value)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && !bytes.Equal(got, data) {
+				t.Errorf("decoded %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package uploads
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Variants of an upload served by the content endpoint
+const (
+	VariantOriginal  = "original"
+	VariantThumbnail = "thumbnail"
+)
+
+// Upload is an image stored for a user. Other resources reference it by ID
+// instead of embedding its data.
+type Upload struct {
+	ID       uuid.UUID `json:"id" db:"id"`
+	UserID   uuid.UUID `json:"user_id" db:"user_id"`
+	FileName string    `json:"file_name" db:"file_name"`
+	// FileType is the sniffed MIME type, never the one the client sent
+	FileType string `json:"file_type" db:"file_type"`
+	// FileSize, Width and Height describe the stored file, after metadata
+	// was stripped
+	FileSize     int64     `json:"file_size" db:"file_size"`
+	Width        int       `json:"width" db:"width"`
+	Height       int       `json:"height" db:"height"`
+	Checksum     string    `json:"checksum" db:"checksum"`
+	StorageKey   string    `json:"-" db:"storage_key"`
+	ThumbnailKey *string   `json:"-" db:"thumbnail_key"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	// URL and ThumbnailURL are signed download links valid until
+	// URLExpiresAt. ThumbnailURL is empty for formats without thumbnails.
+	URL          string     `json:"url,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	URLExpiresAt *time.Time `json:"url_expires_at,omitempty"`
+}
+
+// ContentRequest is the signed query of a download link
+type ContentRequest struct {
+	Variant   string `json:"variant" validate:"required,oneof=original thumbnail"`
+	Expires   int64  `json:"expires" validate:"required"`
+	Signature string `json:"signature" validate:"required,hexadecimal"`
+}
+
+// UploadList is a page of uploads, newest first
+type UploadList struct {
+	Uploads []*Upload `json:"uploads"`
+	Page    int       `json:"page"`
+	Limit   int       `json:"limit"`
+	Total   int       `json:"total"`
+}
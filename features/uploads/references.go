@@ -0,0 +1,27 @@
+package uploads
+
+import (
+	"context"
+	"foodlink_backend/errors"
+
+	"github.com/google/uuid"
+)
+
+var errInvalidReference = errors.NewAppError(errors.ErrBadRequest.Code, "Referenced upload does not exist or belongs to another user")
+
+// CheckReference verifies that a resource saved by the user may reference
+// the upload: it must exist and be theirs. Features call it before saving an
+// upload ID; a nil ID, clearing the reference, is always allowed.
+func CheckReference(ctx context.Context, userID uuid.UUID, uploadID *uuid.UUID) error {
+	if uploadID == nil {
+		return nil
+	}
+	owned, err := NewRepository().WithContext(ctx).IsOwnedBy(*uploadID, userID)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return errInvalidReference
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package uploads
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+const uploadColumns = `id, user_id, file_name, file_type, file_size, width, height, checksum, storage_key, thumbnail_key, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUpload(row rowScanner) (*Upload, error) {
+	u := &Upload{}
+	err := row.Scan(&u.ID, &u.UserID, &u.FileName, &u.FileType, &u.FileSize, &u.Width, &u.Height, &u.Checksum, &u.StorageKey, &u.ThumbnailKey, &u.CreatedAt, &u.UpdatedAt)
+	return u, err
+}
+
+// Create inserts the upload, setting its timestamps
+func (r *Repository) Create(u *Upload) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO uploads (id, user_id, file_name, file_type, file_size, width, height, checksum, storage_key, thumbnail_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING created_at, updated_at`
+	err := r.conn().QueryRow(query, u.ID, u.UserID, u.FileName, u.FileType, u.FileSize, u.Width, u.Height, u.Checksum, u.StorageKey, u.ThumbnailKey).Scan(&u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+func (r *Repository) GetByID(id uuid.UUID) (*Upload, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	u, err := scanUpload(r.conn().QueryRow(`SELECT `+uploadColumns+` FROM uploads WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return u, nil
+}
+
+// GetByUser returns a page of the user's uploads, newest first, with their
+// total
+func (r *Repository) GetByUser(userID uuid.UUID, page, limit int) ([]*Upload, int, error) {
+	if r.db == nil {
+		return nil, 0, errors.ErrDatabase
+	}
+	var total int
+	if err := r.conn().QueryRow(`SELECT COUNT(*) FROM uploads WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	rows, err := r.conn().Query(`SELECT `+uploadColumns+` FROM uploads WHERE user_id = $1 ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	list := []*Upload{}
+	for rows.Next() {
+		u, err := scanUpload(rows)
+		if err != nil {
+			return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+		}
+		list = append(list, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return list, total, nil
+}
+
+// Delete removes the upload; references to it are cleared by their
+// foreign keys
+func (r *Repository) Delete(id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM uploads WHERE id = $1`, id)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// IsOwnedBy reports whether the upload exists and belongs to the user
+func (r *Repository) IsOwnedBy(id, userID uuid.UUID) (bool, error) {
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	var owned bool
+	err := r.conn().QueryRow(`SELECT EXISTS (SELECT 1 FROM uploads WHERE id = $1 AND user_id = $2)`, id, userID).Scan(&owned)
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return owned, nil
+}
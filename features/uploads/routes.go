@@ -0,0 +1,40 @@
+package uploads
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the upload routes, mounted under /api/v1. Download
+// links are signed and work without signing in.
+func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
+	content := middleware.Handle(handler.Content)
+	protected := authMiddleware(middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodPost:
+			return handler.Upload(w, r)
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.List(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.Get(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	}))
+	routes := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if parts := pathParts(r); len(parts) == 2 && parts[1] == "content" {
+			content.ServeHTTP(w, r)
+			return
+		}
+		protected.ServeHTTP(w, r)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/uploads", routes)
+	mux.Handle("/uploads/", routes)
+	return mux
+}
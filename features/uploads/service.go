@@ -0,0 +1,259 @@
+package uploads
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"foodlink_backend/config"
+	"foodlink_backend/errors"
+	"foodlink_backend/metrics"
+	"foodlink_backend/utils"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit  = 20
+	maxListLimit      = 100
+	maxFileNameLength = 255
+)
+
+var (
+	errStorageUnavailable = errors.NewAppError(errors.ErrInternalServer.Code, "File storage is not available")
+	errStorage            = errors.NewAppError(errors.ErrInternalServer.Code, "File storage error")
+	errEmptyFile          = errors.NewAppError(errors.ErrBadRequest.Code, "The file is empty")
+	errFileTooLarge       = errors.NewAppError(errors.ErrRequestTooLarge.Code, "The file is too large")
+	errLinkExpired        = errors.NewAppError(errors.ErrForbidden.Code, "Download link expired")
+	errInvalidLink        = errors.NewAppError(errors.ErrForbidden.Code, "Invalid download link")
+)
+
+// Content is an upload variant being downloaded
+type Content struct {
+	io.ReadCloser
+	FileName    string
+	ContentType string
+	ExpiresAt   time.Time
+}
+
+type Service struct {
+	repo    *Repository
+	cfg     *config.Config
+	storage Storage
+}
+
+// NewService creates the uploads service keeping files in storage
+func NewService(cfg *config.Config, storage Storage) *Service {
+	return &Service{repo: NewRepository(), cfg: cfg, storage: storage}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx), cfg: s.cfg, storage: s.storage}
+}
+
+func (s *Service) context() context.Context {
+	if s.repo.ctx == nil {
+		return context.Background()
+	}
+	return s.repo.ctx
+}
+
+// secret signs download links
+func (s *Service) secret() string {
+	return s.cfg.Auth.JWTSecret
+}
+
+// Upload stores an image for the user, stripped of metadata, with its
+// thumbnail
+func (s *Service) Upload(userID uuid.UUID, fileName string, data []byte) (*Upload, error) {
+	if s.storage == nil {
+		return nil, errStorageUnavailable
+	}
+	if len(data) == 0 {
+		return nil, errEmptyFile
+	}
+	if int64(len(data)) > s.cfg.Uploads.MaxFileBytes {
+		return nil, errFileTooLarge
+	}
+	img, err := processImage(data, s.cfg.Uploads.MaxPixels, s.cfg.Uploads.ThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+	u := &Upload{ID: uuid.New(), UserID: userID, FileName: cleanFileName(fileName)}
+	if err := storeImage(s.context(), s.storage, u, img); err != nil {
+		return nil, errors.WrapError(err, errStorage)
+	}
+	if err := s.repo.Create(u); err != nil {
+		s.deleteFiles(u)
+		return nil, err
+	}
+	metrics.UploadsTotal.Inc(u.FileType)
+	s.signURLs(u)
+	return u, nil
+}
+
+// List returns a page of the user's uploads
+func (s *Service) List(userID uuid.UUID, page, limit int) (*UploadList, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	list, total, err := s.repo.GetByUser(userID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range list {
+		s.signURLs(u)
+	}
+	return &UploadList{Uploads: list, Page: page, Limit: limit, Total: total}, nil
+}
+
+// Get returns an upload with fresh download links. Any signed-in user may
+// get one, as the resources referencing uploads are shared.
+func (s *Service) Get(id uuid.UUID) (*Upload, error) {
+	u, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	s.signURLs(u)
+	return u, nil
+}
+
+// Delete removes the user's upload, clearing references to it, then its
+// files
+func (s *Service) Delete(id, userID uuid.UUID) error {
+	u, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if u.UserID != userID {
+		return errors.ErrForbidden
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	s.deleteFiles(u)
+	return nil
+}
+
+// Open checks a signed download link and opens the file it points to
+func (s *Service) Open(id uuid.UUID, req *ContentRequest) (*Content, error) {
+	if v := utils.ValidateStruct(req); len(v) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+v[0], utils.ValidationErrors(v))
+	}
+	if !VerifyContent(s.secret(), id, req.Variant, req.Expires, req.Signature) {
+		return nil, errInvalidLink
+	}
+	expiresAt := time.Unix(req.Expires, 0)
+	if time.Now().After(expiresAt) {
+		return nil, errLinkExpired
+	}
+	if s.storage == nil {
+		return nil, errStorageUnavailable
+	}
+	u, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	key, contentType := u.StorageKey, u.FileType
+	if req.Variant == VariantThumbnail {
+		if u.ThumbnailKey == nil {
+			return nil, errors.ErrNotFound
+		}
+		key, contentType = *u.ThumbnailKey, thumbnailType(u.FileType)
+	}
+	body, err := s.storage.Open(s.context(), key)
+	if stderrors.Is(err, ErrObjectNotFound) {
+		return nil, errors.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errStorage)
+	}
+	return &Content{ReadCloser: body, FileName: u.FileName, ContentType: contentType, ExpiresAt: expiresAt}, nil
+}
+
+// signURLs sets the upload's download links
+func (s *Service) signURLs(u *Upload) {
+	expires := time.Now().Add(s.cfg.Uploads.URLExpiry).Truncate(time.Second)
+	u.URL = contentURL(s.secret(), u.ID, VariantOriginal, expires.Unix())
+	if u.ThumbnailKey != nil {
+		u.ThumbnailURL = contentURL(s.secret(), u.ID, VariantThumbnail, expires.Unix())
+	}
+	u.URLExpiresAt = &expires
+}
+
+// deleteFiles removes an upload's files. Failures are only logged: the
+// files are unreachable once the upload is gone.
+func (s *Service) deleteFiles(u *Upload) {
+	deleteFiles(s.context(), s.storage, u)
+}
+
+// storeImage puts the files of the processed image in storage and sets the
+// upload's file fields. Nothing is left in storage when it fails.
+func storeImage(ctx context.Context, storage Storage, u *Upload, img *processedImage) error {
+	sum := sha256.Sum256(img.Data)
+	u.FileType = img.ContentType
+	u.FileSize = int64(len(img.Data))
+	u.Width, u.Height = img.Width, img.Height
+	u.Checksum = hex.EncodeToString(sum[:])
+	u.StorageKey = objectKey(u, VariantOriginal)
+	u.ThumbnailKey = nil
+	if err := storage.Put(ctx, u.StorageKey, img.Data, img.ContentType); err != nil {
+		return err
+	}
+	if img.Thumbnail != nil {
+		key := objectKey(u, VariantThumbnail)
+		u.ThumbnailKey = &key
+		if err := storage.Put(ctx, key, img.Thumbnail, img.ThumbnailType); err != nil {
+			deleteFiles(ctx, storage, u)
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteFiles(ctx context.Context, storage Storage, u *Upload) {
+	keys := []string{u.StorageKey}
+	if u.ThumbnailKey != nil {
+		keys = append(keys, *u.ThumbnailKey)
+	}
+	for _, key := range keys {
+		if err := storage.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "Failed to delete uploaded file", "upload_id", u.ID, "key", key, "error", err)
+		}
+	}
+}
+
+// cleanFileName keeps the base name of a client file name, without control
+// characters, so it is safe to echo in headers
+func cleanFileName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	for utf8.RuneCountInString(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	if name == "" || name == "." || name == ".." {
+		return "upload"
+	}
+	return name
+}
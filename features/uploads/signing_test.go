@@ -0,0 +1,136 @@
+package uploads
+
+import (
+	"context"
+	"foodlink_backend/config"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const testSecret = "test-signing-secret"
+
+var testUserID = uuid.MustParse("7d9f6f38-3c1e-4d53-9a4e-3c0d9a9f8b11")
+
+func testConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.Auth.JWTSecret = testSecret
+	cfg.Uploads = config.UploadsConfig{MaxFileBytes: 1 << 20, MaxPixels: testMaxPixels, ThumbnailSize: testThumbnailSize, URLExpiry: time.Minute}
+	return cfg
+}
+
+// recordingStorage counts the files put, and fails every open
+type recordingStorage struct {
+	puts int
+}
+
+func (s *recordingStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	s.puts++
+	return nil
+}
+
+func (s *recordingStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return nil, ErrObjectNotFound
+}
+
+func (s *recordingStorage) Delete(ctx context.Context, key string) error { return nil }
+
+func TestVerifyContent(t *testing.T) {
+	id := uuid.New()
+	expires := time.Now().Add(time.Minute).Unix()
+	signature := SignContent(testSecret, id, VariantOriginal, expires)
+
+	if !VerifyContent(testSecret, id, VariantOriginal, expires, signature) {
+		t.Fatal("valid signature rejected")
+	}
+	if !VerifyContent(testSecret, id, VariantOriginal, expires, strings.ToUpper(signature)) {
+		t.Error("upper-case signature rejected")
+	}
+
+	flipped := []byte(signature)
+	if flipped[0] == '0' {
+		flipped[0] = '1'
+	} else {
+		flipped[0] = '0'
+	}
+	tests := []struct {
+		name      string
+		secret    string
+		id        uuid.UUID
+		variant   string
+		expires   int64
+		signature string
+	}{
+		{"tampered signature", testSecret, id, VariantOriginal, expires, string(flipped)},
+		{"other upload", testSecret, uuid.New(), VariantOriginal, expires, signature},
+		{"other variant", testSecret, id, VariantThumbnail, expires, signature},
+		{"extended expiry", testSecret, id, VariantOriginal, expires + 3600, signature},
+		{"other secret", "other-secret", id, VariantOriginal, expires, signature},
+		{"empty signature", testSecret, id, VariantOriginal, expires, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if VerifyContent(tt.secret, tt.id, tt.variant, tt.expires, tt.signature) {
+				t.Error("accepted")
+			}
+		})
+	}
+}
+
+func TestContentURL(t *testing.T) {
+	id := uuid.New()
+	expires := time.Now().Add(time.Minute).Unix()
+	link, err := url.Parse(contentURL(testSecret, id, VariantThumbnail, expires))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Path != "/api/v1/uploads/"+id.String()+"/content" {
+		t.Errorf("path = %q", link.Path)
+	}
+	query := link.Query()
+	gotExpires, _ := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if query.Get("variant") != VariantThumbnail || gotExpires != expires ||
+		!VerifyContent(testSecret, id, VariantThumbnail, gotExpires, query.Get("signature")) {
+		t.Errorf("link does not verify: %s", link)
+	}
+}
+
+// TestOpenRejectsBadLinks checks that links are refused before the upload
+// is looked up
+func TestOpenRejectsBadLinks(t *testing.T) {
+	s := NewService(testConfig(), &recordingStorage{})
+	id := uuid.New()
+	valid := time.Now().Add(time.Minute).Unix()
+	expired := time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name    string
+		req     *ContentRequest
+		wantErr error
+	}{
+		{"tampered expiry", &ContentRequest{Variant: VariantOriginal, Expires: valid + 60, Signature: SignContent(testSecret, id, VariantOriginal, valid)}, errInvalidLink},
+		{"tampered variant", &ContentRequest{Variant: VariantThumbnail, Expires: valid, Signature: SignContent(testSecret, id, VariantOriginal, valid)}, errInvalidLink},
+		{"other secret", &ContentRequest{Variant: VariantOriginal, Expires: valid, Signature: SignContent("other-secret", id, VariantOriginal, valid)}, errInvalidLink},
+		{"expired", &ContentRequest{Variant: VariantOriginal, Expires: expired, Signature: SignContent(testSecret, id, VariantOriginal, expired)}, errLinkExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := s.Open(id, tt.req)
+			if content != nil {
+				content.Close()
+			}
+			if err != tt.wantErr {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := s.Open(id, &ContentRequest{Variant: "original", Expires: valid, Signature: "not-hex"}); err == nil {
+		t.Error("malformed signature accepted")
+	}
+}
@@ -0,0 +1,51 @@
+package uploads
+
+import (
+	"context"
+	stderrors "errors"
+	"foodlink_backend/config"
+	"io"
+)
+
+// ErrObjectNotFound is returned by Storage.Open for a key that is not stored
+var ErrObjectNotFound = stderrors.New("object not found")
+
+// Storage keeps upload files by key. Keys are generated by the service and
+// only contain letters, digits, dashes, underscores and slashes.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes a key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage returns the storage of the configured driver
+func NewStorage(cfg *config.Config) (Storage, error) {
+	if cfg.Storage.Driver == "s3" {
+		s3, err := NewS3Storage(S3Config{
+			Bucket:          cfg.Storage.S3Bucket,
+			Region:          cfg.Storage.S3Region,
+			Endpoint:        cfg.Storage.S3Endpoint,
+			AccessKeyID:     cfg.Storage.S3AccessKeyID,
+			SecretAccessKey: cfg.Storage.S3SecretAccessKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return s3, nil
+	}
+	local, err := NewLocalStorage(cfg.Storage.LocalPath)
+	if err != nil {
+		return nil, err
+	}
+	return local, nil
+}
+
+// objectKey returns the storage key of an upload's variant
+func objectKey(upload *Upload, variant string) string {
+	key := "uploads/" + upload.UserID.String() + "/" + upload.ID.String()
+	if variant == VariantThumbnail {
+		key += "_thumb"
+	}
+	return key
+}
@@ -0,0 +1,125 @@
+package uploads
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+)
+
+// thumbnailSamples is the number of source pixels averaged per thumbnail
+// pixel along each axis
+const thumbnailSamples = 4
+
+// thumbnail decodes an image and encodes a copy fitting a size×size box,
+// turned upright, returning it with its MIME type
+func thumbnail(data []byte, contentType string, orientation, size int) ([]byte, string, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), size)
+	dst := orient(resize(src, width, height), orientation)
+
+	var buf bytes.Buffer
+	mimeType := thumbnailType(contentType)
+	if mimeType == TypeJPEG {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), mimeType, nil
+}
+
+// thumbnailType returns the MIME type of an image's thumbnails: JPEG for
+// JPEGs, PNG for PNGs and GIFs to keep transparency
+func thumbnailType(contentType string) string {
+	if contentType == TypeJPEG {
+		return TypeJPEG
+	}
+	return TypePNG
+}
+
+// fit scales width×height down to fit a size×size box, keeping the aspect
+// ratio. Smaller images keep their size.
+func fit(width, height, size int) (int, int) {
+	switch {
+	case width <= size && height <= size:
+		return width, height
+	case width >= height:
+		return size, max(1, height*size/width)
+	default:
+		return max(1, width*size/height), size
+	}
+}
+
+// resize scales src to width×height, each pixel averaging a grid of
+// samples of the source area it covers
+func resize(src image.Image, width, height int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * sh / height
+		y1 := max((y+1)*sh/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * sw / width
+			x1 := max((x+1)*sw/width, x0+1)
+			var r, g, bl, a uint32
+			for sy := 0; sy < thumbnailSamples; sy++ {
+				py := y0 + (y1-y0)*(2*sy+1)/(2*thumbnailSamples)
+				for sx := 0; sx < thumbnailSamples; sx++ {
+					px := x0 + (x1-x0)*(2*sx+1)/(2*thumbnailSamples)
+					cr, cg, cb, ca := src.At(b.Min.X+px, b.Min.Y+py).RGBA()
+					r, g, bl, a = r+cr, g+cg, bl+cb, a+ca
+				}
+			}
+			const n = thumbnailSamples * thumbnailSamples
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
+
+// orient applies an EXIF orientation, returning the image as displayed
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // flipped
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90° clockwise
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated 90° counter-clockwise
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
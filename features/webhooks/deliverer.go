@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/metrics"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// retryBaseDelay and retryMaxDelay bound the exponential retry backoff
+const (
+	retryBaseDelay = time.Minute
+	retryMaxDelay  = 6 * time.Hour
+)
+
+// RetryDelay returns how long to wait before the next attempt after attempt
+// failed: 1m, 2m, 4m, ... capped at six hours
+func RetryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// Deliverer sends queued deliveries. Instances may run concurrently:
+// deliveries are claimed with FOR UPDATE SKIP LOCKED.
+type Deliverer struct {
+	repo   store
+	sender *Sender
+	cfg    config.WebhooksConfig
+}
+
+// NewDeliverer creates a deliverer sending with sender
+func NewDeliverer(cfg *config.Config, sender *Sender) *Deliverer {
+	return &Deliverer{repo: NewRepository(), sender: sender, cfg: cfg.Webhooks}
+}
+
+// Start sends deliveries in the background until ctx is done
+func (d *Deliverer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.cfg.DeliveryInterval)
+		defer ticker.Stop()
+		for {
+			// Keep going without waiting while full batches come back
+			n, err := d.RunOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				slog.Warn("Failed to send webhooks", "error", err)
+			}
+			if n == d.cfg.BatchSize {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Check implements health.Check: it fails when more than the backlog
+// threshold of deliveries are overdue
+func (d *Deliverer) Check(ctx context.Context) error {
+	overdue, err := d.repo.withContext(ctx).CountOverdue(d.cfg.BacklogAge)
+	if err != nil {
+		return err
+	}
+	if overdue > d.cfg.BacklogThreshold {
+		return fmt.Errorf("%d deliveries overdue by more than %s", overdue, d.cfg.BacklogAge)
+	}
+	return nil
+}
+
+// RunOnce claims one batch of due deliveries and sends them concurrently,
+// returning how many were claimed
+func (d *Deliverer) RunOnce(ctx context.Context) (int, error) {
+	repo := d.repo.withContext(ctx)
+	// Every request of the batch finishes within the timeout, so the lease
+	// only expires when this instance dies
+	claimed, err := repo.ClaimDue(d.cfg.BatchSize, d.cfg.Timeout+time.Minute)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	for _, c := range claimed {
+		wg.Add(1)
+		go func(c *claimedDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, repo, c)
+		}(c)
+	}
+	wg.Wait()
+	return len(claimed), nil
+}
+
+// deliver sends one delivery and records the outcome
+func (d *Deliverer) deliver(ctx context.Context, repo store, c *claimedDelivery) {
+	logger := slog.With("delivery_id", c.ID, "subscription_id", c.SubscriptionID, "event_type", c.EventType, "attempt", c.Attempts)
+	result := d.sender.Send(ctx, c.URL, c.Secret, &c.Delivery)
+	if ctx.Err() != nil {
+		// Shutting down: the lease expires and the attempt is repeated
+		return
+	}
+
+	if result.OK() {
+		if _, err := repo.RecordAttempt(c.ID, StatusSucceeded, result, 0); err != nil {
+			logger.Error("Failed to record webhook delivery", "error", err)
+			return
+		}
+		if err := repo.ResetFailures(c.SubscriptionID); err != nil {
+			logger.Warn("Failed to reset webhook failure count", "error", err)
+		}
+		metrics.WebhookDeliveriesTotal.Inc(c.EventType, StatusSucceeded)
+		logger.Debug("Webhook delivered", "status", result.StatusCode)
+		return
+	}
+
+	status, outcome := StatusPending, "retry"
+	if c.Attempts >= d.cfg.MaxAttempts {
+		status, outcome = StatusFailed, StatusFailed
+	}
+	if _, err := repo.RecordAttempt(c.ID, status, result, RetryDelay(c.Attempts)); err != nil {
+		logger.Error("Failed to record webhook delivery failure", "error", err)
+		return
+	}
+	metrics.WebhookDeliveriesTotal.Inc(c.EventType, outcome)
+	if status == StatusFailed {
+		logger.Warn("Webhook delivery failed", "error", result.Error())
+	} else {
+		logger.Info("Webhook delivery failed, will retry", "error", result.Error(), "retry_in", RetryDelay(c.Attempts).String())
+	}
+
+	reason := fmt.Sprintf("Disabled after %d consecutive failed deliveries", d.cfg.DisableAfter)
+	disabled, err := repo.AddFailure(c.SubscriptionID, d.cfg.DisableAfter, reason)
+	if err != nil {
+		logger.Warn("Failed to count webhook failure", "error", err)
+		return
+	}
+	if disabled {
+		metrics.WebhookSubscriptionsDisabledTotal.Inc()
+		logger.Warn("Webhook subscription disabled after repeated failures", "failures", d.cfg.DisableAfter)
+	}
+}
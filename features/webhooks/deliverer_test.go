@@ -0,0 +1,341 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"foodlink_backend/config"
+	"foodlink_backend/tracing"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const testSecret = "whsec_test"
+
+// receivedRequest is a request received by a test endpoint
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a webhook endpoint answering with the queued statuses, then
+// with 200
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+func newReceiver(t *testing.T, statuses ...int) (*receiver, *httptest.Server) {
+	rec := &receiver{statuses: statuses}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, receivedRequest{header: r.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(rec.statuses) > 0 {
+			status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+		}
+		rec.mu.Unlock()
+		w.WriteHeader(status)
+		io.WriteString(w, http.StatusText(status))
+	}))
+	t.Cleanup(srv.Close)
+	return rec, srv
+}
+
+func (rec *receiver) received() []receivedRequest {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]receivedRequest(nil), rec.requests...)
+}
+
+func testWebhooksConfig() config.WebhooksConfig {
+	return config.WebhooksConfig{BatchSize: 10, Timeout: 5 * time.Second, MaxAttempts: 3, DisableAfter: 4}
+}
+
+func testSubscription(url string) *Subscription {
+	return &Subscription{ID: uuid.New(), UserID: uuid.New(), URL: url, Secret: testSecret, Active: true}
+}
+
+// claim returns the delivery of a new event to sub as ClaimDue would, on its
+// attempt-th attempt
+func claim(sub *Subscription, attempt int) *claimedDelivery {
+	return &claimedDelivery{
+		Delivery: Delivery{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			EventID:        uuid.New(),
+			EventType:      "OfferAccepted",
+			Payload:        json.RawMessage(`{"offer_id":"42"}`),
+			Attempts:       attempt,
+			OccurredAt:     time.Now(),
+		},
+		URL:    sub.URL,
+		Secret: sub.Secret,
+	}
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	rec, srv := newReceiver(t)
+	sub := testSubscription(srv.URL)
+	c := claim(sub, 1)
+
+	result := NewSender(5*time.Second, true).Send(context.Background(), sub.URL, sub.Secret, &c.Delivery)
+	if !result.OK() {
+		t.Fatalf("Send failed: %s", result.Error())
+	}
+	requests := rec.received()
+	if len(requests) != 1 {
+		t.Fatalf("%d requests received", len(requests))
+	}
+	req := requests[0]
+	if got := req.header.Get(EventHeader); got != "OfferAccepted" {
+		t.Errorf("%s = %q", EventHeader, got)
+	}
+	if got := req.header.Get(DeliveryHeader); got != c.ID.String() {
+		t.Errorf("%s = %q, want the delivery ID", DeliveryHeader, got)
+	}
+	signature := req.header.Get(SignatureHeader)
+	if err := VerifySignature(testSecret, signature, req.body, time.Minute); err != nil {
+		t.Errorf("signature %q does not verify: %v", signature, err)
+	}
+	timestamp, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+	if sent, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(sent, 0)).Abs() > time.Minute {
+		t.Errorf("signature %q does not carry the time it was sent", signature)
+	}
+
+	var body payload
+	if err := json.Unmarshal(req.body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ID != c.EventID.String() || body.Type != "OfferAccepted" || string(body.Data) != `{"offer_id":"42"}` {
+		t.Errorf("unexpected payload %s", req.body)
+	}
+}
+
+func TestSendPropagatesTraceContext(t *testing.T) {
+	shutdown, err := tracing.Init(context.Background(), &config.Config{Tracing: config.TracingConfig{Exporter: tracing.ExporterNone, SampleRatio: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(context.Background())
+
+	rec, srv := newReceiver(t)
+	sub := testSubscription(srv.URL)
+	ctx, span := tracing.Start(context.Background(), "deliver")
+	defer span.End()
+
+	result := NewSender(5*time.Second, true).Send(ctx, sub.URL, sub.Secret, &claim(sub, 1).Delivery)
+	if !result.OK() {
+		t.Fatalf("Send failed: %s", result.Error())
+	}
+	requests := rec.received()
+	if len(requests) != 1 {
+		t.Fatalf("%d requests received", len(requests))
+	}
+	traceparent := requests[0].header.Get("Traceparent")
+	if tracing.TraceID(ctx) == "" || !strings.Contains(traceparent, tracing.TraceID(ctx)) {
+		t.Errorf("traceparent = %q, want trace %s", traceparent, tracing.TraceID(ctx))
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		wantErr bool
+	}{
+		{"valid", testSecret, Sign(testSecret, now, body), body, false},
+		{"other secret", "whsec_other", Sign(testSecret, now, body), body, true},
+		{"tampered body", testSecret, Sign(testSecret, now, body), []byte(`{"id":"2"}`), true},
+		{"old timestamp", testSecret, Sign(testSecret, now.Add(-10*time.Minute), body), body, true},
+		{"future timestamp", testSecret, Sign(testSecret, now.Add(10*time.Minute), body), body, true},
+		{"replayed with new timestamp", testSecret, "t=" + strconv.FormatInt(now.Unix(), 10) + ",v1=" + computeSignature(testSecret, strconv.FormatInt(now.Unix()-600, 10), body), body, true},
+		{"malformed", testSecret, "v1=abc", body, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, tt.header, tt.body, 5*time.Minute)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSenderRefusesPrivateAddresses(t *testing.T) {
+	rec, srv := newReceiver(t)
+	c := claim(testSubscription(srv.URL), 1)
+	result := NewSender(5*time.Second, false).Send(context.Background(), srv.URL, testSecret, &c.Delivery)
+	if result.OK() || result.Err == nil || !strings.Contains(result.Err.Error(), errPrivateAddress.Error()) {
+		t.Errorf("loopback endpoint reached: %+v", result)
+	}
+	if n := len(rec.received()); n != 0 {
+		t.Errorf("%d requests received", n)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{9, 256 * time.Minute},
+		{10, 6 * time.Hour},
+		{50, 6 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := RetryDelay(tt.attempt); got != tt.want {
+			t.Errorf("RetryDelay(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestDeliverRetriesServerErrorsWithBackoff(t *testing.T) {
+	rec, srv := newReceiver(t, http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusBadGateway)
+	sub := testSubscription(srv.URL)
+	store := newFakeStore(sub)
+	d := &Deliverer{repo: store, sender: NewSender(5*time.Second, true), cfg: testWebhooksConfig()}
+
+	// The same delivery, claimed again after each failure
+	c := claim(sub, 1)
+	for attempt := 1; attempt <= 3; attempt++ {
+		c.Attempts = attempt
+		d.deliver(context.Background(), store, c)
+	}
+
+	attempts := store.attemptsOf(c.ID)
+	want := []recordedAttempt{
+		{status: StatusPending, retryIn: time.Minute},
+		{status: StatusPending, retryIn: 2 * time.Minute},
+		{status: StatusFailed},
+	}
+	if len(attempts) != len(want) {
+		t.Fatalf("%d attempts recorded, want %d", len(attempts), len(want))
+	}
+	for i, got := range attempts {
+		if got.status != want[i].status {
+			t.Errorf("attempt %d: status %q, want %q", i+1, got.status, want[i].status)
+		}
+		if want[i].status == StatusPending && got.retryIn != want[i].retryIn {
+			t.Errorf("attempt %d: retry in %s, want %s", i+1, got.retryIn, want[i].retryIn)
+		}
+		if got.result.StatusCode < 500 || got.result.OK() {
+			t.Errorf("attempt %d: result %+v, want a 5xx failure", i+1, got.result)
+		}
+	}
+	if n := len(rec.received()); n != 3 {
+		t.Errorf("%d requests received, want 3", n)
+	}
+	if failures := store.subscription(sub.ID).ConsecutiveFailures; failures != 3 {
+		t.Errorf("%d consecutive failures, want 3", failures)
+	}
+}
+
+func TestDeliverSuccessResetsFailures(t *testing.T) {
+	_, srv := newReceiver(t, http.StatusInternalServerError)
+	sub := testSubscription(srv.URL)
+	store := newFakeStore(sub)
+	d := &Deliverer{repo: store, sender: NewSender(5*time.Second, true), cfg: testWebhooksConfig()}
+
+	c := claim(sub, 1)
+	d.deliver(context.Background(), store, c)
+	c.Attempts = 2
+	d.deliver(context.Background(), store, c)
+
+	attempts := store.attemptsOf(c.ID)
+	if len(attempts) != 2 || attempts[1].status != StatusSucceeded || attempts[1].result.StatusCode != http.StatusOK {
+		t.Fatalf("attempts = %+v, want a retry that succeeds", attempts)
+	}
+	if failures := store.subscription(sub.ID).ConsecutiveFailures; failures != 0 {
+		t.Errorf("%d consecutive failures after a success", failures)
+	}
+}
+
+func TestDeliverDisablesSubscriptionAfterConsecutiveFailures(t *testing.T) {
+	_, srv := newReceiver(t, 500, 500, 500, 500, 500)
+	sub := testSubscription(srv.URL)
+	store := newFakeStore(sub)
+	cfg := testWebhooksConfig()
+	d := &Deliverer{repo: store, sender: NewSender(5*time.Second, true), cfg: cfg}
+
+	// Failures of different deliveries add up
+	for i := 1; i < cfg.DisableAfter; i++ {
+		d.deliver(context.Background(), store, claim(sub, 1))
+		if !store.subscription(sub.ID).Active {
+			t.Fatalf("disabled after %d failures, want %d", i, cfg.DisableAfter)
+		}
+	}
+	d.deliver(context.Background(), store, claim(sub, 1))
+
+	got := store.subscription(sub.ID)
+	if got.Active || got.DisabledAt == nil {
+		t.Fatalf("subscription still active after %d failures", cfg.DisableAfter)
+	}
+	if got.DisabledReason == nil || !strings.Contains(*got.DisabledReason, "4 consecutive failed deliveries") {
+		t.Errorf("disabled reason = %v", got.DisabledReason)
+	}
+}
+
+func TestDeliverConnectionErrorIsRetried(t *testing.T) {
+	_, srv := newReceiver(t)
+	sub := testSubscription(srv.URL)
+	srv.Close()
+	store := newFakeStore(sub)
+	d := &Deliverer{repo: store, sender: NewSender(time.Second, true), cfg: testWebhooksConfig()}
+
+	c := claim(sub, 1)
+	d.deliver(context.Background(), store, c)
+	attempts := store.attemptsOf(c.ID)
+	if len(attempts) != 1 || attempts[0].status != StatusPending || attempts[0].result.Err == nil || attempts[0].retryIn != time.Minute {
+		t.Errorf("attempts = %+v, want a retry after a connection error", attempts)
+	}
+}
+
+func TestCheckCountsOverdueDeliveries(t *testing.T) {
+	sub := testSubscription("https://example.com/hook")
+	store := newFakeStore(sub)
+	cfg := testWebhooksConfig()
+	cfg.BacklogThreshold, cfg.BacklogAge = 1, time.Minute
+	d := &Deliverer{repo: store, cfg: cfg}
+
+	// Overdue, just due, and overdue but finished
+	for _, delivery := range []Delivery{
+		{Status: StatusPending, NextAttemptAt: time.Now().Add(-time.Hour)},
+		{Status: StatusPending, NextAttemptAt: time.Now()},
+		{Status: StatusSucceeded, NextAttemptAt: time.Now().Add(-time.Hour)},
+	} {
+		delivery.ID, delivery.SubscriptionID = uuid.New(), sub.ID
+		store.deliveries[delivery.ID] = &delivery
+	}
+	if err := d.Check(context.Background()); err != nil {
+		t.Errorf("Check failed at the threshold: %v", err)
+	}
+
+	overdue := &Delivery{ID: uuid.New(), SubscriptionID: sub.ID, Status: StatusPending, NextAttemptAt: time.Now().Add(-2 * time.Minute)}
+	store.deliveries[overdue.ID] = overdue
+	if err := d.Check(context.Background()); err == nil {
+		t.Error("Check passed over the threshold")
+	}
+
+	// Deliveries of disabled subscriptions wait for it to be re-enabled
+	sub.Active = false
+	if err := d.Check(context.Background()); err != nil {
+		t.Errorf("Check counted a disabled subscription: %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"foodlink_backend/errors"
+	"foodlink_backend/features/auth"
+	"foodlink_backend/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service *Service
+}
+
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
+	user, ok := r.Context().Value("user").(*auth.User)
+	if !ok || user == nil {
+		return uuid.Nil, errors.ErrUnauthorized
+	}
+	return user.ID, nil
+}
+
+// pathParts splits a /webhooks/... path relative to /api/v1
+func pathParts(r *http.Request) []string {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/webhooks"), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// subscriptionID parses the subscription ID at the start of the path
+func subscriptionID(r *http.Request) (uuid.UUID, error) {
+	parts := pathParts(r)
+	if len(parts) == 0 {
+		return uuid.Nil, errors.ErrInvalidPath
+	}
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, errors.ErrInvalidID
+	}
+	return id, nil
+}
+
+// EventTypes handles GET /api/v1/webhooks/event-types
+// @Summary      List webhook event types
+// @Description  List the events organizations can subscribe to, and which account receives each
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   EventTypeInfo
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Router       /webhooks/event-types [get]
+func (h *Handler) EventTypes(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	utils.OKResponse(w, "Webhook event types retrieved successfully", h.service.EventTypes())
+	return nil
+}
+
+// GetAll handles GET /api/v1/webhooks
+// @Summary      List webhook subscriptions
+// @Description  List the organization's webhook subscriptions. Secrets are not included.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   Subscription
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Router       /webhooks [get]
+func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	subs, err := h.service.WithContext(r.Context()).GetAll(userID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve webhooks")
+	}
+	utils.OKResponse(w, "Webhooks retrieved successfully", subs)
+	return nil
+}
+
+// Create handles POST /api/v1/webhooks
+// @Summary      Create webhook subscription
+// @Description  Subscribe an endpoint to the organization's events. An empty event_types list receives every type. The response contains the signing secret, which is not shown again.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        subscription  body      CreateSubscriptionRequest  true  "Subscription"
+// @Success      201           {object}  Subscription
+// @Failure      400           {object}  errors.Problem
+// @Failure      401           {object}  errors.Problem
+// @Failure      403           {object}  errors.Problem
+// @Router       /webhooks [post]
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	var req CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	sub, err := h.service.WithContext(r.Context()).Create(userID, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create webhook")
+	}
+	utils.CreatedResponse(w, "Webhook created successfully", sub)
+	return nil
+}
+
+// GetByID handles GET /api/v1/webhooks/:id
+// @Summary      Get webhook subscription
+// @Description  Get one of the organization's webhook subscriptions
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  Subscription
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /webhooks/{id} [get]
+func (h *Handler) GetByID(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := subscriptionID(r)
+	if err != nil {
+		return err
+	}
+	sub, err := h.service.WithContext(r.Context()).GetByID(userID, id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve webhook")
+	}
+	utils.OKResponse(w, "Webhook retrieved successfully", sub)
+	return nil
+}
+
+// Update handles PUT /api/v1/webhooks/:id
+// @Summary      Update webhook subscription
+// @Description  Change the URL, description, event types or active state. Setting active to true re-enables a disabled subscription; its pending deliveries are then sent.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path      string                     true  "Subscription ID"
+// @Param        subscription  body      UpdateSubscriptionRequest  true  "Fields to change"
+// @Success      200           {object}  Subscription
+// @Failure      400           {object}  errors.Problem
+// @Failure      401           {object}  errors.Problem
+// @Failure      403           {object}  errors.Problem
+// @Failure      404           {object}  errors.Problem
+// @Router       /webhooks/{id} [put]
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPut {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := subscriptionID(r)
+	if err != nil {
+		return err
+	}
+	var req UpdateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
+	}
+	sub, err := h.service.WithContext(r.Context()).Update(userID, id, &req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update webhook")
+	}
+	utils.OKResponse(w, "Webhook updated successfully", sub)
+	return nil
+}
+
+// Delete handles DELETE /api/v1/webhooks/:id
+// @Summary      Delete webhook subscription
+// @Description  Delete a subscription together with its delivery log
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /webhooks/{id} [delete]
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodDelete {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := subscriptionID(r)
+	if err != nil {
+		return err
+	}
+	if err := h.service.WithContext(r.Context()).Delete(userID, id); err != nil {
+		return errors.Wrap(err, "Failed to delete webhook")
+	}
+	utils.OKResponse(w, "Webhook deleted successfully", map[string]string{"message": "Deleted"})
+	return nil
+}
+
+// RotateSecret handles POST /api/v1/webhooks/:id/rotate-secret
+// @Summary      Rotate webhook secret
+// @Description  Replace the signing secret. The response contains the new secret; the old one stops working immediately.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  Subscription
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /webhooks/{id}/rotate-secret [post]
+func (h *Handler) RotateSecret(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := subscriptionID(r)
+	if err != nil {
+		return err
+	}
+	sub, err := h.service.WithContext(r.Context()).RotateSecret(userID, id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to rotate webhook secret")
+	}
+	utils.OKResponse(w, "Webhook secret rotated successfully", sub)
+	return nil
+}
+
+// SendTest handles POST /api/v1/webhooks/:id/test
+// @Summary      Send test event
+// @Description  Send a signed WebhookTest event to the endpoint now, also when the subscription is disabled, and return the logged delivery with the endpoint's response. Test deliveries are not retried.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  Delivery
+// @Failure      400  {object}  errors.Problem
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      404  {object}  errors.Problem
+// @Router       /webhooks/{id}/test [post]
+func (h *Handler) SendTest(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := subscriptionID(r)
+	if err != nil {
+		return err
+	}
+	delivery, err := h.service.WithContext(r.Context()).SendTest(userID, id)
+	if err != nil {
+		return errors.Wrap(err, "Failed to send test event")
+	}
+	utils.OKResponse(w, "Test event sent", delivery)
+	return nil
+}
+
+// GetDeliveries handles GET /api/v1/webhooks/:id/deliveries
+// @Summary      List webhook deliveries
+// @Description  The subscription's delivery log, most recent first, with the outcome of each delivery's latest attempt
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true   "Subscription ID"
+// @Param        status  query     string  false  "Delivery status"  Enums(pending, delivering, succeeded, failed)
+// @Param        limit   query     int     false  "Number of deliveries (default: 50, max: 200)"
+// @Success      200     {array}   Delivery
+// @Failure      400     {object}  errors.Problem
+// @Failure      401     {object}  errors.Problem
+// @Failure      403     {object}  errors.Problem
+// @Failure      404     {object}  errors.Problem
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *Handler) GetDeliveries(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.ErrMethodNotAllowed
+	}
+	userID, err := h.getUserID(r)
+	if err != nil {
+		return errors.ErrAuthRequired
+	}
+	id, err := subscriptionID(r)
+	if err != nil {
+		return err
+	}
+	filter := DeliveryFilter{Status: r.URL.Query().Get("status")}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil {
+			filter.Limit = l
+		}
+	}
+	deliveries, err := h.service.WithContext(r.Context()).GetDeliveries(userID, id, filter)
+	if err != nil {
+		return errors.Wrap(err, "Failed to retrieve webhook deliveries")
+	}
+	utils.OKResponse(w, "Webhook deliveries retrieved successfully", deliveries)
+	return nil
+}
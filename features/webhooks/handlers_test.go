@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"foodlink_backend/features/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testRoutes serves the webhook routes to the user, as the auth and
+// organization middleware would once they admitted them
+func testRoutes(store *fakeStore, userID uuid.UUID) http.Handler {
+	service := &Service{repo: store, sender: NewSender(5*time.Second, true)}
+	signedIn := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := &auth.User{ID: userID, Role: "restaurant"}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "user", user)))
+		})
+	}
+	pass := func(next http.Handler) http.Handler { return next }
+	return SetupRoutes(service, NewHandler(service), signedIn, pass)
+}
+
+func TestSendTestEndpoint(t *testing.T) {
+	rec, srv := newReceiver(t, http.StatusAccepted)
+	sub := testSubscription(srv.URL)
+	// Test events are sent to disabled subscriptions too
+	sub.Active = false
+	store := newFakeStore(sub)
+
+	w := httptest.NewRecorder()
+	testRoutes(store, sub.UserID).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/"+sub.ID.String()+"/test", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var response struct {
+		Data Delivery `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	delivery := response.Data
+	if delivery.Status != StatusSucceeded || delivery.ResponseStatus == nil || *delivery.ResponseStatus != http.StatusAccepted {
+		t.Errorf("delivery = %+v, want succeeded with the endpoint's 202", delivery)
+	}
+	if delivery.EventType != TypeWebhookTest || delivery.SubscriptionID != sub.ID {
+		t.Errorf("delivery = %+v, want a %s of the subscription", delivery, TypeWebhookTest)
+	}
+
+	requests := rec.received()
+	if len(requests) != 1 {
+		t.Fatalf("%d requests received", len(requests))
+	}
+	if err := VerifySignature(testSecret, requests[0].header.Get(SignatureHeader), requests[0].body, time.Minute); err != nil {
+		t.Errorf("test event signature does not verify: %v", err)
+	}
+	if got := requests[0].header.Get(EventHeader); got != TypeWebhookTest {
+		t.Errorf("%s = %q", EventHeader, got)
+	}
+}
+
+func TestSendTestEndpointFailureIsNotRetried(t *testing.T) {
+	_, srv := newReceiver(t, http.StatusInternalServerError)
+	sub := testSubscription(srv.URL)
+	store := newFakeStore(sub)
+
+	w := httptest.NewRecorder()
+	testRoutes(store, sub.UserID).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/"+sub.ID.String()+"/test", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var response struct {
+		Data Delivery `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response.Data.Status != StatusFailed || response.Data.LastError == nil {
+		t.Errorf("delivery = %+v, want failed with the error", response.Data)
+	}
+	if got := store.subscription(sub.ID); !got.Active || got.ConsecutiveFailures != 0 {
+		t.Errorf("test failure counted against the subscription: %+v", got)
+	}
+}
+
+func TestSendTestEndpointOtherOrganization(t *testing.T) {
+	rec, srv := newReceiver(t)
+	sub := testSubscription(srv.URL)
+	store := newFakeStore(sub)
+
+	w := httptest.NewRecorder()
+	testRoutes(store, uuid.New()).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/"+sub.ID.String()+"/test", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status %d, want 404", w.Code)
+	}
+	if n := len(rec.received()); n != 0 {
+		t.Errorf("%d requests sent for another organization's subscription", n)
+	}
+}
@@ -0,0 +1,29 @@
+package webhooks
+
+import (
+	"context"
+	"foodlink_backend/config"
+	"foodlink_backend/jobs"
+	"log/slog"
+	"time"
+)
+
+// RegisterJobs registers the webhook background jobs
+func RegisterJobs(scheduler *jobs.Scheduler, cfg *config.Config) {
+	retention := cfg.Webhooks.Retention
+	scheduler.Schedule("webhooks.delete_old_deliveries", "@daily", func(ctx context.Context, job *jobs.Job) error {
+		return deleteOldDeliveries(ctx, retention)
+	})
+}
+
+// deleteOldDeliveries trims the delivery log to the retention period
+func deleteOldDeliveries(ctx context.Context, retention time.Duration) error {
+	count, err := NewRepository().WithContext(ctx).DeleteFinishedBefore(time.Now().Add(-retention))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		slog.Info("Deleted old webhook deliveries", "count", count)
+	}
+	return nil
+}
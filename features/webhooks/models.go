@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses in webhook_deliveries
+const (
+	StatusPending    = "pending"
+	StatusDelivering = "delivering"
+	StatusSucceeded  = "succeeded"
+	StatusFailed     = "failed"
+)
+
+// TypeWebhookTest is the event type of deliveries sent by the test endpoint
+const TypeWebhookTest = "WebhookTest"
+
+// Subscription sends an organization's events to one HTTPS endpoint
+type Subscription struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description,omitempty"`
+	// EventTypes filters the events sent; empty means every supported type
+	EventTypes []string `json:"event_types"`
+	// Secret signs the payloads. It is only returned when the subscription
+	// is created and when the secret is rotated.
+	Secret              string     `json:"secret,omitempty"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      *string    `json:"disabled_reason,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// Delivery is one event sent, or to be sent, to a subscription. Attempts
+// and the response fields describe the latest attempt.
+type Delivery struct {
+	ID             uuid.UUID       `json:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id"`
+	EventID        uuid.UUID       `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	ResponseBody   *string         `json:"response_body,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	DurationMs     *int            `json:"duration_ms,omitempty"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// CreateSubscriptionRequest creates a subscription
+type CreateSubscriptionRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048"`
+	Description string   `json:"description" validate:"max=255"`
+	EventTypes  []string `json:"event_types"`
+}
+
+// UpdateSubscriptionRequest changes the fields that are set. Setting active
+// to true re-enables a disabled subscription and clears its failure count.
+type UpdateSubscriptionRequest struct {
+	URL         *string  `json:"url" validate:"omitempty,url,max=2048"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types"`
+	Active      *bool    `json:"active"`
+}
+
+// DeliveryFilter narrows the deliveries returned for a subscription
+type DeliveryFilter struct {
+	Status string
+	Limit  int
+}
+
+// EventTypeInfo describes an event type subscriptions can receive
+type EventTypeInfo struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,418 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db  *sql.DB
+	tx  *sql.Tx
+	ctx context.Context
+}
+
+func NewRepository() *Repository {
+	return &Repository{db: database.GetDB()}
+}
+
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, tx: r.tx, ctx: ctx}
+}
+
+func (r *Repository) WithTx(tx *sql.Tx) *Repository {
+	return &Repository{db: r.db, tx: tx, ctx: r.ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, r.tx)
+}
+
+// store is the persistence of the service and the deliverer: a
+// *Repository, or a fake in tests
+type store interface {
+	withContext(ctx context.Context) store
+	GetAllByUserID(userID uuid.UUID) ([]*Subscription, error)
+	GetByID(userID, id uuid.UUID) (*Subscription, error)
+	GetSecret(userID, id uuid.UUID) (string, error)
+	Create(sub *Subscription) error
+	Update(sub *Subscription) error
+	UpdateSecret(userID, id uuid.UUID, secret string) (*Subscription, error)
+	Delete(userID, id uuid.UUID) error
+	GetDeliveries(subscriptionID uuid.UUID, filter DeliveryFilter) ([]*Delivery, error)
+	CreateDelivery(d *Delivery) error
+	ClaimDue(limit int, lease time.Duration) ([]*claimedDelivery, error)
+	CountOverdue(age time.Duration) (int, error)
+	RecordAttempt(id uuid.UUID, status string, result *Result, retryIn time.Duration) (*Delivery, error)
+	ResetFailures(subscriptionID uuid.UUID) error
+	AddFailure(subscriptionID uuid.UUID, disableAfter int, reason string) (bool, error)
+}
+
+func (r *Repository) withContext(ctx context.Context) store {
+	return r.WithContext(ctx)
+}
+
+const subscriptionColumns = `id, user_id, url, COALESCE(description, ''), event_types, active, consecutive_failures, disabled_at, disabled_reason, created_at, updated_at`
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, response_body, last_error, duration_ms, occurred_at, created_at, delivered_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row rowScanner) (*Subscription, error) {
+	sub := &Subscription{}
+	err := row.Scan(&sub.ID, &sub.UserID, &sub.URL, &sub.Description, pq.Array(&sub.EventTypes), &sub.Active, &sub.ConsecutiveFailures, &sub.DisabledAt, &sub.DisabledReason, &sub.CreatedAt, &sub.UpdatedAt)
+	if sub.EventTypes == nil {
+		sub.EventTypes = []string{}
+	}
+	return sub, err
+}
+
+func scanDelivery(row rowScanner) (*Delivery, error) {
+	d := &Delivery{}
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.ResponseStatus, &d.ResponseBody, &d.LastError, &d.DurationMs, &d.OccurredAt, &d.CreatedAt, &d.DeliveredAt)
+	return d, err
+}
+
+func (r *Repository) GetAllByUserID(userID uuid.UUID) ([]*Subscription, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	rows, err := r.conn().Query(`SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	subs := []*Subscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+
+// GetByID returns the user's subscription, or ErrNotFound when it belongs
+// to someone else
+func (r *Repository) GetByID(userID, id uuid.UUID) (*Subscription, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	sub, err := scanSubscription(r.conn().QueryRow(`SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = $1 AND user_id = $2`, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return sub, nil
+}
+
+// GetSecret returns the signing secret of the user's subscription
+func (r *Repository) GetSecret(userID, id uuid.UUID) (string, error) {
+	if r.db == nil {
+		return "", errors.ErrDatabase
+	}
+	var secret string
+	err := r.conn().QueryRow(`SELECT secret FROM webhook_subscriptions WHERE id = $1 AND user_id = $2`, id, userID).Scan(&secret)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", errors.ErrNotFound
+		}
+		return "", errors.WrapError(err, errors.ErrDatabase)
+	}
+	return secret, nil
+}
+
+func (r *Repository) Create(sub *Subscription) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO webhook_subscriptions (id, user_id, url, description, event_types, secret)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + subscriptionColumns
+	secret := sub.Secret
+	created, err := scanSubscription(r.conn().QueryRow(query, sub.ID, sub.UserID, sub.URL, sub.Description, pq.Array(sub.EventTypes), sub.Secret))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*sub = *created
+	sub.Secret = secret
+	return nil
+}
+
+// Update saves the editable fields and the enabled state of sub
+func (r *Repository) Update(sub *Subscription) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE webhook_subscriptions
+		SET url = $3, description = $4, event_types = $5, active = $6, consecutive_failures = $7,
+			disabled_at = $8, disabled_reason = $9, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + subscriptionColumns
+	updated, err := scanSubscription(r.conn().QueryRow(query, sub.ID, sub.UserID, sub.URL, sub.Description, pq.Array(sub.EventTypes), sub.Active, sub.ConsecutiveFailures, sub.DisabledAt, sub.DisabledReason))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.ErrNotFound
+		}
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*sub = *updated
+	return nil
+}
+
+// UpdateSecret replaces the signing secret of the user's subscription
+func (r *Repository) UpdateSecret(userID, id uuid.UUID, secret string) (*Subscription, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `UPDATE webhook_subscriptions SET secret = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + subscriptionColumns
+	sub, err := scanSubscription(r.conn().QueryRow(query, id, userID, secret))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	sub.Secret = secret
+	return sub, nil
+}
+
+func (r *Repository) Delete(userID, id uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM webhook_subscriptions WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// GetDeliveries returns the subscription's deliveries matching filter, most
+// recent first
+func (r *Repository) GetDeliveries(subscriptionID uuid.UUID, filter DeliveryFilter) ([]*Delivery, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC LIMIT $3`
+	rows, err := r.conn().Query(query, subscriptionID, filter.Status, filter.Limit)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, nil
+}
+
+// EnqueueEvent adds a pending delivery of an event to every active
+// subscription of userID that accepts eventType
+func (r *Repository) EnqueueEvent(userID, eventID uuid.UUID, eventType string, payload json.RawMessage, occurredAt time.Time) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, occurred_at)
+		SELECT id, $2, $3, $4, $5 FROM webhook_subscriptions
+		WHERE user_id = $1 AND active AND (cardinality(event_types) = 0 OR $3 = ANY(event_types))
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+	if _, err := r.conn().Exec(query, userID, eventID, eventType, payload, occurredAt); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// GetOfferOwner returns the NGO that owns an offer, or uuid.Nil when the
+// offer no longer exists
+func (r *Repository) GetOfferOwner(offerID uuid.UUID) (uuid.UUID, error) {
+	if r.db == nil {
+		return uuid.Nil, errors.ErrDatabase
+	}
+	var ngoUserID uuid.UUID
+	err := r.conn().QueryRow(`SELECT ngo_user_id FROM ngo_donation_offers WHERE id = $1`, offerID).Scan(&ngoUserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return ngoUserID, nil
+}
+
+// CreateDelivery stores a delivery that is being sent right away
+func (r *Repository) CreateDelivery(d *Delivery) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, attempts, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, 'delivering', 1, $6)
+		RETURNING ` + deliveryColumns
+	created, err := scanDelivery(r.conn().QueryRow(query, d.ID, d.SubscriptionID, d.EventID, d.EventType, d.Payload, d.OccurredAt))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	*d = *created
+	return nil
+}
+
+// claimedDelivery is a delivery locked for sending, with its endpoint
+type claimedDelivery struct {
+	Delivery
+	URL    string
+	Secret string
+}
+
+// ClaimDue locks up to limit due deliveries of active subscriptions,
+// including ones whose lease expired, and counts the attempt
+func (r *Repository) ClaimDue(limit int, lease time.Duration) ([]*claimedDelivery, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	query := `UPDATE webhook_deliveries d
+		SET status = 'delivering', attempts = d.attempts + 1,
+			locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND d.id IN (
+			SELECT wd.id FROM webhook_deliveries wd
+			JOIN webhook_subscriptions ws ON ws.id = wd.subscription_id
+			WHERE ws.active AND (
+				(wd.status = 'pending' AND wd.next_attempt_at <= CURRENT_TIMESTAMP)
+				OR (wd.status = 'delivering' AND wd.locked_until < CURRENT_TIMESTAMP))
+			ORDER BY wd.created_at
+			LIMIT $1
+			FOR UPDATE OF wd SKIP LOCKED
+		)
+		RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts, d.occurred_at, s.url, s.secret`
+	rows, err := r.conn().Query(query, limit, lease.Seconds())
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	defer rows.Close()
+	var claimed []*claimedDelivery
+	for rows.Next() {
+		c := &claimedDelivery{}
+		if err := rows.Scan(&c.ID, &c.SubscriptionID, &c.EventID, &c.EventType, &c.Payload, &c.Attempts, &c.OccurredAt, &c.URL, &c.Secret); err != nil {
+			return nil, errors.WrapError(err, errors.ErrDatabase)
+		}
+		claimed = append(claimed, c)
+	}
+	return claimed, rows.Err()
+}
+
+// CountOverdue counts pending deliveries of active subscriptions that have
+// been due for longer than age
+func (r *Repository) CountOverdue(age time.Duration) (int, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	var count int
+	err := r.conn().QueryRow(`SELECT COUNT(*) FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE s.active AND d.status = 'pending' AND d.next_attempt_at < CURRENT_TIMESTAMP - make_interval(secs => $1)`, age.Seconds()).Scan(&count)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return count, nil
+}
+
+// RecordAttempt stores the outcome of an attempt. A pending status
+// schedules the next attempt after retryIn.
+func (r *Repository) RecordAttempt(id uuid.UUID, status string, result *Result, retryIn time.Duration) (*Delivery, error) {
+	if r.db == nil {
+		return nil, errors.ErrDatabase
+	}
+	var responseStatus *int
+	if result.StatusCode != 0 {
+		responseStatus = &result.StatusCode
+	}
+	var responseBody, lastError *string
+	if result.StatusCode != 0 {
+		responseBody = &result.Body
+	}
+	if !result.OK() {
+		msg := result.Error()
+		lastError = &msg
+	}
+	query := `UPDATE webhook_deliveries
+		SET status = $2, response_status = $3, response_body = $4, last_error = $5, duration_ms = $6,
+			locked_until = NULL,
+			next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $7),
+			delivered_at = CASE WHEN $2 = 'succeeded' THEN CURRENT_TIMESTAMP ELSE delivered_at END
+		WHERE id = $1
+		RETURNING ` + deliveryColumns
+	d, err := scanDelivery(r.conn().QueryRow(query, id, status, responseStatus, responseBody, lastError, result.Duration.Milliseconds(), retryIn.Seconds()))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return d, nil
+}
+
+// ResetFailures clears the consecutive failure count after a success
+func (r *Repository) ResetFailures(subscriptionID uuid.UUID) error {
+	if r.db == nil {
+		return errors.ErrDatabase
+	}
+	query := `UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures > 0`
+	if _, err := r.conn().Exec(query, subscriptionID); err != nil {
+		return errors.WrapError(err, errors.ErrDatabase)
+	}
+	return nil
+}
+
+// AddFailure counts a failed attempt and disables the subscription once
+// disableAfter consecutive attempts failed. It reports whether this call
+// disabled it.
+func (r *Repository) AddFailure(subscriptionID uuid.UUID, disableAfter int, reason string) (bool, error) {
+	if r.db == nil {
+		return false, errors.ErrDatabase
+	}
+	query := `UPDATE webhook_subscriptions
+		SET consecutive_failures = consecutive_failures + 1,
+			active = active AND consecutive_failures + 1 < $2,
+			disabled_at = CASE WHEN active AND consecutive_failures + 1 >= $2 THEN CURRENT_TIMESTAMP ELSE disabled_at END,
+			disabled_reason = CASE WHEN active AND consecutive_failures + 1 >= $2 THEN $3 ELSE disabled_reason END
+		WHERE id = $1
+		RETURNING active, consecutive_failures`
+	var active bool
+	var failures int
+	if err := r.conn().QueryRow(query, subscriptionID, disableAfter, reason).Scan(&active, &failures); err != nil {
+		return false, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return !active && failures == disableAfter, nil
+}
+
+// DeleteFinishedBefore deletes succeeded and failed deliveries created
+// before cutoff
+func (r *Repository) DeleteFinishedBefore(cutoff time.Time) (int64, error) {
+	if r.db == nil {
+		return 0, errors.ErrDatabase
+	}
+	result, err := r.conn().Exec(`DELETE FROM webhook_deliveries WHERE status IN ('succeeded', 'failed') AND created_at < $1`, cutoff)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrDatabase)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/middleware"
+	"net/http"
+)
+
+// SetupRoutes sets up the webhook routes, mounted under /api/v1.
+// requireOrganization runs after authentication and admits the accounts
+// that receive events.
+func SetupRoutes(service *Service, handler *Handler, authMiddleware, requireOrganization func(http.Handler) http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	routes := middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		parts := pathParts(r)
+		switch {
+		case len(parts) == 0 && r.Method == http.MethodGet:
+			return handler.GetAll(w, r)
+		case len(parts) == 0 && r.Method == http.MethodPost:
+			return handler.Create(w, r)
+		case len(parts) == 1 && parts[0] == "event-types" && r.Method == http.MethodGet:
+			return handler.EventTypes(w, r)
+		case len(parts) == 1 && r.Method == http.MethodGet:
+			return handler.GetByID(w, r)
+		case len(parts) == 1 && r.Method == http.MethodPut:
+			return handler.Update(w, r)
+		case len(parts) == 1 && r.Method == http.MethodDelete:
+			return handler.Delete(w, r)
+		case len(parts) == 2 && parts[1] == "deliveries" && r.Method == http.MethodGet:
+			return handler.GetDeliveries(w, r)
+		case len(parts) == 2 && parts[1] == "test" && r.Method == http.MethodPost:
+			return handler.SendTest(w, r)
+		case len(parts) == 2 && parts[1] == "rotate-secret" && r.Method == http.MethodPost:
+			return handler.RotateSecret(w, r)
+		default:
+			return errors.ErrMethodNotAllowed
+		}
+	})
+	mux.Handle("/webhooks", routes)
+	mux.Handle("/webhooks/", routes)
+	return middleware.Chain(authMiddleware, requireOrganization)(mux)
+}
@@ -0,0 +1,130 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"foodlink_backend/tracing"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxResponseBody is how much of a response body is kept in the delivery log
+const maxResponseBody = 2048
+
+// errPrivateAddress rejects connections to non-public addresses
+var errPrivateAddress = fmt.Errorf("webhook endpoint resolves to a private or loopback address")
+
+// Result is the outcome of one delivery attempt
+type Result struct {
+	// StatusCode is 0 when no response was received
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+	Err        error
+}
+
+// OK reports whether the endpoint accepted the delivery with a 2xx response
+func (r *Result) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Error describes a failed attempt for the delivery log
+func (r *Result) Error() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	return fmt.Sprintf("endpoint responded with status %d", r.StatusCode)
+}
+
+// payload is the JSON body of a delivery
+type payload struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Sender posts signed deliveries to subscription endpoints
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a sender whose requests time out after timeout. Unless
+// allowPrivate is set, it refuses to connect to loopback, private and
+// link-local addresses, checked after DNS resolution.
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConnsPerHost: 2,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	return &Sender{client: &http.Client{
+		// Client spans and traceparent headers, over the guarded dialer
+		Transport: tracing.NewTransport(transport),
+		Timeout:   timeout,
+		// Redirects are not followed: a 3xx response counts as a failure
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// Send posts delivery to url, signed with secret
+func (s *Sender) Send(ctx context.Context, url, secret string, delivery *Delivery) *Result {
+	body, err := json.Marshal(payload{
+		ID:         delivery.EventID.String(),
+		Type:       delivery.EventType,
+		OccurredAt: delivery.OccurredAt,
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		return &Result{Err: fmt.Errorf("failed to encode payload: %w", err)}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return &Result{Err: fmt.Errorf("invalid webhook request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Foodlink-Webhooks/1.0")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now(), body))
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return &Result{Duration: time.Since(start), Err: err}
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// Drain a little more so the connection can be reused
+	io.CopyN(io.Discard, resp.Body, 64<<10)
+	// Postgres text rejects invalid UTF-8 and NUL bytes
+	text := strings.ReplaceAll(strings.ToValidUTF8(string(excerpt), ""), "\x00", "")
+	return &Result{StatusCode: resp.StatusCode, Body: text, Duration: time.Since(start)}
+}
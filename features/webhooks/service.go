@@ -0,0 +1,232 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"foodlink_backend/config"
+	"foodlink_backend/errors"
+	"foodlink_backend/metrics"
+	"foodlink_backend/utils"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Default and maximum number of deliveries returned by GetDeliveries
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+// Service manages an organization's webhook subscriptions
+type Service struct {
+	repo         store
+	ctx          context.Context
+	sender       *Sender
+	requireHTTPS bool
+}
+
+// NewService creates the service. Endpoints must use HTTPS in production.
+func NewService(cfg *config.Config, sender *Sender) *Service {
+	return &Service{repo: NewRepository(), sender: sender, requireHTTPS: cfg.IsProduction()}
+}
+
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.withContext(ctx), ctx: ctx, sender: s.sender, requireHTTPS: s.requireHTTPS}
+}
+
+// EventTypes returns the event types subscriptions can receive
+func (s *Service) EventTypes() []EventTypeInfo {
+	return EventTypes
+}
+
+func (s *Service) GetAll(userID uuid.UUID) ([]*Subscription, error) {
+	return s.repo.GetAllByUserID(userID)
+}
+
+func (s *Service) GetByID(userID, id uuid.UUID) (*Subscription, error) {
+	return s.repo.GetByID(userID, id)
+}
+
+// Create adds a subscription. The response carries the signing secret,
+// which is not returned again.
+func (s *Service) Create(userID uuid.UUID, req *CreateSubscriptionRequest) (*Subscription, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if err := s.validateURL(req.URL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	sub := &Subscription{
+		ID:          uuid.New(),
+		UserID:      userID,
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+	}
+	if err := s.repo.Create(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *Service) Update(userID, id uuid.UUID, req *UpdateSubscriptionRequest) (*Subscription, error) {
+	sub, err := s.repo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
+	}
+	if req.URL != nil {
+		if err := s.validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+		sub.URL = *req.URL
+	}
+	if req.Description != nil {
+		sub.Description = *req.Description
+	}
+	if req.EventTypes != nil {
+		if sub.EventTypes, err = normalizeEventTypes(req.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+	if req.Active != nil && *req.Active != sub.Active {
+		sub.Active = *req.Active
+		if sub.Active {
+			sub.ConsecutiveFailures = 0
+			sub.DisabledAt = nil
+			sub.DisabledReason = nil
+		} else {
+			now := time.Now()
+			reason := "Disabled by the organization"
+			sub.DisabledAt = &now
+			sub.DisabledReason = &reason
+		}
+	}
+	if err := s.repo.Update(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *Service) Delete(userID, id uuid.UUID) error {
+	return s.repo.Delete(userID, id)
+}
+
+// RotateSecret replaces the signing secret. The old secret stops working
+// immediately.
+func (s *Service) RotateSecret(userID, id uuid.UUID) (*Subscription, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateSecret(userID, id, secret)
+}
+
+// GetDeliveries returns the delivery log of the user's subscription
+func (s *Service) GetDeliveries(userID, id uuid.UUID, filter DeliveryFilter) ([]*Delivery, error) {
+	switch filter.Status {
+	case "", StatusPending, StatusDelivering, StatusSucceeded, StatusFailed:
+	default:
+		return nil, errors.NewAppError(errors.ErrInvalidInput.Code, "Status must be one of pending, delivering, succeeded or failed")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	if filter.Limit > maxDeliveryLimit {
+		filter.Limit = maxDeliveryLimit
+	}
+	if _, err := s.repo.GetByID(userID, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetDeliveries(id, filter)
+}
+
+// SendTest sends a WebhookTest event to the subscription right away, also
+// when it is disabled, and returns the logged delivery. Test deliveries are
+// not retried and don't count towards disabling the subscription.
+func (s *Service) SendTest(userID, id uuid.UUID) (*Delivery, error) {
+	sub, err := s.repo.GetByID(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.repo.GetSecret(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(map[string]interface{}{
+		"subscription_id": sub.ID,
+		"message":         "This is a test event from Foodlink",
+	})
+	delivery := &Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		EventID:        uuid.New(),
+		EventType:      TypeWebhookTest,
+		Payload:        data,
+		OccurredAt:     time.Now(),
+	}
+	if err := s.repo.CreateDelivery(delivery); err != nil {
+		return nil, err
+	}
+
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result := s.sender.Send(ctx, sub.URL, secret, delivery)
+	status := StatusSucceeded
+	if !result.OK() {
+		status = StatusFailed
+	}
+	metrics.WebhookDeliveriesTotal.Inc(TypeWebhookTest, status)
+	return s.repo.RecordAttempt(delivery.ID, status, result, 0)
+}
+
+// validateURL accepts absolute http(s) URLs, and only https in production
+func (s *Service) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.NewAppError(errors.ErrValidationFailed.Code, "URL must be an absolute http or https URL")
+	}
+	if s.requireHTTPS && u.Scheme != "https" {
+		return errors.NewAppError(errors.ErrValidationFailed.Code, "URL must use https")
+	}
+	if u.User != nil {
+		return errors.NewAppError(errors.ErrValidationFailed.Code, "URL must not contain credentials")
+	}
+	return nil
+}
+
+// normalizeEventTypes checks the requested types and removes duplicates
+func normalizeEventTypes(requested []string) ([]string, error) {
+	types := []string{}
+	seen := map[string]bool{}
+	for _, t := range requested {
+		if !isSupportedEventType(t) {
+			supported := make([]string, len(EventTypes))
+			for i, info := range EventTypes {
+				supported[i] = info.Type
+			}
+			return nil, errors.NewAppError(errors.ErrValidationFailed.Code, "Unsupported event type "+t+", must be one of "+strings.Join(supported, ", "))
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Request headers sent with every delivery
+const (
+	SignatureHeader = "X-Foodlink-Signature"
+	EventHeader     = "X-Foodlink-Event"
+	DeliveryHeader  = "X-Foodlink-Delivery"
+)
+
+// secretPrefix marks subscription secrets so they are easy to recognize
+const secretPrefix = "whsec_"
+
+// newSecret returns a random signing secret
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign returns the signature header value for body sent at t:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">".
+// Signing the timestamp lets receivers reject replayed requests.
+func Sign(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + timestamp + ",v1=" + computeSignature(secret, timestamp, body)
+}
+
+// VerifySignature checks a signature header against body, rejecting
+// signatures older than tolerance
+func VerifySignature(secret, header string, body []byte, tolerance time.Duration) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return fmt.Errorf("malformed signature header")
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("signature timestamp is outside the tolerance")
+	}
+	if !hmac.Equal([]byte(signature), []byte(computeSignature(secret, timestamp, body))) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+func computeSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,233 @@
+package webhooks
+
+import (
+	"context"
+	"foodlink_backend/errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordedAttempt is an attempt recorded with fakeStore.RecordAttempt
+type recordedAttempt struct {
+	status  string
+	result  *Result
+	retryIn time.Duration
+}
+
+// fakeStore keeps subscriptions and deliveries in memory, counting failures
+// and disabling subscriptions as Repository.AddFailure does
+type fakeStore struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]*Subscription
+	deliveries    map[uuid.UUID]*Delivery
+	attempts      map[uuid.UUID][]recordedAttempt
+}
+
+func newFakeStore(subs ...*Subscription) *fakeStore {
+	s := &fakeStore{
+		subscriptions: map[uuid.UUID]*Subscription{},
+		deliveries:    map[uuid.UUID]*Delivery{},
+		attempts:      map[uuid.UUID][]recordedAttempt{},
+	}
+	for _, sub := range subs {
+		s.subscriptions[sub.ID] = sub
+	}
+	return s
+}
+
+func (s *fakeStore) withContext(context.Context) store { return s }
+
+func (s *fakeStore) owned(userID, id uuid.UUID) (*Subscription, error) {
+	sub, ok := s.subscriptions[id]
+	if !ok || sub.UserID != userID {
+		return nil, errors.ErrNotFound
+	}
+	return sub, nil
+}
+
+func (s *fakeStore) GetAllByUserID(userID uuid.UUID) ([]*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var subs []*Subscription
+	for _, sub := range s.subscriptions {
+		if sub.UserID == userID {
+			copied := *sub
+			copied.Secret = ""
+			subs = append(subs, &copied)
+		}
+	}
+	return subs, nil
+}
+
+func (s *fakeStore) GetByID(userID, id uuid.UUID) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	copied := *sub
+	copied.Secret = ""
+	return &copied, nil
+}
+
+func (s *fakeStore) GetSecret(userID, id uuid.UUID) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, err := s.owned(userID, id)
+	if err != nil {
+		return "", err
+	}
+	return sub.Secret, nil
+}
+
+func (s *fakeStore) Create(sub *Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub.Active = true
+	copied := *sub
+	s.subscriptions[sub.ID] = &copied
+	return nil
+}
+
+func (s *fakeStore) Update(sub *Subscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, err := s.owned(sub.UserID, sub.ID)
+	if err != nil {
+		return err
+	}
+	secret := stored.Secret
+	*stored = *sub
+	stored.Secret = secret
+	return nil
+}
+
+func (s *fakeStore) UpdateSecret(userID, id uuid.UUID, secret string) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Secret = secret
+	copied := *sub
+	return &copied, nil
+}
+
+func (s *fakeStore) Delete(userID, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.owned(userID, id); err != nil {
+		return err
+	}
+	delete(s.subscriptions, id)
+	return nil
+}
+
+func (s *fakeStore) GetDeliveries(subscriptionID uuid.UUID, filter DeliveryFilter) ([]*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deliveries []*Delivery
+	for _, d := range s.deliveries {
+		if d.SubscriptionID == subscriptionID && (filter.Status == "" || d.Status == filter.Status) {
+			copied := *d
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	return deliveries, nil
+}
+
+func (s *fakeStore) CreateDelivery(d *Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d.Status = StatusDelivering
+	d.Attempts = 1
+	d.CreatedAt = time.Now()
+	copied := *d
+	s.deliveries[d.ID] = &copied
+	return nil
+}
+
+// ClaimDue is not used: tests hand claimed deliveries to the deliverer
+func (s *fakeStore) ClaimDue(limit int, lease time.Duration) ([]*claimedDelivery, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) CountOverdue(age time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, d := range s.deliveries {
+		if d.Status == StatusPending && s.subscriptions[d.SubscriptionID] != nil && s.subscriptions[d.SubscriptionID].Active &&
+			time.Since(d.NextAttemptAt) > age {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *fakeStore) RecordAttempt(id uuid.UUID, status string, result *Result, retryIn time.Duration) (*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts[id] = append(s.attempts[id], recordedAttempt{status: status, result: result, retryIn: retryIn})
+	d, ok := s.deliveries[id]
+	if !ok {
+		d = &Delivery{ID: id}
+		s.deliveries[id] = d
+	}
+	d.Status = status
+	d.NextAttemptAt = time.Now().Add(retryIn)
+	if result.StatusCode != 0 {
+		d.ResponseStatus = &result.StatusCode
+		d.ResponseBody = &result.Body
+	}
+	if !result.OK() {
+		msg := result.Error()
+		d.LastError = &msg
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func (s *fakeStore) ResetFailures(subscriptionID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.subscriptions[subscriptionID]; ok {
+		sub.ConsecutiveFailures = 0
+	}
+	return nil
+}
+
+func (s *fakeStore) AddFailure(subscriptionID uuid.UUID, disableAfter int, reason string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub, ok := s.subscriptions[subscriptionID]
+	if !ok {
+		return false, errors.ErrNotFound
+	}
+	sub.ConsecutiveFailures++
+	if sub.Active && sub.ConsecutiveFailures >= disableAfter {
+		now := time.Now()
+		sub.Active = false
+		sub.DisabledAt = &now
+		sub.DisabledReason = &reason
+	}
+	return !sub.Active && sub.ConsecutiveFailures == disableAfter, nil
+}
+
+// subscription returns a copy of the stored subscription
+func (s *fakeStore) subscription(id uuid.UUID) Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.subscriptions[id]
+}
+
+// attemptsOf returns the attempts recorded for the delivery
+func (s *fakeStore) attemptsOf(id uuid.UUID) []recordedAttempt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]recordedAttempt(nil), s.attempts[id]...)
+}
@@ -0,0 +1,90 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"foodlink_backend/events"
+
+	"github.com/google/uuid"
+)
+
+// EventTypes lists the domain events organizations can receive, with the
+// account each is delivered to
+var EventTypes = []EventTypeInfo{
+	{events.TypeOfferAccepted, "An NGO accepted a donation offer; sent to the NGO"},
+	{events.TypePickupStatusChanged, "An NGO pickup changed status; sent to the NGO that owns the offer"},
+	{events.TypePickupDelivered, "An NGO pickup was delivered; sent to the NGO that owns the offer"},
+	{events.TypeFeedbackSubmitted, "An NGO filed feedback on a delivery; sent to the NGO"},
+	{events.TypeDonationLogged, "A restaurant logged a donation; sent to the restaurant"},
+	{events.TypeInventoryExpired, "A restaurant or shop inventory item expired; sent to its owner"},
+}
+
+func isSupportedEventType(eventType string) bool {
+	for _, info := range EventTypes {
+		if info.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// eventOwners returns the account an event is delivered to, or uuid.Nil
+// when no organization receives it
+var eventOwners = map[string]func(repo *Repository, event *events.Envelope) (uuid.UUID, error){
+	events.TypeOfferAccepted: func(_ *Repository, event *events.Envelope) (uuid.UUID, error) {
+		var e events.OfferAccepted
+		err := event.Decode(&e)
+		return e.NGOUserID, err
+	},
+	events.TypePickupStatusChanged: func(_ *Repository, event *events.Envelope) (uuid.UUID, error) {
+		var e events.PickupStatusChanged
+		err := event.Decode(&e)
+		return e.NGOUserID, err
+	},
+	events.TypePickupDelivered: func(repo *Repository, event *events.Envelope) (uuid.UUID, error) {
+		var e events.PickupDelivered
+		if err := event.Decode(&e); err != nil {
+			return uuid.Nil, err
+		}
+		return repo.GetOfferOwner(e.OfferID)
+	},
+	events.TypeFeedbackSubmitted: func(_ *Repository, event *events.Envelope) (uuid.UUID, error) {
+		var e events.FeedbackSubmitted
+		err := event.Decode(&e)
+		return e.NGOUserID, err
+	},
+	events.TypeDonationLogged: func(_ *Repository, event *events.Envelope) (uuid.UUID, error) {
+		var e events.DonationLogged
+		err := event.Decode(&e)
+		return e.RestaurantID, err
+	},
+	events.TypeInventoryExpired: func(_ *Repository, event *events.Envelope) (uuid.UUID, error) {
+		var e events.InventoryExpired
+		if err := event.Decode(&e); err != nil || e.Source == events.InventoryFamily {
+			return uuid.Nil, err
+		}
+		return e.OwnerID, nil
+	},
+}
+
+// RegisterSubscribers subscribes webhooks to every supported event type
+func RegisterSubscribers(bus *events.Bus) {
+	for _, info := range EventTypes {
+		bus.Subscribe(info.Type, "webhooks.enqueue", enqueueDeliveries)
+	}
+}
+
+// enqueueDeliveries queues the event for the owner's matching subscriptions;
+// the deliverer sends them
+func enqueueDeliveries(ctx context.Context, tx *sql.Tx, event *events.Envelope) error {
+	owner, ok := eventOwners[event.Type]
+	if !ok {
+		return nil
+	}
+	repo := NewRepository().WithContext(ctx).WithTx(tx)
+	userID, err := owner(repo, event)
+	if err != nil || userID == uuid.Nil {
+		return err
+	}
+	return repo.EnqueueEvent(userID, event.EventID, event.Type, event.Payload, event.OccurredAt)
+}
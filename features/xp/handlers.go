@@ -35,28 +35,22 @@ func (h *Handler) getUserID(r *http.Request) (uuid.UUID, error) {
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200  {object}  UserXP
-// @Failure      401  {object}  errors.AppError
+// @Failure      401  {object}  errors.Problem
 // @Router       /xp [get]
-func (h *Handler) GetUserXP(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetUserXP(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
-	xp, err := h.service.GetByUserID(userID)
+	xp, err := h.service.WithContext(r.Context()).GetByUserID(userID)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve XP", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve XP")
 	}
 	utils.OKResponse(w, "XP retrieved successfully", xp)
+	return nil
 }
 
 // AddXP handles POST /api/v1/xp/add
@@ -68,38 +62,30 @@ func (h *Handler) GetUserXP(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        request  body      AddXPRequest  true  "XP data"
 // @Success      200      {object}  UserXP
-// @Failure      400      {object}  errors.AppError
-// @Failure      401      {object}  errors.AppError
+// @Failure      400      {object}  errors.Problem
+// @Failure      401      {object}  errors.Problem
 // @Router       /xp/add [post]
-func (h *Handler) AddXP(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) AddXP(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodPost {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	userID, err := h.getUserID(r)
 	if err != nil {
-		utils.UnauthorizedResponse(w, "Authentication required")
-		return
+		return errors.ErrAuthRequired
 	}
 	var req AddXPRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.BadRequestResponse(w, "Invalid request body", err.Error())
-		return
+		return errors.WrapError(err, errors.ErrInvalidRequestBody)
 	}
 	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
-		utils.BadRequestResponse(w, "Validation failed: "+validationErrors[0], nil)
-		return
+		return errors.NewAppErrorWithErr(errors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], utils.ValidationErrors(validationErrors))
 	}
-	xp, err := h.service.AddXP(userID, req.Amount)
+	xp, err := h.service.WithContext(r.Context()).AddXP(userID, req.Amount)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to add XP", err.Error())
-		return
+		return errors.Wrap(err, "Failed to add XP")
 	}
 	utils.OKResponse(w, "XP added successfully", xp)
+	return nil
 }
 
 // GetLeaderboard handles GET /api/v1/xp/leaderboard
@@ -111,12 +97,11 @@ func (h *Handler) AddXP(w http.ResponseWriter, r *http.Request) {
 // @Security     BearerAuth
 // @Param        limit  query     int  false  "Number of entries (default: 10, max: 100)"
 // @Success      200    {array}   LeaderboardEntry
-// @Failure      401    {object}  errors.AppError
+// @Failure      401    {object}  errors.Problem
 // @Router       /xp/leaderboard [get]
-func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 	limit := 10
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
@@ -124,14 +109,10 @@ func (h *Handler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
 			limit = l
 		}
 	}
-	leaderboard, err := h.service.GetLeaderboard(limit)
+	leaderboard, err := h.service.WithContext(r.Context()).GetLeaderboard(limit)
 	if err != nil {
-		if appErr, ok := err.(*errors.AppError); ok {
-			utils.ErrorResponse(w, appErr.Code, appErr.Message, nil)
-			return
-		}
-		utils.InternalServerErrorResponse(w, "Failed to retrieve leaderboard", err.Error())
-		return
+		return errors.Wrap(err, "Failed to retrieve leaderboard")
 	}
 	utils.OKResponse(w, "Leaderboard retrieved successfully", leaderboard)
+	return nil
 }
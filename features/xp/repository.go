@@ -1,6 +1,7 @@
 package xp
 
 import (
+	"context"
 	"database/sql"
 	"foodlink_backend/database"
 	"foodlink_backend/errors"
@@ -10,20 +11,29 @@ import (
 )
 
 type Repository struct {
-	db *sql.DB
+	db  *sql.DB
+	ctx context.Context
 }
 
 func NewRepository() *Repository {
 	return &Repository{db: database.GetDB()}
 }
 
+func (r *Repository) WithContext(ctx context.Context) *Repository {
+	return &Repository{db: r.db, ctx: ctx}
+}
+
+func (r *Repository) conn() database.Querier {
+	return database.Conn(r.ctx, r.db, nil)
+}
+
 func (r *Repository) GetByUserID(userID uuid.UUID) (*UserXP, error) {
 	if r.db == nil {
 		return nil, errors.ErrDatabase
 	}
 	xp := &UserXP{}
 	query := `SELECT id, user_id, total_xp, level, current_level_xp, next_level_xp, updated_at FROM user_xp WHERE user_id = $1`
-	err := r.db.QueryRow(query, userID).Scan(&xp.ID, &xp.UserID, &xp.TotalXP, &xp.Level, &xp.CurrentLevelXP, &xp.NextLevelXP, &xp.UpdatedAt)
+	err := r.conn().QueryRow(query, userID).Scan(&xp.ID, &xp.UserID, &xp.TotalXP, &xp.Level, &xp.CurrentLevelXP, &xp.NextLevelXP, &xp.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.ErrNotFound
@@ -48,7 +58,7 @@ func (r *Repository) CreateOrUpdate(xp *UserXP) error {
 			updated_at = EXCLUDED.updated_at
 		RETURNING id, user_id, total_xp, level, current_level_xp, next_level_xp, updated_at
 	`
-	err := r.db.QueryRow(query, xp.ID, xp.UserID, xp.TotalXP, xp.Level, xp.CurrentLevelXP, xp.NextLevelXP, time.Now()).Scan(&xp.ID, &xp.UserID, &xp.TotalXP, &xp.Level, &xp.CurrentLevelXP, &xp.NextLevelXP, &xp.UpdatedAt)
+	err := r.conn().QueryRow(query, xp.ID, xp.UserID, xp.TotalXP, xp.Level, xp.CurrentLevelXP, xp.NextLevelXP, time.Now()).Scan(&xp.ID, &xp.UserID, &xp.TotalXP, &xp.Level, &xp.CurrentLevelXP, &xp.NextLevelXP, &xp.UpdatedAt)
 	if err != nil {
 		return errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -70,7 +80,7 @@ func (r *Repository) GetLeaderboard(limit int) ([]*LeaderboardEntry, error) {
 		ORDER BY ux.total_xp DESC
 		LIMIT $1
 	`
-	rows, err := r.db.Query(query, limit)
+	rows, err := r.conn().Query(query, limit)
 	if err != nil {
 		return nil, errors.WrapError(err, errors.ErrDatabase)
 	}
@@ -1,32 +1,33 @@
 package xp
 
 import (
+	"foodlink_backend/errors"
 	"foodlink_backend/middleware"
 	"net/http"
 )
 
 func SetupRoutes(service *Service, handler *Handler, authMiddleware func(http.Handler) http.Handler) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodGet {
-			handler.GetUserXP(w, r)
+			return handler.GetUserXP(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/add", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/add", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodPost {
-			handler.AddXP(w, r)
+			return handler.AddXP(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
-	mux.HandleFunc("/leaderboard", func(w http.ResponseWriter, r *http.Request) {
+	}))
+	mux.Handle("/leaderboard", middleware.Handle(func(w http.ResponseWriter, r *http.Request) error {
 		if r.Method == http.MethodGet {
-			handler.GetLeaderboard(w, r)
+			return handler.GetLeaderboard(w, r)
 		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return errors.ErrMethodNotAllowed
 		}
-	})
+	}))
 	return middleware.Chain(authMiddleware)(mux)
 }
@@ -1,6 +1,7 @@
 package xp
 
 import (
+	"context"
 	"foodlink_backend/errors"
 
 	"github.com/google/uuid"
@@ -14,6 +15,10 @@ func NewService() *Service {
 	return &Service{repo: NewRepository()}
 }
 
+func (s *Service) WithContext(ctx context.Context) *Service {
+	return &Service{repo: s.repo.WithContext(ctx)}
+}
+
 func (s *Service) GetByUserID(userID uuid.UUID) (*UserXP, error) {
 	xp, err := s.repo.GetByUserID(userID)
 	if err != nil && err == errors.ErrNotFound {
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"foodlink_backend/database"
+	"foodlink_backend/errors"
+	"foodlink_backend/health"
 	"foodlink_backend/utils"
 	"net/http"
 )
@@ -18,16 +20,16 @@ type APIResponse struct {
 
 // HealthCheck handles the health check endpoint
 // @Summary      Health check
-// @Description  Check if the server is running and database connectivity
+// @Description  Check if the server is running and database connectivity. Returns 503 when the database is unreachable; prefer /livez and /readyz for probes.
 // @Tags         health
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  HealthResponse
+// @Failure      503  {object}  HealthResponse
 // @Router       /health [get]
-func HealthCheck(w http.ResponseWriter, r *http.Request) {
+func HealthCheck(w http.ResponseWriter, r *http.Request) error {
 	if r.Method != http.MethodGet {
-		utils.BadRequestResponse(w, "Method not allowed", nil)
-		return
+		return errors.ErrMethodNotAllowed
 	}
 
 	response := HealthResponse{
@@ -35,16 +37,22 @@ func HealthCheck(w http.ResponseWriter, r *http.Request) {
 		Message: "Server is running",
 	}
 
-	// Check database connection if available
-	if database.GetDB() != nil {
-		if err := database.HealthCheck(); err == nil {
-			response.Database = "connected"
-		} else {
-			response.Database = "disconnected"
-		}
+	// Check database connection
+	if err := database.Ping(r.Context()); err != nil {
+		response.Status = health.StatusUnavailable
+		response.Message = "Database is unavailable"
+		response.Database = "disconnected"
+		utils.JSONResponse(w, http.StatusServiceUnavailable, utils.Response{
+			Success: false,
+			Message: "Server is unhealthy",
+			Data:    response,
+		})
+		return nil
 	}
+	response.Database = "connected"
 
 	utils.OKResponse(w, "Server is healthy", response)
+	return nil
 }
 
 // APIV1 handles API v1 routes
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"foodlink_backend/health"
+	"foodlink_backend/utils"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// startedAt is when the process started serving
+var startedAt = time.Now()
+
+// LiveResponse is returned by the liveness probe
+type LiveResponse struct {
+	Status string `json:"status" example:"ok"`
+}
+
+// HealthDetailsResponse describes every dependency with its latency
+type HealthDetailsResponse struct {
+	health.Report
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds float64   `json:"uptime_seconds" example:"3600"`
+	GoVersion     string    `json:"go_version" example:"go1.25.5"`
+}
+
+// Livez handles the liveness probe
+// @Summary      Liveness probe
+// @Description  Reports that the process is running. It never checks dependencies, so a database outage does not restart the server.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  LiveResponse
+// @Router       /livez [get]
+func Livez(w http.ResponseWriter, r *http.Request) {
+	utils.JSONResponse(w, http.StatusOK, LiveResponse{Status: health.StatusOK})
+}
+
+// Readyz handles the readiness probe
+// @Summary      Readiness probe
+// @Description  Runs every dependency check (database, pending migrations, scheduler, notification and webhook delivery backlogs) and returns 503 when any fails. Error details are only shown on /health/details.
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Failure      503  {object}  health.Report
+// @Router       /readyz [get]
+func Readyz(w http.ResponseWriter, r *http.Request) {
+	report := health.Run(r.Context())
+	for name, result := range report.Checks {
+		result.Error = ""
+		report.Checks[name] = result
+	}
+	utils.JSONResponse(w, readinessStatus(report), report)
+}
+
+// HealthDetails handles the detailed health report for admins
+// @Summary      Detailed health
+// @Description  Runs every dependency check (database, pending migrations, scheduler, notification and webhook delivery backlogs) and reports status, latency and errors per dependency (admin only)
+// @Tags         health
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  HealthDetailsResponse
+// @Failure      401  {object}  errors.Problem
+// @Failure      403  {object}  errors.Problem
+// @Failure      503  {object}  HealthDetailsResponse
+// @Router       /health/details [get]
+func HealthDetails(w http.ResponseWriter, r *http.Request) {
+	report := health.Run(r.Context())
+	utils.JSONResponse(w, readinessStatus(report), HealthDetailsResponse{
+		Report:        *report,
+		StartedAt:     startedAt,
+		UptimeSeconds: time.Since(startedAt).Seconds(),
+		GoVersion:     runtime.Version(),
+	})
+}
+
+func readinessStatus(report *health.Report) int {
+	if report.Healthy() {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
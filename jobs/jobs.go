@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Run statuses in job_runs
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// DefaultMaxAttempts is how often a run is tried before it is marked failed
+const DefaultMaxAttempts = 5
+
+// Job is a claimed run as passed to a handler
+type Job struct {
+	ID      uuid.UUID
+	Name    string
+	Payload json.RawMessage
+	// Attempt counts tries of this run, starting at 1
+	Attempt int
+	// ScheduledAt is the cron fire time of a recurring run; nil for
+	// one-off runs
+	ScheduledAt *time.Time
+	// PreviousScheduledAt is the fire time of the run before, so a recurring
+	// job can process exactly the window since then. It is nil for the
+	// first run of a schedule.
+	PreviousScheduledAt *time.Time
+
+	maxAttempts int
+}
+
+// Decode unmarshals the payload into v
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Window returns the period a recurring run covers: from the previous fire
+// time, or lookback before this one for the first run, up to this fire time.
+// One-off runs cover lookback up to now.
+func (j *Job) Window(lookback time.Duration) (from, to time.Time) {
+	to = time.Now()
+	if j.ScheduledAt != nil {
+		to = *j.ScheduledAt
+	}
+	from = to.Add(-lookback)
+	if j.PreviousScheduledAt != nil {
+		from = *j.PreviousScheduledAt
+	}
+	return from, to
+}
+
+// Handler runs a job. A returned error or panic schedules a retry with
+// backoff until the run's attempts are used up. Handlers may run more than
+// once for the same run, so they must be idempotent.
+type Handler func(ctx context.Context, job *Job) error
+
+// Execer is satisfied by *sql.DB and *sql.Tx
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Enqueue adds a one-off run of the job name, due at runAt. Pass a *sql.Tx
+// to enqueue atomically with other changes. The job must be registered on
+// the instances that should run it.
+func Enqueue(ctx context.Context, q Execer, name string, payload interface{}, runAt time.Time) (uuid.UUID, error) {
+	if payload == nil {
+		payload = struct{}{}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to encode %s job payload: %w", name, err)
+	}
+	id := uuid.New()
+	_, err = q.ExecContext(ctx, `
+		INSERT INTO job_runs (id, name, payload, run_at, max_attempts)
+		VALUES ($1, $2, $3, $4, $5)
+	`, id, name, data, runAt, DefaultMaxAttempts)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to enqueue %s job: %w", name, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,431 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"foodlink_backend/metrics"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// leaderLockKey is the Postgres advisory lock held by the leader
+const leaderLockKey int64 = 0x666f6f646a6f6273 // "foodjobs"
+
+// retryBaseDelay and retryMaxDelay bound the exponential retry backoff
+const (
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = time.Hour
+)
+
+// cleanupInterval is how often the leader deletes old succeeded runs
+const cleanupInterval = time.Hour
+
+// RetryDelay returns how long to wait before the next try after attempt
+// failed: 30s, 1m, 2m, ... capped at one hour
+func RetryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempt && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// Config tunes the scheduler
+type Config struct {
+	// PollInterval is how often idle workers look for due runs and the
+	// leader checks schedules
+	PollInterval time.Duration
+	// Concurrency is the number of workers in this instance
+	Concurrency int
+	// Timeout bounds a single run; runs locked longer than this are
+	// considered abandoned and picked up again
+	Timeout time.Duration
+	// Retention is how long succeeded runs are kept
+	Retention time.Duration
+}
+
+// definition is a registered job
+type definition struct {
+	name     string
+	schedule *Schedule
+	handler  Handler
+}
+
+// Definition describes a registered job
+type Definition struct {
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule,omitempty"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+}
+
+// Scheduler runs registered jobs from the job_runs table. Every instance
+// runs workers that claim due runs with FOR UPDATE SKIP LOCKED; one instance,
+// holding a Postgres advisory lock, is the leader and enqueues the runs of
+// cron schedules.
+type Scheduler struct {
+	db  *sql.DB
+	cfg Config
+
+	mu   sync.RWMutex
+	jobs map[string]*definition
+
+	leader    atomic.Bool
+	heartbeat atomic.Int64
+}
+
+// NewScheduler creates a scheduler without jobs
+func NewScheduler(db *sql.DB, cfg Config) *Scheduler {
+	return &Scheduler{db: db, cfg: cfg, jobs: map[string]*definition{}}
+}
+
+// Register adds a job that only runs when enqueued. Names identify runs in
+// the database, so they must be unique and stay stable across releases.
+func (s *Scheduler) Register(name string, handler Handler) {
+	s.add(&definition{name: name, handler: handler})
+}
+
+// Schedule adds a job that also runs on the cron schedule spec. It panics
+// on an invalid spec.
+func (s *Scheduler) Schedule(name, spec string, handler Handler) {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		panic(fmt.Sprintf("jobs: %s: %v", name, err))
+	}
+	s.add(&definition{name: name, schedule: schedule, handler: handler})
+}
+
+func (s *Scheduler) add(def *definition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[def.name]; exists {
+		panic(fmt.Sprintf("jobs: %s already registered", def.name))
+	}
+	s.jobs[def.name] = def
+}
+
+func (s *Scheduler) job(name string) (*definition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	def, ok := s.jobs[name]
+	return def, ok
+}
+
+// names returns the registered job names
+func (s *Scheduler) names() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Definitions returns the registered jobs sorted by name, with the next
+// fire time of scheduled ones
+func (s *Scheduler) Definitions() []Definition {
+	now := time.Now()
+	defs := []Definition{}
+	for _, name := range s.names() {
+		def, _ := s.job(name)
+		info := Definition{Name: name}
+		if def.schedule != nil {
+			info.Schedule = def.schedule.String()
+			if next := def.schedule.Next(now); !next.IsZero() {
+				info.NextRun = &next
+			}
+		}
+		defs = append(defs, info)
+	}
+	return defs
+}
+
+// Registered reports whether a job called name exists
+func (s *Scheduler) Registered(name string) bool {
+	_, ok := s.job(name)
+	return ok
+}
+
+// IsLeader reports whether this instance currently enqueues scheduled runs
+func (s *Scheduler) IsLeader() bool {
+	return s.leader.Load()
+}
+
+// Check implements health.Check: it fails when the scheduler loop has
+// stalled
+func (s *Scheduler) Check(ctx context.Context) error {
+	last := s.heartbeat.Load()
+	if last == 0 {
+		return fmt.Errorf("scheduler has not started")
+	}
+	if since := time.Since(time.Unix(0, last)); since > 3*s.cfg.PollInterval {
+		return fmt.Errorf("scheduler loop stalled for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+// Start runs the leader loop and the workers until ctx is done
+func (s *Scheduler) Start(ctx context.Context) {
+	s.heartbeat.Store(time.Now().UnixNano())
+	go s.leaderLoop(ctx)
+	for i := 0; i < s.cfg.Concurrency; i++ {
+		go s.worker(ctx)
+	}
+}
+
+// leaderLoop competes for leadership and, while leader, enqueues due runs
+func (s *Scheduler) leaderLoop(ctx context.Context) {
+	var conn *sql.Conn
+	var lastCleanup time.Time
+	defer func() {
+		if conn != nil {
+			s.release(conn)
+		}
+	}()
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		s.heartbeat.Store(time.Now().UnixNano())
+		if conn == nil {
+			conn = s.acquire(ctx)
+		} else if err := conn.PingContext(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("Lost scheduler leadership", "error", err)
+			s.release(conn)
+			conn = nil
+		}
+
+		if conn != nil {
+			s.enqueueDue(ctx)
+			if time.Since(lastCleanup) >= cleanupInterval {
+				lastCleanup = time.Now()
+				s.cleanup(ctx)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// acquire tries to take the leader lock on a dedicated connection,
+// returning the connection while the lock is held
+func (s *Scheduler) acquire(ctx context.Context) *sql.Conn {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, leaderLockKey).Scan(&acquired); err != nil || !acquired {
+		conn.Close()
+		return nil
+	}
+	s.leader.Store(true)
+	slog.Info("Acquired scheduler leadership")
+	return conn
+}
+
+// release gives up leadership. The lock belongs to the session, so a
+// connection whose unlock fails is discarded rather than returned to the
+// pool still holding it.
+func (s *Scheduler) release(conn *sql.Conn) {
+	s.leader.Store(false)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, leaderLockKey); err != nil {
+		conn.Raw(func(driverConn interface{}) error { return driver.ErrBadConn })
+	}
+	conn.Close()
+}
+
+// enqueueDue adds the runs of schedules whose fire time has passed. Missed
+// fire times are coalesced into one run for the latest of them, and the
+// unique (name, scheduled_at) key keeps a fire time from being enqueued
+// twice across leader changes.
+func (s *Scheduler) enqueueDue(ctx context.Context) {
+	now := time.Now().UTC()
+	for _, name := range s.names() {
+		def, _ := s.job(name)
+		if def.schedule == nil {
+			continue
+		}
+
+		var last sql.NullTime
+		err := s.db.QueryRowContext(ctx, `SELECT MAX(scheduled_at) FROM job_runs WHERE name = $1`, name).Scan(&last)
+		if err != nil {
+			slog.Warn("Failed to read last scheduled run", "job", name, "error", err)
+			continue
+		}
+
+		// Without a previous run, fire times from the last two polls count
+		// so one falling between polls isn't skipped
+		base := now.Add(-2 * s.cfg.PollInterval)
+		var previous *time.Time
+		if last.Valid {
+			base = last.Time
+			previous = &last.Time
+		}
+		due := def.schedule.Next(base)
+		if due.IsZero() || due.After(now) {
+			continue
+		}
+		for next := def.schedule.Next(due); !next.IsZero() && !next.After(now); next = def.schedule.Next(due) {
+			due = next
+		}
+
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO job_runs (name, payload, run_at, scheduled_at, previous_scheduled_at, max_attempts)
+			VALUES ($1, '{}', $2, $2, $3, $4)
+			ON CONFLICT (name, scheduled_at) DO NOTHING
+		`, name, due, previous, DefaultMaxAttempts)
+		if err != nil {
+			slog.Warn("Failed to enqueue scheduled run", "job", name, "error", err)
+		}
+	}
+}
+
+// worker claims and runs due runs until ctx is done
+func (s *Scheduler) worker(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := s.claim(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to claim job run", "error", err)
+		}
+		if job != nil {
+			s.run(ctx, job)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim locks the oldest due run of a job registered here, including runs
+// abandoned by a crashed instance
+func (s *Scheduler) claim(ctx context.Context) (*Job, error) {
+	job := &Job{}
+	err := s.db.QueryRowContext(ctx, `
+		UPDATE job_runs
+		SET status = 'running', attempts = attempts + 1, started_at = CURRENT_TIMESTAMP,
+			locked_until = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		WHERE id = (
+			SELECT id FROM job_runs
+			WHERE name = ANY($1)
+				AND ((status = 'pending' AND run_at <= CURRENT_TIMESTAMP)
+					OR (status = 'running' AND locked_until < CURRENT_TIMESTAMP))
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, name, payload, attempts, max_attempts, scheduled_at, previous_scheduled_at
+	`, pq.Array(s.names()), s.cfg.Timeout.Seconds()).Scan(&job.ID, &job.Name, &job.Payload, &job.Attempt, &job.maxAttempts, &job.ScheduledAt, &job.PreviousScheduledAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// run executes a claimed run and records the outcome
+func (s *Scheduler) run(ctx context.Context, job *Job) {
+	def, _ := s.job(job.Name)
+	logger := slog.With("job", job.Name, "run_id", job.ID, "attempt", job.Attempt)
+
+	started := time.Now()
+	err := s.execute(ctx, def.handler, job)
+	metrics.JobDuration.Observe(time.Since(started).Seconds(), job.Name)
+
+	if err == nil {
+		s.finish(job.ID, StatusSucceeded, nil, 0)
+		metrics.JobRunsTotal.Inc(job.Name, StatusSucceeded)
+		logger.Debug("Job run succeeded", "duration_ms", time.Since(started).Milliseconds())
+		return
+	}
+
+	if job.Attempt >= job.maxAttempts {
+		s.finish(job.ID, StatusFailed, err, 0)
+		metrics.JobRunsTotal.Inc(job.Name, StatusFailed)
+		logger.Error("Job run failed", "error", err)
+		return
+	}
+	delay := RetryDelay(job.Attempt)
+	s.finish(job.ID, StatusPending, err, delay)
+	metrics.JobRunsTotal.Inc(job.Name, "retry")
+	logger.Warn("Job run failed, will retry", "error", err, "retry_in", delay.String())
+}
+
+// execute runs handler within the run timeout, turning panics into errors
+func (s *Scheduler) execute(ctx context.Context, handler Handler, job *Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// finish records the outcome of a run. Pending runs are retried after
+// delay. The update runs without the scheduler's context so a result is
+// still stored during shutdown.
+func (s *Scheduler) finish(id uuid.UUID, status string, cause error, delay time.Duration) {
+	var lastError *string
+	if cause != nil {
+		message := cause.Error()
+		lastError = &message
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE job_runs
+		SET status = $2, last_error = $3, locked_until = NULL,
+			run_at = CURRENT_TIMESTAMP + make_interval(secs => $4),
+			finished_at = CASE WHEN $2 = 'pending' THEN NULL ELSE CURRENT_TIMESTAMP END
+		WHERE id = $1
+	`, id, status, lastError, delay.Seconds())
+	if err != nil {
+		// The lock expires and the run is picked up again
+		slog.Error("Failed to record job run result", "run_id", id, "error", err)
+	}
+}
+
+// cleanup deletes succeeded runs past retention. The latest run of each
+// schedule is kept: it anchors the next fire time.
+func (s *Scheduler) cleanup(ctx context.Context) {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM job_runs r
+		WHERE r.status = 'succeeded'
+			AND r.finished_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+			AND (r.scheduled_at IS NULL OR EXISTS (
+				SELECT 1 FROM job_runs newer
+				WHERE newer.name = r.name AND newer.scheduled_at > r.scheduled_at
+			))
+	`, s.cfg.Retention.Seconds())
+	if err != nil {
+		slog.Warn("Failed to delete old job runs", "error", err)
+	}
+}
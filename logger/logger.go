@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"foodlink_backend/config"
+)
+
+type contextKey string
+
+const (
+	loggerKey contextKey = "logger"
+	fieldsKey contextKey = "log_fields"
+)
+
+// Init builds the application logger from the configured level and format,
+// installs it as the slog default and returns it
+func Init(cfg *config.Config) *slog.Logger {
+	l := New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	slog.SetDefault(l)
+	return l
+}
+
+// New creates a logger writing to w. Level is one of debug, info, warn or
+// error (default info) and format is json (default) or text. Sensitive
+// attributes are redacted before they are written.
+func New(w io.Writer, level string, format string) *slog.Logger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:       lvl,
+		ReplaceAttr: redactAttr,
+	}
+
+	var handler slog.Handler
+	if strings.EqualFold(format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return slog.New(handler)
+}
+
+// NewContext returns a copy of ctx carrying l
+func NewContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, l)
+}
+
+// FromContext returns the request-scoped logger, or the default logger when
+// ctx carries none
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey).(*slog.Logger); ok && l != nil {
+			return l
+		}
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger includes args. The fields are also
+// recorded on the request's access log line when ctx belongs to an HTTP request.
+func With(ctx context.Context, args ...any) context.Context {
+	if fields, ok := ctx.Value(fieldsKey).(*Fields); ok {
+		fields.Add(args...)
+	}
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
+
+// Fields collects attributes added while a request is handled so the access
+// log line, written after the handler returns, can include them
+type Fields struct {
+	mu   sync.Mutex
+	args []any
+}
+
+// WithFields returns a copy of ctx carrying an empty field collector
+func WithFields(ctx context.Context) (context.Context, *Fields) {
+	fields := &Fields{}
+	return context.WithValue(ctx, fieldsKey, fields), fields
+}
+
+// Add appends key/value pairs or slog.Attr values
+func (f *Fields) Add(args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.args = append(f.args, args...)
+}
+
+// Args returns the collected attributes
+func (f *Fields) Args() []any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]any(nil), f.args...)
+}
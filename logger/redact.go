@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the value of sensitive attributes
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute and header names whose values are never logged
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"set-cookie":    true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"jwt_secret":    true,
+	"database_url":  true,
+}
+
+// IsSensitive reports whether values stored under key must be redacted.
+// Besides the fixed list, any key mentioning a password, secret or phone
+// number is treated as sensitive.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	if sensitiveKeys[key] {
+		return true
+	}
+	return strings.Contains(key, "password") ||
+		strings.Contains(key, "secret") ||
+		strings.Contains(key, "phone")
+}
+
+// RedactHeaders returns a loggable copy of h with sensitive headers redacted
+func RedactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		if IsSensitive(name) {
+			out[name] = Redacted
+			continue
+		}
+		out[name] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+// redactAttr is the slog ReplaceAttr hook applied to every handler
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	if a.Value.Kind() == slog.KindAny {
+		if h, ok := a.Value.Any().(http.Header); ok {
+			return slog.Any(a.Key, RedactHeaders(h))
+		}
+	}
+	return a
+}
@@ -0,0 +1,69 @@
+// Package mailer sends outgoing email through the driver set in the mail
+// configuration.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/tracing"
+	"log/slog"
+	"net/mail"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Message is one email
+type Message struct {
+	To      string
+	Subject string
+	// Text is the plain text body
+	Text string
+	// HTML is an optional HTML alternative of Text
+	HTML string
+	// Headers are extra headers, such as List-Unsubscribe
+	Headers map[string]string
+}
+
+// Mailer sends email
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New returns the mailer of the configured driver
+func New(cfg *config.Config) (Mailer, error) {
+	from, err := mail.ParseAddress(cfg.Mail.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mail.from: %w", err)
+	}
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return &SMTPMailer{
+			from:     from,
+			host:     cfg.Mail.SMTPHost,
+			port:     cfg.Mail.SMTPPort,
+			username: cfg.Mail.SMTPUsername,
+			password: cfg.Mail.SMTPPassword,
+		}, nil
+	default:
+		return &LogMailer{from: from}, nil
+	}
+}
+
+// LogMailer only logs messages, for development
+type LogMailer struct {
+	from *mail.Address
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg *Message) error {
+	ctx, span := startSpan(ctx, "log")
+	defer tracing.End(span, nil)
+	slog.InfoContext(ctx, "Mail not sent (log driver)", "from", m.from.String(), "to", msg.To, "subject", msg.Subject, "body", msg.Text)
+	return nil
+}
+
+// startSpan starts the span of a send with the driver
+func startSpan(ctx context.Context, driver string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "mail send", attribute.String("mail.driver", driver))
+}
@@ -0,0 +1,147 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"foodlink_backend/tracing"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPMailer sends through an SMTP server, with STARTTLS when offered
+type SMTPMailer struct {
+	from     *mail.Address
+	host     string
+	port     int
+	username string
+	password string
+}
+
+// Send sends msg, giving up when ctx is done. net/smtp takes no context, so
+// the SMTP exchange itself keeps running in the background after ctx is
+// cancelled, until the server answers or the connection fails.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) (err error) {
+	ctx, span := startSpan(ctx, "smtp")
+	defer func() { tracing.End(span, err) }()
+
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	body, err := m.build(to, msg)
+	if err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+
+	// net/smtp takes no context; give up waiting when ctx is done
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, m.from.Address, []string{to.Address}, body)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// build renders the message as MIME, multipart/alternative when it has HTML
+func (m *SMTPMailer) build(to *mail.Address, msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := map[string]string{
+		"From":         m.from.String(),
+		"To":           to.String(),
+		"Subject":      mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date":         time.Now().Format(time.RFC1123Z),
+		"Message-ID":   messageID(m.from.Address),
+		"MIME-Version": "1.0",
+	}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+
+	var writer *multipart.Writer
+	if msg.HTML != "" {
+		writer = multipart.NewWriter(&buf)
+		headers["Content-Type"] = "multipart/alternative; boundary=" + writer.Boundary()
+	} else {
+		headers["Content-Type"] = "text/plain; charset=utf-8"
+		headers["Content-Transfer-Encoding"] = "quoted-printable"
+	}
+	var head bytes.Buffer
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.ContainsAny(headers[k], "\r\n") {
+			return nil, fmt.Errorf("invalid %s header", k)
+		}
+		fmt.Fprintf(&head, "%s: %s\r\n", k, headers[k])
+	}
+	head.WriteString("\r\n")
+
+	if writer == nil {
+		if err := writeQuotedPrintable(&buf, msg.Text); err != nil {
+			return nil, err
+		}
+		return append(head.Bytes(), buf.Bytes()...), nil
+	}
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return append(head.Bytes(), buf.Bytes()...), nil
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(text)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID returns a unique Message-ID on the sender's domain
+func messageID(from string) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	domain := "foodlink.local"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
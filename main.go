@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	_ "foodlink_backend/docs" // Import docs for Swagger
 	"foodlink_backend/config"
 	"foodlink_backend/database"
+	"foodlink_backend/features/stream"
+	"foodlink_backend/logger"
 	"foodlink_backend/routes"
+	"foodlink_backend/tracing"
 	"foodlink_backend/utils"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 // @title           Foodlink Backend API
@@ -36,62 +41,123 @@ import (
 // @schemes   http https
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "recipes" {
+		os.Exit(runRecipesCommand(os.Args[2:]))
+	}
+
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize structured logging
+	logger.Init(cfg)
+	if dump, err := cfg.Dump(); err == nil {
+		slog.Debug("Loaded configuration", "config", dump)
+	}
+
+	// Initialize tracing
+	shutdownTracing, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		slog.Warn("Failed to initialize tracing, spans will not be exported", "error", err)
+		shutdownTracing = func(context.Context) error { return nil }
+	}
 
 	// Initialize JWT
 	utils.InitJWT(cfg)
 
-	// Initialize database connection
-	if cfg.DatabaseURL != "" {
+	registerMigrations(cfg)
+
+	// Initialize database connection. In strict mode the server refuses to
+	// start without it; otherwise it serves and /readyz reports not ready.
+	if cfg.Database.URL != "" {
 		if err := database.Init(cfg); err != nil {
-			log.Printf("Warning: Failed to initialize database: %v", err)
-			log.Println("Server will start without database connection")
+			if cfg.Database.StrictStartup {
+				slog.Error("Failed to initialize database, refusing to start", "error", err)
+				os.Exit(1)
+			}
+			slog.Warn("Failed to initialize database, server will start without database connection", "error", err)
 		} else {
-			// Initialize schema if database is connected
-			if err := database.InitSchema(); err != nil {
-				log.Printf("Warning: Failed to initialize schema: %v", err)
+			// Migrate and initialize schema if database is connected
+			if err := initSchema(); err != nil {
+				if cfg.Database.StrictStartup {
+					slog.Error("Failed to initialize schema, refusing to start", "error", err)
+					os.Exit(1)
+				}
+				slog.Warn("Failed to initialize schema", "error", err)
 			}
 		}
 		defer database.Close()
 	} else {
-		log.Println("Warning: DATABASE_URL not set, database features will be unavailable")
+		if cfg.Database.StrictStartup {
+			slog.Error("DATABASE_URL not set, refusing to start")
+			os.Exit(1)
+		}
+		slog.Warn("DATABASE_URL not set, database features will be unavailable")
 	}
 
 	// Setup routes with middleware
 	router := routes.SetupRoutes(cfg)
 
 	// Start server
-	serverAddr := ":" + cfg.Port
-	log.Printf("Server starting on port %s", cfg.Port)
-	log.Printf("Server running at http://localhost%s", serverAddr)
-	log.Printf("Swagger UI: http://localhost%s/swagger/index.html", serverAddr)
-	log.Printf("Swagger JSON: http://localhost%s/swagger/doc.json", serverAddr)
+	serverAddr := ":" + cfg.HTTP.Port
+	slog.Info("Server starting",
+		"port", cfg.HTTP.Port,
+		"environment", cfg.Environment,
+		"url", "http://localhost"+serverAddr,
+		"swagger_ui", "http://localhost"+serverAddr+"/swagger/index.html",
+	)
 
 	// Setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	server := &http.Server{
-		Addr:    serverAddr,
-		Handler: router,
+		Addr:              serverAddr,
+		Handler:           router,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
 	}
+	// Event streams never finish on their own, so end them when draining
+	server.RegisterOnShutdown(stream.Default.Close)
 
 	// Start server in a goroutine
 	go func() {
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal("Server failed to start: ", err)
+			slog.Error("Server failed to start", "error", err)
+			os.Exit(1)
 		}
 	}()
 
 	// Wait for interrupt signal
 	<-sigChan
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
+
+	// Stop accepting requests and let in-flight ones finish
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Error("Error shutting down server", "error", err)
+	}
 
 	// Close database connection
 	if err := database.Close(); err != nil {
-		log.Printf("Error closing database: %v", err)
+		slog.Error("Error closing database", "error", err)
+	}
+
+	// Flush pending spans
+	tracingCtx, tracingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer tracingCancel()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		slog.Error("Error shutting down tracing", "error", err)
 	}
 
-	log.Println("Server stopped")
+	slog.Info("Server stopped")
 }
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+)
+
+// dbStatsCollector reports sql.DB connection pool statistics at scrape time
+type dbStatsCollector struct {
+	db func() *sql.DB
+}
+
+// RegisterDBStats exposes the pool statistics of the database returned by db.
+// The callback is evaluated on every scrape, so it may return nil until the
+// connection is established.
+func RegisterDBStats(db func() *sql.DB) {
+	Default.Register("db_pool", &dbStatsCollector{db: db})
+}
+
+func (c *dbStatsCollector) Collect(w io.Writer) {
+	var stats sql.DBStats
+	if db := c.db(); db != nil {
+		stats = db.Stats()
+	}
+
+	gauge := func(name, help string, value float64) {
+		writeHeader(w, name, help, "gauge")
+		fmt.Fprintf(w, "%s %s\n", name, formatValue(value))
+	}
+	counter := func(name, help string, value float64) {
+		writeHeader(w, name, help, "counter")
+		fmt.Fprintf(w, "%s %s\n", name, formatValue(value))
+	}
+
+	gauge("foodlink_db_max_open_connections", "Maximum number of open connections to the database.", float64(stats.MaxOpenConnections))
+	gauge("foodlink_db_open_connections", "Number of established connections, both in use and idle.", float64(stats.OpenConnections))
+	gauge("foodlink_db_in_use_connections", "Number of connections currently in use.", float64(stats.InUse))
+	gauge("foodlink_db_idle_connections", "Number of idle connections.", float64(stats.Idle))
+	counter("foodlink_db_wait_count_total", "Total number of connections waited for.", float64(stats.WaitCount))
+	counter("foodlink_db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", stats.WaitDuration.Seconds())
+	counter("foodlink_db_max_idle_closed_total", "Total number of connections closed due to SetMaxIdleConns.", float64(stats.MaxIdleClosed))
+	counter("foodlink_db_max_idle_time_closed_total", "Total number of connections closed due to SetConnMaxIdleTime.", float64(stats.MaxIdleTimeClosed))
+	counter("foodlink_db_max_lifetime_closed_total", "Total number of connections closed due to SetConnMaxLifetime.", float64(stats.MaxLifetimeClosed))
+}
@@ -0,0 +1,184 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// HTTP metrics recorded by middleware.Metrics
+var (
+	HTTPRequestsTotal = NewCounterVec(
+		"foodlink_http_requests_total",
+		"Total number of HTTP requests by method, route pattern and status code.",
+		"method", "route", "status",
+	)
+	HTTPRequestDuration = NewHistogramVec(
+		"foodlink_http_request_duration_seconds",
+		"HTTP request latency in seconds by method, route pattern and status code.",
+		nil,
+		"method", "route", "status",
+	)
+	RateLimitedTotal = NewCounterVec(
+		"foodlink_rate_limited_requests_total",
+		"Total number of requests rejected by the rate limiter, by route group and role.",
+		"group", "role",
+	)
+)
+
+// Domain counters recorded by the feature services
+var (
+	SurplusPostsCreated = NewCounterVec(
+		"foodlink_surplus_posts_created_total",
+		"Total number of surplus posts created, by source (community or restaurant).",
+		"source",
+	)
+	SurplusPostsClaimed = NewCounterVec(
+		"foodlink_surplus_posts_claimed_total",
+		"Total number of surplus posts claimed, by source (community or restaurant).",
+		"source",
+	)
+	OffersTotal = NewCounterVec(
+		"foodlink_ngo_offers_total",
+		"Total number of NGO donation offers resolved, by outcome (accepted, declined or expired).",
+		"outcome",
+	)
+	PickupsTotal = NewCounterVec(
+		"foodlink_ngo_pickups_total",
+		"Total number of NGO pickups entering each status.",
+		"status",
+	)
+	DonatedKg = NewCounterVec(
+		"foodlink_donated_kg_total",
+		"Total kilograms of food donated, by source.",
+		"source",
+	)
+)
+
+// Event delivery metrics recorded by the events dispatcher
+var (
+	EventDeliveriesTotal = NewCounterVec(
+		"foodlink_event_deliveries_total",
+		"Total number of domain event delivery attempts, by event type, subscriber and outcome (delivered, retry or dead).",
+		"type", "subscriber", "outcome",
+	)
+)
+
+// Webhook metrics recorded by the webhook deliverer
+var (
+	WebhookDeliveriesTotal = NewCounterVec(
+		"foodlink_webhook_deliveries_total",
+		"Total number of webhook delivery attempts, by event type and outcome (succeeded, retry or failed).",
+		"type", "outcome",
+	)
+	WebhookSubscriptionsDisabledTotal = NewCounterVec(
+		"foodlink_webhook_subscriptions_disabled_total",
+		"Total number of webhook subscriptions disabled after repeated failures.",
+	)
+)
+
+// Notification metrics recorded by the notification deliverer
+var (
+	NotificationDeliveriesTotal = NewCounterVec(
+		"foodlink_notification_deliveries_total",
+		"Total number of notification delivery attempts, by channel and outcome (sent, retry or failed).",
+		"channel", "outcome",
+	)
+	NotificationDigestsTotal = NewCounterVec(
+		"foodlink_notification_digests_total",
+		"Total number of notification digests created, by channel.",
+		"channel",
+	)
+)
+
+// Email digest metrics recorded by the digest jobs
+var EmailDigestsTotal = NewCounterVec(
+	"foodlink_email_digests_total",
+	"Total number of email digests, by frequency and outcome (sent, empty or failed).",
+	"frequency", "outcome",
+)
+
+// Upload metrics recorded by the uploads service
+var UploadsTotal = NewCounterVec(
+	"foodlink_uploads_total",
+	"Total number of files uploaded, by sniffed MIME type.",
+	"type",
+)
+
+// Stream metrics recorded by the Server-Sent Events hub
+var (
+	StreamClients = NewGaugeVec(
+		"foodlink_stream_clients",
+		"Number of open event streams.",
+	)
+	StreamEventsSentTotal = NewCounterVec(
+		"foodlink_stream_events_sent_total",
+		"Total number of events written to streams, by event type.",
+		"type",
+	)
+	StreamClientsDroppedTotal = NewCounterVec(
+		"foodlink_stream_clients_dropped_total",
+		"Total number of streams closed because the client fell behind.",
+	)
+)
+
+// Job metrics recorded by the job scheduler
+var (
+	JobRunsTotal = NewCounterVec(
+		"foodlink_job_runs_total",
+		"Total number of background job runs, by job and outcome (succeeded, retry or failed).",
+		"job", "outcome",
+	)
+	JobDuration = NewHistogramVec(
+		"foodlink_job_duration_seconds",
+		"Background job run duration in seconds, by job.",
+		nil,
+		"job",
+	)
+)
+
+// Surplus sources
+const (
+	SourceCommunity  = "community"
+	SourceRestaurant = "restaurant"
+)
+
+// expiredInventoryCollector counts expired inventory items at scrape time
+type expiredInventoryCollector struct {
+	db func() *sql.DB
+}
+
+// expiredInventoryQueries maps the scope label to the query counting its expired items
+var expiredInventoryQueries = []struct {
+	scope string
+	query string
+}{
+	{"family", `SELECT COUNT(*) FROM inventory_items WHERE expiry_date IS NOT NULL AND expiry_date < CURRENT_TIMESTAMP`},
+	{"restaurant", `SELECT COUNT(*) FROM restaurant_inventory_items WHERE expiry_date < CURRENT_TIMESTAMP`},
+	{"shop", `SELECT COUNT(*) FROM shop_inventory_items WHERE expiry_date < CURRENT_TIMESTAMP`},
+}
+
+// RegisterExpiredInventory exposes the number of expired inventory items per
+// scope (family, restaurant and shop), queried from db on every scrape
+func RegisterExpiredInventory(db func() *sql.DB) {
+	Default.Register("foodlink_inventory_expired_items", &expiredInventoryCollector{db: db})
+}
+
+func (c *expiredInventoryCollector) Collect(w io.Writer) {
+	name := "foodlink_inventory_expired_items"
+	writeHeader(w, name, "Number of inventory items past their expiry date, by scope.", "gauge")
+
+	db := c.db()
+	if db == nil {
+		return
+	}
+	for _, q := range expiredInventoryQueries {
+		var count int64
+		if err := db.QueryRow(q.query).Scan(&count); err != nil {
+			slog.Warn("Failed to count expired inventory", "scope", q.scope, "error", err)
+			continue
+		}
+		fmt.Fprintf(w, "%s%s %d\n", name, formatLabels([]string{"scope"}, []string{q.scope}), count)
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"foodlink_backend/errors"
+	"net/http"
+	"strings"
+)
+
+// BodyLimits sets the maximum request body size in bytes. Routes maps path
+// prefixes to their own limit, e.g. endpoints accepting images; the longest
+// matching prefix wins and other requests use Default.
+type BodyLimits struct {
+	Default int64
+	Routes  map[string]int64
+}
+
+// limit returns the body limit that applies to path
+func (l BodyLimits) limit(path string) int64 {
+	limit := l.Default
+	matched := 0
+	for prefix, routeLimit := range l.Routes {
+		if len(prefix) > matched && strings.HasPrefix(path, prefix) {
+			limit = routeLimit
+			matched = len(prefix)
+		}
+	}
+	return limit
+}
+
+// BodyLimit rejects requests whose declared Content-Length exceeds the limit
+// with 413 and wraps the body in http.MaxBytesReader, so bodies without a
+// length fail while being read. A limit of zero or less disables the check.
+func BodyLimit(limits BodyLimits) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limit := limits.limit(r.URL.Path)
+			if limit <= 0 || r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.ContentLength > limit {
+				RenderError(w, r, errors.ErrRequestTooLarge)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
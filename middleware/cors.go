@@ -2,81 +2,93 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 )
 
-// CORS handles Cross-Origin Resource Sharing
+// CORSConfig configures cross-origin resource sharing
+type CORSConfig struct {
+	// AllowedOrigins lists origins allowed to call the API. "*" allows any
+	// origin, but is not combined with credentials.
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long, in seconds, browsers may cache a preflight response
+	MaxAge int
+}
+
+// DefaultCORSConfig allows any origin without credentials
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "Authorization", "X-Request-ID", "If-Match"},
+		ExposedHeaders: []string{
+			"X-Request-ID", "X-Trace-ID", "ETag",
+			"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+		},
+		MaxAge: 3600,
+	}
+}
+
+// CORS handles Cross-Origin Resource Sharing with the default configuration
 func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
-		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
-		w.Header().Set("Access-Control-Max-Age", "3600")
+	return CORSWithConfig(DefaultCORSConfig())(next)
+}
 
-		// Handle preflight requests
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusNoContent)
-			return
+// CORSWithConfig handles CORS with custom configuration. Only origins in
+// AllowedOrigins get CORS headers; requests from other origins are still
+// served, and the browser blocks the response. A wildcard origin is answered
+// with "*" and never with credentials, so an allow-all configuration cannot
+// be used to read authenticated responses from another site.
+func CORSWithConfig(cfg CORSConfig) func(http.Handler) http.Handler {
+	allowAny := false
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+			continue
 		}
+		origins[strings.TrimSuffix(origin, "/")] = true
+	}
 
-		next.ServeHTTP(w, r)
-	})
-}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(cfg.MaxAge)
 
-// CORSWithConfig handles CORS with custom configuration
-func CORSWithConfig(allowedOrigins []string, allowedMethods []string, allowedHeaders []string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get("Origin")
+			h := w.Header()
+			h.Add("Vary", "Origin")
 
-			// Check if origin is allowed
+			origin := r.Header.Get("Origin")
 			allowed := false
-			if len(allowedOrigins) == 0 {
-				allowed = true // Allow all if no origins specified
-			} else {
-				for _, allowedOrigin := range allowedOrigins {
-					if origin == allowedOrigin || allowedOrigin == "*" {
-						allowed = true
-						break
-					}
-				}
-			}
-
-			if allowed {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
-			}
-
-			// Set allowed methods
-			methods := "GET, POST, PUT, DELETE, PATCH, OPTIONS"
-			if len(allowedMethods) > 0 {
-				methods = ""
-				for i, method := range allowedMethods {
-					if i > 0 {
-						methods += ", "
-					}
-					methods += method
+			switch {
+			case origin != "" && origins[origin]:
+				h.Set("Access-Control-Allow-Origin", origin)
+				if cfg.AllowCredentials {
+					h.Set("Access-Control-Allow-Credentials", "true")
 				}
+				allowed = true
+			case allowAny:
+				h.Set("Access-Control-Allow-Origin", "*")
+				allowed = true
 			}
-			w.Header().Set("Access-Control-Allow-Methods", methods)
 
-			// Set allowed headers
-			headers := "Content-Type, Authorization, X-Request-ID"
-			if len(allowedHeaders) > 0 {
-				headers = ""
-				for i, header := range allowedHeaders {
-					if i > 0 {
-						headers += ", "
-					}
-					headers += header
-				}
+			if allowed && exposed != "" {
+				h.Set("Access-Control-Expose-Headers", exposed)
 			}
-			w.Header().Set("Access-Control-Allow-Headers", headers)
-			w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
-			w.Header().Set("Access-Control-Max-Age", "3600")
 
 			// Handle preflight requests
-			if r.Method == http.MethodOptions {
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				if allowed {
+					h.Set("Access-Control-Allow-Methods", methods)
+					h.Set("Access-Control-Allow-Headers", headers)
+					h.Set("Access-Control-Max-Age", maxAge)
+				}
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
@@ -2,94 +2,86 @@ package middleware
 
 import (
 	"encoding/json"
+	stderrors "errors"
+	"foodlink_backend/config"
 	"foodlink_backend/errors"
+	"foodlink_backend/logger"
 	"foodlink_backend/utils"
-	"log"
 	"net/http"
-)
-
-// ErrorHandler handles errors and returns appropriate HTTP responses
-func ErrorHandler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Create a custom ResponseWriter to capture errors
-		rw := &errorResponseWriter{
-			ResponseWriter: w,
-			statusCode:    http.StatusOK,
-		}
-
-		next.ServeHTTP(rw, r)
-
-		// If an error was set, handle it
-		if rw.err != nil {
-			handleError(w, r, rw.err, rw.statusCode)
-		}
-	})
-}
+	"net/url"
+	"runtime/debug"
 
-type errorResponseWriter struct {
-	http.ResponseWriter
-	statusCode int
-	err        error
-}
+	"go.opentelemetry.io/otel/trace"
+)
 
-func (rw *errorResponseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
-}
+// exposeErrorDetails controls whether the underlying cause of an error is
+// included in responses. It is off until InitErrorRenderer enables it.
+var exposeErrorDetails bool
 
-func (rw *errorResponseWriter) Write(b []byte) (int, error) {
-	return rw.ResponseWriter.Write(b)
+// InitErrorRenderer configures error responses. Causes are only exposed
+// outside production.
+func InitErrorRenderer(cfg *config.Config) {
+	exposeErrorDetails = !cfg.IsProduction()
 }
 
-// handleError processes and sends error responses
-func handleError(w http.ResponseWriter, r *http.Request, err error, statusCode int) {
-	requestID := GetRequestID(r)
+// HandlerFunc is an HTTP handler that returns an error instead of writing
+// error responses itself
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
 
-	// Log the error
-	log.Printf("Error [%s]: %v", requestID, err)
-
-	// Check if it's an AppError
-	if appErr, ok := err.(*errors.AppError); ok {
-		errorResponse := map[string]interface{}{
-			"code":      appErr.Code,
-			"message":   appErr.Message,
-			"request_id": requestID,
-		}
-
-		if appErr.Err != nil {
-			errorResponse["details"] = appErr.Err.Error()
+// Handle adapts a HandlerFunc to http.HandlerFunc, rendering any returned
+// error with RenderError
+func Handle(fn HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			RenderError(w, r, err)
 		}
+	}
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(appErr.Code)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"success":    false,
-			"error":      errorResponse,
-			"request_id": requestID,
-		})
-		return
+// RenderError writes err as an RFC 7807 problem+json response carrying the
+// request ID. See errors.Resolve for how errors map to status codes.
+func RenderError(w http.ResponseWriter, r *http.Request, err error) {
+	appErr := errors.Resolve(err)
+
+	problem := errors.Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(appErr.Code),
+		Status:    appErr.Code,
+		Detail:    appErr.Message,
+		Instance:  requestPath(r),
+		RequestID: GetRequestID(r),
+	}
+	if problem.Title == "" {
+		problem.Title = appErr.Message
 	}
 
-	// Handle generic errors
-	errorResponse := map[string]interface{}{
-		"code":       statusCode,
-		"message":    err.Error(),
-		"request_id": requestID,
+	var validationErrors utils.ValidationErrors
+	if stderrors.As(err, &validationErrors) {
+		problem.Errors = validationErrors
+	} else if exposeErrorDetails && appErr.Err != nil {
+		problem.Cause = appErr.Err.Error()
 	}
 
-	// Don't expose internal errors in production
-	if statusCode >= http.StatusInternalServerError {
-		errorResponse["message"] = "Internal server error"
-		log.Printf("Internal error [%s]: %v", requestID, err)
+	log := logger.FromContext(r.Context())
+	if appErr.Code >= http.StatusInternalServerError {
+		log.Error("request failed", "error", err, "status", appErr.Code)
+		trace.SpanFromContext(r.Context()).RecordError(err)
+	} else {
+		log.Debug("request rejected", "error", err, "status", appErr.Code)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success":    false,
-		"error":      errorResponse,
-		"request_id": requestID,
-	})
+	w.Header().Set("Content-Type", errors.ProblemContentType)
+	w.WriteHeader(appErr.Code)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// requestPath returns the path the client requested. r.URL.Path cannot be
+// used since feature routers see it with their prefix stripped.
+func requestPath(r *http.Request) string {
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		return u.Path
+	}
+	return r.URL.Path
 }
 
 // RecoverPanic recovers from panics and returns error responses
@@ -97,16 +89,8 @@ func RecoverPanic(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				requestID := GetRequestID(r)
-				log.Printf("Panic recovered [%s]: %v", requestID, err)
-
-				utils.InternalServerErrorResponse(
-					w,
-					"Internal server error",
-					map[string]interface{}{
-						"request_id": requestID,
-					},
-				)
+				logger.FromContext(r.Context()).Error("panic recovered", "request_id", GetRequestID(r), "panic", err, "stack", string(debug.Stack()))
+				RenderError(w, r, errors.ErrInternalServer)
 			}
 		}()
 
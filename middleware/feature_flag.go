@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/featureflags"
+	"net/http"
+)
+
+// RequireFlag answers 404 while the flag key is off for the caller, so a
+// module behind a flag looks absent. Place it after authentication so
+// targeting sees the user.
+func RequireFlag(flags featureflags.Evaluator, key string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !flags.Enabled(r.Context(), key) {
+				RenderError(w, r, errors.ErrNotFound)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
-	"log"
+	"foodlink_backend/logger"
+	"foodlink_backend/tracing"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -29,28 +31,68 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
-// Logging logs HTTP requests
-func Logging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		rw := &responseWriter{
-			ResponseWriter: w,
-			statusCode:    0,
-		}
-
-		next.ServeHTTP(rw, r)
-
-		duration := time.Since(start)
-
-		log.Printf(
-			"%s %s %s %d %d %v",
-			r.Method,
-			r.RequestURI,
-			r.RemoteAddr,
-			rw.statusCode,
-			rw.written,
-			duration,
-		)
-	})
+// Unwrap lets http.ResponseController reach the underlying writer, for
+// flushing streamed responses
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Logging writes one structured access log line per request. It stores a
+// request-scoped logger carrying the request ID in the context, and routes
+// resolves the matched route pattern. Fields added later with logger.With,
+// such as the user ID and role set by the auth middleware, are included in
+// the access log line.
+func Logging(routes *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			reqLogger := slog.Default().With(
+				"request_id", GetRequestID(r),
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			if traceID := tracing.TraceID(r.Context()); traceID != "" {
+				reqLogger = reqLogger.With("trace_id", traceID)
+			}
+			ctx, fields := logger.WithFields(logger.NewContext(r.Context(), reqLogger))
+			r = r.WithContext(ctx)
+
+			rw := &responseWriter{
+				ResponseWriter: w,
+				statusCode:    0,
+			}
+
+			next.ServeHTTP(rw, r)
+
+			status := rw.statusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			route := ""
+			if routes != nil {
+				_, route = routes.Handler(r)
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+
+			args := append([]any{
+				"route", route,
+				"status", status,
+				"bytes", rw.written,
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+				"remote_addr", r.RemoteAddr,
+				"user_agent", r.UserAgent(),
+			}, fields.Args()...)
+
+			reqLogger.Log(r.Context(), level, "http_request", args...)
+		})
+	}
 }
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"foodlink_backend/metrics"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metrics records request counts and latency by method, route pattern and
+// status code. routes resolves the route pattern so that IDs in paths do not
+// create a new series per request.
+func Metrics(routes *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			rw := &responseWriter{ResponseWriter: w}
+			next.ServeHTTP(rw, r)
+
+			status := rw.statusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			route := "unmatched"
+			if routes != nil {
+				if _, pattern := routes.Handler(r); pattern != "" {
+					route = pattern
+				}
+			}
+
+			statusLabel := strconv.Itoa(status)
+			metrics.HTTPRequestsTotal.Inc(r.Method, route, statusLabel)
+			metrics.HTTPRequestDuration.Observe(time.Since(start).Seconds(), r.Method, route, statusLabel)
+		})
+	}
+}
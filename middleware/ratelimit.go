@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"foodlink_backend/errors"
+	"foodlink_backend/logger"
+	"foodlink_backend/metrics"
+	"foodlink_backend/ratelimit"
+	"foodlink_backend/utils"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit throttles requests with limiter. Authenticated requests are
+// counted per user and role, taken from the bearer token; other requests are
+// counted per client IP. When trustProxy is set the client IP is read from
+// X-Forwarded-For or X-Real-IP. Every limited response carries the
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy
+// headers, and rejected requests get 429 with Retry-After. If the store
+// fails the request is let through, so an outage of the store never takes
+// the API down.
+func RateLimit(limiter *ratelimit.Limiter, trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := rateLimitClient(r, trustProxy)
+
+			decision, limited, err := limiter.Allow(r.Context(), r, client)
+			if err != nil {
+				logger.FromContext(r.Context()).Warn("Rate limiter unavailable, allowing request", "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !limited {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+			h.Set("RateLimit-Policy", strconv.Itoa(decision.Limit)+";w="+strconv.Itoa(ceilSeconds(decision.Quota.Period)))
+
+			if !decision.Allowed {
+				role := client.Role
+				if role == "" {
+					role = ratelimit.RoleAnonymous
+				}
+				metrics.RateLimitedTotal.Inc(decision.Group, role)
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+				RenderError(w, r, errors.ErrTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitClient identifies the caller. Invalid or expired tokens are
+// ignored here and the request is counted against its IP; the auth
+// middleware rejects them later.
+func rateLimitClient(r *http.Request, trustProxy bool) ratelimit.Client {
+	client := ratelimit.Client{IP: clientIP(r, trustProxy)}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if claims, err := utils.ValidateToken(token); err == nil {
+			client.UserID = claims.UserID.String()
+			client.Role = claims.Role
+		}
+	}
+	return client
+}
+
+// clientIP returns the address of the client that sent r
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+		if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+			return realIP
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"foodlink_backend/tracing"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span for every request, continuing the trace from
+// an incoming traceparent header when present. The span is named after the
+// method and the route pattern resolved by routes, and the trace ID is echoed
+// in the X-Trace-ID response header.
+func Tracing(routes *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			route := ""
+			if routes != nil {
+				_, route = routes.Handler(r)
+			}
+			name := r.Method
+			if route != "" {
+				name += " " + route
+			}
+
+			ctx, span := tracing.Tracer().Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+					attribute.String("http.route", route),
+					attribute.String("request.id", GetRequestID(r)),
+					attribute.String("user_agent.original", r.UserAgent()),
+				),
+			)
+			defer span.End()
+
+			if traceID := tracing.TraceID(ctx); traceID != "" {
+				w.Header().Set("X-Trace-ID", traceID)
+			}
+
+			rw := &responseWriter{ResponseWriter: w}
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			status := rw.statusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
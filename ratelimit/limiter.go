@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+)
+
+// Client identifies who a request is counted against
+type Client struct {
+	// UserID is set for authenticated requests
+	UserID string
+	// Role is the user's role, or RoleAnonymous
+	Role string
+	// IP is the client address, used as the key for anonymous requests
+	IP string
+}
+
+// key returns the bucket key of the client within group
+func (c Client) key(group string) string {
+	if c.UserID != "" {
+		return group + ":user:" + c.UserID
+	}
+	return group + ":ip:" + c.IP
+}
+
+// Limiter applies a Policy using a Store
+type Limiter struct {
+	store  Store
+	policy Policy
+}
+
+// NewLimiter creates a limiter
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{store: store, policy: policy}
+}
+
+// Decision is the result of checking a request
+type Decision struct {
+	Result
+	Group string
+	Quota Quota
+}
+
+// Allow takes a token for the client from the bucket of the request's route
+// group. ok is false when the request is not rate limited at all.
+func (l *Limiter) Allow(ctx context.Context, r *http.Request, client Client) (decision Decision, ok bool, err error) {
+	group := Group(r)
+	if group == "" {
+		return Decision{}, false, nil
+	}
+	role := client.Role
+	if role == "" {
+		role = RoleAnonymous
+	}
+	quota, found := l.policy.Quota(group, role)
+	if !found {
+		return Decision{}, false, nil
+	}
+
+	result, err := l.store.Take(ctx, client.key(group), quota)
+	if err != nil {
+		return Decision{}, true, err
+	}
+	return Decision{Result: result, Group: group, Quota: quota}, true, nil
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often idle buckets are dropped from a MemoryStore
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt is when the bucket will be full again and can be forgotten
+	fullAt time.Time
+}
+
+// MemoryStore keeps buckets in process memory. Limits are enforced per
+// instance, so use PostgresStore when running several instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take implements Store
+func (s *MemoryStore) Take(ctx context.Context, key string, quota Quota) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: quota.Capacity(), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	result, tokens := take(b.tokens, now.Sub(b.updatedAt), quota)
+	b.tokens = tokens
+	b.updatedAt = now
+	b.fullAt = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep removes buckets that have refilled completely, since they are
+// equivalent to a new bucket. The caller must hold s.mu.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Route groups share one bucket per client
+const (
+	// GroupAuth covers registration, login and token refresh
+	GroupAuth = "auth"
+	// GroupPublic covers unauthenticated read endpoints
+	GroupPublic = "public"
+	// GroupCommunityWrite covers posting comments, requests and other
+	// community content
+	GroupCommunityWrite = "community_write"
+	// GroupDefault covers every other API endpoint
+	GroupDefault = "default"
+)
+
+// RoleAnonymous is the role used for requests without a valid token
+const RoleAnonymous = "anonymous"
+
+// AnyRole matches every role that has no quota of its own within a group
+const AnyRole = "*"
+
+// Group returns the route group of a request, or "" when the request is not
+// rate limited (health checks, metrics and documentation)
+func Group(r *http.Request) string {
+	path := r.URL.Path
+	if !strings.HasPrefix(path, "/api/v1/") {
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/api/v1/auth/"):
+		return GroupAuth
+	case strings.HasPrefix(path, "/api/v1/community/") && r.Method != http.MethodGet && r.Method != http.MethodHead:
+		return GroupCommunityWrite
+	case strings.HasPrefix(path, "/api/v1/price-comparisons/"),
+		strings.HasPrefix(path, "/api/v1/food-items/") && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+		return GroupPublic
+	}
+	return GroupDefault
+}
+
+// Policy maps a route group and role to a quota
+type Policy map[string]map[string]Quota
+
+// DefaultPolicy returns the built-in quotas
+func DefaultPolicy() Policy {
+	return Policy{
+		GroupAuth: {
+			AnyRole: {Requests: 20, Period: time.Minute, Burst: 10},
+		},
+		GroupPublic: {
+			RoleAnonymous: {Requests: 60, Period: time.Minute},
+			AnyRole:       {Requests: 300, Period: time.Minute},
+		},
+		GroupCommunityWrite: {
+			AnyRole: {Requests: 30, Period: time.Minute, Burst: 10},
+		},
+		GroupDefault: {
+			RoleAnonymous: {Requests: 60, Period: time.Minute},
+			AnyRole:       {Requests: 300, Period: time.Minute},
+			"admin":       {Requests: 1200, Period: time.Minute},
+		},
+	}
+}
+
+// Quota returns the quota for role in group, falling back to the group's
+// AnyRole quota and then to the default group
+func (p Policy) Quota(group, role string) (Quota, bool) {
+	for _, g := range []string{group, GroupDefault} {
+		quotas, ok := p[g]
+		if !ok {
+			continue
+		}
+		if q, ok := quotas[role]; ok {
+			return q, true
+		}
+		if q, ok := quotas[AnyRole]; ok {
+			return q, true
+		}
+	}
+	return Quota{}, false
+}
+
+// Override parses a comma-separated list of group:role=quota entries, such as
+// "auth:*=10/1m:5,default:ngo=600/1m", and applies them on top of p
+func (p Policy) Override(spec string) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		target, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid rate limit entry %q: expected group:role=quota", entry)
+		}
+		group, role, ok := strings.Cut(target, ":")
+		if !ok || group == "" || role == "" {
+			return fmt.Errorf("invalid rate limit entry %q: expected group:role=quota", entry)
+		}
+
+		quota, err := ParseQuota(value)
+		if err != nil {
+			return fmt.Errorf("invalid rate limit entry %q: %w", entry, err)
+		}
+
+		if p[group] == nil {
+			p[group] = make(map[string]Quota)
+		}
+		p[group][role] = quota
+	}
+	return nil
+}
+
+// ParseQuota parses requests/period[:burst], e.g. "100/1m" or "20/1s:40"
+func ParseQuota(s string) (Quota, error) {
+	rate, burst, hasBurst := strings.Cut(strings.TrimSpace(s), ":")
+	requests, period, ok := strings.Cut(rate, "/")
+	if !ok {
+		return Quota{}, fmt.Errorf("quota %q must be requests/period", s)
+	}
+
+	var q Quota
+	var err error
+	if q.Requests, err = strconv.Atoi(requests); err != nil || q.Requests <= 0 {
+		return Quota{}, fmt.Errorf("quota %q has an invalid request count", s)
+	}
+	if q.Period, err = time.ParseDuration(period); err != nil || q.Period <= 0 {
+		return Quota{}, fmt.Errorf("quota %q has an invalid period", s)
+	}
+	if hasBurst {
+		if q.Burst, err = strconv.Atoi(burst); err != nil || q.Burst <= 0 {
+			return Quota{}, fmt.Errorf("quota %q has an invalid burst", s)
+		}
+	}
+	return q, nil
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// cleanupInterval is how often PostgresStore deletes idle buckets
+const cleanupInterval = 10 * time.Minute
+
+// idleBucketTTL is how long a bucket is kept after its last request. It must
+// exceed the longest quota period.
+const idleBucketTTL = 24 * time.Hour
+
+// PostgresStore keeps buckets in the rate_limit_buckets table so that all API
+// instances share the same limits. Each Take runs in its own transaction and
+// locks the bucket row; timestamps come from the database clock so instance
+// clock skew does not matter.
+type PostgresStore struct {
+	db *sql.DB
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+// NewPostgresStore creates a store backed by db
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take implements Store
+func (s *PostgresStore) Take(ctx context.Context, key string, quota Quota) (Result, error) {
+	s.cleanup(ctx)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to begin rate limit transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		ON CONFLICT (key) DO NOTHING
+	`, key, quota.Capacity())
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create rate limit bucket: %w", err)
+	}
+
+	var tokens, elapsedSeconds float64
+	err = tx.QueryRowContext(ctx, `
+		SELECT tokens, GREATEST(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - updated_at)), 0)
+		FROM rate_limit_buckets
+		WHERE key = $1
+		FOR UPDATE
+	`, key).Scan(&tokens, &elapsedSeconds)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read rate limit bucket: %w", err)
+	}
+
+	result, tokens := take(tokens, time.Duration(elapsedSeconds*float64(time.Second)), quota)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE rate_limit_buckets SET tokens = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE key = $1
+	`, key, tokens)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to update rate limit bucket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("failed to commit rate limit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// cleanup deletes buckets that have not been used for idleBucketTTL, at most
+// once per cleanupInterval per instance
+func (s *PostgresStore) cleanup(ctx context.Context) {
+	s.mu.Lock()
+	if time.Since(s.lastCleanup) < cleanupInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastCleanup = time.Now()
+	s.mu.Unlock()
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < $1`, time.Now().Add(-idleBucketTTL)); err != nil {
+		slog.Warn("Failed to delete idle rate limit buckets", "error", err)
+	}
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Quota is a token bucket: Requests tokens are refilled evenly over Period,
+// and at most Burst tokens can accumulate. A zero Burst means Requests.
+type Quota struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Capacity returns the maximum number of tokens in the bucket
+func (q Quota) Capacity() float64 {
+	if q.Burst > 0 {
+		return float64(q.Burst)
+	}
+	return float64(q.Requests)
+}
+
+// RatePerSecond returns how many tokens are refilled per second
+func (q Quota) RatePerSecond() float64 {
+	if q.Period <= 0 {
+		return 0
+	}
+	return float64(q.Requests) / q.Period.Seconds()
+}
+
+// String formats the quota as requests/period[:burst], the format accepted
+// by ParseQuota
+func (q Quota) String() string {
+	s := fmt.Sprintf("%d/%s", q.Requests, q.Period)
+	if q.Burst > 0 {
+		s += fmt.Sprintf(":%d", q.Burst)
+	}
+	return s
+}
+
+// Result is the outcome of taking a token from a bucket
+type Result struct {
+	Allowed bool
+	// Limit is the bucket capacity
+	Limit int
+	// Remaining is the number of whole tokens left after this request
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available when the
+	// request was denied
+	RetryAfter time.Duration
+}
+
+// Store keeps token buckets. Take refills the bucket for key according to
+// the quota and consumes one token if available.
+type Store interface {
+	Take(ctx context.Context, key string, quota Quota) (Result, error)
+}
+
+// take applies the token bucket algorithm to a bucket holding tokens that
+// was last updated elapsed ago. It returns the result and the new token count.
+func take(tokens float64, elapsed time.Duration, quota Quota) (Result, float64) {
+	capacity := quota.Capacity()
+	rate := quota.RatePerSecond()
+
+	if elapsed > 0 {
+		tokens = math.Min(capacity, tokens+elapsed.Seconds()*rate)
+	}
+
+	result := Result{Limit: int(capacity)}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else if rate > 0 {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	if rate > 0 {
+		result.Reset = time.Duration((capacity - tokens) / rate * float64(time.Second))
+	}
+	return result, tokens
+}
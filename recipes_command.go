@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"foodlink_backend/config"
+	"foodlink_backend/database"
+	"foodlink_backend/features/recipes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// runRecipesCommand implements the "recipes" subcommand and returns the exit code.
+//
+//	foodlink_backend recipes seed [--config path] file.json|file.csv
+//
+// seed imports a recipe catalog file into the database the server is
+// configured for, replacing the recipes it names, as POST
+// /api/v1/admin/recipes/import does.
+func runRecipesCommand(args []string) int {
+	const usage = "usage: foodlink_backend recipes seed [--config path] file.json|file.csv"
+	if len(args) == 0 || args[0] != "seed" {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("recipes seed", flag.ContinueOnError)
+	path := flags.String("config", os.Getenv(config.FileEnv), "YAML or TOML config file (defaults to $"+config.FileEnv+")")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	file := flags.Arg(0)
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	if format != recipes.FormatJSON && format != recipes.FormatCSV {
+		fmt.Fprintln(os.Stderr, "catalog file must be .json or .csv")
+		return 2
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer f.Close()
+	inputs, err := recipes.ParseCatalog(f, format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	cfg, err := config.LoadFile(*path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	registerMigrations(cfg)
+	if err := database.Init(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to the database:", err)
+		return 1
+	}
+	defer database.Close()
+	if err := initSchema(); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to initialize schema:", err)
+		return 1
+	}
+
+	result, err := recipes.NewService().Import(inputs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to import recipes:", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "%d recipe(s) created, %d updated\n", result.Created, result.Updated)
+	for _, name := range result.Unlinked {
+		fmt.Fprintln(os.Stderr, "no food item for", name)
+	}
+	return 0
+}
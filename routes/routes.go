@@ -1,36 +1,63 @@
 package routes
 
 import (
+	"context"
+	"fmt"
 	"foodlink_backend/config"
+	"foodlink_backend/database"
+	"foodlink_backend/database/migrations"
 	_ "foodlink_backend/docs" // Import docs for Swagger
+	"foodlink_backend/events"
+	"foodlink_backend/featureflags"
 	"foodlink_backend/features/auth"
 	"foodlink_backend/features/badges"
+	"foodlink_backend/features/calendar"
 	"foodlink_backend/features/community/kitchen_events"
 	"foodlink_backend/features/community/leaderboard"
 	"foodlink_backend/features/community/leftovers"
 	"foodlink_backend/features/community/profiles"
 	"foodlink_backend/features/community/surplus"
 	"foodlink_backend/features/consumption"
+	"foodlink_backend/features/digests"
+	"foodlink_backend/features/flags"
 	"foodlink_backend/features/food_items"
 	"foodlink_backend/features/inventory"
+	"foodlink_backend/features/jobruns"
+	"foodlink_backend/features/meal_plans"
 	ngo_capacity "foodlink_backend/features/ngo/capacity"
 	ngo_feedback "foodlink_backend/features/ngo/feedback"
 	ngo_history "foodlink_backend/features/ngo/history"
 	ngo_offers "foodlink_backend/features/ngo/offers"
 	ngo_partners "foodlink_backend/features/ngo/partners"
 	ngo_pickups "foodlink_backend/features/ngo/pickups"
+	"foodlink_backend/features/notifications"
 	"foodlink_backend/features/nutrition"
+	"foodlink_backend/features/outbox"
 	"foodlink_backend/features/preferences"
 	"foodlink_backend/features/price_comparisons"
+	"foodlink_backend/features/recipes"
+	"foodlink_backend/features/resources"
 	restaurant_donations "foodlink_backend/features/restaurant/donations"
 	restaurant_inventory "foodlink_backend/features/restaurant/inventory"
 	restaurant_menu "foodlink_backend/features/restaurant/menu"
 	restaurant_preferences "foodlink_backend/features/restaurant/preferences"
 	restaurant_staff "foodlink_backend/features/restaurant/staff"
 	restaurant_surplus "foodlink_backend/features/restaurant/surplus"
+	shop_inventory "foodlink_backend/features/shop/inventory"
+	shop_surplus "foodlink_backend/features/shop/surplus"
+	"foodlink_backend/features/shopping_list"
+	"foodlink_backend/features/stream"
+	"foodlink_backend/features/uploads"
+	"foodlink_backend/features/webhooks"
 	"foodlink_backend/features/xp"
 	"foodlink_backend/handlers"
+	"foodlink_backend/health"
+	"foodlink_backend/jobs"
+	"foodlink_backend/mailer"
+	"foodlink_backend/metrics"
 	"foodlink_backend/middleware"
+	"foodlink_backend/ratelimit"
+	"log/slog"
 	"net/http"
 
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -39,8 +66,21 @@ import (
 func SetupRoutes(cfg *config.Config) http.Handler {
 	mux := http.NewServeMux()
 
+	// Render errors as problem+json, hiding causes in production
+	middleware.InitErrorRenderer(cfg)
+
 	// Health check endpoint (no middleware needed)
-	mux.HandleFunc("/health", handlers.HealthCheck)
+	mux.Handle("/health", middleware.Handle(handlers.HealthCheck))
+
+	// Liveness and readiness probes (no middleware needed)
+	registerHealthChecks()
+	mux.HandleFunc("/livez", handlers.Livez)
+	mux.HandleFunc("/readyz", handlers.Readyz)
+
+	// Prometheus metrics (no middleware needed)
+	metrics.RegisterDBStats(database.GetDB)
+	metrics.RegisterExpiredInventory(database.GetDB)
+	mux.Handle("/metrics", metrics.Handler())
 
 	// API routes
 	mux.HandleFunc("/api/v1/", handlers.APIV1)
@@ -51,6 +91,124 @@ func SetupRoutes(cfg *config.Config) http.Handler {
 	authRoutes := auth.SetupRoutes(authService, authHandler)
 	mux.Handle("/api/v1/auth/", http.StripPrefix("/api/v1/auth", authRoutes))
 
+	// Feature flags, evaluated from an in-memory copy refreshed in the background
+	flagStore := featureflags.NewPostgresStore(database.GetDB())
+	flagClient := featureflags.NewClient(flagStore, cfg.Environment)
+	if database.GetDB() != nil {
+		flagClient.Start(context.Background(), cfg.FeatureFlags.RefreshInterval)
+	}
+	flagsService := flags.NewService(flagStore, flagClient)
+	flagsHandler := flags.NewHandler(flagsService)
+	flagsRoutes := flags.SetupRoutes(flagsHandler, auth.OptionalAuth(authService))
+	mux.Handle("/api/v1/flags", http.StripPrefix("/api/v1", flagsRoutes))
+	flagsAdminRoutes := flags.SetupAdminRoutes(flagsHandler, auth.AuthMiddleware(authService), auth.RequireRole("admin"))
+	mux.Handle("/api/v1/admin/flags", http.StripPrefix("/api/v1/admin", flagsAdminRoutes))
+	mux.Handle("/api/v1/admin/flags/", http.StripPrefix("/api/v1/admin", flagsAdminRoutes))
+
+	// Domain events, delivered from the outbox to the feature subscribers
+	registerSubscribers(events.Default, cfg)
+	if db := database.GetDB(); db != nil {
+		events.NewDispatcher(db, events.Default, dispatcherConfig(cfg)).Start(context.Background())
+	}
+	outboxHandler := outbox.NewHandler(outbox.NewService())
+	outboxAdminRoutes := outbox.SetupAdminRoutes(outboxHandler, auth.AuthMiddleware(authService), auth.RequireRole("admin"))
+	mux.Handle("/api/v1/admin/events", http.StripPrefix("/api/v1/admin", outboxAdminRoutes))
+	mux.Handle("/api/v1/admin/events/", http.StripPrefix("/api/v1/admin", outboxAdminRoutes))
+
+	// Outgoing email of notifications and digests
+	mail, err := mailer.New(cfg)
+	if err != nil {
+		slog.Error("Invalid mail configuration, email will not be sent", "error", err)
+	}
+
+	// Background jobs: every instance runs workers, the leader enqueues
+	// scheduled runs
+	scheduler := jobs.NewScheduler(database.GetDB(), schedulerConfig(cfg))
+	registerJobs(scheduler, cfg, mail)
+	if cfg.Scheduler.Enabled && database.GetDB() != nil {
+		scheduler.Start(context.Background())
+		health.Register("scheduler", scheduler.Check)
+	}
+	jobRunsHandler := jobruns.NewHandler(jobruns.NewService(scheduler))
+	jobRunsAdminRoutes := jobruns.SetupAdminRoutes(jobRunsHandler, auth.AuthMiddleware(authService), auth.RequireRole("admin"))
+	mux.Handle("/api/v1/admin/jobs", http.StripPrefix("/api/v1/admin", jobRunsAdminRoutes))
+	mux.Handle("/api/v1/admin/jobs/", http.StripPrefix("/api/v1/admin", jobRunsAdminRoutes))
+
+	// Organization webhooks, queued from domain events and sent in the background
+	webhookSender := webhooks.NewSender(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks)
+	if database.GetDB() != nil {
+		webhookDeliverer := webhooks.NewDeliverer(cfg, webhookSender)
+		webhookDeliverer.Start(context.Background())
+		health.Register("webhook_deliveries", webhookDeliverer.Check)
+	}
+	webhooksService := webhooks.NewService(cfg, webhookSender)
+	webhooksHandler := webhooks.NewHandler(webhooksService)
+	webhooksRoutes := webhooks.SetupRoutes(webhooksService, webhooksHandler, auth.AuthMiddleware(authService), auth.RequireRole("restaurant", "shop", "ngo"))
+	mux.Handle("/api/v1/webhooks", http.StripPrefix("/api/v1", webhooksRoutes))
+	mux.Handle("/api/v1/webhooks/", http.StripPrefix("/api/v1", webhooksRoutes))
+
+	// Notifications of all features, read and managed by their recipient and
+	// sent by email, SMS and push in the background
+	if database.GetDB() != nil {
+		channels := notifications.NewChannels(cfg, mail, notifications.NewSMSProvider(cfg), notifications.NewPushProvider(cfg))
+		notificationDeliverer := notifications.NewDeliverer(cfg, channels)
+		notificationDeliverer.Start(context.Background())
+		health.Register("notification_deliveries", notificationDeliverer.Check)
+	}
+	notificationsService := notifications.NewService()
+	notificationsHandler := notifications.NewHandler(notificationsService)
+	notificationsRoutes := notifications.SetupRoutes(notificationsService, notificationsHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/notifications", http.StripPrefix("/api/v1", notificationsRoutes))
+	mux.Handle("/api/v1/notifications/", http.StripPrefix("/api/v1", notificationsRoutes))
+
+	// Daily and weekly email digests; the jobs are registered above
+	digestsService := digests.NewService(cfg, mail)
+	digestsHandler := digests.NewHandler(digestsService)
+	digestsRoutes := digests.SetupRoutes(digestsService, digestsHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/digests/", http.StripPrefix("/api/v1", digestsRoutes))
+
+	// iCalendar feeds of meal plans, kitchen shifts and NGO pickups, read by
+	// calendar apps through secret links
+	calendarHandler := calendar.NewHandler(calendar.NewService(cfg))
+	calendarRoutes := calendar.SetupRoutes(calendarHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/calendar/", http.StripPrefix("/api/v1", calendarRoutes))
+
+	// Uploaded images, referenced by ID from other resources and served
+	// through signed links
+	uploadStorage, err := uploads.NewStorage(cfg)
+	if err != nil {
+		slog.Error("Invalid storage configuration, uploads are disabled", "error", err)
+	}
+	uploadsService := uploads.NewService(cfg, uploadStorage)
+	uploadsHandler := uploads.NewHandler(uploadsService)
+	uploadsRoutes := uploads.SetupRoutes(uploadsService, uploadsHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/uploads", http.StripPrefix("/api/v1", uploadsRoutes))
+	mux.Handle("/api/v1/uploads/", http.StripPrefix("/api/v1", uploadsRoutes))
+
+	// Educational resources: public library and search, managed by admins
+	resourcesHandler := resources.NewHandler(resources.NewService())
+	resourcesRoutes := resources.SetupRoutes(resourcesHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/resources", http.StripPrefix("/api/v1", resourcesRoutes))
+	mux.Handle("/api/v1/resources/", http.StripPrefix("/api/v1", resourcesRoutes))
+	resourcesAdminRoutes := resources.SetupAdminRoutes(resourcesHandler, auth.AuthMiddleware(authService), auth.RequireRole("admin"))
+	mux.Handle("/api/v1/admin/resources", http.StripPrefix("/api/v1/admin", resourcesAdminRoutes))
+	mux.Handle("/api/v1/admin/resources/", http.StripPrefix("/api/v1/admin", resourcesAdminRoutes))
+
+	// Real-time updates over Server-Sent Events, fed by domain events
+	if database.GetDB() != nil {
+		stream.Default.Start(context.Background(), cfg.Database.URL)
+	}
+	streamService := stream.NewService(cfg)
+	streamHandler := stream.NewHandler(streamService, stream.Default)
+	streamRoutes := stream.SetupRoutes(streamService, streamHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/stream", http.StripPrefix("/api/v1", streamRoutes))
+
+	// Detailed dependency health (admin only)
+	mux.Handle("/health/details", middleware.Chain(
+		auth.AuthMiddleware(authService),
+		auth.RequireRole("admin"),
+	)(http.HandlerFunc(handlers.HealthDetails)))
+
 	// Food Items routes (public, but admin-only for create/update/delete)
 	foodItemsService := food_items.NewService()
 	foodItemsHandler := food_items.NewHandler(foodItemsService)
@@ -69,6 +227,30 @@ func SetupRoutes(cfg *config.Config) http.Handler {
 	consumptionRoutes := consumption.SetupRoutes(consumptionService, consumptionHandler, auth.AuthMiddleware(authService))
 	mux.Handle("/api/v1/consumption/", http.StripPrefix("/api/v1/consumption", consumptionRoutes))
 
+	// Shopping list routes (protected), shared by the household
+	shoppingListService := shopping_list.NewService()
+	shoppingListHandler := shopping_list.NewHandler(shoppingListService)
+	shoppingListRoutes := shopping_list.SetupRoutes(shoppingListService, shoppingListHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/shopping-list", http.StripPrefix("/api/v1", shoppingListRoutes))
+	mux.Handle("/api/v1/shopping-list/", http.StripPrefix("/api/v1", shoppingListRoutes))
+
+	// Meal plan routes (protected), shared by the household
+	mealPlansService := meal_plans.NewService()
+	mealPlansHandler := meal_plans.NewHandler(mealPlansService)
+	mealPlansRoutes := meal_plans.SetupRoutes(mealPlansService, mealPlansHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/meal-plans", http.StripPrefix("/api/v1", mealPlansRoutes))
+	mux.Handle("/api/v1/meal-plans/", http.StripPrefix("/api/v1", mealPlansRoutes))
+
+	// Recipe catalog: public, with use-it-up suggestions for signed-in
+	// users, managed by admins
+	recipesHandler := recipes.NewHandler(recipes.NewService())
+	recipesRoutes := recipes.SetupRoutes(recipesHandler, auth.AuthMiddleware(authService))
+	mux.Handle("/api/v1/recipes", http.StripPrefix("/api/v1", recipesRoutes))
+	mux.Handle("/api/v1/recipes/", http.StripPrefix("/api/v1", recipesRoutes))
+	recipesAdminRoutes := recipes.SetupAdminRoutes(recipesHandler, auth.AuthMiddleware(authService), auth.RequireRole("admin"))
+	mux.Handle("/api/v1/admin/recipes", http.StripPrefix("/api/v1/admin", recipesAdminRoutes))
+	mux.Handle("/api/v1/admin/recipes/", http.StripPrefix("/api/v1/admin", recipesAdminRoutes))
+
 	// Preferences routes (protected)
 	preferencesService := preferences.NewService()
 	preferencesHandler := preferences.NewHandler(preferencesService)
@@ -118,7 +300,7 @@ func SetupRoutes(cfg *config.Config) http.Handler {
 	mux.Handle("/api/v1/community/kitchen-events/", http.StripPrefix("/api/v1/community/kitchen-events", kitchenEventsRoutes))
 
 	// Community Leaderboard & Impact routes (protected)
-	leaderboardService := leaderboard.NewService()
+	leaderboardService := leaderboard.NewService(flagClient)
 	leaderboardHandler := leaderboard.NewHandler(leaderboardService)
 	leaderboardRoutes := leaderboard.SetupRoutes(leaderboardService, leaderboardHandler, auth.AuthMiddleware(authService))
 	mux.Handle("/api/v1/community/leaderboard", http.StripPrefix("/api/v1/community", leaderboardRoutes))
@@ -168,8 +350,14 @@ func SetupRoutes(cfg *config.Config) http.Handler {
 	restaurantPreferencesRoutes := restaurant_preferences.SetupRoutes(restaurantPreferencesService, restaurantPreferencesHandler, auth.AuthMiddleware(authService))
 	mux.Handle("/api/v1/restaurant/preferences/", http.StripPrefix("/api/v1/restaurant/preferences", restaurantPreferencesRoutes))
 
+	// Shop Inventory routes (protected, behind the shop_module flag)
+	shopInventoryService := shop_inventory.NewService()
+	shopInventoryHandler := shop_inventory.NewHandler(shopInventoryService)
+	shopInventoryRoutes := shop_inventory.SetupRoutes(shopInventoryService, shopInventoryHandler, auth.AuthMiddleware(authService), middleware.RequireFlag(flagClient, featureflags.ShopModule))
+	mux.Handle("/api/v1/shop/inventory/", http.StripPrefix("/api/v1/shop/inventory", shopInventoryRoutes))
+
 	// NGO Capacity Settings routes (protected)
-	ngoCapacityService := ngo_capacity.NewService()
+	ngoCapacityService := ngo_capacity.NewService(cfg)
 	ngoCapacityHandler := ngo_capacity.NewHandler(ngoCapacityService)
 	ngoCapacityRoutes := ngo_capacity.SetupRoutes(ngoCapacityService, ngoCapacityHandler, auth.AuthMiddleware(authService))
 	mux.Handle("/api/v1/ngo/capacity/", http.StripPrefix("/api/v1/ngo/capacity", ngoCapacityRoutes))
@@ -234,14 +422,141 @@ func SetupRoutes(cfg *config.Config) http.Handler {
 		http.Redirect(w, r, "/swagger/index.html", http.StatusMovedPermanently)
 	})
 
-	// Apply middleware chain
-	handler := middleware.Chain(
-		middleware.RecoverPanic,
+	// Apply middleware chain. The request ID comes first so that the error
+	// rendered for a recovered panic carries it too.
+	chain := []func(http.Handler) http.Handler{
 		middleware.RequestID,
-		middleware.Logging,
-		middleware.CORS,
-		middleware.ErrorHandler,
-	)(mux)
+		middleware.RecoverPanic,
+		middleware.Tracing(mux),
+		middleware.Logging(mux),
+		middleware.Metrics(mux),
+		middleware.SecurityHeaders(cfg.HTTP.HSTSEnabled),
+		middleware.CORSWithConfig(corsConfig(cfg)),
+	}
+	if cfg.RateLimit.Enabled {
+		chain = append(chain, middleware.RateLimit(newRateLimiter(cfg), cfg.HTTP.TrustProxy))
+	}
+	chain = append(chain, middleware.BodyLimit(bodyLimits(cfg)))
+	handler := middleware.Chain(chain...)(mux)
 
 	return handler
 }
+
+// newRateLimiter builds the rate limiter from configuration. The Postgres
+// store is used only when a database connection is available.
+func newRateLimiter(cfg *config.Config) *ratelimit.Limiter {
+	policy := ratelimit.DefaultPolicy()
+	if err := policy.Override(cfg.RateLimit.Quotas); err != nil {
+		slog.Warn("Invalid RATE_LIMIT_QUOTAS, using default quotas", "error", err)
+		policy = ratelimit.DefaultPolicy()
+	}
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimit.Store == "postgres" {
+		if db := database.GetDB(); db != nil {
+			store = ratelimit.NewPostgresStore(db)
+		} else {
+			slog.Warn("RATE_LIMIT_STORE is postgres but the database is unavailable, using in-memory store")
+		}
+	}
+
+	return ratelimit.NewLimiter(store, policy)
+}
+
+// registerSubscribers subscribes the features that react to domain events
+func registerSubscribers(bus *events.Bus, cfg *config.Config) {
+	leftovers.RegisterSubscribers(bus)
+	surplus.RegisterSubscribers(bus)
+	ngo_offers.RegisterSubscribers(bus)
+	ngo_history.RegisterSubscribers(bus)
+	restaurant_donations.RegisterSubscribers(bus)
+	restaurant_inventory.RegisterSubscribers(bus)
+	badges.RegisterSubscribers(bus)
+	webhooks.RegisterSubscribers(bus)
+	stream.RegisterSubscribers(bus)
+	notifications.RegisterSubscribers(bus, cfg)
+}
+
+// dispatcherConfig builds the outbox dispatcher settings from configuration
+func dispatcherConfig(cfg *config.Config) events.DispatcherConfig {
+	return events.DispatcherConfig{
+		Interval:    cfg.Events.DispatchInterval,
+		BatchSize:   cfg.Events.BatchSize,
+		MaxAttempts: cfg.Events.MaxAttempts,
+		Lease:       cfg.Events.Lease,
+		Retention:   cfg.Events.Retention,
+	}
+}
+
+// registerJobs registers the features' background jobs
+func registerJobs(scheduler *jobs.Scheduler, cfg *config.Config, mail mailer.Mailer) {
+	inventory.RegisterJobs(scheduler)
+	surplus.RegisterJobs(scheduler)
+	ngo_offers.RegisterJobs(scheduler)
+	ngo_pickups.RegisterJobs(scheduler)
+	restaurant_inventory.RegisterJobs(scheduler)
+	shop_inventory.RegisterJobs(scheduler)
+	shop_surplus.RegisterJobs(scheduler)
+	webhooks.RegisterJobs(scheduler, cfg)
+	stream.RegisterJobs(scheduler, cfg)
+	notifications.RegisterJobs(scheduler, cfg)
+	digests.RegisterJobs(scheduler, cfg, mail)
+}
+
+// schedulerConfig builds the job scheduler settings from configuration
+func schedulerConfig(cfg *config.Config) jobs.Config {
+	return jobs.Config{
+		PollInterval: cfg.Scheduler.PollInterval,
+		Concurrency:  cfg.Scheduler.Concurrency,
+		Timeout:      cfg.Scheduler.JobTimeout,
+		Retention:    cfg.Scheduler.Retention,
+	}
+}
+
+// corsConfig builds the CORS configuration from the environment
+func corsConfig(cfg *config.Config) middleware.CORSConfig {
+	cors := middleware.DefaultCORSConfig()
+	cors.AllowedOrigins = cfg.CORS.AllowedOrigins
+	cors.AllowedMethods = cfg.CORS.AllowedMethods
+	cors.AllowedHeaders = cfg.CORS.AllowedHeaders
+	cors.AllowCredentials = cfg.CORS.AllowCredentials
+	return cors
+}
+
+// bodyLimits allows larger bodies on the upload endpoint and on those still
+// accepting inline images
+func bodyLimits(cfg *config.Config) middleware.BodyLimits {
+	limits := middleware.BodyLimits{
+		Default: cfg.HTTP.MaxBodyBytes,
+		Routes:  make(map[string]int64),
+	}
+	for _, prefix := range []string{
+		"/api/v1/uploads",
+		"/api/v1/community/kitchen-events/",
+		"/api/v1/ngo/offers/",
+		"/api/v1/ngo/history/",
+	} {
+		limits.Routes[prefix] = cfg.HTTP.MaxUploadBodyBytes
+	}
+	return limits
+}
+
+// registerHealthChecks registers the readiness checks of the core
+// dependencies; background workers register their own
+func registerHealthChecks() {
+	health.Register("database", database.Ping)
+	health.Register("migrations", func(ctx context.Context) error {
+		db := database.GetDB()
+		if db == nil {
+			return fmt.Errorf("database connection is not initialized")
+		}
+		pending, err := migrations.PendingMigrations(db)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migrations, first is %d (%s)", len(pending), pending[0].Version, pending[0].Name)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"foodlink_backend/config"
+	"foodlink_backend/database"
+	"foodlink_backend/database/migrations"
+	"foodlink_backend/features/resources"
+	"foodlink_backend/features/shopping_list"
+	"foodlink_backend/features/uploads"
+)
+
+// registerMigrations registers the migrations of every feature. It must run
+// once, before migrations are run or checked.
+func registerMigrations(cfg *config.Config) {
+	uploads.RegisterMigrations(cfg)
+	resources.RegisterMigrations()
+	shopping_list.RegisterMigrations()
+}
+
+// initSchema runs the pending migrations, which bring the tables of earlier
+// versions up to date, then applies schema.sql
+func initSchema() error {
+	if err := migrations.RunMigrations(database.GetDB()); err != nil {
+		return err
+	}
+	return database.InitSchema()
+}
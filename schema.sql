@@ -76,9 +76,17 @@ CREATE TABLE IF NOT EXISTS shopping_list_items (
     unit VARCHAR(50),
     category VARCHAR(100),
     priority VARCHAR(20) DEFAULT 'medium' CHECK (priority IN ('low', 'medium', 'high')),
+    aisle VARCHAR(100),
     purchased BOOLEAN DEFAULT FALSE,
     purchased_at TIMESTAMP WITH TIME ZONE,
+    purchased_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    inventory_item_id UUID REFERENCES inventory_items(id) ON DELETE SET NULL,
     estimated_price DECIMAL(10, 2),
+    -- store is where the item is cheapest, by price comparisons
+    store VARCHAR(255),
+    -- version is incremented by every change; clients send it back in
+    -- If-Match so concurrent edits by household members are not lost
+    version INTEGER NOT NULL DEFAULT 1,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -98,6 +106,47 @@ CREATE TABLE IF NOT EXISTS meal_plans (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Recipes catalog, seeded from a JSON or CSV file; name is unique so
+-- seeding again updates recipes
+CREATE TABLE IF NOT EXISTS recipes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description TEXT,
+    cuisine VARCHAR(100),
+    meal_type VARCHAR(20) CHECK (meal_type IN ('breakfast', 'lunch', 'dinner', 'snack')),
+    -- diets the recipe fits: vegan, vegetarian, halal, keto, low-sodium,
+    -- and tags such as high-protein
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    prep_minutes INTEGER,
+    servings INTEGER NOT NULL DEFAULT 2,
+    instructions TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Recipe ingredients, linked to the reference food item of the same name
+CREATE TABLE IF NOT EXISTS recipe_ingredients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    recipe_id UUID NOT NULL REFERENCES recipes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    quantity DECIMAL(10, 2),
+    unit VARCHAR(50),
+    food_item_id UUID REFERENCES food_items(id) ON DELETE SET NULL,
+    optional BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE(recipe_id, position)
+);
+
+-- Resources search document: title first, then tags and category, then
+-- description. array_to_string is not immutable, so generated columns call
+-- this wrapper.
+CREATE OR REPLACE FUNCTION resource_search_vector(title TEXT, description TEXT, category TEXT, tags TEXT[])
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(array_to_string(tags, ' '), '') || ' ' || COALESCE(category, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
 -- Resources table
 CREATE TABLE IF NOT EXISTS resources (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -105,7 +154,8 @@ CREATE TABLE IF NOT EXISTS resources (
     description TEXT,
     category VARCHAR(100) NOT NULL,
     url TEXT,
-    tags TEXT[],
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    search_vector tsvector GENERATED ALWAYS AS (resource_search_vector(title, description, category, tags)) STORED,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -117,9 +167,11 @@ CREATE TABLE IF NOT EXISTS uploads (
     file_name VARCHAR(255) NOT NULL,
     file_type VARCHAR(100) NOT NULL,
     file_size BIGINT NOT NULL,
-    data TEXT, -- base64 encoded
-    associated_type VARCHAR(50) CHECK (associated_type IN ('inventory', 'log', 'profile')),
-    associated_id UUID,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    checksum VARCHAR(64) NOT NULL, -- hex SHA-256 of the stored file
+    storage_key TEXT NOT NULL,
+    thumbnail_key TEXT,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -239,7 +291,7 @@ CREATE TABLE IF NOT EXISTS community_surplus_posts (
     pickup_window JSONB NOT NULL, -- {start: string, end: string}
     pickup_location TEXT NOT NULL,
     distance_km DECIMAL(10, 2),
-    image TEXT,
+    image_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     status VARCHAR(20) DEFAULT 'available' CHECK (status IN ('available', 'claimed', 'expired')),
     expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
@@ -281,7 +333,7 @@ CREATE TABLE IF NOT EXISTS leftover_items (
     allergens TEXT[],
     pickup_window TEXT NOT NULL,
     status VARCHAR(20) DEFAULT 'available' CHECK (status IN ('available', 'claimed')),
-    image TEXT,
+    image_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -385,7 +437,7 @@ CREATE TABLE IF NOT EXISTS restaurant_inventory_items (
     batch_code VARCHAR(100),
     alert_tags TEXT[],
     status VARCHAR(20) DEFAULT 'normal' CHECK (status IN ('normal', 'expiring', 'overstocked')),
-    invoice_image TEXT,
+    invoice_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -417,7 +469,7 @@ CREATE TABLE IF NOT EXISTS restaurant_surplus_items (
     storage_type VARCHAR(20) NOT NULL CHECK (storage_type IN ('fresh', 'chilled', 'frozen')),
     pickup_window JSONB NOT NULL, -- {start: string, end: string}
     tags TEXT[],
-    image TEXT,
+    image_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     assigned_to VARCHAR(50) CHECK (assigned_to IN ('ngo', 'kitchen')),
     recipient_name VARCHAR(255),
     status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'picked-up', 'expired')),
@@ -626,7 +678,7 @@ CREATE TABLE IF NOT EXISTS ngo_feedback_entries (
     rating INTEGER CHECK (rating >= 1 AND rating <= 5),
     comment TEXT NOT NULL,
     tags TEXT[] CHECK (tags <@ ARRAY['quality', 'temperature', 'packaging', 'late', 'positive']),
-    photo TEXT,
+    photo_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'acknowledged', 'resolved')),
     corrective_action TEXT,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
@@ -640,7 +692,7 @@ CREATE TABLE IF NOT EXISTS ngo_impact_stories (
     title VARCHAR(255) NOT NULL,
     excerpt TEXT NOT NULL,
     beneficiary_type VARCHAR(100) NOT NULL,
-    image TEXT,
+    photo_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     metrics JSONB NOT NULL, -- {meals, families, smiles}
     published_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -676,7 +728,7 @@ CREATE TABLE IF NOT EXISTS shop_inventory_items (
     expiry_date TIMESTAMP WITH TIME ZONE NOT NULL,
     storage_type VARCHAR(20) NOT NULL CHECK (storage_type IN ('frozen', 'chilled', 'ambient')),
     shelf_location VARCHAR(100),
-    image_data TEXT,
+    image_upload_id UUID REFERENCES uploads(id) ON DELETE SET NULL,
     markdown_status VARCHAR(20) DEFAULT 'none' CHECK (markdown_status IN ('none', 'scheduled', 'active')),
     surplus_eligible BOOLEAN DEFAULT FALSE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
@@ -804,6 +856,217 @@ CREATE TABLE IF NOT EXISTS shop_profiles (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- ============================================================================
+-- PLATFORM
+-- ============================================================================
+
+-- Rate limit token buckets, shared by all API instances
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    key VARCHAR(255) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Feature flags with targeting rules; empty arrays match everyone
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(100) PRIMARY KEY,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    roles TEXT[] NOT NULL DEFAULT '{}',
+    org_ids TEXT[] NOT NULL DEFAULT '{}',
+    percentage SMALLINT NOT NULL DEFAULT 100 CHECK (percentage BETWEEN 0 AND 100),
+    environments TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Flags the application checks, off until an admin enables them
+INSERT INTO feature_flags (key, description) VALUES
+    ('matching_engine', 'Donation matching engine'),
+    ('leaderboard_v2', 'Compute community leaderboards from live activity'),
+    ('shop_module', 'Shop API')
+ON CONFLICT (key) DO NOTHING;
+
+-- Transactional outbox: one row per event and subscriber, written in the
+-- same transaction as the state change and delivered by the dispatcher
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    subscriber VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(event_id, subscriber)
+);
+
+-- Background job runs: one-off runs and the runs of cron schedules, which
+-- carry their fire time so each fire time is enqueued once
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    scheduled_at TIMESTAMP WITH TIME ZONE,
+    previous_scheduled_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(name, scheduled_at)
+);
+
+-- Outbound webhook subscriptions of restaurant, shop and NGO accounts; an
+-- empty event_types array receives every supported event type
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    description VARCHAR(255),
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    disabled_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Webhook delivery log: one row per event and subscription, with the outcome
+-- of the latest attempt
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivering', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    response_status INTEGER,
+    response_body TEXT,
+    last_error TEXT,
+    duration_ms INTEGER,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(subscription_id, event_id)
+);
+
+-- Server-Sent Events sent to users, kept briefly for Last-Event-ID resume
+CREATE TABLE IF NOT EXISTS stream_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(100) NOT NULL,
+    data JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Notification delivery settings: time zone, quiet hours ("HH:MM" local
+-- time) and the phone number for SMS
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    quiet_hours_start VARCHAR(5),
+    quiet_hours_end VARCHAR(5),
+    phone VARCHAR(20),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Channels each notification source and type is sent on besides in-app
+CREATE TABLE IF NOT EXISTS notification_channel_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('community', 'ngo')),
+    type VARCHAR(50) NOT NULL,
+    channels TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, source, type)
+);
+
+-- Devices registered for push notifications
+CREATE TABLE IF NOT EXISTS notification_push_tokens (
+    token TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(20) NOT NULL CHECK (platform IN ('ios', 'android', 'web')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Notifications sent by email, SMS or push: one row per notification and
+-- channel, or per digest merging batched rows
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    notification_id UUID,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'sms', 'push')),
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'sent', 'failed', 'batched', 'digested')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    digest_id UUID REFERENCES notification_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE(notification_id, channel)
+);
+
+-- Email digest subscriptions; users without a row get their role's default
+CREATE TABLE IF NOT EXISTS email_digest_subscriptions (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly')),
+    subscribed BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, frequency)
+);
+
+-- Email digests sent, one per user and scheduled run. total_xp is the
+-- user's XP when the digest was sent, the baseline of the next one.
+CREATE TABLE IF NOT EXISTS email_digest_sends (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly')),
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_xp INTEGER,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, frequency, period_end)
+);
+
+-- iCalendar feeds users subscribe to; only the SHA-256 of the secret token
+-- in the feed URL is kept. Revoked feeds stop serving.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('meal-plans', 'kitchen-events', 'pickups')),
+    name VARCHAR(100),
+    token_hash TEXT NOT NULL UNIQUE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- SEQUENCE of each calendar event by UID, bumped when the fingerprint of
+-- its details changes between two feed renders
+CREATE TABLE IF NOT EXISTS calendar_event_sequences (
+    uid TEXT PRIMARY KEY,
+    sequence INTEGER NOT NULL DEFAULT 0,
+    fingerprint TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
 -- ============================================================================
 -- INDEXES FOR PERFORMANCE
 -- ============================================================================
@@ -833,6 +1096,18 @@ CREATE INDEX IF NOT EXISTS idx_shopping_purchased ON shopping_list_items(purchas
 CREATE INDEX IF NOT EXISTS idx_meal_plans_user_date ON meal_plans(user_id, date);
 CREATE INDEX IF NOT EXISTS idx_meal_plans_household_id ON meal_plans(household_id);
 
+-- Uploads indexes
+CREATE INDEX IF NOT EXISTS idx_uploads_user_created ON uploads(user_id, created_at DESC);
+
+-- Resources indexes
+CREATE INDEX IF NOT EXISTS idx_resources_search ON resources USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_resources_tags ON resources USING GIN(tags);
+CREATE INDEX IF NOT EXISTS idx_resources_category ON resources(category);
+
+-- Recipes indexes
+CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_recipe_id ON recipe_ingredients(recipe_id);
+CREATE INDEX IF NOT EXISTS idx_recipe_ingredients_food_item_id ON recipe_ingredients(food_item_id);
+
 -- Badges indexes
 CREATE INDEX IF NOT EXISTS idx_badges_user_id ON badges(user_id);
 CREATE INDEX IF NOT EXISTS idx_badges_badge_id ON badges(badge_id);
@@ -870,6 +1145,21 @@ CREATE INDEX IF NOT EXISTS idx_shop_inventory_barcode ON shop_inventory_items(ba
 CREATE INDEX IF NOT EXISTS idx_shop_inventory_expiry_date ON shop_inventory_items(expiry_date);
 CREATE INDEX IF NOT EXISTS idx_shop_surplus_user_id ON shop_surplus_items(user_id);
 
+-- Platform indexes
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
+CREATE INDEX IF NOT EXISTS idx_event_outbox_status_next_attempt ON event_outbox(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_job_runs_status_run_at ON job_runs(status, run_at);
+CREATE INDEX IF NOT EXISTS idx_job_runs_name_created_at ON job_runs(name, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_user_id ON webhook_subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status_next_attempt ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_created_at ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_stream_events_user_id_id ON stream_events(user_id, id);
+CREATE INDEX IF NOT EXISTS idx_stream_events_created_at ON stream_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_notification_push_tokens_user_id ON notification_push_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_status_next_attempt ON notification_deliveries(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_user_channel_created_at ON notification_deliveries(user_id, channel, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_calendar_feeds_user_id ON calendar_feeds(user_id);
+
 -- ============================================================================
 -- TRIGGERS FOR AUTO-UPDATING updated_at
 -- ============================================================================
@@ -884,98 +1174,138 @@ END;
 $$ language 'plpgsql';
 
 -- Apply triggers to all tables with updated_at column
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
 CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_food_items_updated_at ON food_items;
 CREATE TRIGGER update_food_items_updated_at BEFORE UPDATE ON food_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_inventory_items_updated_at ON inventory_items;
 CREATE TRIGGER update_inventory_items_updated_at BEFORE UPDATE ON inventory_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_consumption_logs_updated_at ON consumption_logs;
 CREATE TRIGGER update_consumption_logs_updated_at BEFORE UPDATE ON consumption_logs
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_shopping_list_items_updated_at ON shopping_list_items;
 CREATE TRIGGER update_shopping_list_items_updated_at BEFORE UPDATE ON shopping_list_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_meal_plans_updated_at ON meal_plans;
 CREATE TRIGGER update_meal_plans_updated_at BEFORE UPDATE ON meal_plans
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_resources_updated_at ON resources;
 CREATE TRIGGER update_resources_updated_at BEFORE UPDATE ON resources
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_recipes_updated_at ON recipes;
+CREATE TRIGGER update_recipes_updated_at BEFORE UPDATE ON recipes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_uploads_updated_at ON uploads;
 CREATE TRIGGER update_uploads_updated_at BEFORE UPDATE ON uploads
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_user_xp_updated_at ON user_xp;
 CREATE TRIGGER update_user_xp_updated_at BEFORE UPDATE ON user_xp
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_family_preferences_updated_at ON family_preferences;
 CREATE TRIGGER update_family_preferences_updated_at BEFORE UPDATE ON family_preferences
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_nutrition_data_updated_at ON nutrition_data;
 CREATE TRIGGER update_nutrition_data_updated_at BEFORE UPDATE ON nutrition_data
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_price_comparisons_updated_at ON price_comparisons;
 CREATE TRIGGER update_price_comparisons_updated_at BEFORE UPDATE ON price_comparisons
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_community_surplus_posts_updated_at ON community_surplus_posts;
 CREATE TRIGGER update_community_surplus_posts_updated_at BEFORE UPDATE ON community_surplus_posts
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_leftover_items_updated_at ON leftover_items;
 CREATE TRIGGER update_leftover_items_updated_at BEFORE UPDATE ON leftover_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_community_kitchen_events_updated_at ON community_kitchen_events;
 CREATE TRIGGER update_community_kitchen_events_updated_at BEFORE UPDATE ON community_kitchen_events
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_community_leaderboard_updated_at ON community_leaderboard;
 CREATE TRIGGER update_community_leaderboard_updated_at BEFORE UPDATE ON community_leaderboard
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_community_impact_updated_at ON community_impact;
 CREATE TRIGGER update_community_impact_updated_at BEFORE UPDATE ON community_impact
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_community_profiles_updated_at ON community_profiles;
 CREATE TRIGGER update_community_profiles_updated_at BEFORE UPDATE ON community_profiles
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_restaurant_inventory_items_updated_at ON restaurant_inventory_items;
 CREATE TRIGGER update_restaurant_inventory_items_updated_at BEFORE UPDATE ON restaurant_inventory_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_restaurant_menu_items_updated_at ON restaurant_menu_items;
 CREATE TRIGGER update_restaurant_menu_items_updated_at BEFORE UPDATE ON restaurant_menu_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_restaurant_surplus_items_updated_at ON restaurant_surplus_items;
 CREATE TRIGGER update_restaurant_surplus_items_updated_at BEFORE UPDATE ON restaurant_surplus_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_restaurant_impact_metrics_updated_at ON restaurant_impact_metrics;
 CREATE TRIGGER update_restaurant_impact_metrics_updated_at BEFORE UPDATE ON restaurant_impact_metrics
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_restaurant_preferences_updated_at ON restaurant_preferences;
 CREATE TRIGGER update_restaurant_preferences_updated_at BEFORE UPDATE ON restaurant_preferences
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_ngo_capacity_settings_updated_at ON ngo_capacity_settings;
 CREATE TRIGGER update_ngo_capacity_settings_updated_at BEFORE UPDATE ON ngo_capacity_settings
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_ngo_donation_offers_updated_at ON ngo_donation_offers;
 CREATE TRIGGER update_ngo_donation_offers_updated_at BEFORE UPDATE ON ngo_donation_offers
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_ngo_pickup_schedules_updated_at ON ngo_pickup_schedules;
 CREATE TRIGGER update_ngo_pickup_schedules_updated_at BEFORE UPDATE ON ngo_pickup_schedules
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_ngo_partner_profiles_updated_at ON ngo_partner_profiles;
 CREATE TRIGGER update_ngo_partner_profiles_updated_at BEFORE UPDATE ON ngo_partner_profiles
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_ngo_feedback_entries_updated_at ON ngo_feedback_entries;
 CREATE TRIGGER update_ngo_feedback_entries_updated_at BEFORE UPDATE ON ngo_feedback_entries
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_shop_inventory_items_updated_at ON shop_inventory_items;
 CREATE TRIGGER update_shop_inventory_items_updated_at BEFORE UPDATE ON shop_inventory_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_shop_surplus_items_updated_at ON shop_surplus_items;
 CREATE TRIGGER update_shop_surplus_items_updated_at BEFORE UPDATE ON shop_surplus_items
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_shop_analytics_records_updated_at ON shop_analytics_records;
 CREATE TRIGGER update_shop_analytics_records_updated_at BEFORE UPDATE ON shop_analytics_records
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_shop_profiles_updated_at ON shop_profiles;
 CREATE TRIGGER update_shop_profiles_updated_at BEFORE UPDATE ON shop_profiles
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_feature_flags_updated_at ON feature_flags;
+CREATE TRIGGER update_feature_flags_updated_at BEFORE UPDATE ON feature_flags
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,214 @@
+package utils
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperrors "foodlink_backend/errors"
+
+	"github.com/google/uuid"
+)
+
+// Batch execution modes
+const (
+	// BatchModeTransactional commits only when every operation succeeds
+	BatchModeTransactional = "transactional"
+	// BatchModeBestEffort commits the operations that succeeded and reports the rest
+	BatchModeBestEffort = "best_effort"
+)
+
+// Batch operation types
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// Per-item batch result statuses
+const (
+	BatchStatusCreated    = "created"
+	BatchStatusUpdated    = "updated"
+	BatchStatusDeleted    = "deleted"
+	BatchStatusFailed     = "failed"
+	BatchStatusRolledBack = "rolled_back"
+)
+
+// MaxBatchSize is the maximum number of operations accepted in one batch
+const MaxBatchSize = 500
+
+// BatchRequest represents a bulk create/update/delete request
+type BatchRequest struct {
+	Mode       string           `json:"mode,omitempty" validate:"omitempty,oneof=transactional best_effort"`
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,max=500,dive"`
+}
+
+// BatchOperation is a single operation in a batch. Data holds the same body
+// as the resource's create or update endpoint; ID is required for update and delete.
+type BatchOperation struct {
+	Op   string          `json:"op" validate:"required,oneof=create update delete"`
+	ID   *uuid.UUID      `json:"id,omitempty"`
+	Data json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
+
+// Decode unmarshals the operation data into v and validates it
+func (op *BatchOperation) Decode(v interface{}) error {
+	if len(op.Data) == 0 {
+		return ValidationErrors{"data is required"}
+	}
+	if err := json.Unmarshal(op.Data, v); err != nil {
+		return ValidationErrors{"data is invalid: " + err.Error()}
+	}
+	if validationErrors := ValidateStruct(v); len(validationErrors) > 0 {
+		return ValidationErrors(validationErrors)
+	}
+	return nil
+}
+
+// BatchItemResult is the outcome of the operation at Index in the request
+type BatchItemResult struct {
+	Index  int         `json:"index"`
+	Op     string      `json:"op"`
+	ID     *uuid.UUID  `json:"id,omitempty"`
+	Status string      `json:"status"`
+	Code   int         `json:"code,omitempty"`
+	Errors []string    `json:"errors,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+}
+
+// BatchResult summarises a batch run
+type BatchResult struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Results   []BatchItemResult `json:"results"`
+}
+
+// BatchFunc applies a single operation using tx and returns the affected record
+type BatchFunc func(tx *sql.Tx, op *BatchOperation) (interface{}, error)
+
+// RunBatch applies every operation in req inside one transaction. Each
+// operation runs under its own savepoint so a failure only undoes that
+// operation. In transactional mode any failure rolls back the whole batch;
+// in best-effort mode the successful operations are committed.
+func RunBatch(ctx context.Context, db *sql.DB, req *BatchRequest, apply BatchFunc) (*BatchResult, error) {
+	if db == nil {
+		return nil, apperrors.ErrDatabase
+	}
+	if validationErrors := ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, apperrors.NewAppErrorWithErr(apperrors.ErrValidationFailed.Code, "Validation failed: "+validationErrors[0], ValidationErrors(validationErrors))
+	}
+
+	mode := req.Mode
+	if mode == "" {
+		mode = BatchModeTransactional
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrDatabase)
+	}
+	defer tx.Rollback()
+
+	result := &BatchResult{
+		Mode:    mode,
+		Results: make([]BatchItemResult, len(req.Operations)),
+	}
+
+	for i := range req.Operations {
+		op := &req.Operations[i]
+		item := BatchItemResult{Index: i, Op: op.Op, ID: op.ID}
+
+		if op.Op != BatchOpCreate && op.ID == nil {
+			item.Status = BatchStatusFailed
+			item.Code = http.StatusBadRequest
+			item.Errors = []string{"id is required"}
+			result.Failed++
+			result.Results[i] = item
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_item"); err != nil {
+			return nil, apperrors.WrapError(err, apperrors.ErrDatabase)
+		}
+
+		data, err := apply(tx, op)
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_item"); rbErr != nil {
+				return nil, apperrors.WrapError(rbErr, apperrors.ErrDatabase)
+			}
+			item.Status = BatchStatusFailed
+			item.Code, item.Errors = batchErrorDetails(err)
+			result.Failed++
+		} else {
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_item"); err != nil {
+				return nil, apperrors.WrapError(err, apperrors.ErrDatabase)
+			}
+			item.Status = batchSuccessStatus(op.Op)
+			item.Data = data
+			result.Succeeded++
+		}
+		result.Results[i] = item
+	}
+
+	if mode == BatchModeTransactional && result.Failed > 0 {
+		for i := range result.Results {
+			if result.Results[i].Status != BatchStatusFailed {
+				result.Results[i].Status = BatchStatusRolledBack
+				result.Results[i].Data = nil
+			}
+		}
+		result.Succeeded = 0
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.WrapError(err, apperrors.ErrDatabase)
+	}
+	result.Committed = true
+
+	return result, nil
+}
+
+// BatchResponse writes a batch result: 200 when every operation succeeded,
+// 207 when a best-effort batch partially succeeded and 422 when the batch
+// was rolled back
+func BatchResponse(w http.ResponseWriter, result *BatchResult) {
+	switch {
+	case result.Failed == 0:
+		OKResponse(w, "Batch completed successfully", result)
+	case result.Committed:
+		SuccessResponse(w, http.StatusMultiStatus, fmt.Sprintf("Batch completed with %d failed operations", result.Failed), result)
+	default:
+		ErrorResponse(w, http.StatusUnprocessableEntity, fmt.Sprintf("Batch rolled back: %d failed operations", result.Failed), result)
+	}
+}
+
+// batchSuccessStatus maps an operation type to its success status
+func batchSuccessStatus(op string) string {
+	switch op {
+	case BatchOpCreate:
+		return BatchStatusCreated
+	case BatchOpUpdate:
+		return BatchStatusUpdated
+	default:
+		return BatchStatusDeleted
+	}
+}
+
+// batchErrorDetails extracts the status code and messages reported for a failed operation
+func batchErrorDetails(err error) (int, []string) {
+	if validationErrors, ok := err.(ValidationErrors); ok {
+		return http.StatusBadRequest, validationErrors
+	}
+	appErr := apperrors.Resolve(err)
+	var validationErrors ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return appErr.Code, validationErrors
+	}
+	return appErr.Code, []string{appErr.Message}
+}
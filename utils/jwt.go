@@ -13,7 +13,7 @@ var jwtSecret []byte
 
 // InitJWT initializes JWT with secret from config
 func InitJWT(cfg *config.Config) {
-	jwtSecret = []byte(cfg.JWTSecret)
+	jwtSecret = []byte(cfg.Auth.JWTSecret)
 }
 
 // Claims represents JWT claims
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"reflect"
+)
+
+// MergePatchContentType is the media type for JSON Merge Patch documents (RFC 7396)
+const MergePatchContentType = "application/merge-patch+json"
+
+// IsMergePatch reports whether the request body is a JSON Merge Patch document
+func IsMergePatch(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == MergePatchContentType
+}
+
+// ApplyMergePatch applies a JSON Merge Patch document to target, which must be
+// a pointer to a struct. Members present in the patch replace the current
+// values, members set to null are removed (reset to their zero value) and
+// members absent from the patch are left unchanged.
+func ApplyMergePatch(target interface{}, patch []byte) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return errors.New("merge patch target must be a non-nil pointer")
+	}
+
+	var patchDoc interface{}
+	if err := decodeJSON(patch, &patchDoc); err != nil {
+		return err
+	}
+	patchObj, ok := patchDoc.(map[string]interface{})
+	if !ok {
+		return errors.New("merge patch document must be a JSON object")
+	}
+
+	current, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+	var currentDoc interface{}
+	if err := decodeJSON(current, &currentDoc); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(currentDoc, patchObj))
+	if err != nil {
+		return err
+	}
+
+	// Decode into a zeroed value so removed members don't keep their old values
+	elem := value.Elem()
+	elem.Set(reflect.Zero(elem.Type()))
+	return json.Unmarshal(merged, target)
+}
+
+// mergePatch implements the MergePatch algorithm from RFC 7396 section 2
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{})
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = mergePatch(targetObj[key], value)
+	}
+
+	return targetObj
+}
+
+// decodeJSON decodes data keeping numbers as json.Number to avoid precision loss
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,22 @@
+package utils
+
+import "strings"
+
+// NameKey is the name ingredients, food items, stock and prices are matched
+// on: lowercased, single-spaced and singular, so "Tomatoes" matches "tomato"
+func NameKey(name string) string {
+	key := strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	switch {
+	case strings.HasSuffix(key, "oes"):
+		return strings.TrimSuffix(key, "es")
+	case strings.HasSuffix(key, "s") && !strings.HasSuffix(key, "ss"):
+		return strings.TrimSuffix(key, "s")
+	}
+	return key
+}
+
+// NameVariants returns the lowercased forms of a name key that are stored
+// names of the same thing
+func NameVariants(key string) []string {
+	return []string{key, key + "s", key + "es"}
+}
@@ -62,33 +62,3 @@ func OKResponse(w http.ResponseWriter, message string, data interface{}) {
 func NoContentResponse(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
-
-// BadRequestResponse sends a 400 Bad Request response
-func BadRequestResponse(w http.ResponseWriter, message string, err interface{}) {
-	ErrorResponse(w, http.StatusBadRequest, message, err)
-}
-
-// UnauthorizedResponse sends a 401 Unauthorized response
-func UnauthorizedResponse(w http.ResponseWriter, message string) {
-	ErrorResponse(w, http.StatusUnauthorized, message, nil)
-}
-
-// ForbiddenResponse sends a 403 Forbidden response
-func ForbiddenResponse(w http.ResponseWriter, message string) {
-	ErrorResponse(w, http.StatusForbidden, message, nil)
-}
-
-// NotFoundResponse sends a 404 Not Found response
-func NotFoundResponse(w http.ResponseWriter, message string) {
-	ErrorResponse(w, http.StatusNotFound, message, nil)
-}
-
-// ConflictResponse sends a 409 Conflict response
-func ConflictResponse(w http.ResponseWriter, message string) {
-	ErrorResponse(w, http.StatusConflict, message, nil)
-}
-
-// InternalServerErrorResponse sends a 500 Internal Server Error response
-func InternalServerErrorResponse(w http.ResponseWriter, message string, err interface{}) {
-	ErrorResponse(w, http.StatusInternalServerError, message, err)
-}
@@ -83,3 +83,11 @@ func ValidateEmail(email string) bool {
 func ValidateUUID(uuid string) bool {
 	return validate.Var(uuid, "required,uuid") == nil
 }
+
+// ValidationErrors is a list of validation messages usable as an error
+type ValidationErrors []string
+
+// Error implements the error interface
+func (v ValidationErrors) Error() string {
+	return "Validation failed: " + strings.Join(v, "; ")
+}